@@ -9,19 +9,33 @@ import (
 	"syscall"
 	"time"
 
+	"sun-stockanalysis-api/internal/cluster"
 	"sun-stockanalysis-api/internal/configurations"
 	"sun-stockanalysis-api/internal/controllers"
 	"sun-stockanalysis-api/internal/database"
 	"sun-stockanalysis-api/internal/domains/alert_events"
+	"sun-stockanalysis-api/internal/domains/alert_rules"
+	"sun-stockanalysis-api/internal/domains/api_keys"
 	"sun-stockanalysis-api/internal/domains/auth"
+	"sun-stockanalysis-api/internal/domains/backfill"
+	"sun-stockanalysis-api/internal/domains/backtests"
+	"sun-stockanalysis-api/internal/domains/candles"
 	"sun-stockanalysis-api/internal/domains/cleanup"
 	"sun-stockanalysis-api/internal/domains/company_news"
+	"sun-stockanalysis-api/internal/domains/fx_rates"
+	"sun-stockanalysis-api/internal/domains/holdings"
+	"sun-stockanalysis-api/internal/domains/market_calendar"
 	"sun-stockanalysis-api/internal/domains/market_open"
+	"sun-stockanalysis-api/internal/domains/portfolios"
 	"sun-stockanalysis-api/internal/domains/push_subscriptions"
 	"sun-stockanalysis-api/internal/domains/relation_news"
 	"sun-stockanalysis-api/internal/domains/stock"
 	"sun-stockanalysis-api/internal/domains/stock_daily"
 	"sun-stockanalysis-api/internal/domains/stock_quotes"
+	"sun-stockanalysis-api/internal/domains/users"
+	"sun-stockanalysis-api/internal/domains/watchlists"
+	"sun-stockanalysis-api/internal/mailer"
+	"sun-stockanalysis-api/internal/marketdata"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/internal/realtime"
 	"sun-stockanalysis-api/internal/repository"
@@ -40,7 +54,7 @@ func main() {
 	db := database.NewPostgresDatabase(cfg.Database).ConnectionGetting()
 
 	// (optional) migrate
-	if err := db.AutoMigrate(
+	if err := database.Migrate(db,
 		&models.Stock{},
 		&models.StockQuote{},
 		&models.User{},
@@ -53,51 +67,140 @@ func main() {
 		&models.RelationNews{},
 		&models.CompanyNews{},
 		&models.AlertEvent{},
+		&models.AlertRule{},
+		&models.Watchlist{},
+		&models.WatchlistItem{},
 		&models.PushSubscription{},
+		&models.Portfolio{},
+		&models.PortfolioOrder{},
+		&models.PortfolioPosition{},
+		&models.BacktestJob{},
+		&models.BackfillJob{},
+		&models.HoldingTransaction{},
+		&models.FXRate{},
+		&models.MarketCalendar{},
+		&models.MarketHoliday{},
+		&models.UserToken{},
+		&models.MFARecoveryCode{},
+		&models.APIKey{},
 	); err != nil {
 		logg.Fatalf("migrate error: %v", err)
 	}
 
+	marketDataProvider, err := marketdata.NewProvider(cfg.MarketData, cfg.Finnhub)
+	if err != nil {
+		logg.Fatalf("market data provider init error: %v", err)
+	}
+	logg.Infof("market data provider: %s", marketDataProvider.Name())
+
 	// DI wiring
 	stockRepo := repository.NewStockRepository(db)
-	stockService := stock.NewStockService(stockRepo, nil, cfg.Finnhub.Token)
-	stockController := controllers.NewStockController(stockService)
+	fxRateRepo := repository.NewFXRateRepository(db)
+	fxRateService, err := fx_rates.NewFXRateService(fxRateRepo, stockRepo, marketDataProvider, cfg.MarketData, logg)
+	if err != nil {
+		logg.Fatalf("fx rate init error: %v", err)
+	}
+	fxRateController := controllers.NewFXRateController(fxRateService)
+	marketCalendarRepo := repository.NewMarketCalendarRepository(db)
+	marketCalendarService := market_calendar.NewMarketCalendarService(marketCalendarRepo, marketDataProvider, logg)
+	if err := marketCalendarService.EnsureDefaults(context.Background()); err != nil {
+		logg.Fatalf("market calendar init error: %v", err)
+	}
+	marketCalendarController := controllers.NewMarketCalendarController(marketCalendarService)
 	stockQuoteRepo := repository.NewStockQuoteRepository(db)
 	alertEventRepo := repository.NewAlertEventRepository(db)
+	alertRuleRepo := repository.NewAlertRuleRepository(db)
 	pushSubscriptionRepo := repository.NewPushSubscriptionRepository(db)
-	alertHub := realtime.NewAlertHub()
-	stockQuoteHub := realtime.NewStockQuoteHub()
-	pushSubscriptionService, err := push_subscriptions.NewPushSubscriptionService(pushSubscriptionRepo, cfg.Push)
+	watchlistRepo := repository.NewWatchlistRepository(db)
+	watchlistService := watchlists.NewWatchlistService(watchlistRepo, stockRepo)
+	watchlistController := controllers.NewWatchlistController(watchlistService)
+	alertHub := realtime.NewAlertHub(watchlistService, alertEventRepo)
+	stockQuoteHub := realtime.NewStockQuoteHub(watchlistService, stockQuoteRepo)
+	// With fan-out enabled, quotes, alerts and watchlist changes published on
+	// one replica reach the WebSocket and SSE clients of every replica.
+	var (
+		quoteNotifier    realtime.StockQuoteNotifier = stockQuoteHub
+		alertHubNotifier realtime.AlertEventNotifier = alertHub
+		bus              *cluster.Bus
+	)
+	if getEnvBool("CLUSTER_FANOUT_ENABLED", true) {
+		bus = cluster.NewBus(db, database.DSN(cfg.Database), logg)
+		quoteNotifier = cluster.NewQuoteFanout(bus, stockQuoteHub, logg)
+		alertHubNotifier = cluster.NewAlertFanout(bus, alertHub, logg)
+		watchlistService.Subscribe(cluster.NewWatchlistFanout(bus, watchlistService, logg, alertHub, stockQuoteHub))
+	} else {
+		watchlistService.Subscribe(alertHub)
+		watchlistService.Subscribe(stockQuoteHub)
+	}
+	// Limit orders fill on the replica that ingests the quote, ahead of the
+	// fan-out, so each order is settled once.
+	portfolioRepo := repository.NewPortfolioRepository(db)
+	portfolioService := portfolios.NewPortfolioService(portfolioRepo, stockRepo, stockQuoteRepo, fxRateService, logg)
+	portfolioController := controllers.NewPortfolioController(portfolioService)
+	quoteNotifier = realtime.NewCompositeQuoteNotifier(quoteNotifier, portfolioService)
+	pushSubscriptionService, err := push_subscriptions.NewPushSubscriptionService(pushSubscriptionRepo, watchlistService, cfg.Push)
 	if err != nil {
 		logg.Fatalf("push subscription init error: %v", err)
 	}
-	alertNotifier := realtime.NewCompositeAlertNotifier(alertHub, pushSubscriptionService)
-	alertEventService := alert_events.NewAlertEventService(stockQuoteRepo, alertEventRepo, alertNotifier)
-	stockQuoteService := stock_quotes.NewStockQuoteService(stockRepo, stockQuoteRepo, alertEventService, stockQuoteHub, nil, cfg.Finnhub.Token)
-	stockQuoteController := controllers.NewStockQuoteController(stockQuoteService)
+	alertNotifier := realtime.NewCompositeAlertNotifier(alertHubNotifier, pushSubscriptionService)
+	alertEventService := alert_events.NewAlertEventService(stockQuoteRepo, alertRuleRepo, alertEventRepo, alertNotifier)
+	stockQuoteService := stock_quotes.NewStockQuoteService(stockRepo, stockQuoteRepo, alertEventService, quoteNotifier, marketDataProvider, marketCalendarService, logg)
+	stockQuoteController := controllers.NewStockQuoteController(stockQuoteService, fxRateService)
+	alertRuleService := alert_rules.NewAlertRuleService(alertRuleRepo, alertEventRepo, stockRepo)
+	alertRuleController := controllers.NewAlertRuleController(alertRuleService)
+	candleService := candles.NewCandleService(stockQuoteRepo)
+	candleController := controllers.NewCandleController(candleService)
 	stockDailyRepo := repository.NewStockDailyRepository(db)
-	stockDailyService := stock_daily.NewStockDailyService(stockRepo, stockQuoteRepo, stockDailyRepo)
-	stockDailyController := controllers.NewStockDailyController(stockDailyService)
+	stockDailyService := stock_daily.NewStockDailyService(stockRepo, stockQuoteRepo, stockDailyRepo, marketCalendarService)
+	stockDailyController := controllers.NewStockDailyController(stockDailyService, fxRateService)
+	backfillJobRepo := repository.NewBackfillJobRepository(db)
+	backfillService := backfill.NewBackfillService(backfillJobRepo, marketDataProvider, stockQuoteService, stockDailyService, logg)
+	backfillController := controllers.NewBackfillController(backfillService)
+	backtestJobRepo := repository.NewBacktestJobRepository(db)
+	backtestService := backtests.NewBacktestService(backtestJobRepo, stockRepo, stockQuoteRepo, stockDailyRepo, logg)
+	backtestController := controllers.NewBacktestController(backtestService)
+	holdingRepo := repository.NewHoldingRepository(db)
+	holdingService := holdings.NewHoldingService(holdingRepo, stockRepo, stockQuoteRepo, stockDailyRepo, fxRateService)
+	holdingController := controllers.NewHoldingController(holdingService)
+	indicatorController := controllers.NewIndicatorController(stockQuoteService, stockDailyService)
+	stockService := stock.NewStockService(stockRepo, marketDataProvider, backfillService)
+	stockController := controllers.NewStockController(stockService)
 	relationNewsRepo := repository.NewRelationNewsRepository(db)
 	relationNewsService := relation_news.NewRelationNewsService(relationNewsRepo)
 	companyNewsRepo := repository.NewCompanyNewsRepository(db)
-	companyNewsService := company_news.NewCompanyNewsService(relationNewsRepo, companyNewsRepo, pushSubscriptionService, nil, cfg.Finnhub.Token, logg)
+	companyNewsService := company_news.NewCompanyNewsService(relationNewsRepo, companyNewsRepo, pushSubscriptionService, marketDataProvider, logg)
 	companyNewsController := controllers.NewCompanyNewsController(companyNewsService)
 	healthRepo := repository.NewHealthRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
-	authService := auth.NewAuthService(userRepo, refreshTokenRepo, cfg.State)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	mfaRecoveryCodeRepo := repository.NewMFARecoveryCodeRepository(db)
+	mail, err := mailer.NewMailer(cfg.Mail, logg)
+	if err != nil {
+		logg.Fatalf("mailer init error: %v", err)
+	}
+	logg.Infof("mailer: %s", mail.Name())
+	authService := auth.NewAuthService(userRepo, refreshTokenRepo, userTokenRepo, mfaRecoveryCodeRepo, mail, cfg.State, cfg.Mail, logg)
 	authController := controllers.NewAuthController(authService)
+	userService := users.NewUserService(userRepo, logg)
+	if err := userService.EnsureAdmin(context.Background(), cfg.State.AdminEmail, cfg.State.AdminPassword); err != nil {
+		logg.Fatalf("admin seed error: %v", err)
+	}
+	userController := controllers.NewUserController(userService)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	apiKeyService := api_keys.NewAPIKeyService(apiKeyRepo, userRepo, logg)
+	apiKeyController := controllers.NewAPIKeyController(apiKeyService)
 	relationNewsController := controllers.NewRelationNewsController(relationNewsService)
 	pushSubscriptionController := controllers.NewPushSubscriptionController(pushSubscriptionService)
 	marketOpenRepo := repository.NewMarketOpenRepository(db)
-	marketOpenService := market_open.NewMarketOpenService(marketOpenRepo, nil, cfg.Finnhub.Token, stockQuoteService, stockDailyService, pushSubscriptionService, logg)
+	marketOpenService := market_open.NewMarketOpenService(marketOpenRepo, marketCalendarService, stockQuoteService, stockDailyService, pushSubscriptionService, logg)
 	cleanupService := cleanup.NewCleanupService(
 		stockQuoteRepo,
 		companyNewsRepo,
 		alertEventRepo,
 		marketOpenRepo,
 		refreshTokenRepo,
+		userTokenRepo,
 		pushSubscriptionRepo,
 		15,
 		7,
@@ -105,18 +208,43 @@ func main() {
 		30,
 		30,
 	)
-	appCtx := context.Background()
-	marketOpenService.Start(appCtx)
-	companyNewsService.Start(appCtx)
-	cleanupService.Start(appCtx)
-	if getEnvBool("PUSH_SIMULATION_ENABLED", false) {
-		interval := time.Duration(getEnvInt("PUSH_SIMULATION_INTERVAL_SECONDS", 60)) * time.Second
-		message := getEnvString("PUSH_SIMULATION_MESSAGE", "Test push notification every 1 minute")
-		pushSubscriptionService.StartSimulation(appCtx, interval, message)
-		logg.Infof("push simulation enabled interval=%s", interval)
+	appCtx, stopApp := context.WithCancel(context.Background())
+	defer stopApp()
+	if bus != nil {
+		bus.Start(appCtx)
+	}
+	backfillService.Start(appCtx)
+	backtestService.Start(appCtx)
+
+	// Schedulers run on one replica at a time; see cluster.Elector.
+	startSchedulers := func(ctx context.Context) {
+		marketOpenService.Start(ctx)
+		companyNewsService.Start(ctx)
+		cleanupService.Start(ctx)
+		fxRateService.Start(ctx)
+		marketCalendarService.Start(ctx)
+		if getEnvBool("PUSH_SIMULATION_ENABLED", false) {
+			interval := time.Duration(getEnvInt("PUSH_SIMULATION_INTERVAL_SECONDS", 60)) * time.Second
+			message := getEnvString("PUSH_SIMULATION_MESSAGE", "Test push notification every 1 minute")
+			pushSubscriptionService.StartSimulation(ctx, interval, message)
+			logg.Infof("push simulation enabled interval=%s", interval)
+		}
+	}
+	if getEnvBool("LEADER_ELECTION_ENABLED", true) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			logg.Fatalf("leader election init error: %v", err)
+		}
+		lockKey := int64(getEnvInt("LEADER_LOCK_KEY", int(cluster.DefaultLeaderLockKey)))
+		interval := time.Duration(getEnvInt("LEADER_ELECTION_INTERVAL_SECONDS", 10)) * time.Second
+		elector := cluster.NewElector(cluster.NewAdvisoryLock(sqlDB, lockKey), interval, logg)
+		go elector.Run(appCtx, startSchedulers)
+	} else {
+		startSchedulers(appCtx)
 	}
 
 	healthController := controllers.NewHealthController(healthRepo, "1.0.0")
+	realtimeController := controllers.NewRealtimeController(alertHub, stockQuoteHub)
 	appControllers := controllers.NewControllers(
 		healthController,
 		stockController,
@@ -126,10 +254,23 @@ func main() {
 		authController,
 		relationNewsController,
 		pushSubscriptionController,
+		backfillController,
+		indicatorController,
+		candleController,
+		alertRuleController,
+		watchlistController,
+		realtimeController,
+		portfolioController,
+		backtestController,
+		holdingController,
+		fxRateController,
+		marketCalendarController,
+		userController,
+		apiKeyController,
 	)
 
 	// Fiber server
-	srv := server.NewServer(cfg, appControllers, alertHub, stockQuoteHub, logg)
+	srv := server.NewServer(cfg, appControllers, apiKeyService, alertHub, stockQuoteHub, logg)
 
 	go func() {
 		logg.Infof("server starting on :%d", cfg.Server.Port)
@@ -142,6 +283,9 @@ func main() {
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	sig := <-sigChan
 	logg.Infof("shutdown signal received: %v", sig)
+	stopApp()
+	alertHub.Close()
+	stockQuoteHub.Close()
 	if err := srv.Stop(); err != nil {
 		logg.Errorf("server shutdown error: %v", err)
 	}
@@ -1,15 +1,36 @@
 package authctx
 
-import "context"
+import (
+	"context"
+	"slices"
+)
+
+// RolesMetadataKey is the huma.Operation metadata key that lists the roles
+// allowed to call an operation. Operations without it accept any
+// authenticated user.
+const RolesMetadataKey = "roles"
+
+// APIKeysMetadataKey is the huma.Operation metadata key that, set to false,
+// makes an operation reject API keys and require a signed-in session.
+const APIKeysMetadataKey = "api_keys"
 
 type contextKey struct{}
 
-var userIDContextKey contextKey
+type roleContextKey struct{}
+
+var (
+	userIDContextKey contextKey
+	roleKey          roleContextKey
+)
 
 func UserIDContextKey() any {
 	return userIDContextKey
 }
 
+func RoleContextKey() any {
+	return roleKey
+}
+
 func UserIDFromContext(ctx context.Context) (string, bool) {
 	if ctx == nil {
 		return "", false
@@ -20,3 +41,34 @@ func UserIDFromContext(ctx context.Context) (string, bool) {
 	}
 	return userID, true
 }
+
+func RoleFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	role, ok := ctx.Value(roleKey).(string)
+	if !ok || role == "" {
+		return "", false
+	}
+	return role, true
+}
+
+// RolesFromMetadata returns the roles an operation requires, or nil when it
+// declares none.
+func RolesFromMetadata(metadata map[string]any) []string {
+	roles, _ := metadata[RolesMetadataKey].([]string)
+	return roles
+}
+
+// Allowed reports whether role satisfies the required roles. An empty
+// requirement allows every role.
+func Allowed(required []string, role string) bool {
+	return len(required) == 0 || slices.Contains(required, role)
+}
+
+// APIKeysAllowed reports whether an operation accepts API keys. Operations
+// accept them unless they opt out.
+func APIKeysAllowed(metadata map[string]any) bool {
+	allowed, ok := metadata[APIKeysMetadataKey].(bool)
+	return !ok || allowed
+}
@@ -0,0 +1,111 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// recordingDriver hands out connections whose queries fail with err and
+// counts how many of them were closed.
+type recordingDriver struct {
+	mu     sync.Mutex
+	err    error
+	closed int
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+func (d *recordingDriver) closedConns() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.closed
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *recordingConn) Close() error {
+	c.driver.mu.Lock()
+	c.driver.closed++
+	c.driver.mu.Unlock()
+	return nil
+}
+
+func (c *recordingConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return nil, c.driver.err
+}
+
+func (c *recordingConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(0), c.driver.err
+}
+
+type AdvisoryLockSuite struct {
+	suite.Suite
+	driver *recordingDriver
+	db     *sql.DB
+}
+
+func (s *AdvisoryLockSuite) SetupTest() {
+	s.driver = &recordingDriver{}
+	s.db = sql.OpenDB(s)
+}
+
+func (s *AdvisoryLockSuite) TearDownTest() {
+	s.Require().NoError(s.db.Close())
+}
+
+// Connect and Driver make the suite a driver.Connector for sql.OpenDB.
+func (s *AdvisoryLockSuite) Connect(context.Context) (driver.Conn, error) {
+	return s.driver.Open("")
+}
+
+func (s *AdvisoryLockSuite) Driver() driver.Driver {
+	return s.driver
+}
+
+func (s *AdvisoryLockSuite) TestFailedCheckClosesTheSession() {
+	lock := NewAdvisoryLock(s.db, DefaultLeaderLockKey)
+	conn, err := s.db.Conn(context.Background())
+	s.Require().NoError(err)
+	lock.conn = conn
+	s.driver.err = context.DeadlineExceeded
+
+	s.Error(lock.Check(context.Background()))
+
+	s.Nil(lock.conn)
+	s.Equal(1, s.driver.closedConns())
+	s.Zero(s.db.Stats().Idle)
+}
+
+func (s *AdvisoryLockSuite) TestUnlockClosesTheSession() {
+	lock := NewAdvisoryLock(s.db, DefaultLeaderLockKey)
+	conn, err := s.db.Conn(context.Background())
+	s.Require().NoError(err)
+	lock.conn = conn
+
+	s.NoError(lock.Unlock(context.Background()))
+
+	s.Equal(1, s.driver.closedConns())
+	s.Zero(s.db.Stats().Idle)
+}
+
+func TestAdvisoryLockSuite(t *testing.T) {
+	suite.Run(t, new(AdvisoryLockSuite))
+}
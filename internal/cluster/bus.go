@@ -0,0 +1,152 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+const (
+	// Postgres rejects NOTIFY payloads of 8000 bytes or more.
+	maxNotifyPayload = 7999
+	publishTimeout   = 2 * time.Second
+	maxListenBackoff = 30 * time.Second
+)
+
+var ErrPayloadTooLarge = errors.New("notify payload too large")
+
+// envelope tags every message with the replica that sent it so the sender,
+// which has already delivered locally, can skip its own notification.
+type envelope struct {
+	Origin  string          `json:"origin"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Bus fans messages out to every replica over Postgres LISTEN/NOTIFY.
+type Bus struct {
+	db     *gorm.DB
+	dsn    string
+	origin string
+	log    *logger.Logger
+
+	mu       sync.RWMutex
+	handlers map[string][]func(payload []byte)
+}
+
+func NewBus(db *gorm.DB, dsn string, log *logger.Logger) *Bus {
+	return &Bus{
+		db:       db,
+		dsn:      dsn,
+		origin:   uuid.NewString(),
+		log:      log,
+		handlers: make(map[string][]func(payload []byte)),
+	}
+}
+
+// Publish sends v as JSON to the other replicas listening on channel.
+func (b *Bus) Publish(ctx context.Context, channel string, v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(envelope{Origin: b.origin, Payload: payload})
+	if err != nil {
+		return err
+	}
+	if len(data) > maxNotifyPayload {
+		return fmt.Errorf("%w: %d bytes on %s", ErrPayloadTooLarge, len(data), channel)
+	}
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", channel, string(data)).Error
+}
+
+// Subscribe registers handler for messages other replicas publish on channel.
+// Handlers must be registered before Start.
+func (b *Bus) Subscribe(channel string, handler func(payload []byte)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[channel] = append(b.handlers[channel], handler)
+}
+
+// Start listens on every subscribed channel until ctx is done, reconnecting
+// with backoff when the listening connection drops.
+func (b *Bus) Start(ctx context.Context) {
+	go func() {
+		backoff := time.Second
+		for {
+			started := time.Now()
+			err := b.listen(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if time.Since(started) > maxListenBackoff {
+				backoff = time.Second
+			}
+			if b.log != nil {
+				b.log.Warnf("cluster: listen connection lost, retrying in %s: %v", backoff, err)
+			}
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			backoff = min(backoff*2, maxListenBackoff)
+		}
+	}()
+}
+
+func (b *Bus) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	b.mu.RLock()
+	channels := make([]string, 0, len(b.handlers))
+	for channel := range b.handlers {
+		channels = append(channels, channel)
+	}
+	b.mu.RUnlock()
+	for _, channel := range channels {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return err
+		}
+	}
+	if b.log != nil {
+		b.log.Infof("cluster: listening on %d channels", len(channels))
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		b.dispatch(notification.Channel, []byte(notification.Payload))
+	}
+}
+
+func (b *Bus) dispatch(channel string, data []byte) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil || env.Origin == b.origin {
+		return
+	}
+	b.mu.RLock()
+	handlers := b.handlers[channel]
+	b.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(env.Payload)
+	}
+}
@@ -0,0 +1,45 @@
+package cluster
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BusSuite struct {
+	suite.Suite
+	bus      *Bus
+	received []string
+}
+
+func (s *BusSuite) SetupTest() {
+	s.bus = NewBus(nil, "", nil)
+	s.received = nil
+	s.bus.Subscribe(ChannelAlerts, func(payload []byte) {
+		s.received = append(s.received, string(payload))
+	})
+}
+
+func (s *BusSuite) notification(origin string) []byte {
+	data, err := json.Marshal(envelope{Origin: origin, Payload: json.RawMessage(`{"n":1}`)})
+	s.Require().NoError(err)
+	return data
+}
+
+func (s *BusSuite) TestDispatchSkipsOwnMessages() {
+	s.bus.dispatch(ChannelAlerts, s.notification(s.bus.origin))
+
+	s.Empty(s.received)
+}
+
+func (s *BusSuite) TestDispatchDeliversOtherReplicas() {
+	s.bus.dispatch(ChannelAlerts, s.notification("other-replica"))
+	s.bus.dispatch(ChannelStockQuotes, s.notification("other-replica"))
+
+	s.Equal([]string{`{"n":1}`}, s.received)
+}
+
+func TestBusSuite(t *testing.T) {
+	suite.Run(t, new(BusSuite))
+}
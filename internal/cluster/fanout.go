@@ -0,0 +1,148 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/realtime"
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+const (
+	ChannelStockQuotes = "realtime_stock_quotes"
+	ChannelAlerts      = "realtime_alerts"
+	ChannelWatchlists  = "realtime_watchlists"
+)
+
+// PubSub publishes messages to the other replicas and delivers theirs.
+type PubSub interface {
+	Publish(ctx context.Context, channel string, v any) error
+	Subscribe(channel string, handler func(payload []byte))
+}
+
+// WatchlistObserver matches watchlists.Observer.
+type WatchlistObserver interface {
+	WatchlistChanged(userID string, symbols []string, restricted bool)
+}
+
+// QuoteFanout delivers quotes to the local hub and publishes them so the hubs
+// of every other replica deliver them too.
+type QuoteFanout struct {
+	bus   PubSub
+	local realtime.StockQuoteNotifier
+	log   *logger.Logger
+}
+
+func NewQuoteFanout(bus PubSub, local realtime.StockQuoteNotifier, log *logger.Logger) *QuoteFanout {
+	f := &QuoteFanout{bus: bus, local: local, log: log}
+	bus.Subscribe(ChannelStockQuotes, f.receive)
+	return f
+}
+
+func (f *QuoteFanout) NotifyQuote(quote *models.StockQuote) {
+	if quote == nil {
+		return
+	}
+	f.local.NotifyQuote(quote)
+	if err := f.bus.Publish(context.Background(), ChannelStockQuotes, quote); err != nil {
+		logPublishError(f.log, ChannelStockQuotes, err)
+	}
+}
+
+func (f *QuoteFanout) receive(payload []byte) {
+	var quote models.StockQuote
+	if err := json.Unmarshal(payload, &quote); err != nil {
+		return
+	}
+	f.local.NotifyQuote(&quote)
+}
+
+type alertPayload struct {
+	Event   *models.AlertEvent `json:"event"`
+	Message string             `json:"message"`
+}
+
+// AlertFanout delivers alert events to the local hub and to the hubs of every
+// other replica.
+type AlertFanout struct {
+	bus   PubSub
+	local realtime.AlertEventNotifier
+	log   *logger.Logger
+}
+
+func NewAlertFanout(bus PubSub, local realtime.AlertEventNotifier, log *logger.Logger) *AlertFanout {
+	f := &AlertFanout{bus: bus, local: local, log: log}
+	bus.Subscribe(ChannelAlerts, f.receive)
+	return f
+}
+
+func (f *AlertFanout) Notify(event *models.AlertEvent, message string) {
+	if event == nil {
+		return
+	}
+	f.local.Notify(event, message)
+	if err := f.bus.Publish(context.Background(), ChannelAlerts, alertPayload{Event: event, Message: message}); err != nil {
+		logPublishError(f.log, ChannelAlerts, err)
+	}
+}
+
+func (f *AlertFanout) receive(payload []byte) {
+	var msg alertPayload
+	if err := json.Unmarshal(payload, &msg); err != nil || msg.Event == nil {
+		return
+	}
+	f.local.Notify(msg.Event, msg.Message)
+}
+
+type watchlistPayload struct {
+	UserID string `json:"user_id"`
+}
+
+// WatchlistFanout forwards watchlist changes to the local observers and to
+// those of every other replica, so connections on any replica see the user's
+// new filter. Only the user id crosses the bus, since a user's symbols can
+// exceed the NOTIFY payload limit; receivers reload them through lookup.
+type WatchlistFanout struct {
+	bus       PubSub
+	lookup    realtime.WatchlistLookup
+	observers []WatchlistObserver
+	log       *logger.Logger
+}
+
+func NewWatchlistFanout(bus PubSub, lookup realtime.WatchlistLookup, log *logger.Logger, observers ...WatchlistObserver) *WatchlistFanout {
+	f := &WatchlistFanout{bus: bus, lookup: lookup, observers: observers, log: log}
+	bus.Subscribe(ChannelWatchlists, f.receive)
+	return f
+}
+
+func (f *WatchlistFanout) WatchlistChanged(userID string, symbols []string, restricted bool) {
+	f.notifyLocal(userID, symbols, restricted)
+	if err := f.bus.Publish(context.Background(), ChannelWatchlists, watchlistPayload{UserID: userID}); err != nil {
+		logPublishError(f.log, ChannelWatchlists, err)
+	}
+}
+
+func (f *WatchlistFanout) receive(payload []byte) {
+	var msg watchlistPayload
+	if err := json.Unmarshal(payload, &msg); err != nil || msg.UserID == "" {
+		return
+	}
+	symbols, restricted, err := f.lookup.WatchedSymbols(context.Background(), msg.UserID)
+	if err != nil {
+		return
+	}
+	f.notifyLocal(msg.UserID, symbols, restricted)
+}
+
+func (f *WatchlistFanout) notifyLocal(userID string, symbols []string, restricted bool) {
+	for _, observer := range f.observers {
+		observer.WatchlistChanged(userID, symbols, restricted)
+	}
+}
+
+func logPublishError(log *logger.Logger, channel string, err error) {
+	if log != nil {
+		log.Warnf("cluster: publish on %s failed: %v", channel, err)
+	}
+}
@@ -0,0 +1,126 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+// memoryBus connects fan-outs in one process the way Bus connects replicas:
+// a publisher's own handlers are skipped.
+type memoryBus struct {
+	network  *memoryNetwork
+	handlers map[string][]func(payload []byte)
+}
+
+type memoryNetwork struct {
+	buses []*memoryBus
+}
+
+func (n *memoryNetwork) join() *memoryBus {
+	bus := &memoryBus{network: n, handlers: make(map[string][]func(payload []byte))}
+	n.buses = append(n.buses, bus)
+	return bus
+}
+
+func (b *memoryBus) Publish(_ context.Context, channel string, v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	for _, other := range b.network.buses {
+		if other == b {
+			continue
+		}
+		for _, handler := range other.handlers[channel] {
+			handler(payload)
+		}
+	}
+	return nil
+}
+
+func (b *memoryBus) Subscribe(channel string, handler func(payload []byte)) {
+	b.handlers[channel] = append(b.handlers[channel], handler)
+}
+
+type recordingHub struct {
+	quotes  []*models.StockQuote
+	alerts  []string
+	changes []string
+}
+
+func (h *recordingHub) NotifyQuote(quote *models.StockQuote) {
+	h.quotes = append(h.quotes, quote)
+}
+
+func (h *recordingHub) Notify(event *models.AlertEvent, message string) {
+	h.alerts = append(h.alerts, message)
+}
+
+func (h *recordingHub) WatchlistChanged(userID string, symbols []string, restricted bool) {
+	h.changes = append(h.changes, userID)
+}
+
+type staticLookup struct{}
+
+func (staticLookup) WatchedSymbols(context.Context, string) ([]string, bool, error) {
+	return []string{"AAPL"}, true, nil
+}
+
+type FanoutSuite struct {
+	suite.Suite
+	local  *recordingHub
+	remote *recordingHub
+	busA   *memoryBus
+	busB   *memoryBus
+}
+
+func (s *FanoutSuite) SetupTest() {
+	network := &memoryNetwork{}
+	s.busA = network.join()
+	s.busB = network.join()
+	s.local = &recordingHub{}
+	s.remote = &recordingHub{}
+}
+
+func (s *FanoutSuite) TestQuoteReachesEveryReplicaOnce() {
+	origin := NewQuoteFanout(s.busA, s.local, nil)
+	NewQuoteFanout(s.busB, s.remote, nil)
+	quote := &models.StockQuote{ID: uuid.New(), Symbol: "AAPL", PriceCurrent: 190.5}
+
+	origin.NotifyQuote(quote)
+
+	s.Require().Len(s.local.quotes, 1)
+	s.Require().Len(s.remote.quotes, 1)
+	s.Equal(quote.ID, s.remote.quotes[0].ID)
+	s.Equal(190.5, s.remote.quotes[0].PriceCurrent)
+}
+
+func (s *FanoutSuite) TestAlertReachesRemoteHub() {
+	origin := NewAlertFanout(s.busA, s.local, nil)
+	NewAlertFanout(s.busB, s.remote, nil)
+
+	origin.Notify(&models.AlertEvent{ID: uuid.New(), UserID: uuid.New(), Symbol: "AAPL"}, "crossed")
+
+	s.Equal([]string{"crossed"}, s.local.alerts)
+	s.Equal([]string{"crossed"}, s.remote.alerts)
+}
+
+func (s *FanoutSuite) TestWatchlistChangeReloadsOnRemote() {
+	origin := NewWatchlistFanout(s.busA, staticLookup{}, nil, s.local)
+	NewWatchlistFanout(s.busB, staticLookup{}, nil, s.remote)
+
+	origin.WatchlistChanged("user-1", []string{"AAPL"}, true)
+
+	s.Equal([]string{"user-1"}, s.local.changes)
+	s.Equal([]string{"user-1"}, s.remote.changes)
+}
+
+func TestFanoutSuite(t *testing.T) {
+	suite.Run(t, new(FanoutSuite))
+}
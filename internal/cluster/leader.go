@@ -0,0 +1,183 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"time"
+
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+const defaultElectionInterval = 10 * time.Second
+
+// DefaultLeaderLockKey is the advisory lock key the scheduler leader holds.
+const DefaultLeaderLockKey int64 = 0x53554e5354
+
+var ErrLockLost = errors.New("leader lock lost")
+
+// Locker is a cluster-wide lock that at most one replica holds.
+type Locker interface {
+	// TryLock acquires the lock without waiting and reports whether it is held.
+	TryLock(ctx context.Context) (bool, error)
+	// Check returns an error once the lock may have been lost.
+	Check(ctx context.Context) error
+	Unlock(ctx context.Context) error
+}
+
+// Elector runs background jobs on a single replica at a time. Replicas that
+// are not the leader retry the lock every interval and take over when the
+// leader stops or loses its database session.
+type Elector struct {
+	locker   Locker
+	interval time.Duration
+	log      *logger.Logger
+
+	mu     sync.RWMutex
+	leader bool
+}
+
+func NewElector(locker Locker, interval time.Duration, log *logger.Logger) *Elector {
+	if interval <= 0 {
+		interval = defaultElectionInterval
+	}
+	return &Elector{
+		locker:   locker,
+		interval: interval,
+		log:      log,
+	}
+}
+
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leader
+}
+
+// Run campaigns for leadership until ctx is done. Each time this replica
+// becomes leader, lead is called with a context that is canceled when
+// leadership is lost. lead must not block.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	var cancel context.CancelFunc
+	for {
+		if cancel == nil {
+			held, err := e.locker.TryLock(ctx)
+			if err != nil {
+				e.logf("cluster: leader lock attempt failed: %v", err)
+			}
+			if held {
+				var leaderCtx context.Context
+				leaderCtx, cancel = context.WithCancel(ctx)
+				e.setLeader(true)
+				e.logf("cluster: became leader")
+				lead(leaderCtx)
+			}
+		} else if err := e.locker.Check(ctx); err != nil {
+			cancel()
+			cancel = nil
+			e.setLeader(false)
+			e.logf("cluster: lost leadership: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if cancel != nil {
+				cancel()
+				e.setLeader(false)
+				unlockCtx, done := context.WithTimeout(context.Background(), 5*time.Second)
+				_ = e.locker.Unlock(unlockCtx)
+				done()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) setLeader(leader bool) {
+	e.mu.Lock()
+	e.leader = leader
+	e.mu.Unlock()
+}
+
+func (e *Elector) logf(format string, args ...any) {
+	if e.log != nil {
+		e.log.Infof(format, args...)
+	}
+}
+
+// AdvisoryLock is a Locker backed by a Postgres session-level advisory lock.
+// The lock lives on one pinned connection, so Postgres releases it as soon as
+// that session ends. Whenever the lock is given up or a query on the
+// connection fails, the connection is closed rather than returned to the pool,
+// so an idle pooled session can never keep holding the lock.
+type AdvisoryLock struct {
+	db   *sql.DB
+	key  int64
+	conn *sql.Conn
+}
+
+func NewAdvisoryLock(db *sql.DB, key int64) *AdvisoryLock {
+	return &AdvisoryLock{db: db, key: key}
+}
+
+func (l *AdvisoryLock) TryLock(ctx context.Context) (bool, error) {
+	if l.conn == nil {
+		conn, err := l.db.Conn(ctx)
+		if err != nil {
+			return false, err
+		}
+		l.conn = conn
+	}
+	var held bool
+	if err := l.conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&held); err != nil {
+		l.release()
+		return false, err
+	}
+	return held, nil
+}
+
+func (l *AdvisoryLock) Check(ctx context.Context) error {
+	if l.conn == nil {
+		return ErrLockLost
+	}
+	var held bool
+	err := l.conn.QueryRowContext(ctx, `SELECT EXISTS (
+		SELECT 1 FROM pg_locks
+		WHERE locktype = 'advisory' AND granted AND pid = pg_backend_pid()
+		AND classid = ($1::bigint >> 32)::oid AND objid = ($1::bigint & 4294967295)::oid
+	)`, l.key).Scan(&held)
+	if err != nil {
+		l.release()
+		return err
+	}
+	if !held {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (l *AdvisoryLock) Unlock(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	l.release()
+	return err
+}
+
+// release discards the pinned connection. Returning driver.ErrBadConn from
+// Raw makes database/sql close the underlying connection, ending the session
+// and with it any advisory lock it still holds.
+func (l *AdvisoryLock) release() {
+	if l.conn != nil {
+		_ = l.conn.Raw(func(any) error { return driver.ErrBadConn })
+		_ = l.conn.Close()
+		l.conn = nil
+	}
+}
@@ -0,0 +1,140 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeLocker struct {
+	mu       sync.Mutex
+	free     bool
+	held     bool
+	lost     bool
+	unlocked bool
+}
+
+func (l *fakeLocker) TryLock(context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.free {
+		return false, nil
+	}
+	l.held = true
+	l.lost = false
+	return true, nil
+}
+
+func (l *fakeLocker) Check(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.lost {
+		l.held = false
+		return errors.New("connection closed")
+	}
+	return nil
+}
+
+func (l *fakeLocker) Unlock(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.held = false
+	l.unlocked = true
+	return nil
+}
+
+func (l *fakeLocker) set(fn func(l *fakeLocker)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fn(l)
+}
+
+type ElectorSuite struct {
+	suite.Suite
+	locker  *fakeLocker
+	elector *Elector
+	terms   chan context.Context
+}
+
+func (s *ElectorSuite) SetupTest() {
+	s.locker = &fakeLocker{}
+	s.elector = NewElector(s.locker, 10*time.Millisecond, nil)
+	s.terms = make(chan context.Context, 4)
+}
+
+func (s *ElectorSuite) run(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.elector.Run(ctx, func(leaderCtx context.Context) {
+			s.terms <- leaderCtx
+		})
+	}()
+	return done
+}
+
+func (s *ElectorSuite) nextTerm() context.Context {
+	select {
+	case term := <-s.terms:
+		return term
+	case <-time.After(time.Second):
+		s.FailNow("no leadership term started")
+		return nil
+	}
+}
+
+func (s *ElectorSuite) TestWaitsForLockThenLeads() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.run(ctx)
+
+	time.Sleep(30 * time.Millisecond)
+	s.False(s.elector.IsLeader())
+	s.Empty(s.terms)
+
+	s.locker.set(func(l *fakeLocker) { l.free = true })
+	term := s.nextTerm()
+
+	s.True(s.elector.IsLeader())
+	s.NoError(term.Err())
+}
+
+func (s *ElectorSuite) TestLostLockCancelsTermAndRecampaigns() {
+	s.locker.set(func(l *fakeLocker) { l.free = true })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.run(ctx)
+	first := s.nextTerm()
+
+	s.locker.set(func(l *fakeLocker) { l.lost = true })
+
+	select {
+	case <-first.Done():
+	case <-time.After(time.Second):
+		s.FailNow("term was not canceled")
+	}
+	second := s.nextTerm()
+	s.NoError(second.Err())
+}
+
+func (s *ElectorSuite) TestStopReleasesLock() {
+	s.locker.set(func(l *fakeLocker) { l.free = true })
+	ctx, cancel := context.WithCancel(context.Background())
+	done := s.run(ctx)
+	term := s.nextTerm()
+
+	cancel()
+	<-done
+
+	s.Error(term.Err())
+	s.False(s.elector.IsLeader())
+	s.True(s.locker.unlocked)
+}
+
+func TestElectorSuite(t *testing.T) {
+	suite.Run(t, new(ElectorSuite))
+}
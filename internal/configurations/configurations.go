@@ -16,10 +16,12 @@ type (
 	Config struct {
 		Server *Server `mapstructure:"server" validate:"required"`
 		// OAuth2   	*OAuth2   	`mapstructure:"oauth2" validate:"required"`
-		State    *State    `mapstructure:"state" validate:"required"`
-		Database *Database `mapstructure:"database" validate:"required"`
-		Finnhub  *Finnhub  `mapstructure:"finnhub" validate:"required"`
-		Push     *Push     `mapstructure:"push"`
+		State      *State      `mapstructure:"state" validate:"required"`
+		Database   *Database   `mapstructure:"database" validate:"required"`
+		Finnhub    *Finnhub    `mapstructure:"finnhub" validate:"required"`
+		MarketData *MarketData `mapstructure:"marketData"`
+		Push       *Push       `mapstructure:"push"`
+		Mail       *Mail       `mapstructure:"mail"`
 	}
 
 	Server struct {
@@ -48,9 +50,12 @@ type (
 	// }
 
 	State struct {
-		Secret     string        `mapstructure:"secret" validate:"required"`
-		ExpiredsAt time.Duration `mapstructure:"expiredsAt" validate:"required"`
-		Issuer     string        `mapstructure:"issuer" validate:"required"`
+		Secret               string        `mapstructure:"secret" validate:"required"`
+		ExpiredsAt           time.Duration `mapstructure:"expiredsAt" validate:"required"`
+		Issuer               string        `mapstructure:"issuer" validate:"required"`
+		AdminEmail           string        `mapstructure:"adminEmail"`
+		AdminPassword        string        `mapstructure:"adminPassword"`
+		RequireVerifiedEmail bool          `mapstructure:"requireVerifiedEmail"`
 	}
 
 	Database struct {
@@ -67,12 +72,28 @@ type (
 		Token string `mapstructure:"token" validate:"required"`
 	}
 
+	MarketData struct {
+		Provider    string `mapstructure:"provider"`
+		ReplayFile  string `mapstructure:"replayFile"`
+		FXRatesFile string `mapstructure:"fxRatesFile"`
+	}
+
 	Push struct {
 		Subject         string `mapstructure:"subject"`
-		TriggerScore    int    `mapstructure:"triggerScore"`
 		VAPIDPublicKey  string `mapstructure:"vapidPublicKey"`
 		VAPIDPrivateKey string `mapstructure:"vapidPrivateKey"`
 	}
+
+	Mail struct {
+		Driver   string `mapstructure:"driver"`
+		Host     string `mapstructure:"host"`
+		Port     int    `mapstructure:"port"`
+		Username string `mapstructure:"username"`
+		Password string `mapstructure:"password"`
+		From     string `mapstructure:"from"`
+		Dir      string `mapstructure:"dir"`
+		AppURL   string `mapstructure:"appUrl"`
+	}
 )
 
 var (
@@ -115,9 +136,12 @@ func ConfigGetting() *Config {
 				TimeOut:        viper.GetDuration("server.timeout"),
 			},
 			State: &State{
-				Secret:     viper.GetString("state.secret"),
-				ExpiredsAt: viper.GetDuration("state.expiredsAt"),
-				Issuer:     viper.GetString("state.issuer"),
+				Secret:               viper.GetString("state.secret"),
+				ExpiredsAt:           viper.GetDuration("state.expiredsAt"),
+				Issuer:               viper.GetString("state.issuer"),
+				AdminEmail:           viper.GetString("state.adminEmail"),
+				AdminPassword:        viper.GetString("state.adminPassword"),
+				RequireVerifiedEmail: viper.GetBool("state.requireVerifiedEmail"),
 			},
 			Database: &Database{
 				Host:     viper.GetString("database.host"),
@@ -131,12 +155,26 @@ func ConfigGetting() *Config {
 			Finnhub: &Finnhub{
 				Token: viper.GetString("finnhub.token"),
 			},
+			MarketData: &MarketData{
+				Provider:    viper.GetString("marketData.provider"),
+				ReplayFile:  viper.GetString("marketData.replayFile"),
+				FXRatesFile: viper.GetString("marketData.fxRatesFile"),
+			},
 			Push: &Push{
 				Subject:         viper.GetString("push.subject"),
-				TriggerScore:    viper.GetInt("push.triggerScore"),
 				VAPIDPublicKey:  viper.GetString("push.vapidPublicKey"),
 				VAPIDPrivateKey: viper.GetString("push.vapidPrivateKey"),
 			},
+			Mail: &Mail{
+				Driver:   viper.GetString("mail.driver"),
+				Host:     viper.GetString("mail.host"),
+				Port:     viper.GetInt("mail.port"),
+				Username: viper.GetString("mail.username"),
+				Password: viper.GetString("mail.password"),
+				From:     viper.GetString("mail.from"),
+				Dir:      viper.GetString("mail.dir"),
+				AppURL:   viper.GetString("mail.appUrl"),
+			},
 		}
 
 		if err := validator.New().Struct(&cfg); err != nil {
@@ -193,6 +231,9 @@ func bindEnvKeys() {
 		"state.secret",
 		"state.expiredsAt",
 		"state.issuer",
+		"state.adminEmail",
+		"state.adminPassword",
+		"state.requireVerifiedEmail",
 		"database.host",
 		"database.port",
 		"database.user",
@@ -201,10 +242,20 @@ func bindEnvKeys() {
 		"database.sslmode",
 		"database.schema",
 		"finnhub.token",
+		"marketData.provider",
+		"marketData.replayFile",
+		"marketData.fxRatesFile",
 		"push.subject",
-		"push.triggerScore",
 		"push.vapidPublicKey",
 		"push.vapidPrivateKey",
+		"mail.driver",
+		"mail.host",
+		"mail.port",
+		"mail.username",
+		"mail.password",
+		"mail.from",
+		"mail.dir",
+		"mail.appUrl",
 	}
 
 	for _, key := range keys {
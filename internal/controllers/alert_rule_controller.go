@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/authctx"
+	"sun-stockanalysis-api/internal/domains/alert_rules"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type AlertRuleController struct {
+	service alert_rules.AlertRuleService
+}
+
+func NewAlertRuleController(service alert_rules.AlertRuleService) *AlertRuleController {
+	return &AlertRuleController{service: service}
+}
+
+type AlertRuleBody struct {
+	Name            string                `json:"name" doc:"Rule name"`
+	Symbol          string                `json:"symbol" doc:"Tracked symbol the rule watches"`
+	Condition       models.AlertCondition `json:"condition" doc:"Condition tree evaluated on every new quote"`
+	CooldownSeconds int                   `json:"cooldown_seconds,omitempty" doc:"Minimum seconds between two firings"`
+	IsActive        *bool                 `json:"is_active,omitempty" doc:"Whether the rule is evaluated; defaults to true"`
+}
+
+type AlertRuleCreateInput struct {
+	Body AlertRuleBody
+}
+
+type AlertRuleUpdateInput struct {
+	ID   string `path:"id" doc:"Alert rule ID (UUID)"`
+	Body AlertRuleBody
+}
+
+type AlertRuleIDInput struct {
+	ID string `path:"id" doc:"Alert rule ID (UUID)"`
+}
+
+type AlertEventListInput struct {
+	Limit int `query:"limit" doc:"Maximum number of events (default 50, max 500)"`
+}
+
+type AlertRuleResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*models.AlertRule]
+}
+
+type AlertRuleListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]models.AlertRule]
+}
+
+type AlertRuleDeleteResponseBody struct {
+	Deleted bool `json:"deleted"`
+}
+
+type AlertRuleDeleteResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[AlertRuleDeleteResponseBody]
+}
+
+type AlertEventListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]models.AlertEvent]
+}
+
+func (c *AlertRuleController) List(ctx context.Context, _ *EmptyRequest) (*AlertRuleListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	rules, err := c.service.List(ctx, userID)
+	if err != nil {
+		return nil, alertRuleError(err)
+	}
+	return &AlertRuleListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(rules),
+	}, nil
+}
+
+func (c *AlertRuleController) Get(ctx context.Context, input *AlertRuleIDInput) (*AlertRuleResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid alert rule id")
+	}
+	rule, err := c.service.Get(ctx, userID, id)
+	if err != nil {
+		return nil, alertRuleError(err)
+	}
+	return &AlertRuleResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(rule),
+	}, nil
+}
+
+func (c *AlertRuleController) Create(ctx context.Context, input *AlertRuleCreateInput) (*AlertRuleResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	rule, err := c.service.Create(ctx, userID, ruleInput(input.Body))
+	if err != nil {
+		return nil, alertRuleError(err)
+	}
+	return &AlertRuleResponse{
+		Status: http.StatusCreated,
+		Body:   response.Success(rule),
+	}, nil
+}
+
+func (c *AlertRuleController) Update(ctx context.Context, input *AlertRuleUpdateInput) (*AlertRuleResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid alert rule id")
+	}
+	rule, err := c.service.Update(ctx, userID, id, ruleInput(input.Body))
+	if err != nil {
+		return nil, alertRuleError(err)
+	}
+	return &AlertRuleResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(rule),
+	}, nil
+}
+
+func (c *AlertRuleController) Delete(ctx context.Context, input *AlertRuleIDInput) (*AlertRuleDeleteResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid alert rule id")
+	}
+	if err := c.service.Delete(ctx, userID, id); err != nil {
+		return nil, alertRuleError(err)
+	}
+	return &AlertRuleDeleteResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(AlertRuleDeleteResponseBody{Deleted: true}),
+	}, nil
+}
+
+func (c *AlertRuleController) ListEvents(ctx context.Context, input *AlertEventListInput) (*AlertEventListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	limit := 0
+	if input != nil {
+		limit = input.Limit
+	}
+	events, err := c.service.ListEvents(ctx, userID, limit)
+	if err != nil {
+		return nil, alertRuleError(err)
+	}
+	return &AlertEventListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(events),
+	}, nil
+}
+
+func ruleInput(body AlertRuleBody) alert_rules.RuleInput {
+	return alert_rules.RuleInput{
+		Name:            body.Name,
+		Symbol:          body.Symbol,
+		Condition:       body.Condition,
+		CooldownSeconds: body.CooldownSeconds,
+		IsActive:        body.IsActive,
+	}
+}
+
+func alertRuleError(err error) error {
+	switch {
+	case errors.Is(err, alert_rules.ErrInvalidUser):
+		return apierror.NewUnauthorized("invalid token context")
+	case errors.Is(err, alert_rules.ErrRuleNotFound):
+		return apierror.NewNotFound(err.Error())
+	case errors.Is(err, alert_rules.ErrTooManyRules):
+		return apierror.NewConflict(err.Error())
+	case errors.Is(err, alert_rules.ErrInvalidRule),
+		errors.Is(err, alert_rules.ErrInvalidCondition),
+		errors.Is(err, alert_rules.ErrUnknownSymbol):
+		return apierror.NewBadRequest(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
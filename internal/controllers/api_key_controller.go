@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/authctx"
+	"sun-stockanalysis-api/internal/domains/api_keys"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type APIKeyController struct {
+	service api_keys.APIKeyService
+}
+
+func NewAPIKeyController(service api_keys.APIKeyService) *APIKeyController {
+	return &APIKeyController{service: service}
+}
+
+type APIKeyCreateInput struct {
+	Body api_keys.CreateInput
+}
+
+type APIKeyIDInput struct {
+	ID string `path:"id" doc:"API key ID (UUID)"`
+}
+
+type APIKeyListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]models.APIKey]
+}
+
+type APIKeyCreateResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*api_keys.CreatedAPIKey]
+}
+
+type APIKeyRevokeResponseBody struct {
+	Revoked bool `json:"revoked"`
+}
+
+type APIKeyRevokeResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[APIKeyRevokeResponseBody]
+}
+
+func (c *APIKeyController) List(ctx context.Context, _ *EmptyRequest) (*APIKeyListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	keys, err := c.service.List(ctx, userID)
+	if err != nil {
+		return nil, apiKeyError(err)
+	}
+	return &APIKeyListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(keys),
+	}, nil
+}
+
+func (c *APIKeyController) Create(ctx context.Context, input *APIKeyCreateInput) (*APIKeyCreateResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	created, err := c.service.Create(ctx, userID, input.Body)
+	if err != nil {
+		return nil, apiKeyError(err)
+	}
+	return &APIKeyCreateResponse{
+		Status: http.StatusCreated,
+		Body:   response.Success(created),
+	}, nil
+}
+
+func (c *APIKeyController) Revoke(ctx context.Context, input *APIKeyIDInput) (*APIKeyRevokeResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid api key id")
+	}
+	if err := c.service.Revoke(ctx, userID, id); err != nil {
+		return nil, apiKeyError(err)
+	}
+	return &APIKeyRevokeResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(APIKeyRevokeResponseBody{Revoked: true}),
+	}, nil
+}
+
+func apiKeyError(err error) error {
+	switch {
+	case errors.Is(err, api_keys.ErrInvalidUser):
+		return apierror.NewUnauthorized("invalid token context")
+	case errors.Is(err, api_keys.ErrAPIKeyNotFound):
+		return apierror.NewNotFound(err.Error())
+	case errors.Is(err, api_keys.ErrTooManyAPIKeys):
+		return apierror.NewConflict(err.Error())
+	case errors.Is(err, api_keys.ErrInvalidInput):
+		return apierror.NewBadRequest(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
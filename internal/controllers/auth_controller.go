@@ -2,8 +2,12 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/authctx"
 	"sun-stockanalysis-api/internal/domains/auth"
 	"sun-stockanalysis-api/pkg/apierror"
 	"sun-stockanalysis-api/pkg/response"
@@ -17,11 +21,16 @@ func NewAuthController(authService auth.AuthService) *AuthController {
 	return &AuthController{authService: authService}
 }
 
+// LoginResponseBody holds the token pair. When the account uses two-factor
+// authentication, login returns only MFARequired and MFAToken, to be
+// exchanged at /login/mfa.
 type LoginResponseBody struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int64  `json:"expires_in"`
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
 }
 
 type LoginResponse struct {
@@ -43,6 +52,73 @@ type RefreshResponse struct {
 	Body   response.ApiResponse[LoginResponseBody]
 }
 
+type LogoutResponseBody struct {
+	LoggedOut bool `json:"logged_out"`
+}
+
+type LogoutResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[LogoutResponseBody]
+}
+
+type SessionListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]auth.UserSession]
+}
+
+type SessionRevokeResponseBody struct {
+	Revoked bool `json:"revoked"`
+}
+
+type SessionRevokeResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[SessionRevokeResponseBody]
+}
+
+type VerifyEmailResponseBody struct {
+	Verified bool `json:"verified"`
+}
+
+type VerifyEmailResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[VerifyEmailResponseBody]
+}
+
+type EmailAcceptedResponseBody struct {
+	Accepted bool `json:"accepted"`
+}
+
+// EmailAcceptedResponse answers requests that mail a link. It looks the same
+// whether or not the address has an account.
+type EmailAcceptedResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[EmailAcceptedResponseBody]
+}
+
+type ResetPasswordResponseBody struct {
+	Reset bool `json:"reset"`
+}
+
+type ResetPasswordResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[ResetPasswordResponseBody]
+}
+
+type MFAStatusResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*auth.MFAStatus]
+}
+
+type TOTPEnrollmentResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*auth.TOTPEnrollment]
+}
+
+type RecoveryCodesResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*auth.RecoveryCodes]
+}
+
 func (c *AuthController) Login(ctx context.Context, input *auth.LoginInput) (*LoginResponse, error) {
 	_ = ctx
 
@@ -55,32 +131,63 @@ func (c *AuthController) Login(ctx context.Context, input *auth.LoginInput) (*Lo
 		if err == auth.ErrInvalidCredentials {
 			return nil, apierror.NewUnauthorized("invalid email or password")
 		}
+		if err == auth.ErrEmailNotVerified {
+			return nil, apierror.NewForbidden("email address not verified")
+		}
 		return nil, apierror.NewInternalError(err.Error())
 	}
 
 	return &LoginResponse{
 		Status: http.StatusOK,
-		Body: response.Success(LoginResponseBody{
-			AccessToken:  result.AccessToken,
-			RefreshToken: result.RefreshToken,
-			TokenType:    "Bearer",
-			ExpiresIn:    result.ExpiresIn,
-		}),
+		Body:   response.Success(loginBody(result)),
 	}, nil
 }
 
-func (c *AuthController) Register(ctx context.Context, input *auth.RegisterInput) (*RegisterResponse, error) {
-	_ = ctx
+func (c *AuthController) LoginMFA(ctx context.Context, input *auth.LoginMFAInput) (*LoginResponse, error) {
+	if input.Body.MFAToken == "" || input.Body.Code == "" {
+		return nil, apierror.NewBadRequest("mfa_token and code required")
+	}
+
+	result, err := c.authService.LoginMFA(ctx, *input)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &LoginResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(loginBody(result)),
+	}, nil
+}
 
+func loginBody(result *auth.LoginResult) LoginResponseBody {
+	if result.MFARequired {
+		return LoginResponseBody{
+			ExpiresIn:   result.ExpiresIn,
+			MFARequired: true,
+			MFAToken:    result.MFAToken,
+		}
+	}
+	return LoginResponseBody{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    result.ExpiresIn,
+	}
+}
+
+func (c *AuthController) Register(ctx context.Context, input *auth.RegisterInput) (*RegisterResponse, error) {
 	if input.Body.Email == "" || input.Body.Password == "" {
 		return nil, apierror.NewBadRequest("email and password required")
 	}
 
-	result, err := c.authService.Register(*input)
+	result, err := c.authService.Register(ctx, *input)
 	if err != nil {
 		if err == auth.ErrEmailAlreadyExists {
 			return nil, apierror.NewBadRequest("email already exists")
 		}
+		if err == auth.ErrInvalidEmail {
+			return nil, apierror.NewBadRequest("invalid email address")
+		}
 		return nil, apierror.NewInternalError(err.Error())
 	}
 
@@ -117,3 +224,244 @@ func (c *AuthController) Refresh(ctx context.Context, input *auth.RefreshInput)
 		}),
 	}, nil
 }
+
+func (c *AuthController) Logout(ctx context.Context, input *auth.LogoutInput) (*LogoutResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input.Body.RefreshToken == "" && !input.Body.All {
+		return nil, apierror.NewBadRequest("refresh_token or all required")
+	}
+
+	if err := c.authService.Logout(userID, *input); err != nil {
+		return nil, sessionError(err)
+	}
+
+	return &LogoutResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(LogoutResponseBody{LoggedOut: true}),
+	}, nil
+}
+
+func (c *AuthController) Sessions(ctx context.Context, _ *EmptyRequest) (*SessionListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+
+	sessions, err := c.authService.Sessions(userID)
+	if err != nil {
+		return nil, sessionError(err)
+	}
+
+	return &SessionListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(sessions),
+	}, nil
+}
+
+func (c *AuthController) RevokeSession(ctx context.Context, input *auth.SessionIDInput) (*SessionRevokeResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	sessionID, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid session id")
+	}
+
+	if err := c.authService.RevokeSession(userID, sessionID); err != nil {
+		return nil, sessionError(err)
+	}
+
+	return &SessionRevokeResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(SessionRevokeResponseBody{Revoked: true}),
+	}, nil
+}
+
+func (c *AuthController) VerifyEmail(ctx context.Context, input *auth.VerifyEmailInput) (*VerifyEmailResponse, error) {
+	if input.Body.Token == "" {
+		return nil, apierror.NewBadRequest("token required")
+	}
+
+	if err := c.authService.VerifyEmail(ctx, *input); err != nil {
+		return nil, emailTokenError(err)
+	}
+
+	return &VerifyEmailResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(VerifyEmailResponseBody{Verified: true}),
+	}, nil
+}
+
+func (c *AuthController) ResendVerification(ctx context.Context, input *auth.EmailInput) (*EmailAcceptedResponse, error) {
+	if input.Body.Email == "" {
+		return nil, apierror.NewBadRequest("email required")
+	}
+
+	if err := c.authService.ResendVerification(ctx, *input); err != nil {
+		return nil, emailTokenError(err)
+	}
+
+	return &EmailAcceptedResponse{
+		Status: http.StatusAccepted,
+		Body:   response.Success(EmailAcceptedResponseBody{Accepted: true}),
+	}, nil
+}
+
+func (c *AuthController) ForgotPassword(ctx context.Context, input *auth.EmailInput) (*EmailAcceptedResponse, error) {
+	if input.Body.Email == "" {
+		return nil, apierror.NewBadRequest("email required")
+	}
+
+	if err := c.authService.ForgotPassword(ctx, *input); err != nil {
+		return nil, emailTokenError(err)
+	}
+
+	return &EmailAcceptedResponse{
+		Status: http.StatusAccepted,
+		Body:   response.Success(EmailAcceptedResponseBody{Accepted: true}),
+	}, nil
+}
+
+func (c *AuthController) ResetPassword(ctx context.Context, input *auth.ResetPasswordInput) (*ResetPasswordResponse, error) {
+	if input.Body.Token == "" || input.Body.Password == "" {
+		return nil, apierror.NewBadRequest("token and password required")
+	}
+
+	if err := c.authService.ResetPassword(ctx, *input); err != nil {
+		return nil, emailTokenError(err)
+	}
+
+	return &ResetPasswordResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(ResetPasswordResponseBody{Reset: true}),
+	}, nil
+}
+
+func (c *AuthController) MFAStatus(ctx context.Context, _ *EmptyRequest) (*MFAStatusResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+
+	status, err := c.authService.MFAStatus(ctx, userID)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &MFAStatusResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(status),
+	}, nil
+}
+
+func (c *AuthController) EnrollTOTP(ctx context.Context, _ *EmptyRequest) (*TOTPEnrollmentResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+
+	enrollment, err := c.authService.EnrollTOTP(ctx, userID)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &TOTPEnrollmentResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(enrollment),
+	}, nil
+}
+
+func (c *AuthController) ConfirmTOTP(ctx context.Context, input *auth.TOTPCodeInput) (*RecoveryCodesResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input.Body.Code == "" {
+		return nil, apierror.NewBadRequest("code required")
+	}
+
+	codes, err := c.authService.ConfirmTOTP(ctx, userID, *input)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &RecoveryCodesResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(codes),
+	}, nil
+}
+
+func (c *AuthController) DisableTOTP(ctx context.Context, input *auth.TOTPCodeInput) (*MFAStatusResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input.Body.Code == "" {
+		return nil, apierror.NewBadRequest("code required")
+	}
+
+	if err := c.authService.DisableTOTP(ctx, userID, *input); err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &MFAStatusResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(&auth.MFAStatus{TOTPEnabled: false}),
+	}, nil
+}
+
+func (c *AuthController) RegenerateRecoveryCodes(ctx context.Context, input *auth.TOTPCodeInput) (*RecoveryCodesResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input.Body.Code == "" {
+		return nil, apierror.NewBadRequest("code required")
+	}
+
+	codes, err := c.authService.RegenerateRecoveryCodes(ctx, userID, *input)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &RecoveryCodesResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(codes),
+	}, nil
+}
+
+func mfaError(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrInvalidMFAToken), errors.Is(err, auth.ErrInvalidMFACode):
+		return apierror.NewUnauthorized(err.Error())
+	case errors.Is(err, auth.ErrUserNotFound):
+		return apierror.NewNotFound(err.Error())
+	case errors.Is(err, auth.ErrMFAAlreadyEnabled), errors.Is(err, auth.ErrMFANotEnabled), errors.Is(err, auth.ErrMFANotEnrolled):
+		return apierror.NewConflict(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
+
+func emailTokenError(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrInvalidToken):
+		return apierror.NewBadRequest(err.Error())
+	case errors.Is(err, auth.ErrPasswordRequired):
+		return apierror.NewBadRequest(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
+
+func sessionError(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrInvalidRefreshToken):
+		return apierror.NewUnauthorized("invalid refresh token")
+	case errors.Is(err, auth.ErrSessionNotFound):
+		return apierror.NewNotFound(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
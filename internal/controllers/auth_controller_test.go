@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"sun-stockanalysis-api/internal/domains/auth"
@@ -103,7 +104,7 @@ func (s *AuthControllerSuite) TestRegister_EmailExists() {
 	input.Body.Email = "user@example.com"
 	input.Body.Password = "secret"
 
-	s.authService.EXPECT().Register(*input).Return((*auth.RegisterResult)(nil), auth.ErrEmailAlreadyExists)
+	s.authService.EXPECT().Register(mock.Anything, *input).Return((*auth.RegisterResult)(nil), auth.ErrEmailAlreadyExists)
 
 	resp, err := s.controller.Register(context.Background(), input)
 
@@ -117,7 +118,7 @@ func (s *AuthControllerSuite) TestRegister_InternalError() {
 	input.Body.Email = "user@example.com"
 	input.Body.Password = "secret"
 
-	s.authService.EXPECT().Register(*input).Return((*auth.RegisterResult)(nil), errors.New("db down"))
+	s.authService.EXPECT().Register(mock.Anything, *input).Return((*auth.RegisterResult)(nil), errors.New("db down"))
 
 	resp, err := s.controller.Register(context.Background(), input)
 
@@ -133,7 +134,7 @@ func (s *AuthControllerSuite) TestRegister_Success() {
 	input.Body.FirstName = "Jane"
 	input.Body.LastName = "Doe"
 
-	s.authService.EXPECT().Register(*input).Return(&auth.RegisterResult{
+	s.authService.EXPECT().Register(mock.Anything, *input).Return(&auth.RegisterResult{
 		UserID: "user-id",
 	}, nil)
 
@@ -203,6 +204,80 @@ func (s *AuthControllerSuite) TestRefresh_Success() {
 	s.Equal(int64(7200), resp.Body.Data.ExpiresIn)
 }
 
+func (s *AuthControllerSuite) TestLogin_EmailNotVerified() {
+	input := &auth.LoginInput{}
+	input.Body.Email = "user@example.com"
+	input.Body.Password = "secret"
+
+	s.authService.EXPECT().Login(*input).Return((*auth.LoginResult)(nil), auth.ErrEmailNotVerified)
+
+	resp, err := s.controller.Login(context.Background(), input)
+
+	s.Nil(resp)
+	s.Error(err)
+	s.Equal(apierror.ErrCodeForbidden, err.(*apierror.APIError).Code)
+}
+
+func (s *AuthControllerSuite) TestLogin_MFAChallenge() {
+	input := &auth.LoginInput{}
+	input.Body.Email = "user@example.com"
+	input.Body.Password = "secret"
+
+	s.authService.EXPECT().Login(*input).Return(&auth.LoginResult{
+		MFARequired: true,
+		MFAToken:    "challenge",
+		ExpiresIn:   300,
+	}, nil)
+
+	resp, err := s.controller.Login(context.Background(), input)
+
+	s.NoError(err)
+	s.True(resp.Body.Data.MFARequired)
+	s.Equal("challenge", resp.Body.Data.MFAToken)
+	s.Empty(resp.Body.Data.AccessToken)
+	s.Empty(resp.Body.Data.TokenType)
+}
+
+func (s *AuthControllerSuite) TestLoginMFA_InvalidCode() {
+	input := &auth.LoginMFAInput{}
+	input.Body.MFAToken = "challenge"
+	input.Body.Code = "000000"
+
+	s.authService.EXPECT().LoginMFA(mock.Anything, *input).Return((*auth.LoginResult)(nil), auth.ErrInvalidMFACode)
+
+	resp, err := s.controller.LoginMFA(context.Background(), input)
+
+	s.Nil(resp)
+	s.Equal(apierror.ErrCodeUnauthorized, err.(*apierror.APIError).Code)
+}
+
+func (s *AuthControllerSuite) TestForgotPassword_Accepted() {
+	input := &auth.EmailInput{}
+	input.Body.Email = "user@example.com"
+
+	s.authService.EXPECT().ForgotPassword(mock.Anything, *input).Return(nil)
+
+	resp, err := s.controller.ForgotPassword(context.Background(), input)
+
+	s.NoError(err)
+	s.Equal(http.StatusAccepted, resp.Status)
+	s.True(resp.Body.Data.Accepted)
+}
+
+func (s *AuthControllerSuite) TestResetPassword_InvalidToken() {
+	input := &auth.ResetPasswordInput{}
+	input.Body.Token = "used"
+	input.Body.Password = "secret"
+
+	s.authService.EXPECT().ResetPassword(mock.Anything, *input).Return(auth.ErrInvalidToken)
+
+	resp, err := s.controller.ResetPassword(context.Background(), input)
+
+	s.Nil(resp)
+	s.Error(err)
+	s.Equal(apierror.ErrCodeBadRequest, err.(*apierror.APIError).Code)
+}
+
 func TestAuthControllerSuite(t *testing.T) {
 	suite.Run(t, new(AuthControllerSuite))
 }
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/domains/backfill"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type BackfillController struct {
+	service backfill.BackfillService
+}
+
+func NewBackfillController(service backfill.BackfillService) *BackfillController {
+	return &BackfillController{service: service}
+}
+
+type BackfillEnqueueInput struct {
+	Body struct {
+		Symbol string `json:"symbol" doc:"Symbol to backfill"`
+	}
+}
+
+type BackfillJobResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*backfill.Job]
+}
+
+type BackfillJobListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]backfill.Job]
+}
+
+type BackfillJobInput struct {
+	ID string `path:"id" doc:"Backfill job ID (UUID)"`
+}
+
+func (c *BackfillController) Enqueue(ctx context.Context, input *BackfillEnqueueInput) (*BackfillJobResponse, error) {
+	if input == nil || strings.TrimSpace(input.Body.Symbol) == "" {
+		return nil, apierror.NewBadRequest("symbol required")
+	}
+
+	job, err := c.service.Enqueue(input.Body.Symbol)
+	if err != nil {
+		if errors.Is(err, backfill.ErrQueueFull) {
+			return nil, apierror.NewConflict(err.Error())
+		}
+		return nil, apierror.NewInternalError(err.Error())
+	}
+
+	return &BackfillJobResponse{
+		Status: http.StatusAccepted,
+		Body:   response.Success(job),
+	}, nil
+}
+
+func (c *BackfillController) List(ctx context.Context, input *EmptyRequest) (*BackfillJobListResponse, error) {
+	jobs, err := c.service.List()
+	if err != nil {
+		return nil, apierror.NewInternalError(err.Error())
+	}
+	return &BackfillJobListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(jobs),
+	}, nil
+}
+
+func (c *BackfillController) Get(ctx context.Context, input *BackfillJobInput) (*BackfillJobResponse, error) {
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid backfill job id")
+	}
+
+	job, err := c.service.Get(id)
+	if err != nil {
+		if errors.Is(err, backfill.ErrJobNotFound) {
+			return nil, apierror.NewNotFound("backfill job not found")
+		}
+		return nil, apierror.NewInternalError(err.Error())
+	}
+
+	return &BackfillJobResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(job),
+	}, nil
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/authctx"
+	"sun-stockanalysis-api/internal/domains/backtests"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type BacktestController struct {
+	service backtests.BacktestService
+}
+
+func NewBacktestController(service backtests.BacktestService) *BacktestController {
+	return &BacktestController{service: service}
+}
+
+type BacktestSubmitInput struct {
+	Body struct {
+		Symbol      string                 `json:"symbol" doc:"Tracked symbol"`
+		Source      string                 `json:"source,omitempty" enum:"quotes,daily" doc:"Replay stock_quotes or stock_daily (default daily)"`
+		Strategy    string                 `json:"strategy,omitempty" enum:"score_ema,rules" doc:"score_ema (default) or rules"`
+		From        time.Time              `json:"from,omitempty" doc:"Range start (RFC 3339); defaults to 7 days (quotes) or 365 days (daily) before to"`
+		To          time.Time              `json:"to,omitempty" doc:"Range end (RFC 3339); defaults to now"`
+		InitialCash float64                `json:"initial_cash,omitempty" doc:"Starting cash (default 10000)"`
+		FeeBps      float64                `json:"fee_bps,omitempty" doc:"Fee per fill in basis points"`
+		SlippageBps float64                `json:"slippage_bps,omitempty" doc:"Slippage per fill in basis points"`
+		EntryScore  int                    `json:"entry_score,omitempty" doc:"score_ema: buy when the score reaches this (1..4, default 3)"`
+		ExitScore   int                    `json:"exit_score,omitempty" doc:"score_ema: sell when the score falls to this (-4..-1, default -3)"`
+		Entry       *models.AlertCondition `json:"entry,omitempty" doc:"rules: condition that opens a position"`
+		Exit        *models.AlertCondition `json:"exit,omitempty" doc:"rules: condition that closes a position"`
+	}
+}
+
+type BacktestJobInput struct {
+	ID string `path:"id" doc:"Backtest job ID (UUID)"`
+}
+
+type BacktestJobResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*backtests.BacktestJob]
+}
+
+type BacktestJobListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]backtests.BacktestJob]
+}
+
+func (c *BacktestController) Submit(ctx context.Context, input *BacktestSubmitInput) (*BacktestJobResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	body := input.Body
+	job, err := c.service.Submit(ctx, userID, backtests.Request{
+		Symbol:   body.Symbol,
+		Source:   body.Source,
+		Strategy: body.Strategy,
+		From:     body.From,
+		To:       body.To,
+		Params: backtests.Params{
+			InitialCash: body.InitialCash,
+			FeeBps:      body.FeeBps,
+			SlippageBps: body.SlippageBps,
+			EntryScore:  body.EntryScore,
+			ExitScore:   body.ExitScore,
+			Entry:       body.Entry,
+			Exit:        body.Exit,
+		},
+	})
+	if err != nil {
+		return nil, backtestError(err)
+	}
+	return &BacktestJobResponse{
+		Status: http.StatusAccepted,
+		Body:   response.Success(job),
+	}, nil
+}
+
+func (c *BacktestController) List(ctx context.Context, _ *EmptyRequest) (*BacktestJobListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	jobs, err := c.service.List(ctx, userID)
+	if err != nil {
+		return nil, backtestError(err)
+	}
+	return &BacktestJobListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(jobs),
+	}, nil
+}
+
+func (c *BacktestController) Get(ctx context.Context, input *BacktestJobInput) (*BacktestJobResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid backtest job id")
+	}
+	job, err := c.service.Get(ctx, userID, id)
+	if err != nil {
+		return nil, backtestError(err)
+	}
+	return &BacktestJobResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(job),
+	}, nil
+}
+
+func (c *BacktestController) Cancel(ctx context.Context, input *BacktestJobInput) (*BacktestJobResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid backtest job id")
+	}
+	job, err := c.service.Cancel(ctx, userID, id)
+	if err != nil {
+		return nil, backtestError(err)
+	}
+	return &BacktestJobResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(job),
+	}, nil
+}
+
+func backtestError(err error) error {
+	switch {
+	case errors.Is(err, backtests.ErrInvalidUser):
+		return apierror.NewUnauthorized("invalid token context")
+	case errors.Is(err, backtests.ErrJobNotFound):
+		return apierror.NewNotFound(err.Error())
+	case errors.Is(err, backtests.ErrQueueFull),
+		errors.Is(err, backtests.ErrTooManyPending),
+		errors.Is(err, backtests.ErrJobFinished):
+		return apierror.NewConflict(err.Error())
+	case errors.Is(err, backtests.ErrInvalidBacktest),
+		errors.Is(err, backtests.ErrUnknownSymbol):
+		return apierror.NewBadRequest(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
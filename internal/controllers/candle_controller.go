@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"sun-stockanalysis-api/internal/domains/candles"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type CandleController struct {
+	service candles.CandleService
+}
+
+func NewCandleController(service candles.CandleService) *CandleController {
+	return &CandleController{service: service}
+}
+
+type CandleListInput struct {
+	Symbol     string    `query:"symbol" doc:"Symbol" required:"true"`
+	Resolution string    `query:"resolution" doc:"Bucket size: 1m, 5m, 15m, 30m, 1h or 4h" default:"5m"`
+	From       time.Time `query:"from" doc:"Range start (RFC 3339); defaults to 24h before to"`
+	To         time.Time `query:"to" doc:"Range end (RFC 3339); defaults to now"`
+	Gaps       string    `query:"gaps" doc:"skip omits empty buckets; fill inserts flat candles for short gaps" default:"skip"`
+}
+
+type CandleListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]candles.Candle]
+}
+
+func (c *CandleController) List(ctx context.Context, input *CandleListInput) (*CandleListResponse, error) {
+	if input == nil || input.Symbol == "" {
+		return nil, apierror.NewBadRequest("symbol required")
+	}
+
+	result, err := c.service.List(ctx, candles.ListInput{
+		Symbol:     input.Symbol,
+		Resolution: input.Resolution,
+		From:       input.From,
+		To:         input.To,
+		Gaps:       input.Gaps,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, candles.ErrInvalidResolution),
+			errors.Is(err, candles.ErrInvalidGaps),
+			errors.Is(err, candles.ErrInvalidRange),
+			errors.Is(err, candles.ErrTooManyCandles):
+			return nil, apierror.NewBadRequest(err.Error())
+		}
+		return nil, apierror.NewInternalError(err.Error())
+	}
+
+	return &CandleListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(result),
+	}, nil
+}
@@ -19,6 +19,19 @@ type Controllers struct {
 	AuthController             *AuthController
 	RelationNewsController     *RelationNewsController
 	PushSubscriptionController *PushSubscriptionController
+	BackfillController         *BackfillController
+	IndicatorController        *IndicatorController
+	CandleController           *CandleController
+	AlertRuleController        *AlertRuleController
+	WatchlistController        *WatchlistController
+	RealtimeController         *RealtimeController
+	PortfolioController        *PortfolioController
+	BacktestController         *BacktestController
+	HoldingController          *HoldingController
+	FXRateController           *FXRateController
+	MarketCalendarController   *MarketCalendarController
+	UserController             *UserController
+	APIKeyController           *APIKeyController
 }
 
 func NewControllers(
@@ -30,6 +43,19 @@ func NewControllers(
 	authController *AuthController,
 	relationNewsController *RelationNewsController,
 	pushSubscriptionController *PushSubscriptionController,
+	backfillController *BackfillController,
+	indicatorController *IndicatorController,
+	candleController *CandleController,
+	alertRuleController *AlertRuleController,
+	watchlistController *WatchlistController,
+	realtimeController *RealtimeController,
+	portfolioController *PortfolioController,
+	backtestController *BacktestController,
+	holdingController *HoldingController,
+	fxRateController *FXRateController,
+	marketCalendarController *MarketCalendarController,
+	userController *UserController,
+	apiKeyController *APIKeyController,
 ) *Controllers {
 	return &Controllers{
 		HealthController:           healthController,
@@ -40,5 +66,18 @@ func NewControllers(
 		AuthController:             authController,
 		RelationNewsController:     relationNewsController,
 		PushSubscriptionController: pushSubscriptionController,
+		BackfillController:         backfillController,
+		IndicatorController:        indicatorController,
+		CandleController:           candleController,
+		AlertRuleController:        alertRuleController,
+		WatchlistController:        watchlistController,
+		RealtimeController:         realtimeController,
+		PortfolioController:        portfolioController,
+		BacktestController:         backtestController,
+		HoldingController:          holdingController,
+		FXRateController:           fxRateController,
+		MarketCalendarController:   marketCalendarController,
+		UserController:             userController,
+		APIKeyController:           apiKeyController,
 	}
 }
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"sun-stockanalysis-api/internal/domains/fx_rates"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type FXRateController struct {
+	service fx_rates.FXRateService
+}
+
+func NewFXRateController(service fx_rates.FXRateService) *FXRateController {
+	return &FXRateController{service: service}
+}
+
+type FXRateListInput struct {
+	Base string `query:"base" doc:"Base currency (default FX_BASE_CURRENCY)"`
+	Date string `query:"date" doc:"Rates in effect on this date (YYYY-MM-DD); defaults to today"`
+}
+
+type FXRateListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*fx_rates.RateTable]
+}
+
+type FXRateSyncResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*fx_rates.SyncResult]
+}
+
+func (c *FXRateController) List(ctx context.Context, input *FXRateListInput) (*FXRateListResponse, error) {
+	var at time.Time
+	if input.Date != "" {
+		loc := time.FixedZone("Asia/Bangkok", 7*60*60)
+		date, err := time.ParseInLocation("2006-01-02", input.Date, loc)
+		if err != nil {
+			return nil, apierror.NewBadRequest("date must be YYYY-MM-DD")
+		}
+		at = date
+	}
+	table, err := c.service.Rates(ctx, input.Base, at)
+	if err != nil {
+		return nil, fxRateError(err)
+	}
+	return &FXRateListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(table),
+	}, nil
+}
+
+func (c *FXRateController) Sync(ctx context.Context, _ *EmptyRequest) (*FXRateSyncResponse, error) {
+	result, err := c.service.Sync(ctx)
+	if err != nil {
+		return nil, fxRateError(err)
+	}
+	return &FXRateSyncResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(result),
+	}, nil
+}
+
+func fxRateError(err error) error {
+	switch {
+	case errors.Is(err, fx_rates.ErrInvalidCurrency):
+		return apierror.NewBadRequest(err.Error())
+	case errors.Is(err, fx_rates.ErrRateUnavailable):
+		return apierror.NewConflict(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
@@ -0,0 +1,240 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/authctx"
+	"sun-stockanalysis-api/internal/domains/fx_rates"
+	"sun-stockanalysis-api/internal/domains/holdings"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+const maxHoldingImportBytes = 1 << 20
+
+type HoldingController struct {
+	service holdings.HoldingService
+}
+
+func NewHoldingController(service holdings.HoldingService) *HoldingController {
+	return &HoldingController{service: service}
+}
+
+type HoldingPositionsInput struct {
+	Method   string `query:"method" enum:"fifo,average" doc:"Cost basis method (default fifo)"`
+	Currency string `query:"currency" doc:"Convert amounts into this ISO 4217 currency, e.g. THB"`
+}
+
+type HoldingHistoryInput struct {
+	From     string `query:"from" doc:"Start date (YYYY-MM-DD); defaults to 90 days before to"`
+	To       string `query:"to" doc:"End date (YYYY-MM-DD); defaults to today"`
+	Method   string `query:"method" enum:"fifo,average" doc:"Cost basis method (default fifo)"`
+	Currency string `query:"currency" doc:"Fold every currency into one series in this ISO 4217 currency"`
+}
+
+type HoldingTransactionListInput struct {
+	Symbol string `query:"symbol" doc:"Filter by symbol"`
+}
+
+type HoldingTransactionCreateInput struct {
+	Body struct {
+		Symbol   string    `json:"symbol" doc:"Tracked symbol"`
+		Type     string    `json:"type" enum:"buy,sell,dividend" doc:"Transaction type"`
+		Quantity float64   `json:"quantity,omitempty" doc:"Shares bought or sold"`
+		Price    float64   `json:"price,omitempty" doc:"Price per share in the stock's currency"`
+		Fee      float64   `json:"fee,omitempty" doc:"Commission, or withholding tax for dividends"`
+		Amount   float64   `json:"amount,omitempty" doc:"Gross dividend cash"`
+		TradedAt time.Time `json:"traded_at" doc:"Trade or payment time (RFC 3339)"`
+		Note     string    `json:"note,omitempty" doc:"Free-form note"`
+	}
+}
+
+type HoldingTransactionImportInput struct {
+	RawBody []byte `contentType:"text/csv" doc:"CSV with a header row: date,symbol,type[,quantity,price,fee,amount,note]"`
+}
+
+type HoldingTransactionIDInput struct {
+	ID string `path:"id" doc:"Transaction ID (UUID)"`
+}
+
+type HoldingPositionsResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*holdings.PositionsReport]
+}
+
+type HoldingHistoryResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*holdings.HistoryReport]
+}
+
+type HoldingTransactionResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*models.HoldingTransaction]
+}
+
+type HoldingTransactionListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]models.HoldingTransaction]
+}
+
+type HoldingDeleteResponseBody struct {
+	Deleted bool `json:"deleted"`
+}
+
+type HoldingDeleteResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[HoldingDeleteResponseBody]
+}
+
+func (c *HoldingController) Positions(ctx context.Context, input *HoldingPositionsInput) (*HoldingPositionsResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	report, err := c.service.Positions(ctx, userID, input.Method, input.Currency)
+	if err != nil {
+		return nil, holdingError(err)
+	}
+	return &HoldingPositionsResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(report),
+	}, nil
+}
+
+func (c *HoldingController) History(ctx context.Context, input *HoldingHistoryInput) (*HoldingHistoryResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
+	var from, to time.Time
+	if input.From != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", input.From, loc)
+		if err != nil {
+			return nil, apierror.NewBadRequest("invalid from date format (YYYY-MM-DD)")
+		}
+		from = parsed
+	}
+	if input.To != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", input.To, loc)
+		if err != nil {
+			return nil, apierror.NewBadRequest("invalid to date format (YYYY-MM-DD)")
+		}
+		to = parsed
+	}
+	report, err := c.service.History(ctx, userID, from, to, input.Method, input.Currency)
+	if err != nil {
+		return nil, holdingError(err)
+	}
+	return &HoldingHistoryResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(report),
+	}, nil
+}
+
+func (c *HoldingController) ListTransactions(ctx context.Context, input *HoldingTransactionListInput) (*HoldingTransactionListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	transactions, err := c.service.ListTransactions(ctx, userID, input.Symbol)
+	if err != nil {
+		return nil, holdingError(err)
+	}
+	return &HoldingTransactionListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(transactions),
+	}, nil
+}
+
+func (c *HoldingController) AddTransaction(ctx context.Context, input *HoldingTransactionCreateInput) (*HoldingTransactionResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	transaction, err := c.service.AddTransaction(ctx, userID, holdings.TransactionInput{
+		Symbol:   input.Body.Symbol,
+		Type:     input.Body.Type,
+		Quantity: input.Body.Quantity,
+		Price:    input.Body.Price,
+		Fee:      input.Body.Fee,
+		Amount:   input.Body.Amount,
+		TradedAt: input.Body.TradedAt,
+		Note:     input.Body.Note,
+	})
+	if err != nil {
+		return nil, holdingError(err)
+	}
+	return &HoldingTransactionResponse{
+		Status: http.StatusCreated,
+		Body:   response.Success(transaction),
+	}, nil
+}
+
+func (c *HoldingController) ImportTransactions(ctx context.Context, input *HoldingTransactionImportInput) (*HoldingTransactionListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil || len(input.RawBody) == 0 {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	if len(input.RawBody) > maxHoldingImportBytes {
+		return nil, apierror.NewBadRequest("import is limited to 1 MB")
+	}
+	transactions, err := c.service.ImportCSV(ctx, userID, input.RawBody)
+	if err != nil {
+		return nil, holdingError(err)
+	}
+	return &HoldingTransactionListResponse{
+		Status: http.StatusCreated,
+		Body:   response.Success(transactions),
+	}, nil
+}
+
+func (c *HoldingController) DeleteTransaction(ctx context.Context, input *HoldingTransactionIDInput) (*HoldingDeleteResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid transaction id")
+	}
+	if err := c.service.DeleteTransaction(ctx, userID, id); err != nil {
+		return nil, holdingError(err)
+	}
+	return &HoldingDeleteResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(HoldingDeleteResponseBody{Deleted: true}),
+	}, nil
+}
+
+func holdingError(err error) error {
+	switch {
+	case errors.Is(err, holdings.ErrInvalidUser):
+		return apierror.NewUnauthorized("invalid token context")
+	case errors.Is(err, holdings.ErrTransactionNotFound):
+		return apierror.NewNotFound(err.Error())
+	case errors.Is(err, holdings.ErrOversold),
+		errors.Is(err, holdings.ErrTooManyTransactions),
+		errors.Is(err, fx_rates.ErrRateUnavailable):
+		return apierror.NewConflict(err.Error())
+	case errors.Is(err, holdings.ErrInvalidTransaction),
+		errors.Is(err, holdings.ErrInvalidImport),
+		errors.Is(err, holdings.ErrInvalidQuery),
+		errors.Is(err, holdings.ErrUnknownSymbol),
+		errors.Is(err, fx_rates.ErrInvalidCurrency):
+		return apierror.NewBadRequest(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"sun-stockanalysis-api/internal/domains/stock_daily"
+	"sun-stockanalysis-api/internal/domains/stock_quotes"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type IndicatorController struct {
+	quoteService stock_quotes.StockQuoteService
+	dailyService stock_daily.StockDailyService
+}
+
+func NewIndicatorController(quoteService stock_quotes.StockQuoteService, dailyService stock_daily.StockDailyService) *IndicatorController {
+	return &IndicatorController{
+		quoteService: quoteService,
+		dailyService: dailyService,
+	}
+}
+
+type RecomputeIndicatorsInput struct {
+	Body struct {
+		Symbol string `json:"symbol,omitempty" doc:"Symbol to recompute; all active symbols when empty"`
+	}
+}
+
+type RecomputeIndicatorsResult struct {
+	QuotesUpdated int `json:"quotes_updated"`
+	DailyUpdated  int `json:"daily_updated"`
+}
+
+type RecomputeIndicatorsResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[RecomputeIndicatorsResult]
+}
+
+func (c *IndicatorController) RecomputeIndicators(ctx context.Context, input *RecomputeIndicatorsInput) (*RecomputeIndicatorsResponse, error) {
+	symbol := ""
+	if input != nil {
+		symbol = input.Body.Symbol
+	}
+
+	quotes, err := c.quoteService.RecomputeIndicators(ctx, symbol)
+	if err != nil {
+		return nil, apierror.NewInternalError(err.Error())
+	}
+	daily, err := c.dailyService.RecomputeIndicators(ctx, symbol)
+	if err != nil {
+		return nil, apierror.NewInternalError(err.Error())
+	}
+
+	return &RecomputeIndicatorsResponse{
+		Status: http.StatusOK,
+		Body: response.Success(RecomputeIndicatorsResult{
+			QuotesUpdated: quotes,
+			DailyUpdated:  daily,
+		}),
+	}, nil
+}
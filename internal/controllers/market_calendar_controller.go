@@ -0,0 +1,302 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/domains/market_calendar"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+const (
+	defaultSessionDays = 14
+	defaultHolidayDays = 365
+)
+
+type MarketCalendarController struct {
+	service market_calendar.MarketCalendarService
+}
+
+func NewMarketCalendarController(service market_calendar.MarketCalendarService) *MarketCalendarController {
+	return &MarketCalendarController{service: service}
+}
+
+type MarketCalendarCodeInput struct {
+	Code string `path:"code" doc:"Calendar code, e.g. US"`
+}
+
+type MarketCalendarRangeInput struct {
+	Code string `path:"code" doc:"Calendar code, e.g. US"`
+	From string `query:"from" doc:"First date (YYYY-MM-DD); defaults to today"`
+	To   string `query:"to" doc:"Last date (YYYY-MM-DD); defaults to two weeks after from"`
+}
+
+type MarketCalendarSaveInput struct {
+	Code string `path:"code" doc:"Calendar code, e.g. US"`
+	Body struct {
+		Name         string `json:"name,omitempty" doc:"Display name"`
+		Timezone     string `json:"timezone" doc:"IANA time zone of the exchange, e.g. America/New_York"`
+		PreOpen      string `json:"pre_open,omitempty" doc:"Pre-market start (HH:MM local)"`
+		Open         string `json:"open" doc:"Regular session open (HH:MM local)"`
+		Close        string `json:"close" doc:"Regular session close (HH:MM local)"`
+		PostClose    string `json:"post_close,omitempty" doc:"Post-market end (HH:MM local)"`
+		Weekdays     string `json:"weekdays,omitempty" doc:"Trading weekdays as Go weekday numbers, Sunday = 0 (default 1,2,3,4,5)"`
+		ProviderCode string `json:"provider_code,omitempty" doc:"Exchange code used to ingest holidays"`
+	}
+}
+
+type MarketHolidayDateInput struct {
+	Code string `path:"code" doc:"Calendar code, e.g. US"`
+	Date string `path:"date" doc:"Holiday date (YYYY-MM-DD)"`
+}
+
+type MarketHolidaySetInput struct {
+	Code string `path:"code" doc:"Calendar code, e.g. US"`
+	Date string `path:"date" doc:"Holiday date (YYYY-MM-DD)"`
+	Body struct {
+		Name       string `json:"name" doc:"Holiday name"`
+		EarlyClose string `json:"early_close,omitempty" doc:"Early close (HH:MM local); omit for a full closure"`
+	}
+}
+
+type MasterExchangeCalendarInput struct {
+	ID   string `path:"id" doc:"Exchange ID (UUID)"`
+	Body struct {
+		CalendarCode string `json:"calendar_code" doc:"Calendar that governs the exchange"`
+	}
+}
+
+type MarketCalendarListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]market_calendar.CalendarStatus]
+}
+
+type MarketCalendarResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*models.MarketCalendar]
+}
+
+type MarketSessionListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]market_calendar.Session]
+}
+
+type MarketHolidayListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]models.MarketHoliday]
+}
+
+type MarketHolidayResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*models.MarketHoliday]
+}
+
+type MarketHolidayDeleteResponseBody struct {
+	Deleted bool `json:"deleted"`
+}
+
+type MarketHolidayDeleteResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[MarketHolidayDeleteResponseBody]
+}
+
+type MarketHolidaySyncResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*market_calendar.HolidaySyncResult]
+}
+
+type MasterExchangeListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]models.MasterExchange]
+}
+
+type MasterExchangeResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*models.MasterExchange]
+}
+
+func (c *MarketCalendarController) List(ctx context.Context, _ *EmptyRequest) (*MarketCalendarListResponse, error) {
+	calendars, err := c.service.List(ctx)
+	if err != nil {
+		return nil, marketCalendarError(err)
+	}
+	return &MarketCalendarListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(calendars),
+	}, nil
+}
+
+func (c *MarketCalendarController) Sessions(ctx context.Context, input *MarketCalendarRangeInput) (*MarketSessionListResponse, error) {
+	from, to, err := calendarDateRange(input.From, input.To, defaultSessionDays)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := c.service.Sessions(ctx, input.Code, from, to)
+	if err != nil {
+		return nil, marketCalendarError(err)
+	}
+	return &MarketSessionListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(sessions),
+	}, nil
+}
+
+func (c *MarketCalendarController) Holidays(ctx context.Context, input *MarketCalendarRangeInput) (*MarketHolidayListResponse, error) {
+	from, to, err := calendarDateRange(input.From, input.To, defaultHolidayDays)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := c.service.Holidays(ctx, input.Code, from, to)
+	if err != nil {
+		return nil, marketCalendarError(err)
+	}
+	return &MarketHolidayListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(holidays),
+	}, nil
+}
+
+func (c *MarketCalendarController) Save(ctx context.Context, input *MarketCalendarSaveInput) (*MarketCalendarResponse, error) {
+	calendar, err := c.service.SaveCalendar(ctx, models.MarketCalendar{
+		Code:         input.Code,
+		Name:         input.Body.Name,
+		Timezone:     input.Body.Timezone,
+		PreOpen:      input.Body.PreOpen,
+		Open:         input.Body.Open,
+		Close:        input.Body.Close,
+		PostClose:    input.Body.PostClose,
+		Weekdays:     input.Body.Weekdays,
+		ProviderCode: input.Body.ProviderCode,
+	})
+	if err != nil {
+		return nil, marketCalendarError(err)
+	}
+	return &MarketCalendarResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(calendar),
+	}, nil
+}
+
+func (c *MarketCalendarController) SetHoliday(ctx context.Context, input *MarketHolidaySetInput) (*MarketHolidayResponse, error) {
+	date, err := calendarDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+	holiday, err := c.service.SetHoliday(ctx, input.Code, market_calendar.HolidayInput{
+		Date:       date,
+		Name:       input.Body.Name,
+		EarlyClose: input.Body.EarlyClose,
+	})
+	if err != nil {
+		return nil, marketCalendarError(err)
+	}
+	return &MarketHolidayResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(holiday),
+	}, nil
+}
+
+func (c *MarketCalendarController) DeleteHoliday(ctx context.Context, input *MarketHolidayDateInput) (*MarketHolidayDeleteResponse, error) {
+	date, err := calendarDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.service.DeleteHoliday(ctx, input.Code, date); err != nil {
+		return nil, marketCalendarError(err)
+	}
+	return &MarketHolidayDeleteResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(MarketHolidayDeleteResponseBody{Deleted: true}),
+	}, nil
+}
+
+func (c *MarketCalendarController) SyncHolidays(ctx context.Context, input *MarketCalendarCodeInput) (*MarketHolidaySyncResponse, error) {
+	result, err := c.service.SyncHolidays(ctx, input.Code)
+	if err != nil {
+		return nil, marketCalendarError(err)
+	}
+	return &MarketHolidaySyncResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(result),
+	}, nil
+}
+
+func (c *MarketCalendarController) ListExchanges(ctx context.Context, _ *EmptyRequest) (*MasterExchangeListResponse, error) {
+	exchanges, err := c.service.ListExchanges(ctx)
+	if err != nil {
+		return nil, marketCalendarError(err)
+	}
+	return &MasterExchangeListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(exchanges),
+	}, nil
+}
+
+func (c *MarketCalendarController) SetExchangeCalendar(ctx context.Context, input *MasterExchangeCalendarInput) (*MasterExchangeResponse, error) {
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid exchange id")
+	}
+	exchange, err := c.service.SetExchangeCalendar(ctx, id, input.Body.CalendarCode)
+	if err != nil {
+		return nil, marketCalendarError(err)
+	}
+	return &MasterExchangeResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(exchange),
+	}, nil
+}
+
+func calendarDate(value string) (time.Time, error) {
+	date, err := time.ParseInLocation("2006-01-02", value, time.FixedZone("Asia/Bangkok", 7*60*60))
+	if err != nil {
+		return time.Time{}, apierror.NewBadRequest("date must be YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+// calendarDateRange parses optional from/to dates, defaulting from to today and
+// to to defaultDays after from.
+func calendarDateRange(fromValue, toValue string, defaultDays int) (time.Time, time.Time, error) {
+	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if fromValue != "" {
+		parsed, err := calendarDate(fromValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 0, defaultDays)
+	if toValue != "" {
+		parsed, err := calendarDate(toValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+	return from, to, nil
+}
+
+func marketCalendarError(err error) error {
+	switch {
+	case errors.Is(err, market_calendar.ErrInvalidCalendar),
+		errors.Is(err, market_calendar.ErrInvalidHoliday),
+		errors.Is(err, market_calendar.ErrInvalidRange):
+		return apierror.NewBadRequest(err.Error())
+	case errors.Is(err, market_calendar.ErrCalendarNotFound),
+		errors.Is(err, market_calendar.ErrHolidayNotFound),
+		errors.Is(err, market_calendar.ErrExchangeNotFound):
+		return apierror.NewNotFound(err.Error())
+	case errors.Is(err, market_calendar.ErrHolidaySyncUnsupported):
+		return apierror.NewConflict(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
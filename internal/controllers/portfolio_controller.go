@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/authctx"
+	"sun-stockanalysis-api/internal/domains/fx_rates"
+	"sun-stockanalysis-api/internal/domains/portfolios"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type PortfolioController struct {
+	service portfolios.PortfolioService
+}
+
+func NewPortfolioController(service portfolios.PortfolioService) *PortfolioController {
+	return &PortfolioController{service: service}
+}
+
+type PortfolioCreateInput struct {
+	Body struct {
+		Name        string   `json:"name" doc:"Portfolio name"`
+		InitialCash *float64 `json:"initial_cash,omitempty" doc:"Starting cash; defaults to PORTFOLIO_DEFAULT_CASH"`
+	}
+}
+
+type PortfolioIDInput struct {
+	ID string `path:"id" doc:"Portfolio ID (UUID)"`
+}
+
+type PortfolioSummaryInput struct {
+	ID       string `path:"id" doc:"Portfolio ID (UUID)"`
+	Currency string `query:"currency" doc:"Convert amounts into this ISO 4217 currency, e.g. THB"`
+}
+
+type PortfolioOrderListInput struct {
+	ID     string `path:"id" doc:"Portfolio ID (UUID)"`
+	Status string `query:"status" doc:"Filter by status: open, filled, canceled or rejected"`
+	Limit  int    `query:"limit" doc:"Maximum number of orders (default 100, max 500)"`
+}
+
+type PortfolioOrderCreateInput struct {
+	ID   string `path:"id" doc:"Portfolio ID (UUID)"`
+	Body struct {
+		Symbol     string   `json:"symbol" doc:"Tracked symbol"`
+		Side       string   `json:"side" doc:"buy or sell"`
+		Type       string   `json:"type,omitempty" doc:"market (default) or limit"`
+		Quantity   float64  `json:"quantity" doc:"Number of shares"`
+		LimitPrice *float64 `json:"limit_price,omitempty" doc:"Limit price, required for limit orders"`
+	}
+}
+
+type PortfolioOrderIDInput struct {
+	ID      string `path:"id" doc:"Portfolio ID (UUID)"`
+	OrderID string `path:"orderId" doc:"Order ID (UUID)"`
+}
+
+type PortfolioResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*models.Portfolio]
+}
+
+type PortfolioListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]models.Portfolio]
+}
+
+type PortfolioSummaryResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*portfolios.Summary]
+}
+
+type PortfolioOrderResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*models.PortfolioOrder]
+}
+
+type PortfolioOrderListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]models.PortfolioOrder]
+}
+
+type PortfolioDeleteResponseBody struct {
+	Deleted bool `json:"deleted"`
+}
+
+type PortfolioDeleteResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[PortfolioDeleteResponseBody]
+}
+
+func (c *PortfolioController) List(ctx context.Context, _ *EmptyRequest) (*PortfolioListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	list, err := c.service.List(ctx, userID)
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	return &PortfolioListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(list),
+	}, nil
+}
+
+func (c *PortfolioController) Create(ctx context.Context, input *PortfolioCreateInput) (*PortfolioResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	portfolio, err := c.service.Create(ctx, userID, input.Body.Name, input.Body.InitialCash)
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	return &PortfolioResponse{
+		Status: http.StatusCreated,
+		Body:   response.Success(portfolio),
+	}, nil
+}
+
+func (c *PortfolioController) Get(ctx context.Context, input *PortfolioSummaryInput) (*PortfolioSummaryResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid portfolio id")
+	}
+	summary, err := c.service.Summary(ctx, userID, id, input.Currency)
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	return &PortfolioSummaryResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(summary),
+	}, nil
+}
+
+func (c *PortfolioController) Delete(ctx context.Context, input *PortfolioIDInput) (*PortfolioDeleteResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid portfolio id")
+	}
+	if err := c.service.Delete(ctx, userID, id); err != nil {
+		return nil, portfolioError(err)
+	}
+	return &PortfolioDeleteResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(PortfolioDeleteResponseBody{Deleted: true}),
+	}, nil
+}
+
+func (c *PortfolioController) ListOrders(ctx context.Context, input *PortfolioOrderListInput) (*PortfolioOrderListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid portfolio id")
+	}
+	orders, err := c.service.ListOrders(ctx, userID, id, input.Status, input.Limit)
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	return &PortfolioOrderListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(orders),
+	}, nil
+}
+
+func (c *PortfolioController) PlaceOrder(ctx context.Context, input *PortfolioOrderCreateInput) (*PortfolioOrderResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid portfolio id")
+	}
+	order, err := c.service.PlaceOrder(ctx, userID, id, portfolios.OrderInput{
+		Symbol:     input.Body.Symbol,
+		Side:       input.Body.Side,
+		Type:       input.Body.Type,
+		Quantity:   input.Body.Quantity,
+		LimitPrice: input.Body.LimitPrice,
+	})
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	return &PortfolioOrderResponse{
+		Status: http.StatusCreated,
+		Body:   response.Success(order),
+	}, nil
+}
+
+func (c *PortfolioController) CancelOrder(ctx context.Context, input *PortfolioOrderIDInput) (*PortfolioOrderResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid portfolio id")
+	}
+	orderID, err := uuid.Parse(input.OrderID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid order id")
+	}
+	order, err := c.service.CancelOrder(ctx, userID, id, orderID)
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	return &PortfolioOrderResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(order),
+	}, nil
+}
+
+func portfolioError(err error) error {
+	switch {
+	case errors.Is(err, portfolios.ErrInvalidUser):
+		return apierror.NewUnauthorized("invalid token context")
+	case errors.Is(err, portfolios.ErrPortfolioNotFound),
+		errors.Is(err, portfolios.ErrOrderNotFound):
+		return apierror.NewNotFound(err.Error())
+	case errors.Is(err, portfolios.ErrTooManyPortfolios),
+		errors.Is(err, portfolios.ErrOrderNotOpen),
+		errors.Is(err, portfolios.ErrNoQuote),
+		errors.Is(err, portfolios.ErrInsufficientCash),
+		errors.Is(err, portfolios.ErrInsufficientHolding),
+		errors.Is(err, fx_rates.ErrRateUnavailable):
+		return apierror.NewConflict(err.Error())
+	case errors.Is(err, portfolios.ErrInvalidPortfolio),
+		errors.Is(err, portfolios.ErrInvalidOrder),
+		errors.Is(err, portfolios.ErrUnknownSymbol),
+		errors.Is(err, fx_rates.ErrInvalidCurrency):
+		return apierror.NewBadRequest(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
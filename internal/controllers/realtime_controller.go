@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"sun-stockanalysis-api/internal/realtime"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+// HubStatsProvider reports the connection and delivery counters of a
+// WebSocket hub.
+type HubStatsProvider interface {
+	Stats() realtime.HubStats
+}
+
+type RealtimeController struct {
+	alerts      HubStatsProvider
+	stockQuotes HubStatsProvider
+}
+
+func NewRealtimeController(alerts, stockQuotes HubStatsProvider) *RealtimeController {
+	return &RealtimeController{
+		alerts:      alerts,
+		stockQuotes: stockQuotes,
+	}
+}
+
+type RealtimeStatsBody struct {
+	Alerts      realtime.HubStats `json:"alerts"`
+	StockQuotes realtime.HubStats `json:"stock_quotes"`
+}
+
+type RealtimeStatsResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[RealtimeStatsBody]
+}
+
+func (c *RealtimeController) Stats(ctx context.Context, input *EmptyRequest) (*RealtimeStatsResponse, error) {
+	var body RealtimeStatsBody
+	if c.alerts != nil {
+		body.Alerts = c.alerts.Stats()
+	}
+	if c.stockQuotes != nil {
+		body.StockQuotes = c.stockQuotes.Stats()
+	}
+	return &RealtimeStatsResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(body),
+	}, nil
+}
@@ -2,8 +2,12 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
+	"sun-stockanalysis-api/internal/domains/fx_rates"
 	"sun-stockanalysis-api/internal/domains/stock_daily"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/pkg/apierror"
@@ -12,14 +16,16 @@ import (
 
 type StockDailyController struct {
 	service stock_daily.StockDailyService
+	fx      fx_rates.FXRateService
 }
 
-func NewStockDailyController(service stock_daily.StockDailyService) *StockDailyController {
-	return &StockDailyController{service: service}
+func NewStockDailyController(service stock_daily.StockDailyService, fx fx_rates.FXRateService) *StockDailyController {
+	return &StockDailyController{service: service, fx: fx}
 }
 
 type StockDailyListInput struct {
-	Symbol string `query:"symbol" doc:"Filter by symbol" required:"true"`
+	Symbol   string `query:"symbol" doc:"Filter by symbol" required:"true"`
+	Currency string `query:"currency" doc:"Convert prices into this ISO 4217 currency at each trade date's rate"`
 }
 
 type StockDailyListResponse struct {
@@ -36,9 +42,55 @@ func (c *StockDailyController) ListBySymbol(ctx context.Context, input *StockDai
 	if err != nil {
 		return nil, apierror.NewInternalError(err.Error())
 	}
+	if strings.TrimSpace(input.Currency) != "" {
+		if err := c.fx.ConvertDaily(ctx, metrics, input.Currency); err != nil {
+			return nil, fxRateError(err)
+		}
+	}
 
 	return &StockDailyListResponse{
 		Status: http.StatusOK,
 		Body:   response.Success(metrics),
 	}, nil
 }
+
+type StockDailyRebuildInput struct {
+	Body struct {
+		Symbol string `json:"symbol,omitempty" doc:"Symbol to rebuild; all active symbols when empty"`
+		From   string `json:"from" doc:"First trade date (YYYY-MM-DD, Asia/Bangkok)" required:"true"`
+		To     string `json:"to" doc:"Last trade date (YYYY-MM-DD, Asia/Bangkok), inclusive" required:"true"`
+	}
+}
+
+type StockDailyRebuildResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[stock_daily.RebuildResult]
+}
+
+func (c *StockDailyController) Rebuild(ctx context.Context, input *StockDailyRebuildInput) (*StockDailyRebuildResponse, error) {
+	if input == nil {
+		return nil, apierror.NewBadRequest("from and to required")
+	}
+	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
+	from, err := time.ParseInLocation("2006-01-02", input.Body.From, loc)
+	if err != nil {
+		return nil, apierror.NewBadRequest("from must be YYYY-MM-DD")
+	}
+	to, err := time.ParseInLocation("2006-01-02", input.Body.To, loc)
+	if err != nil {
+		return nil, apierror.NewBadRequest("to must be YYYY-MM-DD")
+	}
+
+	result, err := c.service.Rebuild(ctx, input.Body.Symbol, from, to)
+	if err != nil {
+		if errors.Is(err, stock_daily.ErrInvalidRange) {
+			return nil, apierror.NewBadRequest(err.Error())
+		}
+		return nil, apierror.NewInternalError(err.Error())
+	}
+
+	return &StockDailyRebuildResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(*result),
+	}, nil
+}
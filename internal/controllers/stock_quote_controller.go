@@ -3,7 +3,9 @@ package controllers
 import (
 	"context"
 	"net/http"
+	"strings"
 
+	"sun-stockanalysis-api/internal/domains/fx_rates"
 	"sun-stockanalysis-api/internal/domains/stock_quotes"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/pkg/apierror"
@@ -12,10 +14,11 @@ import (
 
 type StockQuoteController struct {
 	service stock_quotes.StockQuoteService
+	fx      fx_rates.FXRateService
 }
 
-func NewStockQuoteController(service stock_quotes.StockQuoteService) *StockQuoteController {
-	return &StockQuoteController{service: service}
+func NewStockQuoteController(service stock_quotes.StockQuoteService, fx fx_rates.FXRateService) *StockQuoteController {
+	return &StockQuoteController{service: service, fx: fx}
 }
 
 type StockQuoteListResponse struct {
@@ -24,21 +27,44 @@ type StockQuoteListResponse struct {
 }
 
 type StockQuoteListInput struct {
-	Symbol string `query:"symbol" doc:"Filter by symbol"`
+	Symbol   string `query:"symbol" doc:"Filter by symbol"`
+	Currency string `query:"currency" doc:"Convert prices into this ISO 4217 currency at each quote's daily rate"`
 }
 
 func (c *StockQuoteController) ListAll(ctx context.Context, input *StockQuoteListInput) (*StockQuoteListResponse, error) {
-	symbol := ""
+	symbol, currency := "", ""
 	if input != nil {
-		symbol = input.Symbol
+		symbol, currency = input.Symbol, input.Currency
 	}
 	quotes, err := c.service.List(ctx, symbol)
 	if err != nil {
 		return nil, apierror.NewInternalError(err.Error())
 	}
+	if strings.TrimSpace(currency) != "" {
+		if err := c.fx.ConvertQuotes(ctx, quotes, currency); err != nil {
+			return nil, fxRateError(err)
+		}
+	}
 
 	return &StockQuoteListResponse{
 		Status: http.StatusOK,
 		Body:   response.Success(quotes),
 	}, nil
 }
+
+type StockQuoteCycleResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*stock_quotes.CycleResult]
+}
+
+func (c *StockQuoteController) LatestCycle(ctx context.Context, input *EmptyRequest) (*StockQuoteCycleResponse, error) {
+	cycle := c.service.LastCycle()
+	if cycle == nil {
+		return nil, apierror.NewNotFound("no quote cycle has run yet")
+	}
+
+	return &StockQuoteCycleResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(cycle),
+	}, nil
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/domains/users"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type UserController struct {
+	service users.UserService
+}
+
+func NewUserController(service users.UserService) *UserController {
+	return &UserController{service: service}
+}
+
+type UserIDInput struct {
+	ID string `path:"id" doc:"User ID (UUID)"`
+}
+
+type UserListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]users.Account]
+}
+
+type UserResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*users.Account]
+}
+
+func (c *UserController) List(ctx context.Context, _ *EmptyRequest) (*UserListResponse, error) {
+	accounts, err := c.service.List(ctx)
+	if err != nil {
+		return nil, userError(err)
+	}
+	return &UserListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(accounts),
+	}, nil
+}
+
+func (c *UserController) Promote(ctx context.Context, input *UserIDInput) (*UserResponse, error) {
+	return c.setRole(ctx, input.ID, models.RoleAdmin)
+}
+
+func (c *UserController) Demote(ctx context.Context, input *UserIDInput) (*UserResponse, error) {
+	return c.setRole(ctx, input.ID, models.RoleUser)
+}
+
+func (c *UserController) setRole(ctx context.Context, rawID, role string) (*UserResponse, error) {
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid user id")
+	}
+	account, err := c.service.SetRole(ctx, id, role)
+	if err != nil {
+		return nil, userError(err)
+	}
+	return &UserResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(account),
+	}, nil
+}
+
+func userError(err error) error {
+	switch {
+	case errors.Is(err, users.ErrUserNotFound):
+		return apierror.NewNotFound(err.Error())
+	case errors.Is(err, users.ErrInvalidRole):
+		return apierror.NewBadRequest(err.Error())
+	case errors.Is(err, users.ErrLastAdmin):
+		return apierror.NewConflict(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
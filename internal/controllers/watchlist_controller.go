@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/authctx"
+	"sun-stockanalysis-api/internal/domains/watchlists"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/pkg/apierror"
+	"sun-stockanalysis-api/pkg/response"
+)
+
+type WatchlistController struct {
+	service watchlists.WatchlistService
+}
+
+func NewWatchlistController(service watchlists.WatchlistService) *WatchlistController {
+	return &WatchlistController{service: service}
+}
+
+type WatchlistBody struct {
+	Name    string   `json:"name" doc:"Watchlist name"`
+	Symbols []string `json:"symbols,omitempty" doc:"Tracked symbols on the watchlist"`
+}
+
+type WatchlistCreateInput struct {
+	Body WatchlistBody
+}
+
+type WatchlistUpdateInput struct {
+	ID   string `path:"id" doc:"Watchlist ID (UUID)"`
+	Body WatchlistBody
+}
+
+type WatchlistIDInput struct {
+	ID string `path:"id" doc:"Watchlist ID (UUID)"`
+}
+
+type WatchlistAddSymbolInput struct {
+	ID   string `path:"id" doc:"Watchlist ID (UUID)"`
+	Body struct {
+		Symbol string `json:"symbol" doc:"Symbol to add"`
+	}
+}
+
+type WatchlistRemoveSymbolInput struct {
+	ID     string `path:"id" doc:"Watchlist ID (UUID)"`
+	Symbol string `path:"symbol" doc:"Symbol to remove"`
+}
+
+type WatchlistResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[*models.Watchlist]
+}
+
+type WatchlistListResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[[]models.Watchlist]
+}
+
+type WatchlistDeleteResponseBody struct {
+	Deleted bool `json:"deleted"`
+}
+
+type WatchlistDeleteResponse struct {
+	Status int `status:"default"`
+	Body   response.ApiResponse[WatchlistDeleteResponseBody]
+}
+
+func (c *WatchlistController) List(ctx context.Context, _ *EmptyRequest) (*WatchlistListResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	lists, err := c.service.List(ctx, userID)
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return &WatchlistListResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(lists),
+	}, nil
+}
+
+func (c *WatchlistController) Get(ctx context.Context, input *WatchlistIDInput) (*WatchlistResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid watchlist id")
+	}
+	watchlist, err := c.service.Get(ctx, userID, id)
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return &WatchlistResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(watchlist),
+	}, nil
+}
+
+func (c *WatchlistController) Create(ctx context.Context, input *WatchlistCreateInput) (*WatchlistResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	watchlist, err := c.service.Create(ctx, userID, input.Body.Name, input.Body.Symbols)
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return &WatchlistResponse{
+		Status: http.StatusCreated,
+		Body:   response.Success(watchlist),
+	}, nil
+}
+
+func (c *WatchlistController) Update(ctx context.Context, input *WatchlistUpdateInput) (*WatchlistResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid watchlist id")
+	}
+	watchlist, err := c.service.Update(ctx, userID, id, input.Body.Name, input.Body.Symbols)
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return &WatchlistResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(watchlist),
+	}, nil
+}
+
+func (c *WatchlistController) Delete(ctx context.Context, input *WatchlistIDInput) (*WatchlistDeleteResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid watchlist id")
+	}
+	if err := c.service.Delete(ctx, userID, id); err != nil {
+		return nil, watchlistError(err)
+	}
+	return &WatchlistDeleteResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(WatchlistDeleteResponseBody{Deleted: true}),
+	}, nil
+}
+
+func (c *WatchlistController) AddSymbol(ctx context.Context, input *WatchlistAddSymbolInput) (*WatchlistResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	if input == nil {
+		return nil, apierror.NewBadRequest("request body required")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid watchlist id")
+	}
+	watchlist, err := c.service.AddSymbol(ctx, userID, id, input.Body.Symbol)
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return &WatchlistResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(watchlist),
+	}, nil
+}
+
+func (c *WatchlistController) RemoveSymbol(ctx context.Context, input *WatchlistRemoveSymbolInput) (*WatchlistResponse, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apierror.NewUnauthorized("invalid token context")
+	}
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, apierror.NewBadRequest("invalid watchlist id")
+	}
+	watchlist, err := c.service.RemoveSymbol(ctx, userID, id, input.Symbol)
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return &WatchlistResponse{
+		Status: http.StatusOK,
+		Body:   response.Success(watchlist),
+	}, nil
+}
+
+func watchlistError(err error) error {
+	switch {
+	case errors.Is(err, watchlists.ErrInvalidUser):
+		return apierror.NewUnauthorized("invalid token context")
+	case errors.Is(err, watchlists.ErrWatchlistNotFound):
+		return apierror.NewNotFound(err.Error())
+	case errors.Is(err, watchlists.ErrTooManyWatchlists):
+		return apierror.NewConflict(err.Error())
+	case errors.Is(err, watchlists.ErrInvalidWatchlist),
+		errors.Is(err, watchlists.ErrUnknownSymbol):
+		return apierror.NewBadRequest(err.Error())
+	}
+	return apierror.NewInternalError(err.Error())
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+// Migrate runs the schema fix-ups that AutoMigrate cannot express and then
+// auto-migrates models.
+func Migrate(db *gorm.DB, models ...any) error {
+	legacyDaily, err := prepareStockDaily(db)
+	if err != nil {
+		return err
+	}
+	if err := archiveLegacyAlertEvents(db); err != nil {
+		return err
+	}
+	if err := dropLegacyMarketOpenIndex(db); err != nil {
+		return err
+	}
+	if err := convertRefreshTokenTimestamps(db); err != nil {
+		return err
+	}
+	if err := addEmailVerifiedColumn(db); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		return err
+	}
+	if legacyDaily {
+		return backfillStockDailyCloses(db)
+	}
+	return nil
+}
+
+// prepareStockDaily renames the legacy stock_daily columns and drops duplicate
+// (symbol, trade_date) rows, keeping the newest, so the unique index can be
+// created. It reports whether the table predates the price_close column.
+func prepareStockDaily(db *gorm.DB) (bool, error) {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.StockDaily{}) {
+		return false, nil
+	}
+	renames := [][2]string{
+		{"dalta_price", "delta_price"},
+		{"trend_date", "trade_date"},
+	}
+	for _, rename := range renames {
+		if migrator.HasColumn(&models.StockDaily{}, rename[0]) && !migrator.HasColumn(&models.StockDaily{}, rename[1]) {
+			if err := migrator.RenameColumn(&models.StockDaily{}, rename[0], rename[1]); err != nil {
+				return false, err
+			}
+		}
+	}
+	legacy := !migrator.HasColumn(&models.StockDaily{}, "price_close")
+
+	err := db.Exec(`DELETE FROM stock_daily a USING stock_daily b
+		WHERE a.symbol = b.symbol AND a.trade_date = b.trade_date
+		AND (a.created_at < b.created_at OR (a.created_at = b.created_at AND a.id < b.id))`).Error
+	return legacy, err
+}
+
+// backfillStockDailyCloses moves the close that legacy rows stored in
+// price_prev_close into price_close and recomputes the previous close from the
+// prior trade date.
+func backfillStockDailyCloses(db *gorm.DB) error {
+	if err := db.Exec(`UPDATE stock_daily SET price_close = price_prev_close WHERE price_close = 0`).Error; err != nil {
+		return err
+	}
+	return db.Exec(`UPDATE stock_daily d SET price_prev_close = COALESCE(p.prev_close, 0)
+		FROM (
+			SELECT id, LAG(price_close) OVER (PARTITION BY symbol ORDER BY trade_date) AS prev_close
+			FROM stock_daily
+		) p
+		WHERE d.id = p.id`).Error
+}
+
+// archiveLegacyAlertEvents moves the events written by the old ScoreEMA
+// scoring into alert_events_legacy and drops the score columns. Those events
+// belong to no rule or user and cannot gain the new not-null columns, so they
+// are kept aside rather than in alert_events.
+func archiveLegacyAlertEvents(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.AlertEvent{}) || !migrator.HasColumn(&models.AlertEvent{}, "score_ema") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`CREATE TABLE IF NOT EXISTS alert_events_legacy AS TABLE alert_events WITH NO DATA`,
+			`INSERT INTO alert_events_legacy SELECT * FROM alert_events`,
+			`DELETE FROM alert_events`,
+			`ALTER TABLE alert_events
+				DROP COLUMN IF EXISTS trend_ema_20,
+				DROP COLUMN IF EXISTS trend_tanh_ema,
+				DROP COLUMN IF EXISTS score_ema,
+				DROP COLUMN IF EXISTS score_p_cross_ema`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// dropLegacyMarketOpenIndex drops the trade_date-only unique index on
+// market_open. Records are now kept per exchange calendar, so the same trade
+// date may appear once for every calendar.
+func dropLegacyMarketOpenIndex(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.MarketOpen{}) || !migrator.HasIndex(&models.MarketOpen{}, "idx_market_date") {
+		return nil
+	}
+	return migrator.DropIndex(&models.MarketOpen{}, "idx_market_date")
+}
+
+// convertRefreshTokenTimestamps turns the legacy refresh_tokens columns into
+// timestamps: expires_at held an RFC 3339 string and revoked_at a Unix time
+// with 0 meaning "not revoked". Rows without an expiry could never be used and
+// are dropped.
+func convertRefreshTokenTimestamps(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.RefreshTokens{}) {
+		return nil
+	}
+	columnTypes, err := migrator.ColumnTypes(&models.RefreshTokens{})
+	if err != nil {
+		return err
+	}
+	for _, column := range columnTypes {
+		typeName := strings.ToLower(column.DatabaseTypeName())
+		switch {
+		case column.Name() == "expires_at" && strings.Contains(typeName, "char"):
+			if err := db.Exec(`DELETE FROM refresh_tokens WHERE expires_at IS NULL OR expires_at = ''`).Error; err != nil {
+				return err
+			}
+			if err := db.Exec(`ALTER TABLE refresh_tokens
+				ALTER COLUMN expires_at TYPE timestamptz USING expires_at::timestamptz`).Error; err != nil {
+				return err
+			}
+		case column.Name() == "revoked_at" && (strings.HasPrefix(typeName, "float") || typeName == "numeric"):
+			if err := db.Exec(`ALTER TABLE refresh_tokens
+				ALTER COLUMN revoked_at DROP NOT NULL,
+				ALTER COLUMN revoked_at TYPE timestamptz
+					USING CASE WHEN revoked_at > 0 THEN to_timestamp(revoked_at) END`).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addEmailVerifiedColumn adds users.is_email_verified ahead of AutoMigrate and
+// marks the existing accounts verified, so turning on
+// state.requireVerifiedEmail does not lock out users who registered before
+// verification existed.
+func addEmailVerifiedColumn(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.User{}) || migrator.HasColumn(&models.User{}, "is_email_verified") {
+		return nil
+	}
+	if err := migrator.AddColumn(&models.User{}, "IsEmailVerified"); err != nil {
+		return err
+	}
+	return db.Exec(`UPDATE users SET is_email_verified = true`).Error
+}
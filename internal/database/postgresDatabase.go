@@ -25,12 +25,7 @@ func (p *postgresDatabase) ConnectionGetting() *gorm.DB {
 
 func NewPostgresDatabase(conf *configurations.Database) Database {
 	once.Do(func() {
-		dsn := fmt.Sprintf(
-			"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s search_path=%s",
-			conf.Host, conf.Port, conf.User, conf.Password, conf.DBname, conf.SSLmode, conf.Schema,
-		)
-
-		conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		conn, err := gorm.Open(postgres.Open(DSN(conf)), &gorm.Config{})
 		if err != nil {
 			panic(err)
 		}
@@ -41,3 +36,13 @@ func NewPostgresDatabase(conf *configurations.Database) Database {
 
 	return postgreDatebaseInstance
 }
+
+// DSN builds the keyword/value connection string for conf. Besides the gorm
+// pool it is used for connections that must stay outside the pool, such as
+// the LISTEN connection of the cluster bus.
+func DSN(conf *configurations.Database) string {
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s search_path=%s",
+		conf.Host, conf.Port, conf.User, conf.Password, conf.DBname, conf.SSLmode, conf.Schema,
+	)
+}
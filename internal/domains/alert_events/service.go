@@ -2,10 +2,11 @@ package alert_events
 
 import (
 	"context"
+	"fmt"
 	"log"
-	"strconv"
 	"time"
 
+	"sun-stockanalysis-api/internal/domains/alert_rules"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/internal/realtime"
 	"sun-stockanalysis-api/internal/repository"
@@ -17,22 +18,30 @@ type AlertEventService interface {
 
 type AlertEventServiceImpl struct {
 	quoteRepo repository.StockQuoteRepository
+	ruleRepo  repository.AlertRuleRepository
 	eventRepo repository.AlertEventRepository
 	notifier  realtime.AlertEventNotifier
+	now       func() time.Time
 }
 
 func NewAlertEventService(
 	quoteRepo repository.StockQuoteRepository,
+	ruleRepo repository.AlertRuleRepository,
 	eventRepo repository.AlertEventRepository,
 	notifier realtime.AlertEventNotifier,
 ) AlertEventService {
 	return &AlertEventServiceImpl{
 		quoteRepo: quoteRepo,
+		ruleRepo:  ruleRepo,
 		eventRepo: eventRepo,
 		notifier:  notifier,
+		now:       time.Now,
 	}
 }
 
+// BuildForSymbol evaluates every active alert rule on symbol against its two
+// latest quotes. Each matching rule outside its cooldown records an event for
+// the rule's owner and notifies them.
 func (s *AlertEventServiceImpl) BuildForSymbol(ctx context.Context, symbol string) error {
 	if symbol == "" {
 		return nil
@@ -43,130 +52,59 @@ func (s *AlertEventServiceImpl) BuildForSymbol(ctx context.Context, symbol strin
 	default:
 	}
 
-	start, end := dayBoundsBangkok(time.Now())
-	quotes, err := s.quoteRepo.FindLatestBySymbolBetween(symbol, start, end, 5)
-	if err != nil || len(quotes) < 5 {
+	rules, err := s.ruleRepo.FindActiveBySymbol(symbol)
+	if err != nil || len(rules) == 0 {
 		return err
 	}
-
-	trendEMA20 := 0
-	for _, q := range quotes {
-		trendEMA20 += signFloat(q.ChangeEMA20)
+	quotes, err := s.quoteRepo.FindRecentBySymbol(symbol, 2)
+	if err != nil || len(quotes) == 0 {
+		return err
 	}
-	latest := quotes[0]
-	trendTanhEMA := signFloat(latest.ChangeTanhEMA)
-	// changeEMA20s := make([]float64, 0, len(quotes))
-	// changeEMA20Signs := make([]int, 0, len(quotes))
-	// for _, q := range quotes {
-	// 	changeEMA20s = append(changeEMA20s, q.ChangeEMA20)
-	// 	changeEMA20Signs = append(changeEMA20Signs, signFloat(q.ChangeEMA20))
-	// }
-	// log.Printf(
-	// 	"Start Alert 1.5 symbol=%s trendEMA20=%d trendTanhEMA=%d changeEMA20=%v changeEMA20Signs=%v latestChangeTanhEMA=%.6f",
-	// 	symbol,
-	// 	trendEMA20,
-	// 	trendTanhEMA,
-	// 	changeEMA20s,
-	// 	changeEMA20Signs,
-	// 	latest.ChangeTanhEMA,
-	// )
-	scoreEMA, ok := scoreFromTrend(trendEMA20, trendTanhEMA)
-	if !ok {
-		return nil
+	curr := &quotes[0]
+	var prev *models.StockQuote
+	if len(quotes) > 1 {
+		prev = &quotes[1]
 	}
-	log.Printf("ScoreEMA=%d symbol=%s trendEMA20=%d trendTanhEMA=%d", scoreEMA, symbol, trendEMA20, trendTanhEMA)
 
-	scorePCrossEMA := 0
-	if latest.PriceCurrent == latest.EMA100 {
-		scorePCrossEMA = 1
-	}
-
-	event := &models.AlertEvent{
-		Symbol:         symbol,
-		TrendEMA20:     trendEMA20,
-		TrendTanhEMA:   trendTanhEMA,
-		ScoreEMA:       float64(scoreEMA),
-		ScorePCrossEMA: float64(scorePCrossEMA),
-	}
-	if scoreEMA >= 3 || scoreEMA <= -3 {
-		if err := s.eventRepo.Create(event); err != nil {
-			return err
+	now := s.now()
+	for i := range rules {
+		rule := &rules[i]
+		if coolingDown(rule, now) || !alert_rules.Evaluate(rule.Condition, prev, curr) {
+			continue
 		}
-		if s.notifier != nil {
-			message := messageForScore(scoreEMA)
-			if message == "" {
-				message = "ScoreEMA: " + strconv.Itoa(scoreEMA)
-			}
-			s.notifier.Notify(event, message)
+		if err := s.fire(rule, curr, now); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func dayBoundsBangkok(t time.Time) (time.Time, time.Time) {
-	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
-	local := t.In(loc)
-	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
-	end := start.AddDate(0, 0, 1).Add(-time.Nanosecond)
-	return start, end
-}
-
-func signFloat(v float64) int {
-	switch {
-	case v > 0:
-		return 1
-	case v < 0:
-		return -1
-	default:
-		return 0
+func (s *AlertEventServiceImpl) fire(rule *models.AlertRule, quote *models.StockQuote, now time.Time) error {
+	event := &models.AlertEvent{
+		RuleID:       rule.ID,
+		UserID:       rule.UserID,
+		Symbol:       rule.Symbol,
+		RuleName:     rule.Name,
+		Message:      fmt.Sprintf("%s: %s %s", rule.Name, rule.Symbol, alert_rules.Describe(rule.Condition)),
+		PriceCurrent: quote.PriceCurrent,
 	}
-}
-
-func scoreFromTrend(trendEMA20, trendTanhEMA int) (int, bool) {
-	switch {
-	case trendEMA20 <= -2:
-		switch trendTanhEMA {
-		case -1:
-			return -4, true
-		case 0, 1:
-			return -3, true
-		}
-	case trendEMA20 >= 2:
-		switch trendTanhEMA {
-		case 1:
-			return 4, true
-		case 0, -1:
-			return 3, true
-		}
-	case trendEMA20 == -1:
-		switch trendTanhEMA {
-		case -1:
-			return -2, true
-		case 0, 1:
-			return -1, true
-		}
-	case trendEMA20 == 1:
-		switch trendTanhEMA {
-		case 1:
-			return 2, true
-		case 0, -1:
-			return 1, true
-		}
+	if err := s.eventRepo.Create(event); err != nil {
+		return err
+	}
+	if err := s.ruleRepo.MarkTriggered(rule.ID, now); err != nil {
+		return err
 	}
-	return 0, false
+	log.Printf("alert rule fired rule_id=%s user_id=%s symbol=%s price=%.4f", rule.ID, rule.UserID, rule.Symbol, quote.PriceCurrent)
+	if s.notifier != nil {
+		s.notifier.Notify(event, event.Message)
+	}
+	return nil
 }
 
-func messageForScore(scoreEMA int) string {
-	switch {
-	case scoreEMA >= 3:
-		return "ต้องซื้อ"
-	case scoreEMA >= 1:
-		return "ควรซื้อ/จับตามอง"
-	case scoreEMA <= -3:
-		return "ต้องขาย"
-	case scoreEMA <= -1:
-		return "ควรขาย/จับตามอง"
-	default:
-		return ""
+func coolingDown(rule *models.AlertRule, now time.Time) bool {
+	if rule.LastTriggeredAt == nil || rule.CooldownSeconds <= 0 {
+		return false
 	}
+	next := time.Time(*rule.LastTriggeredAt).Add(time.Duration(rule.CooldownSeconds) * time.Second)
+	return now.Before(next)
 }
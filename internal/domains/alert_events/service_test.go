@@ -0,0 +1,102 @@
+package alert_events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/domains/alert_rules"
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type recordingNotifier struct {
+	events   []*models.AlertEvent
+	messages []string
+}
+
+func (n *recordingNotifier) Notify(event *models.AlertEvent, message string) {
+	n.events = append(n.events, event)
+	n.messages = append(n.messages, message)
+}
+
+type AlertEventServiceSuite struct {
+	suite.Suite
+	quoteRepo *repositorymock.MockStockQuoteRepository
+	ruleRepo  *repositorymock.MockAlertRuleRepository
+	eventRepo *repositorymock.MockAlertEventRepository
+	notifier  *recordingNotifier
+	service   *AlertEventServiceImpl
+	now       time.Time
+}
+
+func (s *AlertEventServiceSuite) SetupTest() {
+	s.quoteRepo = repositorymock.NewMockStockQuoteRepository(s.T())
+	s.ruleRepo = repositorymock.NewMockAlertRuleRepository(s.T())
+	s.eventRepo = repositorymock.NewMockAlertEventRepository(s.T())
+	s.notifier = &recordingNotifier{}
+	s.service = NewAlertEventService(s.quoteRepo, s.ruleRepo, s.eventRepo, s.notifier).(*AlertEventServiceImpl)
+	s.now = time.Date(2024, 3, 4, 15, 0, 0, 0, time.UTC)
+	s.service.now = func() time.Time { return s.now }
+}
+
+func crossAbove(level float64) models.AlertCondition {
+	return models.AlertCondition{Type: alert_rules.ConditionPriceCross, Direction: alert_rules.DirectionAbove, Level: level}
+}
+
+func (s *AlertEventServiceSuite) TestBuildForSymbol_FiresMatchingRulesForOwner() {
+	owner := uuid.New()
+	fired := models.AlertRule{ID: uuid.New(), UserID: owner, Name: "Breakout", Symbol: "AAPL", Condition: crossAbove(100)}
+	missed := models.AlertRule{ID: uuid.New(), UserID: owner, Name: "Moon", Symbol: "AAPL", Condition: crossAbove(500)}
+	s.ruleRepo.EXPECT().FindActiveBySymbol("AAPL").Return([]models.AlertRule{fired, missed}, nil)
+	s.quoteRepo.EXPECT().FindRecentBySymbol("AAPL", 2).Return([]models.StockQuote{
+		{Symbol: "AAPL", PriceCurrent: 101},
+		{Symbol: "AAPL", PriceCurrent: 99},
+	}, nil)
+	s.eventRepo.EXPECT().Create(mock.Anything).RunAndReturn(func(e *models.AlertEvent) error {
+		s.Equal(fired.ID, e.RuleID)
+		s.Equal(owner, e.UserID)
+		s.Equal(101.0, e.PriceCurrent)
+		return nil
+	}).Once()
+	s.ruleRepo.EXPECT().MarkTriggered(fired.ID, s.now).Return(nil).Once()
+
+	err := s.service.BuildForSymbol(context.Background(), "AAPL")
+
+	s.Require().NoError(err)
+	s.Require().Len(s.notifier.events, 1)
+	s.Equal("Breakout: AAPL price crossed above 100.00", s.notifier.messages[0])
+}
+
+func (s *AlertEventServiceSuite) TestBuildForSymbol_RespectsCooldown() {
+	recent := models.NewLocalTime(s.now.Add(-time.Minute))
+	old := models.NewLocalTime(s.now.Add(-time.Hour))
+	cooling := models.AlertRule{ID: uuid.New(), UserID: uuid.New(), Symbol: "AAPL", Condition: crossAbove(100), CooldownSeconds: 600, LastTriggeredAt: &recent}
+	ready := models.AlertRule{ID: uuid.New(), UserID: uuid.New(), Symbol: "AAPL", Condition: crossAbove(100), CooldownSeconds: 600, LastTriggeredAt: &old}
+	s.ruleRepo.EXPECT().FindActiveBySymbol("AAPL").Return([]models.AlertRule{cooling, ready}, nil)
+	s.quoteRepo.EXPECT().FindRecentBySymbol("AAPL", 2).Return([]models.StockQuote{
+		{Symbol: "AAPL", PriceCurrent: 101},
+		{Symbol: "AAPL", PriceCurrent: 99},
+	}, nil)
+	s.eventRepo.EXPECT().Create(mock.MatchedBy(func(e *models.AlertEvent) bool { return e.RuleID == ready.ID })).Return(nil).Once()
+	s.ruleRepo.EXPECT().MarkTriggered(ready.ID, s.now).Return(nil).Once()
+
+	err := s.service.BuildForSymbol(context.Background(), "AAPL")
+
+	s.Require().NoError(err)
+	s.Len(s.notifier.events, 1)
+}
+
+func (s *AlertEventServiceSuite) TestBuildForSymbol_NoRules() {
+	s.ruleRepo.EXPECT().FindActiveBySymbol("AAPL").Return(nil, nil)
+
+	s.NoError(s.service.BuildForSymbol(context.Background(), "AAPL"))
+}
+
+func TestAlertEventServiceSuite(t *testing.T) {
+	suite.Run(t, new(AlertEventServiceSuite))
+}
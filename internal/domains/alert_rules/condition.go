@@ -0,0 +1,193 @@
+package alert_rules
+
+import (
+	"fmt"
+	"strings"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+const (
+	ConditionPriceCross    = "price_cross"
+	ConditionChangePercent = "change_percent"
+	ConditionEMACross      = "ema_cross"
+	ConditionIndicator     = "indicator"
+	ConditionAnd           = "and"
+	ConditionOr            = "or"
+)
+
+const (
+	DirectionAbove = "above"
+	DirectionBelow = "below"
+)
+
+const (
+	maxConditionDepth = 4
+	maxConditionNodes = 20
+)
+
+// indicatorFields maps the field names accepted by indicator conditions onto
+// the quote values they read. A nil result means the value is not available
+// yet and the condition does not match.
+var indicatorFields = map[string]func(q *models.StockQuote) *float64{
+	"price":          func(q *models.StockQuote) *float64 { return &q.PriceCurrent },
+	"change_percent": func(q *models.StockQuote) *float64 { return q.ChangePercent },
+	"ema_20":         func(q *models.StockQuote) *float64 { return &q.EMA20 },
+	"ema_100":        func(q *models.StockQuote) *float64 { return &q.EMA100 },
+	"rsi_14":         func(q *models.StockQuote) *float64 { return q.RSI14 },
+	"macd":           func(q *models.StockQuote) *float64 { return q.MACD },
+	"macd_signal":    func(q *models.StockQuote) *float64 { return q.MACDSignal },
+	"macd_histogram": func(q *models.StockQuote) *float64 { return q.MACDHistogram },
+	"bb_upper":       func(q *models.StockQuote) *float64 { return q.BollingerUpper },
+	"bb_middle":      func(q *models.StockQuote) *float64 { return q.BollingerMiddle },
+	"bb_lower":       func(q *models.StockQuote) *float64 { return q.BollingerLower },
+	"atr_14":         func(q *models.StockQuote) *float64 { return q.ATR14 },
+}
+
+// ValidateCondition checks that cond is a well-formed condition tree.
+func ValidateCondition(cond models.AlertCondition) error {
+	nodes := 0
+	return validateCondition(cond, 1, &nodes)
+}
+
+func validateCondition(cond models.AlertCondition, depth int, nodes *int) error {
+	*nodes++
+	if *nodes > maxConditionNodes {
+		return fmt.Errorf("%w: more than %d conditions", ErrInvalidCondition, maxConditionNodes)
+	}
+	if depth > maxConditionDepth {
+		return fmt.Errorf("%w: nested deeper than %d levels", ErrInvalidCondition, maxConditionDepth)
+	}
+
+	switch cond.Type {
+	case ConditionPriceCross:
+		if err := validateDirection(cond.Direction); err != nil {
+			return err
+		}
+		if cond.Level <= 0 {
+			return fmt.Errorf("%w: price_cross level must be positive", ErrInvalidCondition)
+		}
+	case ConditionEMACross:
+		return validateDirection(cond.Direction)
+	case ConditionChangePercent:
+		return validateOperator(cond.Operator)
+	case ConditionIndicator:
+		if _, ok := indicatorFields[cond.Field]; !ok {
+			return fmt.Errorf("%w: unknown indicator field %q", ErrInvalidCondition, cond.Field)
+		}
+		return validateOperator(cond.Operator)
+	case ConditionAnd, ConditionOr:
+		if len(cond.Conditions) < 2 {
+			return fmt.Errorf("%w: %s needs at least two conditions", ErrInvalidCondition, cond.Type)
+		}
+		for _, child := range cond.Conditions {
+			if err := validateCondition(child, depth+1, nodes); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unknown condition type %q", ErrInvalidCondition, cond.Type)
+	}
+	return nil
+}
+
+func validateDirection(direction string) error {
+	if direction != DirectionAbove && direction != DirectionBelow {
+		return fmt.Errorf("%w: direction must be above or below", ErrInvalidCondition)
+	}
+	return nil
+}
+
+func validateOperator(operator string) error {
+	switch operator {
+	case ">", ">=", "<", "<=":
+		return nil
+	}
+	return fmt.Errorf("%w: operator must be one of >, >=, <, <=", ErrInvalidCondition)
+}
+
+// Evaluate reports whether cond holds for the latest quote. Cross conditions
+// compare against prev and never match without it.
+func Evaluate(cond models.AlertCondition, prev, curr *models.StockQuote) bool {
+	if curr == nil {
+		return false
+	}
+	switch cond.Type {
+	case ConditionPriceCross:
+		if prev == nil {
+			return false
+		}
+		if cond.Direction == DirectionAbove {
+			return prev.PriceCurrent < cond.Level && curr.PriceCurrent >= cond.Level
+		}
+		return prev.PriceCurrent > cond.Level && curr.PriceCurrent <= cond.Level
+	case ConditionEMACross:
+		if prev == nil {
+			return false
+		}
+		if cond.Direction == DirectionAbove {
+			return prev.EMA20 <= prev.EMA100 && curr.EMA20 > curr.EMA100
+		}
+		return prev.EMA20 >= prev.EMA100 && curr.EMA20 < curr.EMA100
+	case ConditionChangePercent:
+		return compare(curr.ChangePercent, cond.Operator, cond.Value)
+	case ConditionIndicator:
+		field, ok := indicatorFields[cond.Field]
+		if !ok {
+			return false
+		}
+		return compare(field(curr), cond.Operator, cond.Value)
+	case ConditionAnd:
+		for _, child := range cond.Conditions {
+			if !Evaluate(child, prev, curr) {
+				return false
+			}
+		}
+		return len(cond.Conditions) > 0
+	case ConditionOr:
+		for _, child := range cond.Conditions {
+			if Evaluate(child, prev, curr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func compare(value *float64, operator string, threshold float64) bool {
+	if value == nil {
+		return false
+	}
+	switch operator {
+	case ">":
+		return *value > threshold
+	case ">=":
+		return *value >= threshold
+	case "<":
+		return *value < threshold
+	case "<=":
+		return *value <= threshold
+	}
+	return false
+}
+
+// Describe renders cond as a short human-readable sentence for notifications.
+func Describe(cond models.AlertCondition) string {
+	switch cond.Type {
+	case ConditionPriceCross:
+		return fmt.Sprintf("price crossed %s %.2f", cond.Direction, cond.Level)
+	case ConditionEMACross:
+		return fmt.Sprintf("EMA20 crossed %s EMA100", cond.Direction)
+	case ConditionChangePercent:
+		return fmt.Sprintf("change %% %s %.2f", cond.Operator, cond.Value)
+	case ConditionIndicator:
+		return fmt.Sprintf("%s %s %.2f", cond.Field, cond.Operator, cond.Value)
+	case ConditionAnd, ConditionOr:
+		parts := make([]string, 0, len(cond.Conditions))
+		for _, child := range cond.Conditions {
+			parts = append(parts, "("+Describe(child)+")")
+		}
+		return strings.Join(parts, " "+strings.ToUpper(cond.Type)+" ")
+	}
+	return cond.Type
+}
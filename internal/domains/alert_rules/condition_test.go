@@ -0,0 +1,96 @@
+package alert_rules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type ConditionSuite struct {
+	suite.Suite
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func (s *ConditionSuite) TestEvaluate_PriceCross() {
+	above := models.AlertCondition{Type: ConditionPriceCross, Direction: DirectionAbove, Level: 100}
+	below := models.AlertCondition{Type: ConditionPriceCross, Direction: DirectionBelow, Level: 100}
+	prev := &models.StockQuote{PriceCurrent: 99}
+	curr := &models.StockQuote{PriceCurrent: 101}
+
+	s.True(Evaluate(above, prev, curr))
+	s.False(Evaluate(above, curr, curr))
+	s.False(Evaluate(above, nil, curr))
+	s.True(Evaluate(below, curr, prev))
+	s.False(Evaluate(below, prev, curr))
+}
+
+func (s *ConditionSuite) TestEvaluate_EMACross() {
+	golden := models.AlertCondition{Type: ConditionEMACross, Direction: DirectionAbove}
+	prev := &models.StockQuote{EMA20: 9, EMA100: 10}
+	curr := &models.StockQuote{EMA20: 11, EMA100: 10}
+
+	s.True(Evaluate(golden, prev, curr))
+	s.False(Evaluate(golden, curr, curr))
+	s.True(Evaluate(models.AlertCondition{Type: ConditionEMACross, Direction: DirectionBelow}, curr, prev))
+}
+
+func (s *ConditionSuite) TestEvaluate_ThresholdsAndComposites() {
+	curr := &models.StockQuote{ChangePercent: ptr(-3.5)}
+	curr.RSI14 = ptr(25)
+
+	drop := models.AlertCondition{Type: ConditionChangePercent, Operator: "<=", Value: -3}
+	oversold := models.AlertCondition{Type: ConditionIndicator, Field: "rsi_14", Operator: "<", Value: 30}
+	overbought := models.AlertCondition{Type: ConditionIndicator, Field: "rsi_14", Operator: ">", Value: 70}
+	macd := models.AlertCondition{Type: ConditionIndicator, Field: "macd", Operator: ">", Value: 0}
+
+	s.True(Evaluate(drop, nil, curr))
+	s.True(Evaluate(oversold, nil, curr))
+	s.False(Evaluate(macd, nil, curr), "missing indicator values never match")
+	s.True(Evaluate(models.AlertCondition{Type: ConditionAnd, Conditions: []models.AlertCondition{drop, oversold}}, nil, curr))
+	s.False(Evaluate(models.AlertCondition{Type: ConditionAnd, Conditions: []models.AlertCondition{drop, overbought}}, nil, curr))
+	s.True(Evaluate(models.AlertCondition{Type: ConditionOr, Conditions: []models.AlertCondition{overbought, oversold}}, nil, curr))
+}
+
+func (s *ConditionSuite) TestValidateCondition() {
+	valid := models.AlertCondition{Type: ConditionOr, Conditions: []models.AlertCondition{
+		{Type: ConditionPriceCross, Direction: DirectionAbove, Level: 10},
+		{Type: ConditionIndicator, Field: "atr_14", Operator: ">=", Value: 2},
+	}}
+	s.NoError(ValidateCondition(valid))
+
+	invalid := []models.AlertCondition{
+		{Type: "unknown"},
+		{Type: ConditionPriceCross, Direction: "sideways", Level: 10},
+		{Type: ConditionPriceCross, Direction: DirectionAbove},
+		{Type: ConditionIndicator, Field: "volume", Operator: ">", Value: 1},
+		{Type: ConditionChangePercent, Operator: "=="},
+		{Type: ConditionAnd, Conditions: []models.AlertCondition{{Type: ConditionEMACross, Direction: DirectionAbove}}},
+	}
+	for _, cond := range invalid {
+		s.True(errors.Is(ValidateCondition(cond), ErrInvalidCondition), cond.Type)
+	}
+
+	deep := models.AlertCondition{Type: ConditionEMACross, Direction: DirectionAbove}
+	for i := 0; i < maxConditionDepth; i++ {
+		deep = models.AlertCondition{Type: ConditionAnd, Conditions: []models.AlertCondition{deep, deep}}
+	}
+	s.True(errors.Is(ValidateCondition(deep), ErrInvalidCondition))
+}
+
+func (s *ConditionSuite) TestDescribe() {
+	cond := models.AlertCondition{Type: ConditionAnd, Conditions: []models.AlertCondition{
+		{Type: ConditionPriceCross, Direction: DirectionAbove, Level: 150},
+		{Type: ConditionIndicator, Field: "rsi_14", Operator: "<", Value: 70},
+	}}
+	s.Equal("(price crossed above 150.00) AND (rsi_14 < 70.00)", Describe(cond))
+}
+
+func TestConditionSuite(t *testing.T) {
+	suite.Run(t, new(ConditionSuite))
+}
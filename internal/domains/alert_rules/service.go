@@ -0,0 +1,252 @@
+package alert_rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+)
+
+const (
+	defaultMaxRulesPerUser = 50
+	defaultEventLimit      = 50
+	maxEventLimit          = 500
+	maxCooldownSeconds     = 7 * 24 * 60 * 60
+)
+
+var maxRulesPerUser = getEnvInt("ALERT_RULE_MAX_PER_USER", defaultMaxRulesPerUser)
+
+var (
+	ErrInvalidUser      = errors.New("invalid user id")
+	ErrRuleNotFound     = errors.New("alert rule not found")
+	ErrInvalidRule      = errors.New("invalid alert rule")
+	ErrInvalidCondition = errors.New("invalid alert condition")
+	ErrUnknownSymbol    = errors.New("symbol is not tracked")
+	ErrTooManyRules     = errors.New("alert rule limit reached")
+)
+
+// RuleInput is the user-editable part of an alert rule. A nil IsActive keeps
+// the current value on update and defaults to true on create.
+type RuleInput struct {
+	Name            string
+	Symbol          string
+	Condition       models.AlertCondition
+	CooldownSeconds int
+	IsActive        *bool
+}
+
+type AlertRuleService interface {
+	List(ctx context.Context, userID string) ([]models.AlertRule, error)
+	Get(ctx context.Context, userID string, id uuid.UUID) (*models.AlertRule, error)
+	Create(ctx context.Context, userID string, input RuleInput) (*models.AlertRule, error)
+	Update(ctx context.Context, userID string, id uuid.UUID, input RuleInput) (*models.AlertRule, error)
+	Delete(ctx context.Context, userID string, id uuid.UUID) error
+	ListEvents(ctx context.Context, userID string, limit int) ([]models.AlertEvent, error)
+}
+
+type AlertRuleServiceImpl struct {
+	ruleRepo  repository.AlertRuleRepository
+	eventRepo repository.AlertEventRepository
+	stockRepo repository.StockRepository
+}
+
+func NewAlertRuleService(
+	ruleRepo repository.AlertRuleRepository,
+	eventRepo repository.AlertEventRepository,
+	stockRepo repository.StockRepository,
+) AlertRuleService {
+	return &AlertRuleServiceImpl{
+		ruleRepo:  ruleRepo,
+		eventRepo: eventRepo,
+		stockRepo: stockRepo,
+	}
+}
+
+func (s *AlertRuleServiceImpl) List(ctx context.Context, userID string) ([]models.AlertRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ruleRepo.FindByUser(owner)
+}
+
+func (s *AlertRuleServiceImpl) Get(ctx context.Context, userID string, id uuid.UUID) (*models.AlertRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.findOwned(owner, id)
+}
+
+func (s *AlertRuleServiceImpl) Create(ctx context.Context, userID string, input RuleInput) (*models.AlertRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.ruleRepo.FindByUser(owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxRulesPerUser {
+		return nil, ErrTooManyRules
+	}
+
+	rule := &models.AlertRule{UserID: owner, IsActive: true}
+	if err := s.apply(rule, input); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *AlertRuleServiceImpl) Update(ctx context.Context, userID string, id uuid.UUID, input RuleInput) (*models.AlertRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := s.findOwned(owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(rule, input); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *AlertRuleServiceImpl) Delete(ctx context.Context, userID string, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.findOwned(owner, id); err != nil {
+		return err
+	}
+	return s.ruleRepo.Delete(id)
+}
+
+// ListEvents returns the user's most recent alert events, newest first.
+func (s *AlertRuleServiceImpl) ListEvents(ctx context.Context, userID string, limit int) ([]models.AlertEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultEventLimit
+	}
+	if limit > maxEventLimit {
+		limit = maxEventLimit
+	}
+	return s.eventRepo.FindByUser(owner, limit)
+}
+
+// findOwned loads a rule and hides rules of other users behind ErrRuleNotFound.
+func (s *AlertRuleServiceImpl) findOwned(owner, id uuid.UUID) (*models.AlertRule, error) {
+	rule, err := s.ruleRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRuleNotFound
+		}
+		return nil, err
+	}
+	if rule.UserID != owner {
+		return nil, ErrRuleNotFound
+	}
+	return rule, nil
+}
+
+// apply validates input and copies it onto rule.
+func (s *AlertRuleServiceImpl) apply(rule *models.AlertRule, input RuleInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > 128 {
+		return fmt.Errorf("%w: name must be 1-128 characters", ErrInvalidRule)
+	}
+	if input.CooldownSeconds < 0 || input.CooldownSeconds > maxCooldownSeconds {
+		return fmt.Errorf("%w: cooldown_seconds out of range", ErrInvalidRule)
+	}
+	symbol, err := s.trackedSymbol(input.Symbol)
+	if err != nil {
+		return err
+	}
+	if err := ValidateCondition(input.Condition); err != nil {
+		return err
+	}
+
+	rule.Name = name
+	rule.Symbol = symbol
+	rule.Condition = input.Condition
+	rule.CooldownSeconds = input.CooldownSeconds
+	if input.IsActive != nil {
+		rule.IsActive = *input.IsActive
+	}
+	return nil
+}
+
+// trackedSymbol returns the stored spelling of symbol when it is an active stock.
+func (s *AlertRuleServiceImpl) trackedSymbol(symbol string) (string, error) {
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return "", ErrUnknownSymbol
+	}
+	symbols, err := s.stockRepo.ListSymbols()
+	if err != nil {
+		return "", err
+	}
+	for _, tracked := range symbols {
+		if strings.EqualFold(tracked, symbol) {
+			return tracked, nil
+		}
+	}
+	return "", ErrUnknownSymbol
+}
+
+func parseUserID(userID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(strings.TrimSpace(userID))
+	if err != nil {
+		return uuid.Nil, ErrInvalidUser
+	}
+	return id, nil
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
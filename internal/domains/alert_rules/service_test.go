@@ -0,0 +1,127 @@
+package alert_rules
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type AlertRuleServiceSuite struct {
+	suite.Suite
+	ruleRepo  *repositorymock.MockAlertRuleRepository
+	eventRepo *repositorymock.MockAlertEventRepository
+	stockRepo *repositorymock.MockStockRepository
+	service   AlertRuleService
+	userID    uuid.UUID
+}
+
+func (s *AlertRuleServiceSuite) SetupTest() {
+	s.ruleRepo = repositorymock.NewMockAlertRuleRepository(s.T())
+	s.eventRepo = repositorymock.NewMockAlertEventRepository(s.T())
+	s.stockRepo = repositorymock.NewMockStockRepository(s.T())
+	s.service = NewAlertRuleService(s.ruleRepo, s.eventRepo, s.stockRepo)
+	s.userID = uuid.New()
+}
+
+func (s *AlertRuleServiceSuite) validInput() RuleInput {
+	return RuleInput{
+		Name:            " Breakout ",
+		Symbol:          "aapl",
+		Condition:       models.AlertCondition{Type: ConditionPriceCross, Direction: DirectionAbove, Level: 200},
+		CooldownSeconds: 300,
+	}
+}
+
+func (s *AlertRuleServiceSuite) TestCreate_NormalisesAndStores() {
+	s.ruleRepo.EXPECT().FindByUser(s.userID).Return(nil, nil)
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL", "TSLA"}, nil)
+	s.ruleRepo.EXPECT().Create(mock.Anything).Return(nil)
+
+	rule, err := s.service.Create(context.Background(), s.userID.String(), s.validInput())
+
+	s.Require().NoError(err)
+	s.Equal(s.userID, rule.UserID)
+	s.Equal("Breakout", rule.Name)
+	s.Equal("AAPL", rule.Symbol)
+	s.Equal(300, rule.CooldownSeconds)
+	s.True(rule.IsActive)
+}
+
+func (s *AlertRuleServiceSuite) TestCreate_RejectsUntrackedSymbolAndBadCondition() {
+	s.ruleRepo.EXPECT().FindByUser(s.userID).Return(nil, nil)
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"TSLA"}, nil)
+
+	_, err := s.service.Create(context.Background(), s.userID.String(), s.validInput())
+	s.True(errors.Is(err, ErrUnknownSymbol))
+
+	input := s.validInput()
+	input.Symbol = "TSLA"
+	input.Condition = models.AlertCondition{Type: ConditionOr}
+	_, err = s.service.Create(context.Background(), s.userID.String(), input)
+	s.True(errors.Is(err, ErrInvalidCondition))
+}
+
+func (s *AlertRuleServiceSuite) TestCreate_EnforcesLimit() {
+	s.ruleRepo.EXPECT().FindByUser(s.userID).Return(make([]models.AlertRule, maxRulesPerUser), nil)
+
+	_, err := s.service.Create(context.Background(), s.userID.String(), s.validInput())
+
+	s.True(errors.Is(err, ErrTooManyRules))
+}
+
+func (s *AlertRuleServiceSuite) TestUpdate_HidesOtherUsersRules() {
+	id := uuid.New()
+	s.ruleRepo.EXPECT().FindByID(id).Return(&models.AlertRule{ID: id, UserID: uuid.New()}, nil)
+
+	_, err := s.service.Update(context.Background(), s.userID.String(), id, s.validInput())
+
+	s.True(errors.Is(err, ErrRuleNotFound))
+}
+
+func (s *AlertRuleServiceSuite) TestUpdate_KeepsActiveFlagWhenOmitted() {
+	id := uuid.New()
+	s.ruleRepo.EXPECT().FindByID(id).Return(&models.AlertRule{ID: id, UserID: s.userID, IsActive: false}, nil)
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL"}, nil)
+	s.ruleRepo.EXPECT().Update(mock.Anything).Return(nil)
+
+	rule, err := s.service.Update(context.Background(), s.userID.String(), id, s.validInput())
+
+	s.Require().NoError(err)
+	s.False(rule.IsActive)
+	s.Equal(200.0, rule.Condition.Level)
+}
+
+func (s *AlertRuleServiceSuite) TestDelete_NotFound() {
+	id := uuid.New()
+	s.ruleRepo.EXPECT().FindByID(id).Return(nil, gorm.ErrRecordNotFound)
+
+	err := s.service.Delete(context.Background(), s.userID.String(), id)
+
+	s.True(errors.Is(err, ErrRuleNotFound))
+}
+
+func (s *AlertRuleServiceSuite) TestListEvents_ClampsLimit() {
+	s.eventRepo.EXPECT().FindByUser(s.userID, maxEventLimit).Return([]models.AlertEvent{}, nil)
+
+	_, err := s.service.ListEvents(context.Background(), s.userID.String(), 10000)
+
+	s.Require().NoError(err)
+}
+
+func (s *AlertRuleServiceSuite) TestInvalidUser() {
+	_, err := s.service.List(context.Background(), "not-a-uuid")
+
+	s.True(errors.Is(err, ErrInvalidUser))
+}
+
+func TestAlertRuleServiceSuite(t *testing.T) {
+	suite.Run(t, new(AlertRuleServiceSuite))
+}
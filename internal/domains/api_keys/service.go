@@ -0,0 +1,280 @@
+package api_keys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+// KeyPrefix starts every API key so clients and the auth middleware can tell
+// keys apart from JWTs. A key looks like "ssa_1a2b3c4d_<43 random chars>";
+// its first prefixLength characters are stored in clear for listings.
+const KeyPrefix = "ssa_"
+
+const (
+	prefixLength        = len(KeyPrefix) + 8
+	defaultMaxKeys      = 20
+	maxExpiryDays       = 365
+	lastUsedInterval    = time.Minute
+	maxNameLength       = 128
+	secretRandomBytes   = 32
+	prefixIDRandomBytes = 4
+)
+
+var maxKeys = getEnvInt("API_KEY_MAX_PER_USER", defaultMaxKeys)
+
+var (
+	ErrInvalidUser    = errors.New("invalid user id")
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+	ErrInvalidInput   = errors.New("invalid api key request")
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrTooManyAPIKeys = errors.New("api key limit reached")
+)
+
+type CreateInput struct {
+	Name          string `json:"name" doc:"Label for the key, e.g. the script that uses it"`
+	Scope         string `json:"scope,omitempty" enum:"read,write" doc:"read allows GET requests only; write allows every method. Defaults to read"`
+	ExpiresInDays int    `json:"expires_in_days,omitempty" minimum:"0" maximum:"365" doc:"Days until the key expires; 0 means it never expires"`
+}
+
+// CreatedAPIKey carries the plaintext key. It is only returned once, when the
+// key is created.
+type CreatedAPIKey struct {
+	APIKey models.APIKey `json:"api_key"`
+	Key    string        `json:"key" doc:"The API key. Store it now; it cannot be shown again"`
+}
+
+// Principal is the user an API key acts for.
+type Principal struct {
+	UserID string
+	Role   string
+	Scope  string
+	KeyID  uuid.UUID
+}
+
+// Allows reports whether the key's scope permits an HTTP method. Read-only
+// keys are limited to safe methods.
+func (p *Principal) Allows(method string) bool {
+	if p.Scope == models.APIKeyScopeWrite {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+type APIKeyService interface {
+	List(ctx context.Context, userID string) ([]models.APIKey, error)
+	Create(ctx context.Context, userID string, input CreateInput) (*CreatedAPIKey, error)
+	Revoke(ctx context.Context, userID string, id uuid.UUID) error
+	// Verify resolves a presented key to its owner. Unknown, revoked and
+	// expired keys, and keys of inactive users, yield ErrInvalidAPIKey.
+	Verify(ctx context.Context, key string) (*Principal, error)
+}
+
+type APIKeyServiceImpl struct {
+	repo     repository.APIKeyRepository
+	userRepo repository.UserRepository
+	log      *logger.Logger
+	now      func() time.Time
+}
+
+func NewAPIKeyService(repo repository.APIKeyRepository, userRepo repository.UserRepository, log *logger.Logger) APIKeyService {
+	return &APIKeyServiceImpl{
+		repo:     repo,
+		userRepo: userRepo,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+func (s *APIKeyServiceImpl) List(ctx context.Context, userID string) ([]models.APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindActiveByUser(owner, s.now())
+}
+
+func (s *APIKeyServiceImpl) Create(ctx context.Context, userID string, input CreateInput) (*CreatedAPIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxNameLength {
+		return nil, fmt.Errorf("%w: name must be 1-%d characters", ErrInvalidInput, maxNameLength)
+	}
+	scope := strings.ToLower(strings.TrimSpace(input.Scope))
+	if scope == "" {
+		scope = models.APIKeyScopeRead
+	}
+	if scope != models.APIKeyScopeRead && scope != models.APIKeyScopeWrite {
+		return nil, fmt.Errorf("%w: scope must be %q or %q", ErrInvalidInput, models.APIKeyScopeRead, models.APIKeyScopeWrite)
+	}
+	if input.ExpiresInDays < 0 || input.ExpiresInDays > maxExpiryDays {
+		return nil, fmt.Errorf("%w: expires_in_days must be 0-%d", ErrInvalidInput, maxExpiryDays)
+	}
+
+	now := s.now()
+	active, err := s.repo.FindActiveByUser(owner, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(active) >= maxKeys {
+		return nil, ErrTooManyAPIKeys
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	record := models.APIKey{
+		UserID:  owner,
+		Name:    name,
+		Prefix:  key[:prefixLength],
+		KeyHash: hashKey(key),
+		Scope:   scope,
+	}
+	if input.ExpiresInDays > 0 {
+		expiresAt := models.NewLocalTime(now.AddDate(0, 0, input.ExpiresInDays))
+		record.ExpiresAt = &expiresAt
+	}
+	if err := s.repo.Create(&record); err != nil {
+		return nil, err
+	}
+	return &CreatedAPIKey{APIKey: record, Key: key}, nil
+}
+
+func (s *APIKeyServiceImpl) Revoke(ctx context.Context, userID string, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return err
+	}
+	revoked, err := s.repo.Revoke(owner, id, s.now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (s *APIKeyServiceImpl) Verify(ctx context.Context, key string) (*Principal, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	key = strings.TrimSpace(key)
+	if !IsAPIKey(key) {
+		return nil, ErrInvalidAPIKey
+	}
+	record, err := s.repo.FindByHash(hashKey(key))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	now := s.now()
+	if record.RevokedAt != nil || (record.ExpiresAt != nil && !now.Before(time.Time(*record.ExpiresAt))) {
+		return nil, ErrInvalidAPIKey
+	}
+	user, err := s.userRepo.FindByID(record.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if record.LastUsedAt == nil || now.Sub(time.Time(*record.LastUsedAt)) >= lastUsedInterval {
+		if err := s.repo.TouchLastUsed(record.ID, now, now.Add(-lastUsedInterval)); err != nil {
+			s.logf("api key %s: record last use failed: %v", record.Prefix, err)
+		}
+	}
+
+	return &Principal{
+		UserID: user.ID.String(),
+		Role:   user.Role,
+		Scope:  record.Scope,
+		KeyID:  record.ID,
+	}, nil
+}
+
+// IsAPIKey reports whether a credential has the API key format rather than,
+// say, a JWT.
+func IsAPIKey(credential string) bool {
+	return len(credential) > prefixLength && strings.HasPrefix(credential, KeyPrefix)
+}
+
+func newKey() (string, error) {
+	raw := make([]byte, prefixIDRandomBytes+secretRandomBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(raw[:prefixIDRandomBytes])
+	secret := base64.RawURLEncoding.EncodeToString(raw[prefixIDRandomBytes:])
+	return KeyPrefix + id + "_" + secret, nil
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func (s *APIKeyServiceImpl) logf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Infof(format, args...)
+	}
+}
+
+func parseUserID(userID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(strings.TrimSpace(userID))
+	if err != nil {
+		return uuid.Nil, ErrInvalidUser
+	}
+	return id, nil
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,138 @@
+package api_keys
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type APIKeyServiceSuite struct {
+	suite.Suite
+	repo     *repositorymock.MockAPIKeyRepository
+	userRepo *repositorymock.MockUserRepository
+	service  *APIKeyServiceImpl
+	userID   uuid.UUID
+	now      time.Time
+}
+
+func (s *APIKeyServiceSuite) SetupTest() {
+	s.repo = repositorymock.NewMockAPIKeyRepository(s.T())
+	s.userRepo = repositorymock.NewMockUserRepository(s.T())
+	s.service = NewAPIKeyService(s.repo, s.userRepo, nil).(*APIKeyServiceImpl)
+	s.now = time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	s.service.now = func() time.Time { return s.now }
+	s.userID = uuid.New()
+}
+
+func (s *APIKeyServiceSuite) TestCreate_StoresHashAndReturnsKeyOnce() {
+	var stored *models.APIKey
+	s.repo.EXPECT().FindActiveByUser(s.userID, s.now).Return(nil, nil)
+	s.repo.EXPECT().Create(mock.Anything).RunAndReturn(func(k *models.APIKey) error {
+		stored = k
+		return nil
+	})
+
+	created, err := s.service.Create(context.Background(), s.userID.String(), CreateInput{
+		Name:          " nightly export ",
+		ExpiresInDays: 30,
+	})
+
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(created.Key, KeyPrefix))
+	s.Equal("nightly export", stored.Name)
+	s.Equal(models.APIKeyScopeRead, stored.Scope)
+	s.Equal(created.Key[:prefixLength], stored.Prefix)
+	s.Equal(hashKey(created.Key), stored.KeyHash)
+	s.NotContains(stored.KeyHash, created.Key)
+	s.Require().NotNil(stored.ExpiresAt)
+	s.True(s.now.AddDate(0, 0, 30).Equal(time.Time(*stored.ExpiresAt)))
+}
+
+func (s *APIKeyServiceSuite) TestCreate_RejectsUnknownScope() {
+	_, err := s.service.Create(context.Background(), s.userID.String(), CreateInput{Name: "bot", Scope: "admin"})
+
+	s.ErrorIs(err, ErrInvalidInput)
+}
+
+func (s *APIKeyServiceSuite) TestCreate_EnforcesLimit() {
+	s.repo.EXPECT().FindActiveByUser(s.userID, s.now).Return(make([]models.APIKey, maxKeys), nil)
+
+	_, err := s.service.Create(context.Background(), s.userID.String(), CreateInput{Name: "bot"})
+
+	s.ErrorIs(err, ErrTooManyAPIKeys)
+}
+
+func (s *APIKeyServiceSuite) TestRevoke_NotOwned() {
+	id := uuid.New()
+	s.repo.EXPECT().Revoke(s.userID, id, s.now).Return(false, nil)
+
+	err := s.service.Revoke(context.Background(), s.userID.String(), id)
+
+	s.ErrorIs(err, ErrAPIKeyNotFound)
+}
+
+func (s *APIKeyServiceSuite) TestVerify_ReturnsOwnerAndRecordsUse() {
+	key := "ssa_1a2b3c4d_secret"
+	record := &models.APIKey{ID: uuid.New(), UserID: s.userID, Prefix: key[:prefixLength], Scope: models.APIKeyScopeWrite}
+	s.repo.EXPECT().FindByHash(hashKey(key)).Return(record, nil)
+	s.userRepo.EXPECT().FindByID(s.userID).Return(&models.User{ID: s.userID, Role: models.RoleAdmin, IsActive: true}, nil)
+	s.repo.EXPECT().TouchLastUsed(record.ID, s.now, s.now.Add(-lastUsedInterval)).Return(nil)
+
+	principal, err := s.service.Verify(context.Background(), key)
+
+	s.Require().NoError(err)
+	s.Equal(s.userID.String(), principal.UserID)
+	s.Equal(models.RoleAdmin, principal.Role)
+	s.True(principal.Allows(http.MethodDelete))
+}
+
+func (s *APIKeyServiceSuite) TestVerify_SkipsRecentLastUse() {
+	key := "ssa_1a2b3c4d_secret"
+	lastUsed := models.NewLocalTime(s.now.Add(-10 * time.Second))
+	record := &models.APIKey{ID: uuid.New(), UserID: s.userID, Scope: models.APIKeyScopeRead, LastUsedAt: &lastUsed}
+	s.repo.EXPECT().FindByHash(hashKey(key)).Return(record, nil)
+	s.userRepo.EXPECT().FindByID(s.userID).Return(&models.User{ID: s.userID, Role: models.RoleUser, IsActive: true}, nil)
+
+	principal, err := s.service.Verify(context.Background(), key)
+
+	s.Require().NoError(err)
+	s.True(principal.Allows(http.MethodGet))
+	s.False(principal.Allows(http.MethodPost))
+}
+
+func (s *APIKeyServiceSuite) TestVerify_RejectsExpiredRevokedAndUnknownKeys() {
+	expired := models.NewLocalTime(s.now.Add(-time.Second))
+	revoked := models.NewLocalTime(s.now.Add(-time.Hour))
+	s.repo.EXPECT().FindByHash(hashKey("ssa_expired_key")).Return(&models.APIKey{UserID: s.userID, ExpiresAt: &expired}, nil)
+	s.repo.EXPECT().FindByHash(hashKey("ssa_revoked_key")).Return(&models.APIKey{UserID: s.userID, RevokedAt: &revoked}, nil)
+	s.repo.EXPECT().FindByHash(hashKey("ssa_unknown_key")).Return(nil, gorm.ErrRecordNotFound)
+
+	for _, key := range []string{"ssa_expired_key", "ssa_revoked_key", "ssa_unknown_key", "eyJhbGciOiJIUzI1NiJ9"} {
+		_, err := s.service.Verify(context.Background(), key)
+		s.ErrorIs(err, ErrInvalidAPIKey, key)
+	}
+}
+
+func (s *APIKeyServiceSuite) TestVerify_RejectsKeysOfInactiveUsers() {
+	key := "ssa_1a2b3c4d_secret"
+	s.repo.EXPECT().FindByHash(hashKey(key)).Return(&models.APIKey{ID: uuid.New(), UserID: s.userID, Scope: models.APIKeyScopeWrite}, nil)
+	s.userRepo.EXPECT().FindByID(s.userID).Return(&models.User{ID: s.userID, Role: models.RoleUser, IsActive: false}, nil)
+
+	_, err := s.service.Verify(context.Background(), key)
+
+	s.ErrorIs(err, ErrInvalidAPIKey)
+}
+
+func TestAPIKeyServiceSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyServiceSuite))
+}
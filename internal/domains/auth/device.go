@@ -0,0 +1,59 @@
+package auth
+
+import "strings"
+
+const maxDeviceLength = 128
+
+// describeDevice turns a User-Agent into a short label such as
+// "Chrome on Windows". It only knows the common browsers and platforms and
+// falls back to "Unknown device".
+func describeDevice(userAgent string) string {
+	var browser, platform string
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(userAgent, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	}
+	switch {
+	case strings.Contains(userAgent, "iPhone"):
+		platform = "iPhone"
+	case strings.Contains(userAgent, "iPad"):
+		platform = "iPad"
+	case strings.Contains(userAgent, "Android"):
+		platform = "Android"
+	case strings.Contains(userAgent, "Windows"):
+		platform = "Windows"
+	case strings.Contains(userAgent, "Macintosh"), strings.Contains(userAgent, "Mac OS X"):
+		platform = "macOS"
+	case strings.Contains(userAgent, "Linux"):
+		platform = "Linux"
+	}
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	}
+	return "Unknown device"
+}
+
+// deviceName prefers the name the client chose and trims it to the column size.
+func deviceName(requested, userAgent string) string {
+	name := strings.TrimSpace(requested)
+	if name == "" {
+		name = describeDevice(userAgent)
+	}
+	if runes := []rune(name); len(runes) > maxDeviceLength {
+		name = string(runes[:maxDeviceLength])
+	}
+	return name
+}
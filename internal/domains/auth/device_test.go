@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DeviceSuite struct {
+	suite.Suite
+}
+
+func (s *DeviceSuite) TestDescribeDevice() {
+	cases := map[string]string{
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1": "Safari on iPhone",
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Mobile Safari/537.36":                       "Chrome on Android",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Safari/537.36 Edg/126.0":                   "Edge on Windows",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14.5; rv:127.0) Gecko/20100101 Firefox/127.0":                                                     "Firefox on macOS",
+		"okhttp/4.12.0": "Unknown device",
+		"":              "Unknown device",
+	}
+	for userAgent, want := range cases {
+		s.Equal(want, describeDevice(userAgent), userAgent)
+	}
+}
+
+func (s *DeviceSuite) TestDeviceNamePrefersRequestedName() {
+	s.Equal("โทรศัพท์ของฉัน", deviceName("  โทรศัพท์ของฉัน ", "okhttp/4.12.0"))
+	s.Equal("Unknown device", deviceName("", ""))
+	s.Equal(maxDeviceLength, len([]rune(deviceName(strings.Repeat("ก", 200), ""))))
+}
+
+func TestDeviceSuite(t *testing.T) {
+	suite.Run(t, new(DeviceSuite))
+}
@@ -1,9 +1,44 @@
 package auth
 
+import (
+	"net"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+// ClientInfo captures the client a session is opened or refreshed from. The
+// IP prefers the first X-Forwarded-For hop so that it survives a reverse
+// proxy; it is only shown back to the user, never trusted for access.
+type ClientInfo struct {
+	UserAgent    string `header:"User-Agent"`
+	ForwardedFor string `header:"X-Forwarded-For"`
+	remoteAddr   string
+}
+
+func (c *ClientInfo) Resolve(ctx huma.Context) []error {
+	c.remoteAddr = ctx.RemoteAddr()
+	return nil
+}
+
+func (c ClientInfo) IP() string {
+	if first, _, _ := strings.Cut(c.ForwardedFor, ","); strings.TrimSpace(first) != "" {
+		return strings.TrimSpace(first)
+	}
+	if host, _, err := net.SplitHostPort(c.remoteAddr); err == nil {
+		return host
+	}
+	return c.remoteAddr
+}
+
 type LoginInput struct {
+	ClientInfo
 	Body struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
+		Device   string `json:"device,omitempty" doc:"Name shown for this session, e.g. the phone model; derived from the User-Agent when empty"`
 	}
 }
 
@@ -17,17 +52,96 @@ type RegisterInput struct {
 }
 
 type RefreshInput struct {
+	ClientInfo
 	Body struct {
 		RefreshToken string `json:"refresh_token"`
 	}
 }
 
+type LogoutInput struct {
+	Body struct {
+		RefreshToken string `json:"refresh_token,omitempty" doc:"Refresh token of the session to end"`
+		All          bool   `json:"all,omitempty" doc:"End every session of the user instead"`
+	}
+}
+
+type VerifyEmailInput struct {
+	Body struct {
+		Token string `json:"token" doc:"Token from the verification email"`
+	}
+}
+
+type EmailInput struct {
+	Body struct {
+		Email string `json:"email" doc:"Account email address"`
+	}
+}
+
+type ResetPasswordInput struct {
+	Body struct {
+		Token    string `json:"token" doc:"Token from the password reset email"`
+		Password string `json:"password" doc:"New password"`
+	}
+}
+
+type LoginMFAInput struct {
+	ClientInfo
+	Body struct {
+		MFAToken string `json:"mfa_token" doc:"Challenge token returned by login"`
+		Code     string `json:"code" doc:"6-digit TOTP code or a recovery code"`
+		Device   string `json:"device,omitempty" doc:"Name shown for this session; derived from the User-Agent when empty"`
+	}
+}
+
+type TOTPCodeInput struct {
+	Body struct {
+		Code string `json:"code" doc:"6-digit TOTP code; disable and recovery-code regeneration also accept a recovery code"`
+	}
+}
+
+type SessionIDInput struct {
+	ID string `path:"id" doc:"Session ID (UUID)"`
+}
+
+// LoginResult carries the token pair, or only an MFA challenge token when
+// MFARequired is set. ExpiresIn then counts down the challenge.
 type LoginResult struct {
 	AccessToken  string
 	RefreshToken string
 	ExpiresIn    int64
+	MFARequired  bool
+	MFAToken     string
 }
 
 type RegisterResult struct {
 	UserID string
 }
+
+// UserSession is one signed-in device: a refresh-token family seen through its
+// current token. LastUsedAt is when the family was last logged in or
+// refreshed.
+type UserSession struct {
+	ID         string           `json:"id"`
+	Device     string           `json:"device"`
+	UserAgent  string           `json:"user_agent"`
+	IPAddress  string           `json:"ip_address"`
+	LastUsedAt models.LocalTime `json:"last_used_at"`
+	ExpiresAt  models.LocalTime `json:"expires_at"`
+}
+
+type MFAStatus struct {
+	TOTPEnabled            bool  `json:"totp_enabled"`
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"`
+}
+
+// TOTPEnrollment is shown once so the user can add the account to an
+// authenticator app, usually by scanning URI as a QR code.
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// RecoveryCodes are shown once; only their hashes are kept.
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
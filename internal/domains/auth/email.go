@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"sun-stockanalysis-api/internal/mailer"
+	"sun-stockanalysis-api/internal/models"
+)
+
+const (
+	verifyEmailTTL   = 24 * time.Hour
+	resetPasswordTTL = time.Hour
+)
+
+// VerifyEmail redeems a verification token and marks the address verified.
+func (s *AuthServiceImpl) VerifyEmail(ctx context.Context, input VerifyEmailInput) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	token, err := s.redeemToken(models.UserTokenVerifyEmail, input.Body.Token)
+	if err != nil {
+		return err
+	}
+	return s.userRepo.MarkEmailVerified(token.UserID)
+}
+
+// ResendVerification mails a fresh verification link. Unknown and already
+// verified addresses succeed silently so the endpoint cannot be used to probe
+// for accounts.
+func (s *AuthServiceImpl) ResendVerification(ctx context.Context, input EmailInput) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	user, err := s.userRepo.FindByEmail(strings.TrimSpace(input.Body.Email))
+	if err != nil || user.IsEmailVerified {
+		return nil
+	}
+	return s.sendVerification(ctx, user)
+}
+
+// ForgotPassword mails a password reset link. Like ResendVerification it
+// does not reveal whether the address has an account.
+func (s *AuthServiceImpl) ForgotPassword(ctx context.Context, input EmailInput) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	user, err := s.userRepo.FindByEmail(strings.TrimSpace(input.Body.Email))
+	if err != nil {
+		return nil
+	}
+	token, err := s.issueToken(user, models.UserTokenResetPassword, resetPasswordTTL)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Text: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset the password of your Sun Stock Analysis account. "+
+			"Use this link to choose a new one:\n\n%s\n\n"+
+			"The link works once and expires in 1 hour. If you did not ask for this, ignore this email; your password stays the same.\n",
+			greetingName(user), s.link("/reset-password", token)),
+	})
+}
+
+// ResetPassword redeems a reset token, sets the new password and signs every
+// session out. Receiving the link also proves the address, so it is marked
+// verified.
+func (s *AuthServiceImpl) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if input.Body.Password == "" {
+		return ErrPasswordRequired
+	}
+	token, err := s.redeemToken(models.UserTokenResetPassword, input.Body.Token)
+	if err != nil {
+		return err
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(input.Body.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.UpdatePassword(token.UserID, string(hashed)); err != nil {
+		return err
+	}
+	if err := s.userRepo.MarkEmailVerified(token.UserID); err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeAllForUser(token.UserID.String(), time.Now())
+}
+
+func (s *AuthServiceImpl) sendVerification(ctx context.Context, user *models.User) error {
+	token, err := s.issueToken(user, models.UserTokenVerifyEmail, verifyEmailTTL)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Text: fmt.Sprintf("Hi %s,\n\nConfirm the email address of your Sun Stock Analysis account with this link:\n\n%s\n\n"+
+			"The link expires in 24 hours. If you did not sign up, ignore this email.\n",
+			greetingName(user), s.link("/verify-email", token)),
+	})
+}
+
+// issueToken stores a new token for purpose and invalidates the ones mailed
+// before it.
+func (s *AuthServiceImpl) issueToken(user *models.User, purpose string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	if err := s.userTokenRepo.InvalidateForUser(user.ID, purpose, now); err != nil {
+		return "", err
+	}
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.userTokenRepo.Create(&models.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hash,
+		ExpiresAt: models.NewLocalTime(now.Add(ttl)),
+	}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// redeemToken checks a mailed token and marks it used.
+func (s *AuthServiceImpl) redeemToken(purpose, raw string) (*models.UserToken, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, ErrInvalidToken
+	}
+	token, err := s.userTokenRepo.FindByHash(purpose, hashToken(raw))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	now := time.Now()
+	if token.UsedAt != nil || now.After(time.Time(token.ExpiresAt)) {
+		return nil, ErrInvalidToken
+	}
+	consumed, err := s.userTokenRepo.Consume(token.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrInvalidToken
+	}
+	return token, nil
+}
+
+func (s *AuthServiceImpl) send(ctx context.Context, msg mailer.Message) error {
+	if s.mailer == nil {
+		return errors.New("mailer not configured")
+	}
+	return s.mailer.Send(ctx, msg)
+}
+
+// link points at the web app page that submits the token. Without
+// mail.appUrl the bare token is mailed instead.
+func (s *AuthServiceImpl) link(path, token string) string {
+	if s.mailConfig == nil || strings.TrimSpace(s.mailConfig.AppURL) == "" {
+		return token
+	}
+	return strings.TrimRight(strings.TrimSpace(s.mailConfig.AppURL), "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func greetingName(user *models.User) string {
+	if name := strings.TrimSpace(user.FirstName); name != "" {
+		return name
+	}
+	return user.Email
+}
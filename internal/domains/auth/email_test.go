@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/mailer"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type recordingMailer struct {
+	sent []mailer.Message
+	err  error
+}
+
+func (m *recordingMailer) Name() string {
+	return "recording"
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return m.err
+}
+
+// mailedToken extracts the token from the last link sent.
+func (s *AuthServiceSuite) mailedToken() string {
+	s.Require().NotEmpty(s.mailer.sent)
+	text := s.mailer.sent[len(s.mailer.sent)-1].Text
+	_, after, found := strings.Cut(text, "?token=")
+	s.Require().True(found, text)
+	token, _, _ := strings.Cut(after, "\n")
+	return token
+}
+
+func (s *AuthServiceSuite) TestRegister_RejectsInvalidEmail() {
+	input := RegisterInput{}
+	input.Body.Email = "not-an-email"
+	input.Body.Password = "secret"
+
+	result, err := s.service.Register(context.Background(), input)
+
+	s.Nil(result)
+	s.ErrorIs(err, ErrInvalidEmail)
+}
+
+func (s *AuthServiceSuite) TestRegister_SucceedsWhenMailFails() {
+	input := RegisterInput{}
+	input.Body.Email = "user@example.com"
+	input.Body.Password = "secret"
+	s.mailer.err = errors.New("smtp down")
+
+	s.userRepo.EXPECT().ExistsByEmail(input.Body.Email).Return(false, nil)
+	s.userRepo.EXPECT().Create(mock.Anything).Return(nil)
+	s.tokenRepo.EXPECT().InvalidateForUser(mock.Anything, models.UserTokenVerifyEmail, mock.Anything).Return(nil)
+	s.tokenRepo.EXPECT().Create(mock.Anything).Return(nil)
+
+	result, err := s.service.Register(context.Background(), input)
+
+	s.NoError(err)
+	s.NotNil(result)
+}
+
+func (s *AuthServiceSuite) TestLogin_RequiresVerifiedEmailWhenConfigured() {
+	s.state.RequireVerifiedEmail = true
+	input := LoginInput{}
+	input.Body.Email = "user@example.com"
+	input.Body.Password = "secret"
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(input.Body.Password), bcrypt.MinCost)
+	s.Require().NoError(err)
+	s.userRepo.EXPECT().FindByEmail(input.Body.Email).Return(&models.User{ID: uuid.New(), Password: string(hashed)}, nil)
+
+	result, err := s.service.Login(input)
+
+	s.Nil(result)
+	s.ErrorIs(err, ErrEmailNotVerified)
+}
+
+func (s *AuthServiceSuite) TestVerifyEmail_RedeemsTokenOnce() {
+	user := &models.User{ID: uuid.New(), Email: "user@example.com", FirstName: "Jane"}
+	var stored *models.UserToken
+	s.userRepo.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	s.tokenRepo.EXPECT().InvalidateForUser(user.ID, models.UserTokenVerifyEmail, mock.Anything).Return(nil)
+	s.tokenRepo.EXPECT().Create(mock.Anything).RunAndReturn(func(token *models.UserToken) error {
+		stored = token
+		stored.ID = uuid.New()
+		return nil
+	})
+
+	resend := EmailInput{}
+	resend.Body.Email = user.Email
+	s.Require().NoError(s.service.ResendVerification(context.Background(), resend))
+	s.Contains(s.mailer.sent[0].Text, "Hi Jane")
+	raw := s.mailedToken()
+	s.Equal(hashToken(raw), stored.TokenHash)
+	s.WithinDuration(time.Now().Add(verifyEmailTTL), time.Time(stored.ExpiresAt), time.Minute)
+
+	s.tokenRepo.EXPECT().FindByHash(models.UserTokenVerifyEmail, stored.TokenHash).Return(stored, nil)
+	s.tokenRepo.EXPECT().Consume(stored.ID, mock.Anything).Return(true, nil).Once()
+	s.userRepo.EXPECT().MarkEmailVerified(user.ID).Return(nil)
+
+	verify := VerifyEmailInput{}
+	verify.Body.Token = raw
+	s.Require().NoError(s.service.VerifyEmail(context.Background(), verify))
+
+	s.tokenRepo.EXPECT().Consume(stored.ID, mock.Anything).Return(false, nil).Once()
+	s.ErrorIs(s.service.VerifyEmail(context.Background(), verify), ErrInvalidToken)
+}
+
+func (s *AuthServiceSuite) TestVerifyEmail_RejectsExpiredToken() {
+	s.tokenRepo.EXPECT().FindByHash(models.UserTokenVerifyEmail, hashToken("expired")).Return(&models.UserToken{
+		ExpiresAt: models.NewLocalTime(time.Now().Add(-time.Minute)),
+	}, nil)
+
+	input := VerifyEmailInput{}
+	input.Body.Token = "expired"
+
+	s.ErrorIs(s.service.VerifyEmail(context.Background(), input), ErrInvalidToken)
+}
+
+func (s *AuthServiceSuite) TestResendVerification_SkipsVerifiedAndUnknownUsers() {
+	s.userRepo.EXPECT().FindByEmail("verified@example.com").Return(&models.User{IsEmailVerified: true}, nil)
+	s.userRepo.EXPECT().FindByEmail("nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	for _, email := range []string{"verified@example.com", "nobody@example.com"} {
+		input := EmailInput{}
+		input.Body.Email = email
+		s.NoError(s.service.ResendVerification(context.Background(), input))
+	}
+	s.Empty(s.mailer.sent)
+}
+
+func (s *AuthServiceSuite) TestForgotPassword_UnknownEmailSendsNothing() {
+	s.userRepo.EXPECT().FindByEmail("nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	input := EmailInput{}
+	input.Body.Email = "nobody@example.com"
+
+	s.NoError(s.service.ForgotPassword(context.Background(), input))
+	s.Empty(s.mailer.sent)
+}
+
+func (s *AuthServiceSuite) TestResetPassword_SetsPasswordAndEndsSessions() {
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+	var stored *models.UserToken
+	s.userRepo.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	s.tokenRepo.EXPECT().InvalidateForUser(user.ID, models.UserTokenResetPassword, mock.Anything).Return(nil)
+	s.tokenRepo.EXPECT().Create(mock.Anything).RunAndReturn(func(token *models.UserToken) error {
+		stored = token
+		stored.ID = uuid.New()
+		return nil
+	})
+
+	forgot := EmailInput{}
+	forgot.Body.Email = user.Email
+	s.Require().NoError(s.service.ForgotPassword(context.Background(), forgot))
+	s.Contains(s.mailer.sent[0].Text, "https://app.example.com/reset-password?token=")
+	s.WithinDuration(time.Now().Add(resetPasswordTTL), time.Time(stored.ExpiresAt), time.Minute)
+
+	s.tokenRepo.EXPECT().FindByHash(models.UserTokenResetPassword, stored.TokenHash).Return(stored, nil)
+	s.tokenRepo.EXPECT().Consume(stored.ID, mock.Anything).Return(true, nil)
+	s.userRepo.EXPECT().UpdatePassword(user.ID, mock.MatchedBy(func(hash string) bool {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte("n3w-secret")) == nil
+	})).Return(nil)
+	s.userRepo.EXPECT().MarkEmailVerified(user.ID).Return(nil)
+	s.refreshRepo.EXPECT().RevokeAllForUser(user.ID.String(), mock.Anything).Return(nil)
+
+	reset := ResetPasswordInput{}
+	reset.Body.Token = s.mailedToken()
+	reset.Body.Password = "n3w-secret"
+
+	s.NoError(s.service.ResetPassword(context.Background(), reset))
+}
+
+func (s *AuthServiceSuite) TestResetPassword_WrongPurposeTokenIsRejected() {
+	s.tokenRepo.EXPECT().FindByHash(models.UserTokenResetPassword, hashToken("verify-token")).Return(nil, gorm.ErrRecordNotFound)
+
+	input := ResetPasswordInput{}
+	input.Body.Token = "verify-token"
+	input.Body.Password = "n3w-secret"
+
+	s.ErrorIs(s.service.ResetPassword(context.Background(), input), ErrInvalidToken)
+}
@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+const (
+	mfaChallengeTTL         = 5 * time.Minute
+	maxMFAChallengeAttempts = 5
+	recoveryCodeCount       = 10
+	recoveryCodeLength      = 10
+	defaultTOTPIssuer       = "sun-stockanalysis-api"
+)
+
+// startMFAChallenge answers a correct password for a TOTP-enabled account
+// with a short-lived challenge token instead of a session.
+func (s *AuthServiceImpl) startMFAChallenge(user *models.User) (*LoginResult, error) {
+	token, err := s.issueToken(user, models.UserTokenMFAChallenge, mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresIn:   int64(mfaChallengeTTL.Seconds()),
+	}, nil
+}
+
+// LoginMFA completes a two-step login with a TOTP or recovery code. A
+// challenge allows a few wrong codes before the user has to enter the
+// password again.
+func (s *AuthServiceImpl) LoginMFA(ctx context.Context, input LoginMFAInput) (*LoginResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.stateConfig == nil || s.stateConfig.Secret == "" {
+		return nil, errors.New("auth secret not configured")
+	}
+	raw := strings.TrimSpace(input.Body.MFAToken)
+	if raw == "" {
+		return nil, ErrInvalidMFAToken
+	}
+
+	challenge, err := s.userTokenRepo.FindByHash(models.UserTokenMFAChallenge, hashToken(raw))
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+	now := time.Now()
+	if challenge.UsedAt != nil || now.After(time.Time(challenge.ExpiresAt)) || challenge.Attempts >= maxMFAChallengeAttempts {
+		return nil, ErrInvalidMFAToken
+	}
+	user, err := s.userRepo.FindByID(challenge.UserID)
+	if err != nil || !user.TOTPEnabled {
+		return nil, ErrInvalidMFAToken
+	}
+
+	ok, err := s.checkSecondFactor(user, input.Body.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.userTokenRepo.RecordAttempt(challenge.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidMFACode
+	}
+	consumed, err := s.userTokenRepo.Consume(challenge.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrInvalidMFAToken
+	}
+
+	return s.startSession(user, input.Body.Device, input.ClientInfo)
+}
+
+func (s *AuthServiceImpl) MFAStatus(ctx context.Context, userID string) (*MFAStatus, error) {
+	user, err := s.mfaUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	status := &MFAStatus{TOTPEnabled: user.TOTPEnabled}
+	if user.TOTPEnabled {
+		remaining, err := s.recoveryCodeRepo.CountUnused(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		status.RecoveryCodesRemaining = remaining
+	}
+	return status, nil
+}
+
+// EnrollTOTP stores a new pending secret. Two-factor login only starts once
+// ConfirmTOTP proves the authenticator app produces matching codes; enrolling
+// again before that replaces the pending secret.
+func (s *AuthServiceImpl) EnrollTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error) {
+	user, err := s.mfaUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SetTOTP(user.ID, secret, false); err != nil {
+		return nil, err
+	}
+	issuer := defaultTOTPIssuer
+	if s.stateConfig != nil && strings.TrimSpace(s.stateConfig.Issuer) != "" {
+		issuer = strings.TrimSpace(s.stateConfig.Issuer)
+	}
+	return &TOTPEnrollment{
+		Secret: secret,
+		URI:    totpURI(issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP turns on two-factor login with the first code from the app and
+// returns the recovery codes.
+func (s *AuthServiceImpl) ConfirmTOTP(ctx context.Context, userID string, input TOTPCodeInput) (*RecoveryCodes, error) {
+	user, err := s.mfaUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+	step, ok := verifyTOTP(user.TOTPSecret, input.Body.Code, time.Now(), 0)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	if err := s.userRepo.SetTOTP(user.ID, user.TOTPSecret, true); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.AdvanceTOTPStep(user.ID, step); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(user.ID)
+}
+
+// DisableTOTP turns two-factor login off after checking a current code.
+func (s *AuthServiceImpl) DisableTOTP(ctx context.Context, userID string, input TOTPCodeInput) error {
+	user, err := s.mfaUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return ErrMFANotEnabled
+	}
+	ok, err := s.checkSecondFactor(user, input.Body.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	if err := s.userRepo.SetTOTP(user.ID, "", false); err != nil {
+		return err
+	}
+	return s.recoveryCodeRepo.DeleteForUser(user.ID)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code, used or not.
+func (s *AuthServiceImpl) RegenerateRecoveryCodes(ctx context.Context, userID string, input TOTPCodeInput) (*RecoveryCodes, error) {
+	user, err := s.mfaUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, ErrMFANotEnabled
+	}
+	ok, err := s.checkSecondFactor(user, input.Body.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	return s.replaceRecoveryCodes(user.ID)
+}
+
+func (s *AuthServiceImpl) mfaUser(ctx context.Context, userID string) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	user, err := s.userRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// checkSecondFactor accepts a TOTP code not used before or an unused
+// recovery code, which it burns.
+func (s *AuthServiceImpl) checkSecondFactor(user *models.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == totpDigits && isDigits(code) {
+		step, ok := verifyTOTP(user.TOTPSecret, code, time.Now(), user.TOTPLastStep)
+		if !ok {
+			return false, nil
+		}
+		return s.userRepo.AdvanceTOTPStep(user.ID, step)
+	}
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return false, nil
+	}
+	return s.recoveryCodeRepo.Consume(user.ID, hashToken(normalized), time.Now())
+}
+
+func (s *AuthServiceImpl) replaceRecoveryCodes(userID uuid.UUID) (*RecoveryCodes, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+	if err := s.recoveryCodeRepo.ReplaceForUser(userID, hashes); err != nil {
+		return nil, err
+	}
+	return &RecoveryCodes{Codes: codes}, nil
+}
+
+// newRecoveryCode returns a code such as "k3j9q-x7m2p" (50 random bits).
+func newRecoveryCode() (string, error) {
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+	code := strings.ToLower(secret[:recoveryCodeLength])
+	return code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:], nil
+}
+
+// normalizeRecoveryCode ignores case, spaces and dashes, and returns "" for
+// anything that cannot be a recovery code.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != recoveryCodeLength {
+		return ""
+	}
+	return code
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
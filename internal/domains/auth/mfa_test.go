@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"encoding/base32"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+// currentTOTP returns the code an authenticator app would show now.
+func currentTOTP(secret string) string {
+	key, _ := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	return hotp(key, totpStep(time.Now()))
+}
+
+func (s *AuthServiceSuite) mfaUserFixture() *models.User {
+	secret, err := newTOTPSecret()
+	s.Require().NoError(err)
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	s.Require().NoError(err)
+	return &models.User{
+		ID:          uuid.New(),
+		Email:       "user@example.com",
+		Password:    string(hashed),
+		TOTPSecret:  secret,
+		TOTPEnabled: true,
+	}
+}
+
+func (s *AuthServiceSuite) TestLogin_ReturnsChallengeWhenTOTPEnabled() {
+	user := s.mfaUserFixture()
+	input := LoginInput{}
+	input.Body.Email = user.Email
+	input.Body.Password = "secret"
+
+	s.userRepo.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	s.tokenRepo.EXPECT().InvalidateForUser(user.ID, models.UserTokenMFAChallenge, mock.Anything).Return(nil)
+	s.tokenRepo.EXPECT().Create(mock.MatchedBy(func(token *models.UserToken) bool {
+		return token.Purpose == models.UserTokenMFAChallenge &&
+			time.Time(token.ExpiresAt).Before(time.Now().Add(mfaChallengeTTL+time.Second))
+	})).Return(nil)
+
+	result, err := s.service.Login(input)
+
+	s.Require().NoError(err)
+	s.True(result.MFARequired)
+	s.NotEmpty(result.MFAToken)
+	s.Empty(result.AccessToken)
+	s.Empty(result.RefreshToken)
+}
+
+func (s *AuthServiceSuite) TestLoginMFA_IssuesSessionForValidCode() {
+	user := s.mfaUserFixture()
+	challenge := &models.UserToken{ID: uuid.New(), UserID: user.ID, ExpiresAt: models.NewLocalTime(time.Now().Add(time.Minute))}
+	input := LoginMFAInput{}
+	input.Body.MFAToken = "challenge"
+	input.Body.Code = currentTOTP(user.TOTPSecret)
+
+	s.tokenRepo.EXPECT().FindByHash(models.UserTokenMFAChallenge, hashToken("challenge")).Return(challenge, nil)
+	s.userRepo.EXPECT().FindByID(user.ID).Return(user, nil)
+	s.userRepo.EXPECT().AdvanceTOTPStep(user.ID, mock.Anything).Return(true, nil)
+	s.tokenRepo.EXPECT().Consume(challenge.ID, mock.Anything).Return(true, nil)
+	s.refreshRepo.EXPECT().Create(mock.Anything).Return(nil)
+	s.userRepo.EXPECT().UpdateLastLogin(user.ID, mock.Anything).Return(nil)
+
+	result, err := s.service.LoginMFA(context.Background(), input)
+
+	s.Require().NoError(err)
+	s.False(result.MFARequired)
+	s.NotEmpty(result.AccessToken)
+	s.NotEmpty(result.RefreshToken)
+}
+
+func (s *AuthServiceSuite) TestLoginMFA_AcceptsRecoveryCode() {
+	user := s.mfaUserFixture()
+	challenge := &models.UserToken{ID: uuid.New(), UserID: user.ID, ExpiresAt: models.NewLocalTime(time.Now().Add(time.Minute))}
+	input := LoginMFAInput{}
+	input.Body.MFAToken = "challenge"
+	input.Body.Code = "ABCDE-FGHIJ"
+
+	s.tokenRepo.EXPECT().FindByHash(models.UserTokenMFAChallenge, hashToken("challenge")).Return(challenge, nil)
+	s.userRepo.EXPECT().FindByID(user.ID).Return(user, nil)
+	s.codeRepo.EXPECT().Consume(user.ID, hashToken("abcdefghij"), mock.Anything).Return(true, nil)
+	s.tokenRepo.EXPECT().Consume(challenge.ID, mock.Anything).Return(true, nil)
+	s.refreshRepo.EXPECT().Create(mock.Anything).Return(nil)
+	s.userRepo.EXPECT().UpdateLastLogin(user.ID, mock.Anything).Return(nil)
+
+	result, err := s.service.LoginMFA(context.Background(), input)
+
+	s.Require().NoError(err)
+	s.NotEmpty(result.AccessToken)
+}
+
+func (s *AuthServiceSuite) TestLoginMFA_WrongCodeCountsAttempt() {
+	user := s.mfaUserFixture()
+	challenge := &models.UserToken{ID: uuid.New(), UserID: user.ID, ExpiresAt: models.NewLocalTime(time.Now().Add(time.Minute))}
+	input := LoginMFAInput{}
+	input.Body.MFAToken = "challenge"
+	input.Body.Code = "abcde-fghij"
+
+	s.tokenRepo.EXPECT().FindByHash(models.UserTokenMFAChallenge, hashToken("challenge")).Return(challenge, nil)
+	s.userRepo.EXPECT().FindByID(user.ID).Return(user, nil)
+	s.codeRepo.EXPECT().Consume(user.ID, mock.Anything, mock.Anything).Return(false, nil)
+	s.tokenRepo.EXPECT().RecordAttempt(challenge.ID).Return(nil)
+
+	result, err := s.service.LoginMFA(context.Background(), input)
+
+	s.Nil(result)
+	s.ErrorIs(err, ErrInvalidMFACode)
+}
+
+func (s *AuthServiceSuite) TestLoginMFA_RejectsExhaustedChallenge() {
+	challenge := &models.UserToken{
+		ID:        uuid.New(),
+		ExpiresAt: models.NewLocalTime(time.Now().Add(time.Minute)),
+		Attempts:  maxMFAChallengeAttempts,
+	}
+	input := LoginMFAInput{}
+	input.Body.MFAToken = "challenge"
+	input.Body.Code = "123456"
+
+	s.tokenRepo.EXPECT().FindByHash(models.UserTokenMFAChallenge, hashToken("challenge")).Return(challenge, nil)
+
+	result, err := s.service.LoginMFA(context.Background(), input)
+
+	s.Nil(result)
+	s.ErrorIs(err, ErrInvalidMFAToken)
+}
+
+func (s *AuthServiceSuite) TestEnrollAndConfirmTOTP() {
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+	var secret string
+	s.userRepo.EXPECT().FindByID(user.ID).Return(user, nil)
+	s.userRepo.EXPECT().SetTOTP(user.ID, mock.Anything, false).RunAndReturn(func(_ uuid.UUID, stored string, _ bool) error {
+		secret = stored
+		return nil
+	})
+
+	enrollment, err := s.service.EnrollTOTP(context.Background(), user.ID.String())
+
+	s.Require().NoError(err)
+	s.Equal(secret, enrollment.Secret)
+	s.Contains(enrollment.URI, "otpauth://totp/test-issuer:user@example.com?")
+
+	user.TOTPSecret = secret
+	s.userRepo.EXPECT().SetTOTP(user.ID, secret, true).Return(nil)
+	s.userRepo.EXPECT().AdvanceTOTPStep(user.ID, mock.Anything).Return(true, nil)
+	s.codeRepo.EXPECT().ReplaceForUser(user.ID, mock.MatchedBy(func(hashes []string) bool {
+		return len(hashes) == recoveryCodeCount
+	})).Return(nil)
+
+	input := TOTPCodeInput{}
+	input.Body.Code = currentTOTP(secret)
+	codes, err := s.service.ConfirmTOTP(context.Background(), user.ID.String(), input)
+
+	s.Require().NoError(err)
+	s.Len(codes.Codes, recoveryCodeCount)
+}
+
+func (s *AuthServiceSuite) TestConfirmTOTP_RequiresEnrollmentAndValidCode() {
+	user := &models.User{ID: uuid.New()}
+	s.userRepo.EXPECT().FindByID(user.ID).Return(user, nil)
+	input := TOTPCodeInput{}
+	input.Body.Code = "123456"
+
+	_, err := s.service.ConfirmTOTP(context.Background(), user.ID.String(), input)
+	s.ErrorIs(err, ErrMFANotEnrolled)
+
+	user.TOTPSecret, _ = newTOTPSecret()
+	input.Body.Code = "abcde-fghij"
+	_, err = s.service.ConfirmTOTP(context.Background(), user.ID.String(), input)
+	s.ErrorIs(err, ErrInvalidMFACode)
+}
+
+func (s *AuthServiceSuite) TestDisableTOTP() {
+	user := s.mfaUserFixture()
+	s.userRepo.EXPECT().FindByID(user.ID).Return(user, nil)
+	s.userRepo.EXPECT().AdvanceTOTPStep(user.ID, mock.Anything).Return(true, nil)
+	s.userRepo.EXPECT().SetTOTP(user.ID, "", false).Return(nil)
+	s.codeRepo.EXPECT().DeleteForUser(user.ID).Return(nil)
+
+	input := TOTPCodeInput{}
+	input.Body.Code = currentTOTP(user.TOTPSecret)
+
+	s.NoError(s.service.DisableTOTP(context.Background(), user.ID.String(), input))
+}
+
+func (s *AuthServiceSuite) TestMFAStatus() {
+	user := s.mfaUserFixture()
+	s.userRepo.EXPECT().FindByID(user.ID).Return(user, nil)
+	s.codeRepo.EXPECT().CountUnused(user.ID).Return(7, nil)
+
+	status, err := s.service.MFAStatus(context.Background(), user.ID.String())
+
+	s.Require().NoError(err)
+	s.True(status.TOTPEnabled)
+	s.Equal(int64(7), status.RecoveryCodesRemaining)
+}
@@ -1,11 +1,14 @@
 package auth
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
+	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -13,39 +16,80 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"sun-stockanalysis-api/internal/configurations"
+	"sun-stockanalysis-api/internal/mailer"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/internal/repository"
+	"sun-stockanalysis-api/pkg/logger"
 )
 
 var (
 	ErrInvalidCredentials  = errors.New("invalid credentials")
 	ErrEmailAlreadyExists  = errors.New("email already exists")
 	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrInvalidEmail        = errors.New("invalid email address")
+	ErrEmailNotVerified    = errors.New("email address not verified")
+	ErrInvalidToken        = errors.New("invalid or expired token")
+	ErrPasswordRequired    = errors.New("password required")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidMFAToken     = errors.New("invalid or expired mfa token")
+	ErrInvalidMFACode      = errors.New("invalid authentication code")
+	ErrMFAAlreadyEnabled   = errors.New("two-factor authentication already enabled")
+	ErrMFANotEnabled       = errors.New("two-factor authentication not enabled")
+	ErrMFANotEnrolled      = errors.New("start TOTP enrollment first")
 )
 
 const refreshTokenTTL = 7 * 24 * time.Hour
 
 type AuthService interface {
 	Login(input LoginInput) (*LoginResult, error)
-	Register(input RegisterInput) (*RegisterResult, error)
+	Register(ctx context.Context, input RegisterInput) (*RegisterResult, error)
 	Refresh(input RefreshInput) (*LoginResult, error)
+	Logout(userID string, input LogoutInput) error
+	Sessions(userID string) ([]UserSession, error)
+	RevokeSession(userID string, sessionID uuid.UUID) error
+	VerifyEmail(ctx context.Context, input VerifyEmailInput) error
+	ResendVerification(ctx context.Context, input EmailInput) error
+	ForgotPassword(ctx context.Context, input EmailInput) error
+	ResetPassword(ctx context.Context, input ResetPasswordInput) error
+	LoginMFA(ctx context.Context, input LoginMFAInput) (*LoginResult, error)
+	MFAStatus(ctx context.Context, userID string) (*MFAStatus, error)
+	EnrollTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID string, input TOTPCodeInput) (*RecoveryCodes, error)
+	DisableTOTP(ctx context.Context, userID string, input TOTPCodeInput) error
+	RegenerateRecoveryCodes(ctx context.Context, userID string, input TOTPCodeInput) (*RecoveryCodes, error)
 }
 
 type AuthServiceImpl struct {
 	userRepo         repository.UserRepository
 	refreshTokenRepo repository.RefreshTokenRepository
+	userTokenRepo    repository.UserTokenRepository
+	recoveryCodeRepo repository.MFARecoveryCodeRepository
+	mailer           mailer.Mailer
 	stateConfig      *configurations.State
+	mailConfig       *configurations.Mail
+	log              *logger.Logger
 }
 
 func NewAuthService(
 	userRepo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	userTokenRepo repository.UserTokenRepository,
+	recoveryCodeRepo repository.MFARecoveryCodeRepository,
+	mailer mailer.Mailer,
 	stateConfig *configurations.State,
+	mailConfig *configurations.Mail,
+	log *logger.Logger,
 ) AuthService {
 	return &AuthServiceImpl{
 		userRepo:         userRepo,
 		refreshTokenRepo: refreshTokenRepo,
+		userTokenRepo:    userTokenRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		mailer:           mailer,
 		stateConfig:      stateConfig,
+		mailConfig:       mailConfig,
+		log:              log,
 	}
 }
 
@@ -63,6 +107,19 @@ func (s *AuthServiceImpl) Login(input LoginInput) (*LoginResult, error) {
 		return nil, ErrInvalidCredentials
 	}
 
+	if s.stateConfig.RequireVerifiedEmail && !user.IsEmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
+	if user.TOTPEnabled {
+		return s.startMFAChallenge(user)
+	}
+
+	return s.startSession(user, input.Body.Device, input.ClientInfo)
+}
+
+// startSession issues the access token and opens a new refresh-token family.
+func (s *AuthServiceImpl) startSession(user *models.User, device string, client ClientInfo) (*LoginResult, error) {
 	accessToken, expiresAt, err := s.createAccessToken(user)
 	if err != nil {
 		return nil, err
@@ -75,9 +132,12 @@ func (s *AuthServiceImpl) Login(input LoginInput) (*LoginResult, error) {
 
 	if err := s.refreshTokenRepo.Create(&models.RefreshTokens{
 		UserID:    user.ID.String(),
+		FamilyID:  uuid.New(),
 		TokenHash: refreshTokenHash,
-		ExpiresAt: refreshExpiresAt.Format(time.RFC3339),
-		RevokedAt: 0,
+		Device:    deviceName(device, client.UserAgent),
+		UserAgent: client.UserAgent,
+		IPAddress: client.IP(),
+		ExpiresAt: models.NewLocalTime(refreshExpiresAt),
 	}); err != nil {
 		return nil, err
 	}
@@ -91,10 +151,13 @@ func (s *AuthServiceImpl) Login(input LoginInput) (*LoginResult, error) {
 	}, nil
 }
 
-func (s *AuthServiceImpl) Register(input RegisterInput) (*RegisterResult, error) {
+func (s *AuthServiceImpl) Register(ctx context.Context, input RegisterInput) (*RegisterResult, error) {
 	if input.Body.Email == "" || input.Body.Password == "" {
 		return nil, errors.New("email and password required")
 	}
+	if !validEmail(input.Body.Email) {
+		return nil, ErrInvalidEmail
+	}
 
 	exists, err := s.userRepo.ExistsByEmail(input.Body.Email)
 	if err != nil {
@@ -114,13 +177,18 @@ func (s *AuthServiceImpl) Register(input RegisterInput) (*RegisterResult, error)
 		Password:  string(hashed),
 		FirstName: input.Body.FirstName,
 		LastName:  input.Body.LastName,
-		Role:      "USER",
+		Role:      models.RoleUser,
 	}
 
 	if err := s.userRepo.Create(user); err != nil {
 		return nil, err
 	}
 
+	// The account exists either way; the user can ask for another link.
+	if err := s.sendVerification(ctx, user); err != nil {
+		s.logf("auth: verification email to %s failed: %v", user.Email, err)
+	}
+
 	return &RegisterResult{UserID: user.ID.String()}, nil
 }
 
@@ -132,20 +200,24 @@ func (s *AuthServiceImpl) Refresh(input RefreshInput) (*LoginResult, error) {
 		return nil, ErrInvalidRefreshToken
 	}
 
-	hashBytes := sha256.Sum256([]byte(input.Body.RefreshToken))
-	hash := hex.EncodeToString(hashBytes[:])
+	hash := hashToken(input.Body.RefreshToken)
 
 	stored, err := s.refreshTokenRepo.FindByHash(hash)
 	if err != nil {
 		return nil, ErrInvalidRefreshToken
 	}
 
-	if stored.RevokedAt > 0 {
+	now := time.Now()
+	if stored.RevokedAt != nil {
+		// A rotated token came back, so it has been copied. Either copy may be
+		// the attacker's, so end the whole session.
+		if err := s.refreshTokenRepo.RevokeFamily(stored.FamilyID, now); err != nil {
+			return nil, err
+		}
 		return nil, ErrInvalidRefreshToken
 	}
 
-	expiresAt, err := time.Parse(time.RFC3339, stored.ExpiresAt)
-	if err != nil || time.Now().After(expiresAt) {
+	if now.After(time.Time(stored.ExpiresAt)) {
 		return nil, ErrInvalidRefreshToken
 	}
 
@@ -169,15 +241,26 @@ func (s *AuthServiceImpl) Refresh(input RefreshInput) (*LoginResult, error) {
 		return nil, err
 	}
 
-	if err := s.refreshTokenRepo.RevokeByHash(hash, float64(time.Now().Unix())); err != nil {
+	rotated, err := s.refreshTokenRepo.Revoke(stored.ID, now)
+	if err != nil {
 		return nil, err
 	}
+	if !rotated {
+		// Another request rotated the same token first: treat it as reuse.
+		if err := s.refreshTokenRepo.RevokeFamily(stored.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
 
 	if err := s.refreshTokenRepo.Create(&models.RefreshTokens{
 		UserID:    user.ID.String(),
+		FamilyID:  stored.FamilyID,
 		TokenHash: newRefreshTokenHash,
-		ExpiresAt: newRefreshExpiresAt.Format(time.RFC3339),
-		RevokedAt: 0,
+		Device:    stored.Device,
+		UserAgent: input.UserAgent,
+		IPAddress: input.IP(),
+		ExpiresAt: models.NewLocalTime(newRefreshExpiresAt),
 	}); err != nil {
 		return nil, err
 	}
@@ -189,6 +272,63 @@ func (s *AuthServiceImpl) Refresh(input RefreshInput) (*LoginResult, error) {
 	}, nil
 }
 
+// Logout ends the session that owns the given refresh token, or every
+// session of the user when All is set. Access tokens already issued stay
+// valid until they expire.
+func (s *AuthServiceImpl) Logout(userID string, input LogoutInput) error {
+	now := time.Now()
+	if input.Body.All {
+		return s.refreshTokenRepo.RevokeAllForUser(userID, now)
+	}
+	if input.Body.RefreshToken == "" {
+		return ErrInvalidRefreshToken
+	}
+
+	stored, err := s.refreshTokenRepo.FindByHash(hashToken(input.Body.RefreshToken))
+	if err != nil || stored.UserID != userID {
+		return ErrInvalidRefreshToken
+	}
+	return s.refreshTokenRepo.RevokeFamily(stored.FamilyID, now)
+}
+
+// Sessions lists the user's signed-in devices, most recently used first.
+func (s *AuthServiceImpl) Sessions(userID string) ([]UserSession, error) {
+	tokens, err := s.refreshTokenRepo.FindActiveByUser(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]UserSession, 0, len(tokens))
+	seen := make(map[uuid.UUID]bool, len(tokens))
+	for _, token := range tokens {
+		if seen[token.FamilyID] {
+			continue
+		}
+		seen[token.FamilyID] = true
+		sessions = append(sessions, UserSession{
+			ID:         token.FamilyID.String(),
+			Device:     token.Device,
+			UserAgent:  token.UserAgent,
+			IPAddress:  token.IPAddress,
+			LastUsedAt: token.CreatedAt,
+			ExpiresAt:  token.ExpiresAt,
+		})
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs one of the user's devices out.
+func (s *AuthServiceImpl) RevokeSession(userID string, sessionID uuid.UUID) error {
+	revoked, err := s.refreshTokenRepo.RevokeUserFamily(userID, sessionID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
 func (s *AuthServiceImpl) createAccessToken(user *models.User) (string, time.Time, error) {
 	expiry := normalizeDuration(s.stateConfig.ExpiredsAt)
 	if expiry <= 0 {
@@ -228,11 +368,35 @@ func normalizeDuration(d time.Duration) time.Duration {
 }
 
 func newRefreshToken() (string, string, time.Time, error) {
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return token, hash, time.Now().Add(refreshTokenTTL), nil
+}
+
+// newOpaqueToken returns a random URL-safe token and the hash to store.
+func newOpaqueToken() (string, string, error) {
 	raw := make([]byte, 32)
 	if _, err := rand.Read(raw); err != nil {
-		return "", "", time.Time{}, err
+		return "", "", err
 	}
 	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashToken(token), nil
+}
+
+func validEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == strings.TrimSpace(email)
+}
+
+func (s *AuthServiceImpl) logf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Infof(format, args...)
+	}
+}
+
+func hashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
-	return token, hex.EncodeToString(hash[:]), time.Now().Add(refreshTokenTTL), nil
+	return hex.EncodeToString(hash[:])
 }
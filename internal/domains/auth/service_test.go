@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
@@ -21,6 +22,9 @@ type AuthServiceSuite struct {
 	suite.Suite
 	userRepo    *repositorymock.MockUserRepository
 	refreshRepo *repositorymock.MockRefreshTokenRepository
+	tokenRepo   *repositorymock.MockUserTokenRepository
+	codeRepo    *repositorymock.MockMFARecoveryCodeRepository
+	mailer      *recordingMailer
 	state       *configurations.State
 	service     AuthService
 }
@@ -28,12 +32,15 @@ type AuthServiceSuite struct {
 func (s *AuthServiceSuite) SetupTest() {
 	s.userRepo = repositorymock.NewMockUserRepository(s.T())
 	s.refreshRepo = repositorymock.NewMockRefreshTokenRepository(s.T())
+	s.tokenRepo = repositorymock.NewMockUserTokenRepository(s.T())
+	s.codeRepo = repositorymock.NewMockMFARecoveryCodeRepository(s.T())
+	s.mailer = &recordingMailer{}
 	s.state = &configurations.State{
 		Secret:     "test-secret",
 		ExpiredsAt: 15 * time.Minute,
 		Issuer:     "test-issuer",
 	}
-	s.service = NewAuthService(s.userRepo, s.refreshRepo, s.state)
+	s.service = NewAuthService(s.userRepo, s.refreshRepo, s.tokenRepo, s.codeRepo, s.mailer, s.state, &configurations.Mail{AppURL: "https://app.example.com/"}, nil)
 }
 
 func (s *AuthServiceSuite) TestRegister_RequiresEmailAndPassword() {
@@ -41,7 +48,7 @@ func (s *AuthServiceSuite) TestRegister_RequiresEmailAndPassword() {
 	input.Body.Email = ""
 	input.Body.Password = ""
 
-	result, err := s.service.Register(input)
+	result, err := s.service.Register(context.Background(), input)
 
 	s.Nil(result)
 	s.Error(err)
@@ -54,7 +61,7 @@ func (s *AuthServiceSuite) TestRegister_EmailAlreadyExists() {
 
 	s.userRepo.EXPECT().ExistsByEmail(input.Body.Email).Return(true, nil)
 
-	result, err := s.service.Register(input)
+	result, err := s.service.Register(context.Background(), input)
 
 	s.Nil(result)
 	s.ErrorIs(err, ErrEmailAlreadyExists)
@@ -77,16 +84,21 @@ func (s *AuthServiceSuite) TestRegister_Success() {
 			user.Password != "" &&
 			user.Password != input.Body.Password
 	})).Return(nil)
+	s.tokenRepo.EXPECT().InvalidateForUser(mock.Anything, models.UserTokenVerifyEmail, mock.Anything).Return(nil)
+	s.tokenRepo.EXPECT().Create(mock.Anything).Return(nil)
 
-	result, err := s.service.Register(input)
+	result, err := s.service.Register(context.Background(), input)
 
 	s.NoError(err)
 	s.NotNil(result)
 	s.NotEmpty(result.UserID)
+	s.Require().Len(s.mailer.sent, 1)
+	s.Equal(input.Body.Email, s.mailer.sent[0].To)
+	s.Contains(s.mailer.sent[0].Text, "https://app.example.com/verify-email?token=")
 }
 
 func (s *AuthServiceSuite) TestLogin_InvalidSecret() {
-	service := NewAuthService(s.userRepo, s.refreshRepo, &configurations.State{})
+	service := NewAuthService(s.userRepo, s.refreshRepo, s.tokenRepo, s.codeRepo, s.mailer, &configurations.State{}, nil, nil)
 
 	input := LoginInput{}
 	input.Body.Email = "user@example.com"
@@ -164,15 +176,21 @@ func (s *AuthServiceSuite) TestRefresh_Success() {
 		Role:  "USER",
 	}
 
+	tokenID := uuid.New()
+	familyID := uuid.New()
 	s.refreshRepo.EXPECT().FindByHash(hash).Return(&models.RefreshTokens{
+		ID:        tokenID,
 		UserID:    userID.String(),
+		FamilyID:  familyID,
 		TokenHash: hash,
-		ExpiresAt: time.Now().Add(time.Hour).Format(time.RFC3339),
-		RevokedAt: 0,
+		Device:    "Pixel 8",
+		ExpiresAt: models.NewLocalTime(time.Now().Add(time.Hour)),
 	}, nil)
 	s.userRepo.EXPECT().FindByID(userID).Return(user, nil)
-	s.refreshRepo.EXPECT().RevokeByHash(hash, mock.Anything).Return(nil)
-	s.refreshRepo.EXPECT().Create(mock.Anything).Return(nil)
+	s.refreshRepo.EXPECT().Revoke(tokenID, mock.Anything).Return(true, nil)
+	s.refreshRepo.EXPECT().Create(mock.MatchedBy(func(token *models.RefreshTokens) bool {
+		return token.FamilyID == familyID && token.Device == "Pixel 8" && token.TokenHash != hash
+	})).Return(nil)
 
 	result, err := s.service.Refresh(input)
 
@@ -183,6 +201,141 @@ func (s *AuthServiceSuite) TestRefresh_Success() {
 	s.Greater(result.ExpiresIn, int64(0))
 }
 
+func (s *AuthServiceSuite) TestLogin_StartsSessionWithClientInfo() {
+	input := LoginInput{}
+	input.Body.Email = "user@example.com"
+	input.Body.Password = "secret"
+	input.UserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Safari/537.36"
+	input.ForwardedFor = "203.0.113.7, 10.0.0.1"
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(input.Body.Password), bcrypt.MinCost)
+	s.Require().NoError(err)
+	userID := uuid.New()
+
+	s.userRepo.EXPECT().FindByEmail(input.Body.Email).Return(&models.User{ID: userID, Password: string(hashed)}, nil)
+	s.refreshRepo.EXPECT().Create(mock.MatchedBy(func(token *models.RefreshTokens) bool {
+		return token.FamilyID != uuid.Nil &&
+			token.Device == "Chrome on Windows" &&
+			token.IPAddress == "203.0.113.7" &&
+			token.RevokedAt == nil &&
+			time.Time(token.ExpiresAt).After(time.Now().Add(refreshTokenTTL-time.Minute))
+	})).Return(nil)
+	s.userRepo.EXPECT().UpdateLastLogin(userID, mock.Anything).Return(nil)
+
+	_, err = s.service.Login(input)
+
+	s.NoError(err)
+}
+
+func (s *AuthServiceSuite) TestRefresh_ReusedTokenRevokesFamily() {
+	input := RefreshInput{}
+	input.Body.RefreshToken = "stolen-token"
+
+	familyID := uuid.New()
+	revokedAt := models.NewLocalTime(time.Now().Add(-time.Minute))
+	s.refreshRepo.EXPECT().FindByHash(hashToken(input.Body.RefreshToken)).Return(&models.RefreshTokens{
+		ID:        uuid.New(),
+		FamilyID:  familyID,
+		ExpiresAt: models.NewLocalTime(time.Now().Add(time.Hour)),
+		RevokedAt: &revokedAt,
+	}, nil)
+	s.refreshRepo.EXPECT().RevokeFamily(familyID, mock.Anything).Return(nil)
+
+	result, err := s.service.Refresh(input)
+
+	s.Nil(result)
+	s.ErrorIs(err, ErrInvalidRefreshToken)
+}
+
+func (s *AuthServiceSuite) TestRefresh_ConcurrentRotationRevokesFamily() {
+	input := RefreshInput{}
+	input.Body.RefreshToken = "refresh-token"
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+	familyID := uuid.New()
+	s.refreshRepo.EXPECT().FindByHash(hashToken(input.Body.RefreshToken)).Return(&models.RefreshTokens{
+		ID:        tokenID,
+		UserID:    userID.String(),
+		FamilyID:  familyID,
+		ExpiresAt: models.NewLocalTime(time.Now().Add(time.Hour)),
+	}, nil)
+	s.userRepo.EXPECT().FindByID(userID).Return(&models.User{ID: userID}, nil)
+	s.refreshRepo.EXPECT().Revoke(tokenID, mock.Anything).Return(false, nil)
+	s.refreshRepo.EXPECT().RevokeFamily(familyID, mock.Anything).Return(nil)
+
+	result, err := s.service.Refresh(input)
+
+	s.Nil(result)
+	s.ErrorIs(err, ErrInvalidRefreshToken)
+}
+
+func (s *AuthServiceSuite) TestRefresh_ExpiredToken() {
+	input := RefreshInput{}
+	input.Body.RefreshToken = "refresh-token"
+
+	s.refreshRepo.EXPECT().FindByHash(hashToken(input.Body.RefreshToken)).Return(&models.RefreshTokens{
+		ExpiresAt: models.NewLocalTime(time.Now().Add(-time.Minute)),
+	}, nil)
+
+	result, err := s.service.Refresh(input)
+
+	s.Nil(result)
+	s.ErrorIs(err, ErrInvalidRefreshToken)
+}
+
+func (s *AuthServiceSuite) TestLogout_RevokesOwnSessionOnly() {
+	userID := uuid.NewString()
+	familyID := uuid.New()
+	input := LogoutInput{}
+	input.Body.RefreshToken = "refresh-token"
+	hash := hashToken(input.Body.RefreshToken)
+
+	s.refreshRepo.EXPECT().FindByHash(hash).Return(&models.RefreshTokens{UserID: userID, FamilyID: familyID}, nil).Once()
+	s.refreshRepo.EXPECT().RevokeFamily(familyID, mock.Anything).Return(nil).Once()
+	s.NoError(s.service.Logout(userID, input))
+
+	s.refreshRepo.EXPECT().FindByHash(hash).Return(&models.RefreshTokens{UserID: uuid.NewString(), FamilyID: familyID}, nil).Once()
+	s.ErrorIs(s.service.Logout(userID, input), ErrInvalidRefreshToken)
+}
+
+func (s *AuthServiceSuite) TestLogout_All() {
+	userID := uuid.NewString()
+	input := LogoutInput{}
+	input.Body.All = true
+
+	s.refreshRepo.EXPECT().RevokeAllForUser(userID, mock.Anything).Return(nil)
+
+	s.NoError(s.service.Logout(userID, input))
+}
+
+func (s *AuthServiceSuite) TestSessions_OnePerFamily() {
+	userID := uuid.NewString()
+	familyA := uuid.New()
+	familyB := uuid.New()
+	s.refreshRepo.EXPECT().FindActiveByUser(userID, mock.Anything).Return([]models.RefreshTokens{
+		{FamilyID: familyA, Device: "iPhone", IPAddress: "203.0.113.7"},
+		{FamilyID: familyB, Device: "Chrome on Windows"},
+		{FamilyID: familyA, Device: "iPhone"},
+	}, nil)
+
+	sessions, err := s.service.Sessions(userID)
+
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 2)
+	s.Equal(familyA.String(), sessions[0].ID)
+	s.Equal("203.0.113.7", sessions[0].IPAddress)
+	s.Equal("Chrome on Windows", sessions[1].Device)
+}
+
+func (s *AuthServiceSuite) TestRevokeSession_NotFound() {
+	userID := uuid.NewString()
+	sessionID := uuid.New()
+	s.refreshRepo.EXPECT().RevokeUserFamily(userID, sessionID, mock.Anything).Return(false, nil)
+
+	s.ErrorIs(s.service.RevokeSession(userID, sessionID), ErrSessionNotFound)
+}
+
 func TestAuthServiceSuite(t *testing.T) {
 	suite.Run(t, new(AuthServiceSuite))
 }
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TOTPSuite struct {
+	suite.Suite
+	secret string
+}
+
+func (s *TOTPSuite) SetupTest() {
+	// RFC 6238 appendix B SHA-1 key.
+	s.secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+}
+
+func (s *TOTPSuite) TestRFC6238Vectors() {
+	// The RFC lists 8-digit codes; a 6-digit code is their last six digits.
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	key := []byte("12345678901234567890")
+	for unix, want := range vectors {
+		s.Equal(want, hotp(key, totpStep(time.Unix(unix, 0))), unix)
+	}
+}
+
+func (s *TOTPSuite) TestVerifyAllowsOneStepOfDrift() {
+	now := time.Unix(1111111109, 0)
+	key := []byte("12345678901234567890")
+	previous := hotp(key, totpStep(now)-1)
+	tooOld := hotp(key, totpStep(now)-2)
+
+	step, ok := verifyTOTP(s.secret, previous, now, 0)
+	s.True(ok)
+	s.Equal(totpStep(now)-1, step)
+
+	_, ok = verifyTOTP(s.secret, tooOld, now, 0)
+	s.False(ok)
+}
+
+func (s *TOTPSuite) TestVerifyRefusesReplayedStep() {
+	now := time.Unix(1111111109, 0)
+
+	step, ok := verifyTOTP(s.secret, "081804", now, 0)
+	s.Require().True(ok)
+
+	_, ok = verifyTOTP(s.secret, "081804", now, step)
+	s.False(ok)
+}
+
+func (s *TOTPSuite) TestVerifyRejectsMalformedInput() {
+	now := time.Unix(59, 0)
+
+	for _, code := range []string{"", "28708", "2870820", "abcdef"} {
+		_, ok := verifyTOTP(s.secret, code, now, 0)
+		s.False(ok, code)
+	}
+	_, ok := verifyTOTP("not base32!", "287082", now, 0)
+	s.False(ok)
+}
+
+func (s *TOTPSuite) TestURI() {
+	uri := totpURI("Sun Stock", "user@example.com", "JBSWY3DPEHPK3PXP")
+
+	parsed, err := url.Parse(uri)
+	s.Require().NoError(err)
+	s.Equal("otpauth", parsed.Scheme)
+	s.Equal("totp", parsed.Host)
+	s.Equal("/Sun Stock:user@example.com", parsed.Path)
+	s.Equal("JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	s.Equal("Sun Stock", parsed.Query().Get("issuer"))
+	s.Equal("6", parsed.Query().Get("digits"))
+}
+
+func (s *TOTPSuite) TestRecoveryCodeFormat() {
+	code, err := newRecoveryCode()
+	s.Require().NoError(err)
+
+	s.Len(code, recoveryCodeLength+1)
+	s.Equal(code, strings.ToLower(code))
+	s.Equal(strings.ReplaceAll(code, "-", ""), normalizeRecoveryCode(" "+strings.ToUpper(code)+" "))
+	s.Empty(normalizeRecoveryCode("short"))
+}
+
+func TestTOTPSuite(t *testing.T) {
+	suite.Run(t, new(TOTPSuite))
+}
@@ -0,0 +1,399 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/domains/stock_daily"
+	"sun-stockanalysis-api/internal/domains/stock_quotes"
+	"sun-stockanalysis-api/internal/marketdata"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+const (
+	defaultDailyDays          = 365
+	defaultIntradayDays       = 5
+	defaultIntradayResolution = marketdata.Resolution5Min
+	defaultQueueSize          = 100
+	defaultJobHistory         = 100
+	defaultRequestTimeoutSec  = 30
+	defaultJobTimeoutSec      = 900
+	defaultRetentionDays      = 30
+
+	// pollInterval is how often the idle worker looks for jobs queued on
+	// other replicas; jobs queued locally wake it at once.
+	pollInterval = 2 * time.Second
+	// maintenanceInterval paces failing stale jobs and pruning old ones.
+	maintenanceInterval = time.Minute
+	// staleGrace is added to the job timeout before a running job counts as
+	// abandoned by a worker that died.
+	staleGrace = time.Minute
+)
+
+var (
+	dailyDays          = getEnvInt("BACKFILL_DAILY_DAYS", defaultDailyDays)
+	intradayDays       = getEnvInt("BACKFILL_INTRADAY_DAYS", defaultIntradayDays)
+	intradayResolution = getEnvString("BACKFILL_INTRADAY_RESOLUTION", defaultIntradayResolution)
+	requestTimeout     = time.Duration(getEnvInt("BACKFILL_TIMEOUT_SECONDS", defaultRequestTimeoutSec)) * time.Second
+	jobTimeout         = time.Duration(getEnvInt("BACKFILL_JOB_TIMEOUT_SECONDS", defaultJobTimeoutSec)) * time.Second
+	retention          = time.Duration(getEnvInt("BACKFILL_RETENTION_DAYS", defaultRetentionDays)) * 24 * time.Hour
+)
+
+const (
+	JobStatusQueued    = models.BackfillJobQueued
+	JobStatusRunning   = models.BackfillJobRunning
+	JobStatusCompleted = models.BackfillJobCompleted
+	JobStatusFailed    = models.BackfillJobFailed
+)
+
+const (
+	StageDaily    = "daily"
+	StageIntraday = "intraday"
+	StageDone     = "done"
+)
+
+var (
+	ErrQueueFull   = errors.New("backfill queue is full")
+	ErrJobNotFound = errors.New("backfill job not found")
+)
+
+// Job tracks one symbol's backfill. Progress is the percentage of stages done.
+// Jobs are stored in backfill_jobs, so any replica can serve them.
+type Job struct {
+	ID              uuid.UUID  `json:"id"`
+	Symbol          string     `json:"symbol"`
+	Status          string     `json:"status"`
+	Stage           string     `json:"stage"`
+	Progress        int        `json:"progress"`
+	DailyCandles    int        `json:"daily_candles"`
+	DailyInserted   int        `json:"daily_inserted"`
+	IntradayCandles int        `json:"intraday_candles"`
+	QuotesInserted  int        `json:"quotes_inserted"`
+	Error           string     `json:"error,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+}
+
+type BackfillService interface {
+	Start(ctx context.Context)
+	Stop()
+	Enqueue(symbol string) (*Job, error)
+	Get(id uuid.UUID) (*Job, error)
+	List() ([]Job, error)
+}
+
+type BackfillServiceImpl struct {
+	jobRepo      repository.BackfillJobRepository
+	provider     marketdata.Provider
+	quoteService stock_quotes.StockQuoteService
+	dailyService stock_daily.StockDailyService
+	log          *logger.Logger
+	wake         chan struct{}
+	mu           sync.Mutex
+	cancel       context.CancelFunc
+	now          func() time.Time
+}
+
+func NewBackfillService(
+	jobRepo repository.BackfillJobRepository,
+	provider marketdata.Provider,
+	quoteService stock_quotes.StockQuoteService,
+	dailyService stock_daily.StockDailyService,
+	log *logger.Logger,
+) BackfillService {
+	return &BackfillServiceImpl{
+		jobRepo:      jobRepo,
+		provider:     provider,
+		quoteService: quoteService,
+		dailyService: dailyService,
+		log:          log,
+		wake:         make(chan struct{}, 1),
+		now:          time.Now,
+	}
+}
+
+func (s *BackfillServiceImpl) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	go s.run(runCtx)
+	go s.maintain(runCtx)
+}
+
+func (s *BackfillServiceImpl) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.cancel = nil
+}
+
+// Enqueue schedules a backfill for symbol. A queued or running job for the same
+// symbol is returned instead of scheduling a duplicate.
+func (s *BackfillServiceImpl) Enqueue(symbol string) (*Job, error) {
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return nil, errors.New("symbol is empty")
+	}
+
+	pending, err := s.jobRepo.FindPendingBySymbol(symbol)
+	if err == nil {
+		return toJob(pending), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	queued, err := s.jobRepo.CountQueued()
+	if err != nil {
+		return nil, err
+	}
+	if queued >= defaultQueueSize {
+		return nil, ErrQueueFull
+	}
+
+	row := &models.BackfillJob{
+		ID:        uuid.New(),
+		Symbol:    symbol,
+		Status:    JobStatusQueued,
+		CreatedAt: models.NewLocalTime(s.now()),
+	}
+	if err := s.jobRepo.Create(row); err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return toJob(row), nil
+}
+
+func (s *BackfillServiceImpl) Get(id uuid.UUID) (*Job, error) {
+	row, err := s.jobRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return toJob(row), nil
+}
+
+// List returns the latest jobs, newest first.
+func (s *BackfillServiceImpl) List() ([]Job, error) {
+	rows, err := s.jobRepo.FindRecent(defaultJobHistory)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]Job, 0, len(rows))
+	for i := range rows {
+		jobs = append(jobs, *toJob(&rows[i]))
+	}
+	return jobs, nil
+}
+
+// run claims queued jobs until none is left, then waits for a local enqueue
+// or the next poll.
+func (s *BackfillServiceImpl) run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		for ctx.Err() == nil && s.processNext(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext runs the oldest queued job and reports whether there was one.
+func (s *BackfillServiceImpl) processNext(ctx context.Context) bool {
+	row, err := s.jobRepo.ClaimNext(s.now())
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.warnf("backfill: claim job failed: %v", err)
+		}
+		return false
+	}
+	s.process(ctx, row)
+	return true
+}
+
+func (s *BackfillServiceImpl) process(ctx context.Context, row *models.BackfillJob) {
+	jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	row.Stage = StageDaily
+	s.saveProgress(row)
+
+	err := s.backfill(jobCtx, row)
+
+	finished := models.NewLocalTime(s.now())
+	row.FinishedAt = &finished
+	if err != nil {
+		row.Status = JobStatusFailed
+		row.Error = err.Error()
+	} else {
+		row.Status = JobStatusCompleted
+		row.Stage = StageDone
+		row.Progress = 100
+	}
+	stored, ferr := s.jobRepo.Finish(row)
+	if ferr != nil {
+		s.warnf("backfill: store %s outcome failed: %v", row.Symbol, ferr)
+		return
+	}
+	if !stored {
+		s.warnf("backfill: %s was failed as stale before it finished", row.Symbol)
+		return
+	}
+	if err != nil {
+		s.warnf("backfill: %s failed: %v", row.Symbol, err)
+		return
+	}
+	s.logf("backfill: %s completed", row.Symbol)
+}
+
+func (s *BackfillServiceImpl) backfill(ctx context.Context, row *models.BackfillJob) error {
+	to := s.now()
+
+	daily, err := s.fetchCandles(ctx, row.Symbol, marketdata.ResolutionDay, to.AddDate(0, 0, -dailyDays), to)
+	if err != nil {
+		return err
+	}
+	row.DailyCandles = len(daily)
+	s.saveProgress(row)
+	inserted, err := s.dailyService.SeedFromCandles(ctx, row.Symbol, daily)
+	row.DailyInserted = inserted
+	if err != nil {
+		return err
+	}
+	row.Stage = StageIntraday
+	row.Progress = 50
+	s.saveProgress(row)
+
+	intraday, err := s.fetchCandles(ctx, row.Symbol, intradayResolution, to.AddDate(0, 0, -intradayDays), to)
+	if err != nil {
+		return err
+	}
+	row.IntradayCandles = len(intraday)
+	s.saveProgress(row)
+	inserted, err = s.quoteService.SeedFromCandles(ctx, row.Symbol, intraday)
+	row.QuotesInserted = inserted
+	return err
+}
+
+func (s *BackfillServiceImpl) fetchCandles(ctx context.Context, symbol, resolution string, from, to time.Time) ([]marketdata.Candle, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	return s.provider.Candles(reqCtx, symbol, resolution, from, to)
+}
+
+// saveProgress stores the progress of a running job. A failed write only
+// makes the reported progress lag, so it is logged and the job goes on.
+func (s *BackfillServiceImpl) saveProgress(row *models.BackfillJob) {
+	if err := s.jobRepo.UpdateProgress(row); err != nil {
+		s.warnf("backfill: store %s progress failed: %v", row.Symbol, err)
+	}
+}
+
+// maintain fails jobs abandoned by a worker that died, for example in a
+// restart, and deletes finished jobs past the retention period.
+func (s *BackfillServiceImpl) maintain(ctx context.Context) {
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+	for {
+		now := s.now()
+		if n, err := s.jobRepo.FailStale(now.Add(-jobTimeout-staleGrace), now, "backfill interrupted"); err != nil {
+			s.warnf("backfill: fail stale jobs failed: %v", err)
+		} else if n > 0 {
+			s.logf("backfill: failed %d interrupted jobs", n)
+		}
+		if err := s.jobRepo.DeleteFinishedBefore(now.Add(-retention)); err != nil {
+			s.warnf("backfill: prune jobs failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func toJob(row *models.BackfillJob) *Job {
+	job := &Job{
+		ID:              row.ID,
+		Symbol:          row.Symbol,
+		Status:          row.Status,
+		Stage:           row.Stage,
+		Progress:        row.Progress,
+		DailyCandles:    row.DailyCandles,
+		DailyInserted:   row.DailyInserted,
+		IntradayCandles: row.IntradayCandles,
+		QuotesInserted:  row.QuotesInserted,
+		Error:           row.Error,
+		CreatedAt:       time.Time(row.CreatedAt),
+	}
+	if row.StartedAt != nil {
+		started := time.Time(*row.StartedAt)
+		job.StartedAt = &started
+	}
+	if row.FinishedAt != nil {
+		finished := time.Time(*row.FinishedAt)
+		job.FinishedAt = &finished
+	}
+	return job
+}
+
+func (s *BackfillServiceImpl) logf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Infof(format, args...)
+	}
+}
+
+func (s *BackfillServiceImpl) warnf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Warnf(format, args...)
+	}
+}
+
+func getEnvString(key, fallback string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
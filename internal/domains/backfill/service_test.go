@@ -0,0 +1,162 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/marketdata"
+	stockdailymock "sun-stockanalysis-api/internal/mocks/domains/stock_daily"
+	stockquotesmock "sun-stockanalysis-api/internal/mocks/domains/stock_quotes"
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type BackfillServiceSuite struct {
+	suite.Suite
+	jobRepo *repositorymock.MockBackfillJobRepository
+	quotes  *stockquotesmock.MockStockQuoteService
+	daily   *stockdailymock.MockStockDailyService
+	service *BackfillServiceImpl
+	now     time.Time
+}
+
+func (s *BackfillServiceSuite) SetupTest() {
+	s.jobRepo = repositorymock.NewMockBackfillJobRepository(s.T())
+	s.quotes = stockquotesmock.NewMockStockQuoteService(s.T())
+	s.daily = stockdailymock.NewMockStockDailyService(s.T())
+	s.now = time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+	provider := marketdata.NewReplayProviderFromFixture(marketdata.ReplayFixture{
+		Candles: map[string]map[string][]marketdata.Candle{
+			"AAPL": {
+				marketdata.ResolutionDay: {
+					{Time: s.now.AddDate(0, 0, -2), Open: 1, High: 2, Low: 1, Close: 2},
+					{Time: s.now.AddDate(0, 0, -1), Open: 2, High: 3, Low: 2, Close: 3},
+				},
+				marketdata.Resolution5Min: {
+					{Time: s.now.Add(-5 * time.Minute), Open: 3, High: 3, Low: 3, Close: 3},
+				},
+			},
+		},
+	})
+	s.service = NewBackfillService(s.jobRepo, provider, s.quotes, s.daily, nil).(*BackfillServiceImpl)
+	s.service.now = func() time.Time { return s.now }
+}
+
+// claim makes the next ClaimNext hand out a running job for symbol and
+// returns the row Finish receives.
+func (s *BackfillServiceSuite) claim(symbol string) *models.BackfillJob {
+	started := models.NewLocalTime(s.now)
+	row := &models.BackfillJob{ID: uuid.New(), Symbol: symbol, Status: JobStatusRunning, StartedAt: &started}
+	s.jobRepo.EXPECT().ClaimNext(s.now).Return(row, nil).Once()
+	s.jobRepo.EXPECT().UpdateProgress(row).Return(nil)
+	s.jobRepo.EXPECT().Finish(row).Return(true, nil).Once()
+	return row
+}
+
+func (s *BackfillServiceSuite) TestEnqueue_StoresQueuedJob() {
+	var stored *models.BackfillJob
+	s.jobRepo.EXPECT().FindPendingBySymbol("AAPL").Return(nil, gorm.ErrRecordNotFound)
+	s.jobRepo.EXPECT().CountQueued().Return(0, nil)
+	s.jobRepo.EXPECT().Create(mock.Anything).RunAndReturn(func(row *models.BackfillJob) error {
+		stored = row
+		return nil
+	})
+
+	job, err := s.service.Enqueue(" AAPL ")
+
+	s.Require().NoError(err)
+	s.Equal(stored.ID, job.ID)
+	s.Equal("AAPL", stored.Symbol)
+	s.Equal(JobStatusQueued, stored.Status)
+	s.True(s.now.Equal(job.CreatedAt))
+}
+
+func (s *BackfillServiceSuite) TestEnqueue_DeduplicatesPendingSymbol() {
+	pending := &models.BackfillJob{ID: uuid.New(), Symbol: "AAPL", Status: JobStatusRunning}
+	s.jobRepo.EXPECT().FindPendingBySymbol("aapl").Return(pending, nil)
+
+	job, err := s.service.Enqueue("aapl")
+
+	s.Require().NoError(err)
+	s.Equal(pending.ID, job.ID)
+	s.Equal(JobStatusRunning, job.Status)
+}
+
+func (s *BackfillServiceSuite) TestEnqueue_QueueFull() {
+	s.jobRepo.EXPECT().FindPendingBySymbol("AAPL").Return(nil, gorm.ErrRecordNotFound)
+	s.jobRepo.EXPECT().CountQueued().Return(defaultQueueSize, nil)
+
+	_, err := s.service.Enqueue("AAPL")
+
+	s.ErrorIs(err, ErrQueueFull)
+}
+
+func (s *BackfillServiceSuite) TestProcessNext_SeedsDailyThenIntraday() {
+	row := s.claim("AAPL")
+	s.daily.EXPECT().SeedFromCandles(mock.Anything, "AAPL", mock.MatchedBy(func(c []marketdata.Candle) bool {
+		return len(c) == 2
+	})).Return(2, nil)
+	s.quotes.EXPECT().SeedFromCandles(mock.Anything, "AAPL", mock.MatchedBy(func(c []marketdata.Candle) bool {
+		return len(c) == 1
+	})).Return(1, nil)
+
+	s.True(s.service.processNext(context.Background()))
+
+	s.Equal(JobStatusCompleted, row.Status)
+	s.Equal(StageDone, row.Stage)
+	s.Equal(100, row.Progress)
+	s.Equal(2, row.DailyInserted)
+	s.Equal(1, row.QuotesInserted)
+	s.NotNil(row.FinishedAt)
+}
+
+func (s *BackfillServiceSuite) TestProcessNext_RecordsFailure() {
+	row := s.claim("AAPL")
+	s.daily.EXPECT().SeedFromCandles(mock.Anything, "AAPL", mock.Anything).Return(0, errors.New("db down"))
+
+	s.True(s.service.processNext(context.Background()))
+
+	s.Equal(JobStatusFailed, row.Status)
+	s.Equal("db down", row.Error)
+	s.Equal(StageDaily, row.Stage)
+}
+
+func (s *BackfillServiceSuite) TestProcessNext_IdleWithoutQueuedJobs() {
+	s.jobRepo.EXPECT().ClaimNext(s.now).Return(nil, gorm.ErrRecordNotFound)
+
+	s.False(s.service.processNext(context.Background()))
+}
+
+func (s *BackfillServiceSuite) TestGet_ReadsJobsRunElsewhere() {
+	finished := models.NewLocalTime(s.now)
+	row := &models.BackfillJob{ID: uuid.New(), Symbol: "AAPL", Status: JobStatusCompleted, Progress: 100, FinishedAt: &finished}
+	s.jobRepo.EXPECT().FindByID(row.ID).Return(row, nil)
+
+	job, err := s.service.Get(row.ID)
+
+	s.Require().NoError(err)
+	s.Equal(JobStatusCompleted, job.Status)
+	s.Equal(100, job.Progress)
+	s.Require().NotNil(job.FinishedAt)
+	s.True(s.now.Equal(*job.FinishedAt))
+}
+
+func (s *BackfillServiceSuite) TestGet_UnknownJob() {
+	id := uuid.New()
+	s.jobRepo.EXPECT().FindByID(id).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.service.Get(id)
+
+	s.ErrorIs(err, ErrJobNotFound)
+}
+
+func TestBackfillServiceSuite(t *testing.T) {
+	suite.Run(t, new(BackfillServiceSuite))
+}
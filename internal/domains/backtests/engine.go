@@ -0,0 +1,362 @@
+package backtests
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"sun-stockanalysis-api/internal/domains/alert_rules"
+	"sun-stockanalysis-api/internal/models"
+)
+
+const (
+	// scoreWindow is the number of bars whose EMA20 slope feeds the ScoreEMA
+	// trend, matching the five quotes the live scoring used.
+	scoreWindow = 5
+
+	maxCurvePoints  = 1000
+	tradingDaysYear = 252
+
+	ExitReasonSignal = "signal"
+	ExitReasonEnd    = "end_of_range"
+)
+
+var bangkok = time.FixedZone("Asia/Bangkok", 7*60*60)
+
+// Params configures a simulation. EntryScore and ExitScore drive the
+// score_ema strategy; Entry and Exit drive the rules strategy.
+type Params struct {
+	InitialCash float64                `json:"initial_cash"`
+	FeeBps      float64                `json:"fee_bps"`
+	SlippageBps float64                `json:"slippage_bps"`
+	EntryScore  int                    `json:"entry_score,omitempty"`
+	ExitScore   int                    `json:"exit_score,omitempty"`
+	Entry       *models.AlertCondition `json:"entry,omitempty"`
+	Exit        *models.AlertCondition `json:"exit,omitempty"`
+}
+
+type Trade struct {
+	EntryAt       time.Time `json:"entry_at"`
+	EntryPrice    float64   `json:"entry_price"`
+	ExitAt        time.Time `json:"exit_at"`
+	ExitPrice     float64   `json:"exit_price"`
+	Quantity      float64   `json:"quantity"`
+	Fees          float64   `json:"fees"`
+	PnL           float64   `json:"pnl"`
+	ReturnPercent float64   `json:"return_percent"`
+	ExitReason    string    `json:"exit_reason"`
+}
+
+type EquityPoint struct {
+	At     time.Time `json:"at"`
+	Equity float64   `json:"equity"`
+}
+
+type Result struct {
+	Bars                 int           `json:"bars"`
+	InitialCash          float64       `json:"initial_cash"`
+	FinalEquity          float64       `json:"final_equity"`
+	TotalReturnPercent   float64       `json:"total_return_percent"`
+	BuyHoldReturnPercent float64       `json:"buy_hold_return_percent"`
+	TradeCount           int           `json:"trade_count"`
+	WinRate              float64       `json:"win_rate"`
+	MaxDrawdownPercent   float64       `json:"max_drawdown_percent"`
+	Sharpe               float64       `json:"sharpe"`
+	FeesPaid             float64       `json:"fees_paid"`
+	Trades               []Trade       `json:"trades"`
+	EquityCurve          []EquityPoint `json:"equity_curve"`
+}
+
+// signalFunc reports whether bar i of bars opens or closes a position. It
+// only looks at bars up to i.
+type signalFunc func(bars []models.StockQuote, i int) (enter, exit bool)
+
+// simulate replays bars through signal as a long-only, all-in strategy. Orders
+// fill at the close of the signalling bar, moved against the trade by the
+// slippage, and pay fees on both legs. The last bar never opens a position and
+// closes any position still open.
+func simulate(ctx context.Context, bars []models.StockQuote, signal signalFunc, params Params, periodsPerYear float64) (*Result, error) {
+	result := &Result{
+		Bars:        len(bars),
+		InitialCash: params.InitialCash,
+		FinalEquity: params.InitialCash,
+		Trades:      []Trade{},
+		EquityCurve: []EquityPoint{},
+	}
+	if len(bars) == 0 {
+		return result, nil
+	}
+
+	fee := params.FeeBps / 10000
+	slippage := params.SlippageBps / 10000
+	cash := params.InitialCash
+	quantity := 0.0
+	var open Trade
+	var entryCost float64
+
+	closePosition := func(bar models.StockQuote, reason string) {
+		price := bar.PriceCurrent * (1 - slippage)
+		proceeds := quantity * price
+		exitFee := proceeds * fee
+		cash += proceeds - exitFee
+		open.ExitAt = time.Time(bar.CreatedAt)
+		open.ExitPrice = price
+		open.Fees += exitFee
+		open.PnL = proceeds - exitFee - entryCost
+		open.ReturnPercent = percent(open.PnL, entryCost)
+		open.ExitReason = reason
+		result.Trades = append(result.Trades, open)
+		result.FeesPaid += open.Fees
+		quantity = 0
+	}
+
+	equity := make([]float64, len(bars))
+	for i, bar := range bars {
+		if i%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		enter, exit := signal(bars, i)
+		switch {
+		case quantity == 0 && enter && cash > 0 && i < len(bars)-1:
+			price := bar.PriceCurrent * (1 + slippage)
+			quantity = cash / (price * (1 + fee))
+			entryFee := quantity * price * fee
+			entryCost = quantity*price + entryFee
+			cash -= entryCost
+			open = Trade{
+				EntryAt:    time.Time(bar.CreatedAt),
+				EntryPrice: price,
+				Quantity:   quantity,
+				Fees:       entryFee,
+			}
+		case quantity > 0 && exit:
+			closePosition(bar, ExitReasonSignal)
+		}
+		if quantity > 0 && i == len(bars)-1 {
+			closePosition(bar, ExitReasonEnd)
+		}
+		equity[i] = cash + quantity*bar.PriceCurrent
+	}
+
+	first, last := bars[0].PriceCurrent, bars[len(bars)-1].PriceCurrent
+	result.FinalEquity = equity[len(equity)-1]
+	result.TotalReturnPercent = percent(result.FinalEquity-params.InitialCash, params.InitialCash)
+	result.BuyHoldReturnPercent = percent(last-first, first)
+	result.TradeCount = len(result.Trades)
+	wins := 0
+	for _, trade := range result.Trades {
+		if trade.PnL > 0 {
+			wins++
+		}
+	}
+	if result.TradeCount > 0 {
+		result.WinRate = float64(wins) / float64(result.TradeCount) * 100
+	}
+	// Risk metrics start from the initial cash so the entry costs count.
+	series := append([]float64{params.InitialCash}, equity...)
+	result.MaxDrawdownPercent = maxDrawdown(series)
+	result.Sharpe = sharpe(series, periodsPerYear)
+	result.EquityCurve = equityCurve(bars, equity)
+	return result, nil
+}
+
+// scoreEMASignal replays the ScoreEMA alert scoring: the EMA20 slope of the
+// last five bars plus the tanh-EMA slope of the latest bar give a score in
+// -4..4. Intraday windows never span two Bangkok sessions, as the live
+// scoring only looked at the current day's quotes.
+func scoreEMASignal(params Params, intraday bool) signalFunc {
+	return func(bars []models.StockQuote, i int) (bool, bool) {
+		if i+1 < scoreWindow {
+			return false, false
+		}
+		window := bars[i+1-scoreWindow : i+1]
+		if intraday && !sameDay(time.Time(window[0].CreatedAt), time.Time(window[len(window)-1].CreatedAt)) {
+			return false, false
+		}
+		trendEMA20 := 0
+		for _, bar := range window {
+			trendEMA20 += signFloat(bar.ChangeEMA20)
+		}
+		score, ok := scoreFromTrend(trendEMA20, signFloat(bars[i].ChangeTanhEMA))
+		if !ok {
+			return false, false
+		}
+		return score >= params.EntryScore, score <= params.ExitScore
+	}
+}
+
+// rulesSignal evaluates user-defined alert conditions as entry and exit
+// triggers. Without an exit condition positions are held to the end.
+func rulesSignal(params Params) signalFunc {
+	return func(bars []models.StockQuote, i int) (bool, bool) {
+		var prev *models.StockQuote
+		if i > 0 {
+			prev = &bars[i-1]
+		}
+		curr := &bars[i]
+		enter := params.Entry != nil && alert_rules.Evaluate(*params.Entry, prev, curr)
+		exit := params.Exit != nil && alert_rules.Evaluate(*params.Exit, prev, curr)
+		return enter, exit
+	}
+}
+
+func scoreFromTrend(trendEMA20, trendTanhEMA int) (int, bool) {
+	switch {
+	case trendEMA20 <= -2:
+		switch trendTanhEMA {
+		case -1:
+			return -4, true
+		case 0, 1:
+			return -3, true
+		}
+	case trendEMA20 >= 2:
+		switch trendTanhEMA {
+		case 1:
+			return 4, true
+		case 0, -1:
+			return 3, true
+		}
+	case trendEMA20 == -1:
+		switch trendTanhEMA {
+		case -1:
+			return -2, true
+		case 0, 1:
+			return -1, true
+		}
+	case trendEMA20 == 1:
+		switch trendTanhEMA {
+		case 1:
+			return 2, true
+		case 0, -1:
+			return 1, true
+		}
+	}
+	return 0, false
+}
+
+// dailyBars adapts stored sessions to the quote shape the strategies read,
+// deriving the EMA slopes the daily table does not store.
+func dailyBars(rows []models.StockDaily) []models.StockQuote {
+	bars := make([]models.StockQuote, 0, len(rows))
+	for i, row := range rows {
+		bar := models.StockQuote{
+			Symbol:              row.Symbol,
+			PriceCurrent:        row.PriceClose,
+			ChangePrice:         row.ChangePrice,
+			ChangePercent:       row.ChangePercent,
+			Volume:              row.Volume,
+			EMA20:               row.EMA20,
+			EMA100:              row.EMA100,
+			TanhEMA:             math.Tanh(row.EMA20-row.EMA100) / 5.0,
+			EMATrend:            row.EMATrend,
+			CreatedAt:           models.NewLocalTime(time.Time(row.TradeDate)),
+			TechnicalIndicators: row.TechnicalIndicators,
+		}
+		if i > 0 {
+			bar.ChangeEMA20 = bar.EMA20 - bars[i-1].EMA20
+			bar.ChangeTanhEMA = bar.TanhEMA - bars[i-1].TanhEMA
+		}
+		bars = append(bars, bar)
+	}
+	return bars
+}
+
+// intradayPeriodsPerYear annualises per-bar returns from the average number
+// of bars per trading day in the sample.
+func intradayPeriodsPerYear(bars []models.StockQuote) float64 {
+	days := make(map[string]struct{})
+	for _, bar := range bars {
+		days[time.Time(bar.CreatedAt).In(bangkok).Format("2006-01-02")] = struct{}{}
+	}
+	if len(days) == 0 {
+		return tradingDaysYear
+	}
+	return tradingDaysYear * float64(len(bars)) / float64(len(days))
+}
+
+func maxDrawdown(equity []float64) float64 {
+	peak, worst := 0.0, 0.0
+	for _, value := range equity {
+		if value > peak {
+			peak = value
+		}
+		if peak > 0 {
+			if dd := (peak - value) / peak * 100; dd > worst {
+				worst = dd
+			}
+		}
+	}
+	return worst
+}
+
+// sharpe is the annualised mean over standard deviation of per-bar returns,
+// with a zero risk-free rate.
+func sharpe(equity []float64, periodsPerYear float64) float64 {
+	if len(equity) < 3 {
+		return 0
+	}
+	returns := make([]float64, 0, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		if equity[i-1] > 0 {
+			returns = append(returns, equity[i]/equity[i-1]-1)
+		}
+	}
+	if len(returns) < 2 {
+		return 0
+	}
+	mean := 0.0
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	std := math.Sqrt(variance / float64(len(returns)-1))
+	if std == 0 {
+		return 0
+	}
+	return mean / std * math.Sqrt(periodsPerYear)
+}
+
+// equityCurve samples the curve down to at most maxCurvePoints, always keeping
+// the last bar.
+func equityCurve(bars []models.StockQuote, equity []float64) []EquityPoint {
+	stride := (len(bars) + maxCurvePoints - 1) / maxCurvePoints
+	if stride < 1 {
+		stride = 1
+	}
+	points := make([]EquityPoint, 0, len(bars)/stride+1)
+	for i := 0; i < len(bars); i += stride {
+		points = append(points, EquityPoint{At: time.Time(bars[i].CreatedAt), Equity: equity[i]})
+	}
+	if last := len(bars) - 1; last%stride != 0 {
+		points = append(points, EquityPoint{At: time.Time(bars[last].CreatedAt), Equity: equity[last]})
+	}
+	return points
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.In(bangkok).Format("2006-01-02") == b.In(bangkok).Format("2006-01-02")
+}
+
+func signFloat(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func percent(value, base float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return value / base * 100
+}
@@ -0,0 +1,146 @@
+package backtests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+// trendingBars builds one-minute quotes moving by step per bar, with EMA
+// slopes following the price.
+func trendingBars(n int, start, step float64) []models.StockQuote {
+	at := time.Date(2025, 1, 6, 21, 30, 0, 0, bangkok)
+	bars := make([]models.StockQuote, n)
+	for i := range bars {
+		bars[i] = models.StockQuote{
+			Symbol:        "AAPL",
+			PriceCurrent:  start + float64(i)*step,
+			ChangeEMA20:   step,
+			ChangeTanhEMA: step,
+			CreatedAt:     models.NewLocalTime(at.Add(time.Duration(i) * time.Minute)),
+		}
+	}
+	return bars
+}
+
+type EngineSuite struct {
+	suite.Suite
+}
+
+func (s *EngineSuite) TestSimulate_TradesOnSignalsWithFees() {
+	bars := trendingBars(4, 100, 10) // 100, 110, 120, 130
+	signal := func(_ []models.StockQuote, i int) (bool, bool) {
+		return i == 0, i == 2
+	}
+
+	result, err := simulate(context.Background(), bars, signal, Params{InitialCash: 1000, FeeBps: 100}, tradingDaysYear)
+
+	s.Require().NoError(err)
+	s.Require().Len(result.Trades, 1)
+	trade := result.Trades[0]
+	s.InDelta(1000/1.01/100, trade.Quantity, 1e-9)
+	s.InDelta(120, trade.ExitPrice, 1e-9)
+	s.Equal(ExitReasonSignal, trade.ExitReason)
+	s.InDelta(trade.Quantity*120*0.99, result.FinalEquity, 1e-9)
+	s.InDelta(result.FinalEquity-1000, trade.PnL, 1e-9)
+	s.Equal(100.0, result.WinRate)
+	s.InDelta(30, result.BuyHoldReturnPercent, 1e-9)
+	s.Len(result.EquityCurve, 4)
+}
+
+func (s *EngineSuite) TestSimulate_ClosesOpenPositionAtEndWithSlippage() {
+	bars := trendingBars(3, 100, -10) // 100, 90, 80
+	signal := func(_ []models.StockQuote, i int) (bool, bool) {
+		return i == 0, false
+	}
+
+	result, err := simulate(context.Background(), bars, signal, Params{InitialCash: 1000, SlippageBps: 100}, tradingDaysYear)
+
+	s.Require().NoError(err)
+	s.Require().Len(result.Trades, 1)
+	s.Equal(ExitReasonEnd, result.Trades[0].ExitReason)
+	s.InDelta(101, result.Trades[0].EntryPrice, 1e-9)
+	s.InDelta(79.2, result.Trades[0].ExitPrice, 1e-9)
+	s.Equal(0.0, result.WinRate)
+	s.InDelta((1-79.2/101)*100, result.MaxDrawdownPercent, 1e-9)
+}
+
+func (s *EngineSuite) TestScoreEMASignal_EntersOnUptrendAndExitsOnDowntrend() {
+	bars := append(trendingBars(6, 100, 1), trendingBars(6, 105, -1)...)
+	for i := range bars {
+		bars[i].CreatedAt = models.NewLocalTime(time.Date(2025, 1, 6, 21, 30+i, 0, 0, bangkok))
+	}
+	signal := scoreEMASignal(Params{EntryScore: 3, ExitScore: -3}, true)
+
+	enter, _ := signal(bars, 3)
+	s.False(enter, "needs a full window")
+	enter, _ = signal(bars, 4)
+	s.True(enter)
+	_, exit := signal(bars, 7)
+	s.False(exit, "window still mixed")
+	_, exit = signal(bars, 11)
+	s.True(exit)
+}
+
+func (s *EngineSuite) TestScoreEMASignal_IntradayWindowStaysInOneSession() {
+	bars := trendingBars(5, 100, 1)
+	bars[0].CreatedAt = models.NewLocalTime(time.Time(bars[0].CreatedAt).AddDate(0, 0, -1))
+
+	enter, _ := scoreEMASignal(Params{EntryScore: 3, ExitScore: -3}, true)(bars, 4)
+	s.False(enter)
+	enter, _ = scoreEMASignal(Params{EntryScore: 3, ExitScore: -3}, false)(bars, 4)
+	s.True(enter)
+}
+
+func (s *EngineSuite) TestRulesSignal_EvaluatesConditions() {
+	bars := trendingBars(3, 100, 10)
+	signal := rulesSignal(Params{
+		Entry: &models.AlertCondition{Type: "price_cross", Direction: "above", Level: 105},
+		Exit:  &models.AlertCondition{Type: "indicator", Field: "price", Operator: ">=", Value: 120},
+	})
+
+	enter, exit := signal(bars, 0)
+	s.False(enter)
+	s.False(exit)
+	enter, _ = signal(bars, 1)
+	s.True(enter)
+	_, exit = signal(bars, 2)
+	s.True(exit)
+}
+
+func (s *EngineSuite) TestDailyBars_DerivesSlopes() {
+	day := time.Date(2025, 1, 6, 0, 0, 0, 0, bangkok)
+	bars := dailyBars([]models.StockDaily{
+		{Symbol: "AAPL", PriceClose: 100, EMA20: 10, EMA100: 10, TradeDate: models.NewLocalDate(day)},
+		{Symbol: "AAPL", PriceClose: 110, EMA20: 12, EMA100: 10, TradeDate: models.NewLocalDate(day.AddDate(0, 0, 1))},
+	})
+
+	s.Require().Len(bars, 2)
+	s.Equal(110.0, bars[1].PriceCurrent)
+	s.Equal(2.0, bars[1].ChangeEMA20)
+	s.Greater(bars[1].ChangeTanhEMA, 0.0)
+}
+
+func (s *EngineSuite) TestMetrics() {
+	s.InDelta(50, maxDrawdown([]float64{100, 200, 100, 150}), 1e-9)
+	s.Equal(0.0, sharpe([]float64{100, 100, 100}, tradingDaysYear))
+	s.Greater(sharpe([]float64{100, 101, 103, 104}, tradingDaysYear), 0.0)
+}
+
+func (s *EngineSuite) TestEquityCurve_SamplesLongRuns() {
+	bars := trendingBars(2500, 100, 0)
+	equity := make([]float64, len(bars))
+
+	points := equityCurve(bars, equity)
+
+	s.LessOrEqual(len(points), maxCurvePoints+1)
+	s.Equal(time.Time(bars[len(bars)-1].CreatedAt), points[len(points)-1].At)
+}
+
+func TestEngineSuite(t *testing.T) {
+	suite.Run(t, new(EngineSuite))
+}
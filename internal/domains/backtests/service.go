@@ -0,0 +1,656 @@
+package backtests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/domains/alert_rules"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+const (
+	defaultWorkers        = 2
+	defaultQueueSize      = 50
+	defaultJobHistory     = 200
+	defaultMaxPending     = 3
+	defaultJobTimeoutSec  = 300
+	defaultRetentionDays  = 30
+	defaultMaxQuoteDays   = 31
+	defaultMaxDailyDays   = 3650
+	defaultQuoteRangeDays = 7
+	defaultDailyRangeDays = 365
+	defaultInitialCash    = 10000
+	defaultEntryScore     = 3
+	defaultExitScore      = -3
+	maxCostBps            = 1000
+
+	// pollInterval is how often idle workers look for jobs queued on other
+	// replicas; jobs queued locally wake them at once.
+	pollInterval = 2 * time.Second
+	// cancelCheckInterval is how often a running job checks whether it was
+	// canceled, possibly through another replica.
+	cancelCheckInterval = 2 * time.Second
+	// maintenanceInterval paces failing stale jobs and pruning old ones.
+	maintenanceInterval = time.Minute
+	// staleGrace is added to the job timeout before a running job counts as
+	// abandoned by a worker that died.
+	staleGrace = time.Minute
+)
+
+var (
+	workers      = getEnvInt("BACKTEST_WORKERS", defaultWorkers)
+	maxPending   = getEnvInt("BACKTEST_MAX_PENDING_PER_USER", defaultMaxPending)
+	jobTimeout   = time.Duration(getEnvInt("BACKTEST_TIMEOUT_SECONDS", defaultJobTimeoutSec)) * time.Second
+	maxQuoteDays = getEnvInt("BACKTEST_MAX_QUOTE_DAYS", defaultMaxQuoteDays)
+	maxDailyDays = getEnvInt("BACKTEST_MAX_DAILY_DAYS", defaultMaxDailyDays)
+	retention    = time.Duration(getEnvInt("BACKTEST_RETENTION_DAYS", defaultRetentionDays)) * 24 * time.Hour
+)
+
+const (
+	JobStatusQueued    = models.BacktestJobQueued
+	JobStatusRunning   = models.BacktestJobRunning
+	JobStatusSucceeded = models.BacktestJobSucceeded
+	JobStatusFailed    = models.BacktestJobFailed
+	JobStatusCanceled  = models.BacktestJobCanceled
+)
+
+const (
+	SourceQuotes = "quotes"
+	SourceDaily  = "daily"
+
+	StrategyScoreEMA = "score_ema"
+	StrategyRules    = "rules"
+)
+
+var (
+	ErrInvalidUser     = errors.New("invalid user id")
+	ErrInvalidBacktest = errors.New("invalid backtest")
+	ErrUnknownSymbol   = errors.New("symbol is not tracked")
+	ErrJobNotFound     = errors.New("backtest job not found")
+	ErrJobFinished     = errors.New("backtest job already finished")
+	ErrQueueFull       = errors.New("backtest queue is full")
+	ErrTooManyPending  = errors.New("too many pending backtests")
+	ErrNoData          = errors.New("no data in range")
+)
+
+type Request struct {
+	Symbol   string
+	Source   string
+	Strategy string
+	From     time.Time
+	To       time.Time
+	Params   Params
+}
+
+// BacktestJob tracks one backtest run. Result is set once the job succeeds.
+// Jobs are stored in backtest_jobs, so any replica can serve them.
+type BacktestJob struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Status     string     `json:"status"`
+	Symbol     string     `json:"symbol"`
+	Source     string     `json:"source"`
+	Strategy   string     `json:"strategy"`
+	From       time.Time  `json:"from"`
+	To         time.Time  `json:"to"`
+	Params     Params     `json:"params"`
+	Result     *Result    `json:"result,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+type BacktestService interface {
+	Start(ctx context.Context)
+	Stop()
+	Submit(ctx context.Context, userID string, req Request) (*BacktestJob, error)
+	Get(ctx context.Context, userID string, id uuid.UUID) (*BacktestJob, error)
+	List(ctx context.Context, userID string) ([]BacktestJob, error)
+	Cancel(ctx context.Context, userID string, id uuid.UUID) (*BacktestJob, error)
+}
+
+type BacktestServiceImpl struct {
+	jobRepo   repository.BacktestJobRepository
+	stockRepo repository.StockRepository
+	quoteRepo repository.StockQuoteRepository
+	dailyRepo repository.StockDailyRepository
+	log       *logger.Logger
+	wake      chan struct{}
+	mu        sync.Mutex
+	running   map[uuid.UUID]context.CancelFunc
+	cancel    context.CancelFunc
+	now       func() time.Time
+}
+
+func NewBacktestService(
+	jobRepo repository.BacktestJobRepository,
+	stockRepo repository.StockRepository,
+	quoteRepo repository.StockQuoteRepository,
+	dailyRepo repository.StockDailyRepository,
+	log *logger.Logger,
+) BacktestService {
+	return &BacktestServiceImpl{
+		jobRepo:   jobRepo,
+		stockRepo: stockRepo,
+		quoteRepo: quoteRepo,
+		dailyRepo: dailyRepo,
+		log:       log,
+		wake:      make(chan struct{}, 1),
+		running:   make(map[uuid.UUID]context.CancelFunc),
+		now:       time.Now,
+	}
+}
+
+func (s *BacktestServiceImpl) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	for i := 0; i < workers; i++ {
+		go s.run(runCtx)
+	}
+	go s.maintain(runCtx)
+}
+
+func (s *BacktestServiceImpl) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.cancel = nil
+}
+
+// Submit validates req and queues it. Each user may have a few queued or
+// running jobs at a time.
+func (s *BacktestServiceImpl) Submit(ctx context.Context, userID string, req Request) (*BacktestJob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.validRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	job.Symbol, err = s.trackedSymbol(job.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	job.ID = uuid.New()
+	job.UserID = owner
+	job.Status = JobStatusQueued
+	job.CreatedAt = s.now()
+
+	pending, err := s.jobRepo.CountPendingByUser(owner)
+	if err != nil {
+		return nil, err
+	}
+	if pending >= int64(maxPending) {
+		return nil, fmt.Errorf("%w: at most %d queued or running", ErrTooManyPending, maxPending)
+	}
+	queued, err := s.jobRepo.CountQueued()
+	if err != nil {
+		return nil, err
+	}
+	if queued >= defaultQueueSize {
+		return nil, ErrQueueFull
+	}
+
+	row, err := toRow(job)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.jobRepo.Create(row); err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+func (s *BacktestServiceImpl) Get(ctx context.Context, userID string, id uuid.UUID) (*BacktestJob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.findOwned(owner, id)
+	if err != nil {
+		return nil, err
+	}
+	return fromRow(row)
+}
+
+// List returns the user's latest jobs newest first, without results.
+func (s *BacktestServiceImpl) List(ctx context.Context, userID string) ([]BacktestJob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.jobRepo.FindByUser(owner, defaultJobHistory)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]BacktestJob, 0, len(rows))
+	for i := range rows {
+		rows[i].Result = nil
+		job, err := fromRow(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, nil
+}
+
+// Cancel stops a queued or running job. A job running on another replica
+// stops once its worker notices the cancellation.
+func (s *BacktestServiceImpl) Cancel(ctx context.Context, userID string, id uuid.UUID) (*BacktestJob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	canceled, err := s.jobRepo.Cancel(owner, id, s.now())
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.findOwned(owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canceled {
+		return nil, fmt.Errorf("%w: job is %s", ErrJobFinished, row.Status)
+	}
+
+	s.mu.Lock()
+	if stop, ok := s.running[id]; ok {
+		stop()
+	}
+	s.mu.Unlock()
+	return fromRow(row)
+}
+
+// run claims queued jobs until none is left, then waits for a local submit
+// or the next poll.
+func (s *BacktestServiceImpl) run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		for ctx.Err() == nil && s.processNext(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext runs the oldest queued job and reports whether there was one.
+func (s *BacktestServiceImpl) processNext(ctx context.Context) bool {
+	row, err := s.jobRepo.ClaimNext(s.now())
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.warnf("backtest: claim job failed: %v", err)
+		}
+		return false
+	}
+	s.process(ctx, row)
+	return true
+}
+
+func (s *BacktestServiceImpl) process(ctx context.Context, row *models.BacktestJob) {
+	jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	s.running[row.ID] = cancel
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, row.ID)
+		s.mu.Unlock()
+	}()
+
+	var (
+		result *Result
+		err    error
+	)
+	job, err := fromRow(row)
+	if err == nil {
+		go s.watchCancel(jobCtx, row.ID, cancel)
+		result, err = s.backtest(jobCtx, job)
+	}
+
+	finished := models.NewLocalTime(s.now())
+	row.FinishedAt = &finished
+	if err != nil {
+		row.Status = JobStatusFailed
+		row.Error = err.Error()
+	} else {
+		row.Status = JobStatusSucceeded
+		if row.Result, err = json.Marshal(result); err != nil {
+			row.Status = JobStatusFailed
+			row.Error = err.Error()
+		}
+	}
+	stored, ferr := s.jobRepo.Finish(row)
+	if ferr != nil {
+		s.warnf("backtest: store %s result failed: %v", row.ID, ferr)
+		return
+	}
+	if !stored {
+		s.logf("backtest: %s %s canceled", row.Symbol, row.Strategy)
+		return
+	}
+	if row.Status == JobStatusFailed {
+		s.warnf("backtest: %s %s failed: %s", row.Symbol, row.Strategy, row.Error)
+		return
+	}
+	s.logf("backtest: %s %s %s", row.Symbol, row.Strategy, row.Status)
+}
+
+// watchCancel stops a running job once it is no longer running in the
+// database, for example because it was canceled through another replica.
+func (s *BacktestServiceImpl) watchCancel(ctx context.Context, id uuid.UUID, cancel context.CancelFunc) {
+	ticker := time.NewTicker(cancelCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		row, err := s.jobRepo.FindByID(id)
+		if err != nil {
+			continue
+		}
+		if row.Status != JobStatusRunning {
+			cancel()
+			return
+		}
+	}
+}
+
+// maintain fails jobs abandoned by a worker that died, for example in a
+// restart, and deletes finished jobs past the retention period.
+func (s *BacktestServiceImpl) maintain(ctx context.Context) {
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+	for {
+		now := s.now()
+		if n, err := s.jobRepo.FailStale(now.Add(-jobTimeout-staleGrace), now, "backtest interrupted"); err != nil {
+			s.warnf("backtest: fail stale jobs failed: %v", err)
+		} else if n > 0 {
+			s.logf("backtest: failed %d interrupted jobs", n)
+		}
+		if err := s.jobRepo.DeleteFinishedBefore(now.Add(-retention)); err != nil {
+			s.warnf("backtest: prune jobs failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *BacktestServiceImpl) backtest(ctx context.Context, job *BacktestJob) (*Result, error) {
+	var (
+		bars           []models.StockQuote
+		periodsPerYear float64
+	)
+	switch job.Source {
+	case SourceQuotes:
+		quotes, err := s.quoteRepo.FindBySymbolBetween(job.Symbol, job.From, job.To)
+		if err != nil {
+			return nil, err
+		}
+		bars = quotes
+		periodsPerYear = intradayPeriodsPerYear(bars)
+	default:
+		rows, err := s.dailyRepo.FindBySymbolBetween(job.Symbol, job.From, job.To)
+		if err != nil {
+			return nil, err
+		}
+		bars = dailyBars(rows)
+		periodsPerYear = tradingDaysYear
+	}
+	if len(bars) == 0 {
+		return nil, ErrNoData
+	}
+
+	signal := rulesSignal(job.Params)
+	if job.Strategy == StrategyScoreEMA {
+		signal = scoreEMASignal(job.Params, job.Source == SourceQuotes)
+	}
+	return simulate(ctx, bars, signal, job.Params, periodsPerYear)
+}
+
+// validRequest normalises req into a job, filling defaults for the source,
+// strategy, range and simulation parameters.
+func (s *BacktestServiceImpl) validRequest(req Request) (*BacktestJob, error) {
+	job := &BacktestJob{
+		Symbol:   strings.TrimSpace(req.Symbol),
+		Source:   strings.ToLower(strings.TrimSpace(req.Source)),
+		Strategy: strings.ToLower(strings.TrimSpace(req.Strategy)),
+		From:     req.From,
+		To:       req.To,
+		Params:   req.Params,
+	}
+	if job.Symbol == "" {
+		return nil, fmt.Errorf("%w: symbol is required", ErrInvalidBacktest)
+	}
+
+	maxDays, rangeDays := maxDailyDays, defaultDailyRangeDays
+	switch job.Source {
+	case "", SourceDaily:
+		job.Source = SourceDaily
+	case SourceQuotes:
+		maxDays, rangeDays = maxQuoteDays, defaultQuoteRangeDays
+	default:
+		return nil, fmt.Errorf("%w: source must be quotes or daily", ErrInvalidBacktest)
+	}
+	if job.To.IsZero() {
+		job.To = s.now()
+	}
+	if job.From.IsZero() {
+		job.From = job.To.AddDate(0, 0, -rangeDays)
+	}
+	if !job.From.Before(job.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidBacktest)
+	}
+	if job.To.Sub(job.From) > time.Duration(maxDays)*24*time.Hour {
+		return nil, fmt.Errorf("%w: %s range is limited to %d days", ErrInvalidBacktest, job.Source, maxDays)
+	}
+
+	params := &job.Params
+	if params.InitialCash == 0 {
+		params.InitialCash = defaultInitialCash
+	}
+	if !(params.InitialCash > 0) || math.IsInf(params.InitialCash, 0) {
+		return nil, fmt.Errorf("%w: initial cash must be positive", ErrInvalidBacktest)
+	}
+	if params.FeeBps < 0 || params.FeeBps > maxCostBps || params.SlippageBps < 0 || params.SlippageBps > maxCostBps {
+		return nil, fmt.Errorf("%w: fee and slippage must be 0-%d bps", ErrInvalidBacktest, maxCostBps)
+	}
+
+	switch job.Strategy {
+	case "", StrategyScoreEMA:
+		job.Strategy = StrategyScoreEMA
+		if params.EntryScore == 0 {
+			params.EntryScore = defaultEntryScore
+		}
+		if params.ExitScore == 0 {
+			params.ExitScore = defaultExitScore
+		}
+		if params.EntryScore < 1 || params.EntryScore > 4 || params.ExitScore < -4 || params.ExitScore > -1 {
+			return nil, fmt.Errorf("%w: entry score must be 1..4 and exit score -4..-1", ErrInvalidBacktest)
+		}
+		params.Entry, params.Exit = nil, nil
+	case StrategyRules:
+		if params.Entry == nil {
+			return nil, fmt.Errorf("%w: rules strategy needs an entry condition", ErrInvalidBacktest)
+		}
+		if err := alert_rules.ValidateCondition(*params.Entry); err != nil {
+			return nil, fmt.Errorf("%w: entry: %v", ErrInvalidBacktest, err)
+		}
+		if params.Exit != nil {
+			if err := alert_rules.ValidateCondition(*params.Exit); err != nil {
+				return nil, fmt.Errorf("%w: exit: %v", ErrInvalidBacktest, err)
+			}
+		}
+		params.EntryScore, params.ExitScore = 0, 0
+	default:
+		return nil, fmt.Errorf("%w: strategy must be score_ema or rules", ErrInvalidBacktest)
+	}
+	return job, nil
+}
+
+// trackedSymbol maps symbol onto the stored spelling of an active stock.
+func (s *BacktestServiceImpl) trackedSymbol(symbol string) (string, error) {
+	all, err := s.stockRepo.ListSymbols()
+	if err != nil {
+		return "", err
+	}
+	for _, tracked := range all {
+		if strings.EqualFold(tracked, symbol) {
+			return tracked, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+}
+
+// findOwned hides other users' jobs behind ErrJobNotFound.
+func (s *BacktestServiceImpl) findOwned(owner, id uuid.UUID) (*models.BacktestJob, error) {
+	row, err := s.jobRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	if row.UserID != owner {
+		return nil, ErrJobNotFound
+	}
+	return row, nil
+}
+
+func toRow(job *BacktestJob) (*models.BacktestJob, error) {
+	params, err := json.Marshal(job.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BacktestJob{
+		ID:        job.ID,
+		UserID:    job.UserID,
+		Status:    job.Status,
+		Symbol:    job.Symbol,
+		Source:    job.Source,
+		Strategy:  job.Strategy,
+		RangeFrom: models.NewLocalTime(job.From),
+		RangeTo:   models.NewLocalTime(job.To),
+		Params:    params,
+		CreatedAt: models.NewLocalTime(job.CreatedAt),
+	}, nil
+}
+
+func fromRow(row *models.BacktestJob) (*BacktestJob, error) {
+	job := &BacktestJob{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Status:    row.Status,
+		Symbol:    row.Symbol,
+		Source:    row.Source,
+		Strategy:  row.Strategy,
+		From:      time.Time(row.RangeFrom),
+		To:        time.Time(row.RangeTo),
+		Error:     row.Error,
+		CreatedAt: time.Time(row.CreatedAt),
+	}
+	if err := json.Unmarshal(row.Params, &job.Params); err != nil {
+		return nil, fmt.Errorf("backtest %s params: %w", row.ID, err)
+	}
+	if len(row.Result) > 0 && string(row.Result) != "null" {
+		job.Result = &Result{}
+		if err := json.Unmarshal(row.Result, job.Result); err != nil {
+			return nil, fmt.Errorf("backtest %s result: %w", row.ID, err)
+		}
+	}
+	if row.StartedAt != nil {
+		started := time.Time(*row.StartedAt)
+		job.StartedAt = &started
+	}
+	if row.FinishedAt != nil {
+		finished := time.Time(*row.FinishedAt)
+		job.FinishedAt = &finished
+	}
+	return job, nil
+}
+
+func (s *BacktestServiceImpl) logf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Infof(format, args...)
+	}
+}
+
+func (s *BacktestServiceImpl) warnf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Warnf(format, args...)
+	}
+}
+
+func parseUserID(userID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(strings.TrimSpace(userID))
+	if err != nil {
+		return uuid.Nil, ErrInvalidUser
+	}
+	return id, nil
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
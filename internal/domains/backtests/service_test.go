@@ -0,0 +1,227 @@
+package backtests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type BacktestServiceSuite struct {
+	suite.Suite
+	jobRepo   *repositorymock.MockBacktestJobRepository
+	stockRepo *repositorymock.MockStockRepository
+	quoteRepo *repositorymock.MockStockQuoteRepository
+	dailyRepo *repositorymock.MockStockDailyRepository
+	service   *BacktestServiceImpl
+	userID    uuid.UUID
+	now       time.Time
+}
+
+func (s *BacktestServiceSuite) SetupTest() {
+	s.jobRepo = repositorymock.NewMockBacktestJobRepository(s.T())
+	s.stockRepo = repositorymock.NewMockStockRepository(s.T())
+	s.quoteRepo = repositorymock.NewMockStockQuoteRepository(s.T())
+	s.dailyRepo = repositorymock.NewMockStockDailyRepository(s.T())
+	s.service = NewBacktestService(s.jobRepo, s.stockRepo, s.quoteRepo, s.dailyRepo, nil).(*BacktestServiceImpl)
+	s.now = time.Date(2025, 3, 1, 0, 0, 0, 0, bangkok)
+	s.service.now = func() time.Time { return s.now }
+	s.userID = uuid.New()
+}
+
+// submit queues req and returns the job and the row written for it.
+func (s *BacktestServiceSuite) submit(req Request) (*BacktestJob, *models.BacktestJob) {
+	var row *models.BacktestJob
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL"}, nil).Once()
+	s.jobRepo.EXPECT().CountPendingByUser(s.userID).Return(0, nil).Once()
+	s.jobRepo.EXPECT().CountQueued().Return(0, nil).Once()
+	s.jobRepo.EXPECT().Create(mock.Anything).RunAndReturn(func(r *models.BacktestJob) error {
+		row = r
+		return nil
+	}).Once()
+
+	job, err := s.service.Submit(context.Background(), s.userID.String(), req)
+	s.Require().NoError(err)
+	return job, row
+}
+
+// claim makes the next ClaimNext hand out row as running.
+func (s *BacktestServiceSuite) claim(row *models.BacktestJob) {
+	started := models.NewLocalTime(s.now)
+	row.Status = JobStatusRunning
+	row.StartedAt = &started
+	s.jobRepo.EXPECT().ClaimNext(s.now).Return(row, nil).Once()
+}
+
+func (s *BacktestServiceSuite) TestSubmit_FillsDefaults() {
+	job, row := s.submit(Request{Symbol: "aapl"})
+
+	s.Equal("AAPL", job.Symbol)
+	s.Equal(JobStatusQueued, job.Status)
+	s.Equal(SourceDaily, job.Source)
+	s.Equal(StrategyScoreEMA, job.Strategy)
+	s.Equal(s.now, job.To)
+	s.Equal(s.now.AddDate(0, 0, -defaultDailyRangeDays), job.From)
+	s.Equal(float64(defaultInitialCash), job.Params.InitialCash)
+	s.Equal(defaultEntryScore, job.Params.EntryScore)
+	s.Equal(defaultExitScore, job.Params.ExitScore)
+
+	s.Equal(job.ID, row.ID)
+	s.Equal(s.userID, row.UserID)
+	s.Equal(JobStatusQueued, row.Status)
+	stored, err := fromRow(row)
+	s.Require().NoError(err)
+	s.Equal(job.Params, stored.Params)
+}
+
+func (s *BacktestServiceSuite) TestSubmit_Validates() {
+	invalidCondition := models.AlertCondition{Type: "nope"}
+	cases := []Request{
+		{Symbol: "AAPL", Source: "ticks"},
+		{Symbol: "AAPL", Strategy: "martingale"},
+		{Symbol: "AAPL", Strategy: StrategyRules},
+		{Symbol: "AAPL", Strategy: StrategyRules, Params: Params{Entry: &invalidCondition}},
+		{Symbol: "AAPL", Source: SourceQuotes, From: s.now.AddDate(0, 0, -90)},
+		{Symbol: "AAPL", From: s.now, To: s.now.AddDate(0, 0, -1)},
+		{Symbol: "AAPL", Params: Params{FeeBps: -1}},
+		{Symbol: "AAPL", Params: Params{EntryScore: 5}},
+	}
+	for _, req := range cases {
+		_, err := s.service.Submit(context.Background(), s.userID.String(), req)
+		s.True(errors.Is(err, ErrInvalidBacktest), "%+v", req)
+	}
+}
+
+func (s *BacktestServiceSuite) TestSubmit_LimitsPendingJobsPerUser() {
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL"}, nil)
+	s.jobRepo.EXPECT().CountPendingByUser(s.userID).Return(int64(maxPending), nil)
+
+	_, err := s.service.Submit(context.Background(), s.userID.String(), Request{Symbol: "AAPL"})
+
+	s.True(errors.Is(err, ErrTooManyPending))
+}
+
+func (s *BacktestServiceSuite) TestSubmit_RejectsWhenQueueIsFull() {
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL"}, nil)
+	s.jobRepo.EXPECT().CountPendingByUser(s.userID).Return(0, nil)
+	s.jobRepo.EXPECT().CountQueued().Return(defaultQueueSize, nil)
+
+	_, err := s.service.Submit(context.Background(), s.userID.String(), Request{Symbol: "AAPL"})
+
+	s.True(errors.Is(err, ErrQueueFull))
+}
+
+func (s *BacktestServiceSuite) TestProcessNext_StoresResult() {
+	job, row := s.submit(Request{Symbol: "AAPL", Source: SourceQuotes})
+	s.claim(row)
+	s.quoteRepo.EXPECT().FindBySymbolBetween("AAPL", mock.Anything, mock.Anything).
+		RunAndReturn(func(_ string, from, to time.Time) ([]models.StockQuote, error) {
+			s.True(from.Equal(job.From))
+			s.True(to.Equal(job.To))
+			return trendingBars(10, 100, 1), nil
+		})
+	s.jobRepo.EXPECT().Finish(row).Return(true, nil)
+
+	s.True(s.service.processNext(context.Background()))
+
+	got, err := fromRow(row)
+	s.Require().NoError(err)
+	s.Equal(JobStatusSucceeded, got.Status)
+	s.Require().NotNil(got.Result)
+	s.Equal(10, got.Result.Bars)
+	s.NotNil(got.FinishedAt)
+}
+
+func (s *BacktestServiceSuite) TestProcessNext_FailsWithoutData() {
+	_, row := s.submit(Request{Symbol: "AAPL"})
+	s.claim(row)
+	s.dailyRepo.EXPECT().FindBySymbolBetween("AAPL", mock.Anything, mock.Anything).Return(nil, nil)
+	s.jobRepo.EXPECT().Finish(row).Return(true, nil)
+
+	s.True(s.service.processNext(context.Background()))
+
+	s.Equal(JobStatusFailed, row.Status)
+	s.Equal(ErrNoData.Error(), row.Error)
+}
+
+func (s *BacktestServiceSuite) TestProcessNext_IdleWithoutQueuedJobs() {
+	s.jobRepo.EXPECT().ClaimNext(s.now).Return(nil, gorm.ErrRecordNotFound)
+
+	s.False(s.service.processNext(context.Background()))
+}
+
+func (s *BacktestServiceSuite) TestCancel_QueuedJob() {
+	_, row := s.submit(Request{Symbol: "AAPL"})
+	s.jobRepo.EXPECT().Cancel(s.userID, row.ID, s.now).Return(true, nil)
+	finished := models.NewLocalTime(s.now)
+	canceled := *row
+	canceled.Status = JobStatusCanceled
+	canceled.FinishedAt = &finished
+	s.jobRepo.EXPECT().FindByID(row.ID).Return(&canceled, nil)
+
+	job, err := s.service.Cancel(context.Background(), s.userID.String(), row.ID)
+
+	s.Require().NoError(err)
+	s.Equal(JobStatusCanceled, job.Status)
+	s.NotNil(job.FinishedAt)
+}
+
+func (s *BacktestServiceSuite) TestCancel_FinishedJob() {
+	_, row := s.submit(Request{Symbol: "AAPL"})
+	row.Status = JobStatusSucceeded
+	s.jobRepo.EXPECT().Cancel(s.userID, row.ID, s.now).Return(false, nil)
+	s.jobRepo.EXPECT().FindByID(row.ID).Return(row, nil)
+
+	_, err := s.service.Cancel(context.Background(), s.userID.String(), row.ID)
+
+	s.True(errors.Is(err, ErrJobFinished))
+}
+
+func (s *BacktestServiceSuite) TestGet_HidesOtherUsersJobs() {
+	_, row := s.submit(Request{Symbol: "AAPL"})
+	s.jobRepo.EXPECT().FindByID(row.ID).Return(row, nil)
+
+	_, err := s.service.Get(context.Background(), uuid.NewString(), row.ID)
+
+	s.True(errors.Is(err, ErrJobNotFound))
+}
+
+func (s *BacktestServiceSuite) TestGet_ReadsJobsFinishedElsewhere() {
+	_, row := s.submit(Request{Symbol: "AAPL"})
+	row.Status = JobStatusSucceeded
+	row.Result = []byte(`{"bars":12,"trades":[],"equity_curve":[]}`)
+	s.jobRepo.EXPECT().FindByID(row.ID).Return(row, nil)
+
+	job, err := s.service.Get(context.Background(), s.userID.String(), row.ID)
+
+	s.Require().NoError(err)
+	s.Equal(JobStatusSucceeded, job.Status)
+	s.Require().NotNil(job.Result)
+	s.Equal(12, job.Result.Bars)
+}
+
+func (s *BacktestServiceSuite) TestList_OmitsResults() {
+	_, row := s.submit(Request{Symbol: "AAPL"})
+	row.Status = JobStatusSucceeded
+	row.Result = []byte(`{"bars":12}`)
+	s.jobRepo.EXPECT().FindByUser(s.userID, defaultJobHistory).Return([]models.BacktestJob{*row}, nil)
+
+	jobs, err := s.service.List(context.Background(), s.userID.String())
+
+	s.Require().NoError(err)
+	s.Require().Len(jobs, 1)
+	s.Equal(JobStatusSucceeded, jobs[0].Status)
+	s.Nil(jobs[0].Result)
+}
+
+func TestBacktestServiceSuite(t *testing.T) {
+	suite.Run(t, new(BacktestServiceSuite))
+}
@@ -0,0 +1,210 @@
+package candles
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+)
+
+const (
+	defaultMaxCandles        = 5000
+	defaultMaxFillGapMinutes = 15
+	defaultRangeDuration     = 24 * time.Hour
+)
+
+var (
+	maxCandles = getEnvInt("CANDLE_MAX_POINTS", defaultMaxCandles)
+	maxFillGap = time.Duration(getEnvInt("CANDLE_MAX_FILL_GAP_MINUTES", defaultMaxFillGapMinutes)) * time.Minute
+)
+
+const (
+	GapsSkip = "skip"
+	GapsFill = "fill"
+)
+
+var (
+	ErrInvalidResolution = errors.New("resolution must be one of 1m, 5m, 15m, 30m, 1h, 4h")
+	ErrInvalidGaps       = errors.New("gaps must be skip or fill")
+	ErrInvalidRange      = errors.New("from must be before to")
+	ErrTooManyCandles    = errors.New("requested range has too many candles for the resolution")
+)
+
+var resolutions = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+}
+
+// Candle is one OHLCV bucket. Time is the bucket start in Unix seconds, the
+// format chart libraries expect. Volume is nil when no quote in the bucket
+// carried one. Filled marks flat candles inserted for missing minutes.
+type Candle struct {
+	Time   int64    `json:"time"`
+	Open   float64  `json:"open"`
+	High   float64  `json:"high"`
+	Low    float64  `json:"low"`
+	Close  float64  `json:"close"`
+	Volume *float64 `json:"volume"`
+	Filled bool     `json:"filled,omitempty"`
+}
+
+type ListInput struct {
+	Symbol     string
+	Resolution string
+	From       time.Time
+	To         time.Time
+	Gaps       string
+}
+
+type CandleService interface {
+	List(ctx context.Context, input ListInput) ([]Candle, error)
+}
+
+type CandleServiceImpl struct {
+	quoteRepo repository.StockQuoteRepository
+	now       func() time.Time
+}
+
+func NewCandleService(quoteRepo repository.StockQuoteRepository) CandleService {
+	return &CandleServiceImpl{
+		quoteRepo: quoteRepo,
+		now:       time.Now,
+	}
+}
+
+// List aggregates the minute quotes of a symbol into candles. Buckets without
+// quotes are omitted, unless gaps is fill, in which case gaps shorter than the
+// configured limit are filled with flat candles at the previous close. Longer
+// gaps are treated as non-trading time and left empty.
+func (s *CandleServiceImpl) List(ctx context.Context, input ListInput) ([]Candle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	symbol := strings.TrimSpace(input.Symbol)
+	if symbol == "" {
+		return nil, errors.New("symbol is empty")
+	}
+	step, ok := resolutions[strings.ToLower(strings.TrimSpace(input.Resolution))]
+	if !ok {
+		return nil, ErrInvalidResolution
+	}
+	gaps := strings.ToLower(strings.TrimSpace(input.Gaps))
+	if gaps == "" {
+		gaps = GapsSkip
+	}
+	if gaps != GapsSkip && gaps != GapsFill {
+		return nil, ErrInvalidGaps
+	}
+
+	to := input.To
+	if to.IsZero() {
+		to = s.now()
+	}
+	from := input.From
+	if from.IsZero() {
+		from = to.Add(-defaultRangeDuration)
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidRange
+	}
+	if int64(to.Sub(from)/step) > int64(maxCandles) {
+		return nil, ErrTooManyCandles
+	}
+
+	quotes, err := s.quoteRepo.FindBySymbolBetween(symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+	candles := Aggregate(quotes, step)
+	if gaps == GapsFill {
+		candles = FillGaps(candles, step, maxFillGap)
+	}
+	return candles, nil
+}
+
+// Aggregate buckets quotes, which must be ordered oldest first, into candles
+// of the given step.
+func Aggregate(quotes []models.StockQuote, step time.Duration) []Candle {
+	candles := make([]Candle, 0)
+	var current *Candle
+	for _, q := range quotes {
+		bucket := time.Time(q.CreatedAt).Truncate(step).Unix()
+		if current == nil || current.Time != bucket {
+			candles = append(candles, Candle{
+				Time:  bucket,
+				Open:  q.PriceCurrent,
+				High:  q.PriceCurrent,
+				Low:   q.PriceCurrent,
+				Close: q.PriceCurrent,
+			})
+			current = &candles[len(candles)-1]
+		} else {
+			if q.PriceCurrent > current.High {
+				current.High = q.PriceCurrent
+			}
+			if q.PriceCurrent < current.Low {
+				current.Low = q.PriceCurrent
+			}
+			current.Close = q.PriceCurrent
+		}
+		if q.Volume != nil {
+			volume := *q.Volume
+			if current.Volume != nil {
+				volume += *current.Volume
+			}
+			current.Volume = &volume
+		}
+	}
+	return candles
+}
+
+// FillGaps inserts flat candles at the previous close for missing buckets,
+// as long as the gap is no longer than maxGap.
+func FillGaps(candles []Candle, step, maxGap time.Duration) []Candle {
+	if len(candles) < 2 {
+		return candles
+	}
+	stepSec := int64(step / time.Second)
+	maxGapSec := int64(maxGap / time.Second)
+	filled := make([]Candle, 0, len(candles))
+	for i, c := range candles {
+		if i > 0 {
+			prev := candles[i-1]
+			if gap := c.Time - prev.Time; gap > stepSec && gap-stepSec <= maxGapSec {
+				for t := prev.Time + stepSec; t < c.Time; t += stepSec {
+					filled = append(filled, Candle{
+						Time:   t,
+						Open:   prev.Close,
+						High:   prev.Close,
+						Low:    prev.Close,
+						Close:  prev.Close,
+						Filled: true,
+					})
+				}
+			}
+		}
+		filled = append(filled, c)
+	}
+	return filled
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
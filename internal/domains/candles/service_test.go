@@ -0,0 +1,102 @@
+package candles
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type CandleServiceSuite struct {
+	suite.Suite
+	repo    *repositorymock.MockStockQuoteRepository
+	service *CandleServiceImpl
+	base    time.Time
+}
+
+func (s *CandleServiceSuite) SetupTest() {
+	s.repo = repositorymock.NewMockStockQuoteRepository(s.T())
+	s.service = NewCandleService(s.repo).(*CandleServiceImpl)
+	s.base = time.Date(2025, 1, 2, 14, 30, 0, 0, time.UTC)
+	s.service.now = func() time.Time { return s.base.Add(time.Hour) }
+}
+
+func (s *CandleServiceSuite) quote(offset time.Duration, price float64, volume *float64) models.StockQuote {
+	return models.StockQuote{
+		Symbol:       "AAPL",
+		PriceCurrent: price,
+		Volume:       volume,
+		CreatedAt:    models.NewLocalTime(s.base.Add(offset)),
+	}
+}
+
+func (s *CandleServiceSuite) TestAggregate_BuildsOHLCV() {
+	v := func(f float64) *float64 { return &f }
+	quotes := []models.StockQuote{
+		s.quote(0, 10, v(100)),
+		s.quote(time.Minute, 12, v(50)),
+		s.quote(2*time.Minute, 9, nil),
+		s.quote(4*time.Minute, 11, v(10)),
+		s.quote(5*time.Minute, 13, nil),
+	}
+
+	candles := Aggregate(quotes, 5*time.Minute)
+
+	s.Require().Len(candles, 2)
+	s.Equal(Candle{Time: s.base.Unix(), Open: 10, High: 12, Low: 9, Close: 11, Volume: v(160)}, candles[0])
+	s.Equal(s.base.Add(5*time.Minute).Unix(), candles[1].Time)
+	s.Nil(candles[1].Volume)
+}
+
+func (s *CandleServiceSuite) TestFillGaps_FillsShortGapsOnly() {
+	step := time.Minute
+	candles := []Candle{
+		{Time: s.base.Unix(), Open: 1, High: 1, Low: 1, Close: 1},
+		{Time: s.base.Add(3 * time.Minute).Unix(), Open: 2, High: 2, Low: 2, Close: 2},
+		{Time: s.base.Add(2 * time.Hour).Unix(), Open: 3, High: 3, Low: 3, Close: 3},
+	}
+
+	filled := FillGaps(candles, step, 15*time.Minute)
+
+	s.Require().Len(filled, 5)
+	s.True(filled[1].Filled)
+	s.True(filled[2].Filled)
+	s.Equal(1.0, filled[2].Close)
+	s.Equal(s.base.Add(2*time.Hour).Unix(), filled[4].Time)
+}
+
+func (s *CandleServiceSuite) TestList_DefaultsRangeAndQueriesRepository() {
+	to := s.base.Add(time.Hour)
+	s.repo.EXPECT().FindBySymbolBetween("AAPL", to.Add(-24*time.Hour), to).Return([]models.StockQuote{
+		s.quote(0, 10, nil),
+	}, nil)
+
+	candles, err := s.service.List(context.Background(), ListInput{Symbol: "AAPL", Resolution: "15m"})
+
+	s.Require().NoError(err)
+	s.Len(candles, 1)
+}
+
+func (s *CandleServiceSuite) TestList_RejectsInvalidInput() {
+	ctx := context.Background()
+
+	_, err := s.service.List(ctx, ListInput{Symbol: "AAPL", Resolution: "2m"})
+	s.ErrorIs(err, ErrInvalidResolution)
+
+	_, err = s.service.List(ctx, ListInput{Symbol: "AAPL", Resolution: "1m", Gaps: "zero"})
+	s.ErrorIs(err, ErrInvalidGaps)
+
+	_, err = s.service.List(ctx, ListInput{Symbol: "AAPL", Resolution: "1m", From: s.base, To: s.base})
+	s.ErrorIs(err, ErrInvalidRange)
+
+	_, err = s.service.List(ctx, ListInput{Symbol: "AAPL", Resolution: "1m", From: s.base.AddDate(0, -1, 0), To: s.base})
+	s.ErrorIs(err, ErrTooManyCandles)
+}
+
+func TestCandleServiceSuite(t *testing.T) {
+	suite.Run(t, new(CandleServiceSuite))
+}
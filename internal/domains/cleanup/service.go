@@ -17,6 +17,7 @@ type CleanupServiceImpl struct {
 	alertEventRepo             repository.AlertEventRepository
 	marketOpenRepo             repository.MarketOpenRepository
 	refreshTokenRepo           repository.RefreshTokenRepository
+	userTokenRepo              repository.UserTokenRepository
 	pushSubscriptionRepo       repository.PushSubscriptionRepository
 	retainDays                 int
 	alertRetainDays            int
@@ -31,6 +32,7 @@ func NewCleanupService(
 	alertEventRepo repository.AlertEventRepository,
 	marketOpenRepo repository.MarketOpenRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	userTokenRepo repository.UserTokenRepository,
 	pushSubscriptionRepo repository.PushSubscriptionRepository,
 	retainDays int,
 	alertRetainDays int,
@@ -59,6 +61,7 @@ func NewCleanupService(
 		alertEventRepo:             alertEventRepo,
 		marketOpenRepo:             marketOpenRepo,
 		refreshTokenRepo:           refreshTokenRepo,
+		userTokenRepo:              userTokenRepo,
 		pushSubscriptionRepo:       pushSubscriptionRepo,
 		retainDays:                 retainDays,
 		alertRetainDays:            alertRetainDays,
@@ -120,6 +123,9 @@ func (s *CleanupServiceImpl) runOnce(ctx context.Context) {
 	if s.refreshTokenRepo != nil {
 		_ = s.refreshTokenRepo.DeleteBefore(refreshTokenCutoffDate)
 	}
+	if s.userTokenRepo != nil {
+		_ = s.userTokenRepo.DeleteBefore(refreshTokenCutoffDate)
+	}
 	if s.pushSubscriptionRepo != nil {
 		_ = s.pushSubscriptionRepo.DeleteBefore(pushSubscriptionCutoffDate)
 	}
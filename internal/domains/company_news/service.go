@@ -2,42 +2,29 @@ package company_news
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"strings"
 	"time"
 
+	"sun-stockanalysis-api/internal/marketdata"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/internal/repository"
 	"sun-stockanalysis-api/pkg/logger"
 )
 
-const (
-	companyNewsURL = "https://finnhub.io/api/v1/company-news"
-)
-
 type CompanyNewsService interface {
 	Start(ctx context.Context)
 	ListBySymbolAndDate(ctx context.Context, symbol string, start, end time.Time) ([]models.CompanyNews, error)
 }
 
 type CompanyNewsNotifier interface {
-	NotifyCompanyNewsReady(message string)
-}
-
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
+	NotifyCompanyNewsReady(message string, symbols []string)
 }
 
 type CompanyNewsServiceImpl struct {
 	relationRepo repository.RelationNewsRepository
 	companyRepo  repository.CompanyNewsRepository
-	httpClient   HTTPClient
-	finnhubToken string
+	provider     marketdata.Provider
 	log          *logger.Logger
 	notifier     CompanyNewsNotifier
 }
@@ -46,19 +33,14 @@ func NewCompanyNewsService(
 	relationRepo repository.RelationNewsRepository,
 	companyRepo repository.CompanyNewsRepository,
 	notifier CompanyNewsNotifier,
-	httpClient HTTPClient,
-	finnhubToken string,
+	provider marketdata.Provider,
 	log *logger.Logger,
 ) CompanyNewsService {
-	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 10 * time.Second}
-	}
 	return &CompanyNewsServiceImpl{
 		relationRepo: relationRepo,
 		companyRepo:  companyRepo,
 		notifier:     notifier,
-		httpClient:   httpClient,
-		finnhubToken: finnhubToken,
+		provider:     provider,
 		log:          log,
 	}
 }
@@ -109,12 +91,13 @@ func (s *CompanyNewsServiceImpl) runOnce(ctx context.Context) {
 		return
 	}
 
-	today := time.Now().In(thailandLocation()).Format("2006-01-02")
+	now := time.Now().In(thailandLocation())
+	today := now.Format("2006-01-02")
 	if s.log != nil {
 		s.log.Infof("company_news fetch started: symbols=%d date=%s", len(symbols), today)
 	}
 	totalSaved := 0
-	notifiedToday := false
+	var withNews []string
 	for _, symbol := range symbols {
 		select {
 		case <-ctx.Done():
@@ -128,14 +111,14 @@ func (s *CompanyNewsServiceImpl) runOnce(ctx context.Context) {
 		if symbol == "" {
 			continue
 		}
-		news, err := s.fetchCompanyNews(symbol, today, today)
+		news, err := s.provider.CompanyNews(ctx, symbol, now, now)
 		if err != nil || len(news) == 0 {
 			continue
 		}
 		items := make([]models.CompanyNews, 0, len(news))
 		for _, n := range news {
 			items = append(items, models.CompanyNews{
-				Symbol:   n.Related,
+				Symbol:   n.Symbol,
 				Headline: n.Headline,
 				Source:   n.Source,
 				Summary:  n.Summary,
@@ -144,58 +127,28 @@ func (s *CompanyNewsServiceImpl) runOnce(ctx context.Context) {
 		}
 		if err := s.companyRepo.CreateMany(items); err == nil {
 			totalSaved += len(items)
-			if s.notifier != nil && !notifiedToday {
-				s.notifier.NotifyCompanyNewsReady("Today's stock market news is here.")
-				notifiedToday = true
-			}
+			withNews = append(withNews, symbol)
 		}
 	}
+	if s.notifier != nil && len(withNews) > 0 {
+		s.notifyDigest(withNews)
+	}
 	if s.log != nil {
 		s.log.Infof("company_news fetch completed: saved=%d date=%s", totalSaved, today)
 	}
 }
 
-type finnhubCompanyNewsResponse struct {
-	Headline string `json:"headline"`
-	Source   string `json:"source"`
-	Summary  string `json:"summary"`
-	URL      string `json:"url"`
-	Related  string `json:"related"`
-}
-
-func (s *CompanyNewsServiceImpl) fetchCompanyNews(symbol, fromDate, toDate string) ([]finnhubCompanyNewsResponse, error) {
-	reqURL, err := url.Parse(companyNewsURL)
-	if err != nil {
-		return nil, err
-	}
-	q := reqURL.Query()
-	q.Set("symbol", symbol)
-	q.Set("from", fromDate)
-	q.Set("to", toDate)
-	reqURL.RawQuery = q.Encode()
-
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+// notifyDigest announces the day's news to users following a stock related to
+// one of the relation symbols that received news.
+func (s *CompanyNewsServiceImpl) notifyDigest(relationSymbols []string) {
+	symbols, err := s.relationRepo.ListSymbolsByRelationSymbols(relationSymbols)
 	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("X-Finnhub-Token", s.finnhubToken)
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("finnhub company-news request failed: %s", strings.TrimSpace(string(body)))
-	}
-
-	var result []finnhubCompanyNewsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+		if s.log != nil {
+			s.log.Warnf("company_news digest symbols lookup failed: %v", err)
+		}
+		return
 	}
-	return result, nil
+	s.notifier.NotifyCompanyNewsReady("Today's stock market news is here.", symbols)
 }
 
 func nextRunDuration(hour, minute int, loc *time.Location) time.Duration {
@@ -0,0 +1,150 @@
+package fx_rates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"sun-stockanalysis-api/internal/marketdata"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type datedRate struct {
+	date time.Time
+	rate float64
+}
+
+// Converter turns amounts in any stored currency into a single target
+// currency. The rate for a given time is the latest one dated on or before
+// that day; days older than every stored rate use the earliest stored rate and
+// currencies that were never stored use the static file.
+type Converter struct {
+	target  string
+	base    string
+	history map[string][]datedRate
+	static  map[string]float64
+}
+
+func NewConverter(target, base string, rates []models.FXRate, static *marketdata.FXRates) *Converter {
+	c := &Converter{
+		target:  target,
+		base:    base,
+		history: make(map[string][]datedRate),
+		static:  map[string]float64{},
+	}
+	if static != nil {
+		c.static = static.Rates
+	}
+	seen := make(map[string]bool, len(rates))
+	for _, row := range rates {
+		date := time.Time(row.RateDate)
+		key := row.Quote + date.Format("2006-01-02")
+		if seen[key] || row.Rate <= 0 {
+			continue
+		}
+		seen[key] = true
+		c.history[row.Quote] = append(c.history[row.Quote], datedRate{date: date, rate: row.Rate})
+	}
+	for _, series := range c.history {
+		sort.Slice(series, func(i, j int) bool { return series[i].date.Before(series[j].date) })
+	}
+	return c
+}
+
+// Target is the currency amounts are converted into.
+func (c *Converter) Target() string {
+	return c.target
+}
+
+// Rate is the number of target units one unit of currency bought at the given
+// time.
+func (c *Converter) Rate(currency string, at time.Time) (float64, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return 0, fmt.Errorf("%w: unknown source currency", ErrRateUnavailable)
+	}
+	if currency == c.target {
+		return 1, nil
+	}
+	from, err := c.baseRate(currency, at)
+	if err != nil {
+		return 0, err
+	}
+	to, err := c.baseRate(c.target, at)
+	if err != nil {
+		return 0, err
+	}
+	return to / from, nil
+}
+
+// Convert expresses amount, held in currency, in the target currency.
+func (c *Converter) Convert(amount float64, currency string, at time.Time) (float64, error) {
+	rate, err := c.Rate(currency, at)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+func (c *Converter) baseRate(currency string, at time.Time) (float64, error) {
+	if currency == c.base {
+		return 1, nil
+	}
+	series := c.history[currency]
+	if len(series) > 0 {
+		day := time.Time(models.NewLocalDate(at))
+		idx := sort.Search(len(series), func(i int) bool { return series[i].date.After(day) })
+		if idx == 0 {
+			return series[0].rate, nil
+		}
+		return series[idx-1].rate, nil
+	}
+	if rate, ok := c.static[currency]; ok && rate > 0 {
+		return rate, nil
+	}
+	return 0, fmt.Errorf("%w: no %s rate against %s", ErrRateUnavailable, currency, c.base)
+}
+
+func scaleQuote(quote *models.StockQuote, rate float64) {
+	quote.PriceCurrent *= rate
+	quote.ChangePrice = scaled(quote.ChangePrice, rate)
+	quote.EMA20 *= rate
+	quote.EMA100 *= rate
+	quote.ChangeEMA20 *= rate
+	scaleIndicators(&quote.TechnicalIndicators, rate)
+}
+
+func scaleDaily(row *models.StockDaily, rate float64) {
+	row.PriceAverage *= rate
+	row.PriceHigh *= rate
+	row.PriceLow *= rate
+	row.PriceOpen *= rate
+	row.PriceClose *= rate
+	row.PricePrevClose *= rate
+	row.ChangePrice = scaled(row.ChangePrice, rate)
+	row.DeltaPrice *= rate
+	row.EMA20 *= rate
+	row.EMA100 *= rate
+	scaleIndicators(&row.TechnicalIndicators, rate)
+}
+
+// scaleIndicators converts the indicators quoted in price units. RSI is a
+// ratio and stays as is.
+func scaleIndicators(ti *models.TechnicalIndicators, rate float64) {
+	ti.MACD = scaled(ti.MACD, rate)
+	ti.MACDSignal = scaled(ti.MACDSignal, rate)
+	ti.MACDHistogram = scaled(ti.MACDHistogram, rate)
+	ti.BollingerMiddle = scaled(ti.BollingerMiddle, rate)
+	ti.BollingerUpper = scaled(ti.BollingerUpper, rate)
+	ti.BollingerLower = scaled(ti.BollingerLower, rate)
+	ti.ATR14 = scaled(ti.ATR14, rate)
+}
+
+func scaled(value *float64, rate float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	v := *value * rate
+	return &v
+}
@@ -0,0 +1,404 @@
+package fx_rates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sun-stockanalysis-api/internal/configurations"
+	"sun-stockanalysis-api/internal/marketdata"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+const (
+	defaultBaseCurrency = "USD"
+	defaultSyncHour     = 6
+	defaultSyncMinute   = 0
+	SourceStatic        = "static"
+)
+
+var (
+	baseCurrency = strings.ToUpper(getEnvString("FX_BASE_CURRENCY", defaultBaseCurrency))
+	syncHour     = getEnvInt("FX_SYNC_HOUR", defaultSyncHour)
+	syncMinute   = getEnvInt("FX_SYNC_MINUTE", defaultSyncMinute)
+	bangkok      = time.FixedZone("Asia/Bangkok", 7*60*60)
+)
+
+var (
+	ErrInvalidCurrency = errors.New("invalid currency")
+	ErrRateUnavailable = errors.New("fx rate unavailable")
+)
+
+// SyncResult reports the rates stored by one ingestion run.
+type SyncResult struct {
+	Date   models.LocalDate `json:"date"`
+	Base   string           `json:"base"`
+	Source string           `json:"source"`
+	Count  int              `json:"count"`
+}
+
+type Rate struct {
+	Currency string           `json:"currency"`
+	Rate     float64          `json:"rate"`
+	RateDate models.LocalDate `json:"rate_date"`
+	Source   string           `json:"source"`
+}
+
+// RateTable lists how many units of each currency one unit of Base bought on
+// Date, using the latest stored rate of every pair.
+type RateTable struct {
+	Base  string           `json:"base"`
+	Date  models.LocalDate `json:"date"`
+	Rates []Rate           `json:"rates"`
+}
+
+type FXRateService interface {
+	Start(ctx context.Context)
+	Sync(ctx context.Context) (*SyncResult, error)
+	Rates(ctx context.Context, base string, at time.Time) (*RateTable, error)
+	Converter(ctx context.Context, target string, start, end time.Time) (*Converter, error)
+	ConvertQuotes(ctx context.Context, quotes []models.StockQuote, target string) error
+	ConvertDaily(ctx context.Context, rows []models.StockDaily, target string) error
+}
+
+type FXRateServiceImpl struct {
+	repo      repository.FXRateRepository
+	stockRepo repository.StockRepository
+	provider  marketdata.Provider
+	static    *marketdata.FXRates
+	base      string
+	now       func() time.Time
+	log       *logger.Logger
+}
+
+// NewFXRateService builds the service. When cfg names a static rates file it
+// is loaded up front and used whenever the provider or the store has no rate.
+func NewFXRateService(
+	repo repository.FXRateRepository,
+	stockRepo repository.StockRepository,
+	provider marketdata.Provider,
+	cfg *configurations.MarketData,
+	log *logger.Logger,
+) (FXRateService, error) {
+	s := &FXRateServiceImpl{
+		repo:      repo,
+		stockRepo: stockRepo,
+		provider:  provider,
+		base:      baseCurrency,
+		now:       time.Now,
+		log:       log,
+	}
+	if cfg != nil && strings.TrimSpace(cfg.FXRatesFile) != "" {
+		static, err := marketdata.LoadFXRatesFile(strings.TrimSpace(cfg.FXRatesFile))
+		if err != nil {
+			return nil, fmt.Errorf("load fx rates file: %w", err)
+		}
+		if static, err = static.Rebase(s.base); err != nil {
+			return nil, fmt.Errorf("load fx rates file: %w", err)
+		}
+		s.static = static
+	}
+	return s, nil
+}
+
+func (s *FXRateServiceImpl) Start(ctx context.Context) {
+	go s.runScheduler(ctx)
+}
+
+// runScheduler syncs once on start so a fresh replica has today's rates, then
+// daily at FX_SYNC_HOUR:FX_SYNC_MINUTE Bangkok time.
+func (s *FXRateServiceImpl) runScheduler(ctx context.Context) {
+	for {
+		if result, err := s.Sync(ctx); err != nil {
+			s.logf("fx_rates: sync failed: %v", err)
+		} else {
+			s.logf("fx_rates: stored %d %s rates from %s", result.Count, result.Base, result.Source)
+		}
+
+		timer := time.NewTimer(nextRunDuration(syncHour, syncMinute, s.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// Sync stores today's rates against the base currency. Rates come from the
+// market data provider and fall back to the static file when the provider
+// fails or returns nothing.
+func (s *FXRateServiceImpl) Sync(ctx context.Context) (*SyncResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	source := s.provider.Name()
+	rates, err := s.provider.FXRates(ctx, s.base)
+	if err == nil && rates != nil {
+		rates, err = rates.Rebase(s.base)
+	}
+	if err != nil || rates == nil || len(rates.Rates) == 0 {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if s.static == nil {
+			if err == nil {
+				err = marketdata.ErrNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", ErrRateUnavailable, err)
+		}
+		if err != nil {
+			s.logf("fx_rates: provider %s failed, using static rates: %v", source, err)
+		}
+		rates, source = s.static, SourceStatic
+	}
+
+	date := models.NewLocalDate(s.now())
+	rows := make([]models.FXRate, 0, len(rates.Rates))
+	for _, currency := range sortedCurrencies(rates.Rates) {
+		rate := rates.Rates[currency]
+		if currency == s.base || rate <= 0 || !validCurrency(currency) {
+			continue
+		}
+		rows = append(rows, models.FXRate{
+			Base:     s.base,
+			Quote:    currency,
+			RateDate: date,
+			Rate:     rate,
+			Source:   source,
+		})
+	}
+	if err := s.repo.Upsert(rows); err != nil {
+		return nil, err
+	}
+	return &SyncResult{Date: date, Base: s.base, Source: source, Count: len(rows)}, nil
+}
+
+// Rates lists the rates of base in effect at the given time, cross-converted
+// through the stored base currency. A zero at means now.
+func (s *FXRateServiceImpl) Rates(ctx context.Context, base string, at time.Time) (*RateTable, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(base) == "" {
+		base = s.base
+	}
+	base, err := normalizeCurrency(base)
+	if err != nil {
+		return nil, err
+	}
+	if at.IsZero() {
+		at = s.now()
+	}
+	stored, err := s.repo.FindLatest(s.base, at)
+	if err != nil {
+		return nil, err
+	}
+
+	date := models.NewLocalDate(at)
+	entries := make(map[string]Rate, len(stored)+1)
+	if s.static != nil {
+		for currency, rate := range s.static.Rates {
+			entries[currency] = Rate{Currency: currency, Rate: rate, Source: SourceStatic}
+		}
+	}
+	for _, row := range stored {
+		entries[row.Quote] = Rate{Currency: row.Quote, Rate: row.Rate, RateDate: row.RateDate, Source: row.Source}
+	}
+	entries[s.base] = Rate{Currency: s.base, Rate: 1, RateDate: date}
+
+	pivot, ok := entries[base]
+	if !ok {
+		return nil, fmt.Errorf("%w: no %s rate", ErrRateUnavailable, base)
+	}
+	table := &RateTable{Base: base, Date: date, Rates: make([]Rate, 0, len(entries))}
+	for _, currency := range sortedCurrencies(entries) {
+		if currency == base {
+			continue
+		}
+		entry := entries[currency]
+		entry.Rate /= pivot.Rate
+		table.Rates = append(table.Rates, entry)
+	}
+	return table, nil
+}
+
+// Converter loads the rates needed to convert amounts into target for any
+// date between start and end.
+func (s *FXRateServiceImpl) Converter(ctx context.Context, target string, start, end time.Time) (*Converter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	target, err := normalizeCurrency(target)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		start, end = end, start
+	}
+	seed, err := s.repo.FindLatest(s.base, start)
+	if err != nil {
+		return nil, err
+	}
+	between, err := s.repo.FindBetween(s.base, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return NewConverter(target, s.base, append(seed, between...), s.static), nil
+}
+
+// ConvertQuotes rewrites the price fields of quotes into target using the
+// rate of each quote's day.
+func (s *FXRateServiceImpl) ConvertQuotes(ctx context.Context, quotes []models.StockQuote, target string) error {
+	if len(quotes) == 0 {
+		_, err := normalizeCurrency(target)
+		return err
+	}
+	start, end := time.Time(quotes[0].CreatedAt), time.Time(quotes[0].CreatedAt)
+	for _, quote := range quotes[1:] {
+		start, end = widen(start, end, time.Time(quote.CreatedAt))
+	}
+	converter, err := s.Converter(ctx, target, start, end)
+	if err != nil {
+		return err
+	}
+	currencies, err := s.stockCurrencies()
+	if err != nil {
+		return err
+	}
+	for i := range quotes {
+		rate, err := converter.Rate(currencies[strings.ToUpper(quotes[i].Symbol)], time.Time(quotes[i].CreatedAt))
+		if err != nil {
+			return fmt.Errorf("%s: %w", quotes[i].Symbol, err)
+		}
+		scaleQuote(&quotes[i], rate)
+		quotes[i].Currency = converter.Target()
+		quotes[i].FXRate = &rate
+	}
+	return nil
+}
+
+// ConvertDaily rewrites the price fields of rows into target using the rate
+// of each row's trade date.
+func (s *FXRateServiceImpl) ConvertDaily(ctx context.Context, rows []models.StockDaily, target string) error {
+	if len(rows) == 0 {
+		_, err := normalizeCurrency(target)
+		return err
+	}
+	start, end := time.Time(rows[0].TradeDate), time.Time(rows[0].TradeDate)
+	for _, row := range rows[1:] {
+		start, end = widen(start, end, time.Time(row.TradeDate))
+	}
+	converter, err := s.Converter(ctx, target, start, end)
+	if err != nil {
+		return err
+	}
+	currencies, err := s.stockCurrencies()
+	if err != nil {
+		return err
+	}
+	for i := range rows {
+		rate, err := converter.Rate(currencies[strings.ToUpper(rows[i].Symbol)], time.Time(rows[i].TradeDate))
+		if err != nil {
+			return fmt.Errorf("%s: %w", rows[i].Symbol, err)
+		}
+		scaleDaily(&rows[i], rate)
+		rows[i].Currency = converter.Target()
+		rows[i].FXRate = &rate
+	}
+	return nil
+}
+
+func (s *FXRateServiceImpl) stockCurrencies() (map[string]string, error) {
+	stocks, err := s.stockRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	currencies := make(map[string]string, len(stocks))
+	for _, stock := range stocks {
+		currencies[strings.ToUpper(stock.Symbol)] = stock.Currency
+	}
+	return currencies, nil
+}
+
+func (s *FXRateServiceImpl) logf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Infof(format, args...)
+	}
+}
+
+// normalizeCurrency upper-cases an ISO 4217 style code and rejects anything
+// that is not three letters.
+func normalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !validCurrency(currency) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	return currency, nil
+}
+
+func validCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func widen(start, end, at time.Time) (time.Time, time.Time) {
+	if at.Before(start) {
+		start = at
+	}
+	if at.After(end) {
+		end = at
+	}
+	return start, end
+}
+
+func sortedCurrencies[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func nextRunDuration(hour, minute int, now time.Time) time.Duration {
+	current := now.In(bangkok)
+	next := time.Date(current.Year(), current.Month(), current.Day(), hour, minute, 0, 0, bangkok)
+	if !next.After(current) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.Sub(current)
+}
+
+func getEnvString(key, fallback string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return fallback
+	}
+	return n
+}
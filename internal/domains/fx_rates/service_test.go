@@ -0,0 +1,203 @@
+package fx_rates
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/configurations"
+	"sun-stockanalysis-api/internal/marketdata"
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+const staticRatesFile = "../../marketdata/testdata/fx_rates.json"
+
+func day(d int) time.Time {
+	return time.Date(2025, 1, d, 0, 0, 0, 0, bangkok)
+}
+
+func usdRate(quote string, d int, rate float64) models.FXRate {
+	return models.FXRate{Base: "USD", Quote: quote, RateDate: models.NewLocalDate(day(d)), Rate: rate, Source: "replay"}
+}
+
+type FXRateServiceSuite struct {
+	suite.Suite
+	repo      *repositorymock.MockFXRateRepository
+	stockRepo *repositorymock.MockStockRepository
+	provider  *marketdata.ReplayProvider
+}
+
+func (s *FXRateServiceSuite) SetupTest() {
+	s.repo = repositorymock.NewMockFXRateRepository(s.T())
+	s.stockRepo = repositorymock.NewMockStockRepository(s.T())
+	s.provider = marketdata.NewReplayProviderFromFixture(marketdata.ReplayFixture{
+		FXRates: map[string]map[string]float64{"usd": {"thb": 36.5, "eur": 0.9, "USD": 1, "b4d": 2}},
+	})
+}
+
+func (s *FXRateServiceSuite) newService(provider marketdata.Provider, ratesFile string) *FXRateServiceImpl {
+	service, err := NewFXRateService(s.repo, s.stockRepo, provider, &configurations.MarketData{FXRatesFile: ratesFile}, nil)
+	s.Require().NoError(err)
+	impl := service.(*FXRateServiceImpl)
+	impl.now = func() time.Time { return day(10).Add(9 * time.Hour) }
+	return impl
+}
+
+func (s *FXRateServiceSuite) TestSync_StoresProviderRates() {
+	var stored []models.FXRate
+	s.repo.EXPECT().Upsert(mock.Anything).RunAndReturn(func(rates []models.FXRate) error {
+		stored = rates
+		return nil
+	})
+
+	result, err := s.newService(s.provider, "").Sync(context.Background())
+
+	s.Require().NoError(err)
+	s.Equal(marketdata.ProviderReplay, result.Source)
+	s.Equal(2, result.Count)
+	s.Require().Len(stored, 2)
+	s.Equal("EUR", stored[0].Quote)
+	s.Equal("THB", stored[1].Quote)
+	s.Equal(36.5, stored[1].Rate)
+	s.Equal(day(10), time.Time(stored[1].RateDate))
+}
+
+func (s *FXRateServiceSuite) TestSync_FallsBackToStaticFile() {
+	s.repo.EXPECT().Upsert(mock.Anything).RunAndReturn(func(rates []models.FXRate) error {
+		for _, rate := range rates {
+			s.Equal(SourceStatic, rate.Source)
+		}
+		return nil
+	})
+	empty := marketdata.NewReplayProviderFromFixture(marketdata.ReplayFixture{})
+
+	result, err := s.newService(empty, staticRatesFile).Sync(context.Background())
+
+	s.Require().NoError(err)
+	s.Equal(SourceStatic, result.Source)
+	s.Equal(7, result.Count)
+}
+
+func (s *FXRateServiceSuite) TestSync_FailsWithoutAnySource() {
+	empty := marketdata.NewReplayProviderFromFixture(marketdata.ReplayFixture{})
+
+	_, err := s.newService(empty, "").Sync(context.Background())
+
+	s.True(errors.Is(err, ErrRateUnavailable))
+}
+
+func (s *FXRateServiceSuite) TestRates_CrossesThroughBase() {
+	s.repo.EXPECT().FindLatest("USD", day(10)).Return([]models.FXRate{
+		usdRate("THB", 9, 36), usdRate("EUR", 10, 0.9),
+	}, nil)
+
+	table, err := s.newService(s.provider, "").Rates(context.Background(), "thb", day(10))
+
+	s.Require().NoError(err)
+	s.Equal("THB", table.Base)
+	s.Require().Len(table.Rates, 2)
+	s.Equal("EUR", table.Rates[0].Currency)
+	s.InDelta(0.025, table.Rates[0].Rate, 1e-12)
+	s.Equal("USD", table.Rates[1].Currency)
+	s.InDelta(1.0/36, table.Rates[1].Rate, 1e-12)
+}
+
+func (s *FXRateServiceSuite) TestConverter_RejectsInvalidCurrency() {
+	_, err := s.newService(s.provider, "").Converter(context.Background(), "baht", day(1), day(2))
+
+	s.True(errors.Is(err, ErrInvalidCurrency))
+}
+
+func (s *FXRateServiceSuite) TestConvertQuotes_UsesEachQuoteDay() {
+	s.repo.EXPECT().FindLatest("USD", day(3)).Return([]models.FXRate{usdRate("THB", 2, 35)}, nil)
+	s.repo.EXPECT().FindBetween("USD", day(3), day(5)).Return([]models.FXRate{usdRate("THB", 4, 36)}, nil)
+	s.stockRepo.EXPECT().FindAll().Return([]models.Stock{
+		{Symbol: "AAPL", Currency: "USD"}, {Symbol: "PTT", Currency: "THB"},
+	}, nil)
+	change, rsi := 2.0, 55.0
+	quotes := []models.StockQuote{
+		{Symbol: "AAPL", PriceCurrent: 10, ChangePrice: &change, CreatedAt: models.NewLocalTime(day(5))},
+		{Symbol: "aapl", PriceCurrent: 10, CreatedAt: models.NewLocalTime(day(3))},
+		{Symbol: "PTT", PriceCurrent: 30, CreatedAt: models.NewLocalTime(day(4))},
+	}
+	quotes[0].RSI14 = &rsi
+
+	err := s.newService(s.provider, "").ConvertQuotes(context.Background(), quotes, "thb")
+
+	s.Require().NoError(err)
+	s.InDelta(360, quotes[0].PriceCurrent, 1e-9)
+	s.InDelta(72, *quotes[0].ChangePrice, 1e-9)
+	s.Equal(55.0, *quotes[0].RSI14)
+	s.Equal("THB", quotes[0].Currency)
+	s.InDelta(350, quotes[1].PriceCurrent, 1e-9)
+	s.Equal(30.0, quotes[2].PriceCurrent)
+	s.Equal(1.0, *quotes[2].FXRate)
+	s.Equal(2.0, change)
+}
+
+func (s *FXRateServiceSuite) TestConvertDaily_UnknownCurrencyFails() {
+	s.repo.EXPECT().FindLatest("USD", day(3)).Return(nil, nil)
+	s.repo.EXPECT().FindBetween("USD", day(3), day(3)).Return(nil, nil)
+	s.stockRepo.EXPECT().FindAll().Return([]models.Stock{{Symbol: "SAP", Currency: "EUR"}}, nil)
+
+	err := s.newService(s.provider, "").ConvertDaily(context.Background(), []models.StockDaily{
+		{Symbol: "SAP", PriceClose: 10, TradeDate: models.NewLocalDate(day(3))},
+	}, "THB")
+
+	s.True(errors.Is(err, ErrRateUnavailable))
+}
+
+func TestFXRateServiceSuite(t *testing.T) {
+	suite.Run(t, new(FXRateServiceSuite))
+}
+
+type ConverterSuite struct {
+	suite.Suite
+}
+
+func (s *ConverterSuite) TestRate_PicksLatestOnOrBeforeDay() {
+	converter := NewConverter("THB", "USD", []models.FXRate{
+		usdRate("THB", 5, 36), usdRate("THB", 2, 35), usdRate("EUR", 2, 0.9),
+	}, nil)
+
+	cases := []struct {
+		currency string
+		at       time.Time
+		want     float64
+	}{
+		{"USD", day(1), 35},
+		{"USD", day(4).Add(23 * time.Hour), 35},
+		{"usd", day(5), 36},
+		{"USD", day(20), 36},
+		{"THB", day(1), 1},
+		{"EUR", day(5), 36 / 0.9},
+	}
+	for _, tc := range cases {
+		rate, err := converter.Rate(tc.currency, tc.at)
+		s.Require().NoError(err)
+		s.InDelta(tc.want, rate, 1e-9, "%s at %s", tc.currency, tc.at)
+	}
+}
+
+func (s *ConverterSuite) TestRate_StaticFallback() {
+	static, err := marketdata.LoadFXRatesFile(staticRatesFile)
+	s.Require().NoError(err)
+	converter := NewConverter("THB", "USD", []models.FXRate{usdRate("THB", 2, 35)}, static)
+
+	amount, err := converter.Convert(10, "EUR", day(3))
+
+	s.Require().NoError(err)
+	s.InDelta(10*35/0.92, amount, 1e-9)
+
+	_, err = converter.Rate("XYZ", day(3))
+	s.True(errors.Is(err, ErrRateUnavailable))
+}
+
+func TestConverterSuite(t *testing.T) {
+	suite.Run(t, new(ConverterSuite))
+}
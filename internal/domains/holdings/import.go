@@ -0,0 +1,187 @@
+package holdings
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+const (
+	maxImportRows   = 5000
+	maxImportErrors = 10
+)
+
+// importColumns are the CSV headers ImportCSV understands. date, symbol and
+// type are required; the others default to zero or empty.
+var importColumns = []string{"date", "symbol", "type", "quantity", "price", "fee", "amount", "note"}
+
+// importDateLayouts are tried in order for the date column. Dates without a
+// time are read as Bangkok midnight.
+var importDateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// ImportCSV parses a broker export or hand-written sheet and stores every row,
+// or none when any row is invalid or would oversell.
+func (s *HoldingServiceImpl) ImportCSV(ctx context.Context, userID string, data []byte) ([]models.HoldingTransaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing header row", ErrInvalidImport)
+	}
+	columns, err := importHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		imported []models.HoldingTransaction
+		problems []string
+		stocks   = make(map[string]*models.Stock)
+	)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidImport, line, err)
+		}
+		if blankRecord(record) {
+			continue
+		}
+		if len(imported)+len(problems) >= maxImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows", ErrInvalidImport, maxImportRows)
+		}
+		input, err := importRow(record, columns)
+		if err == nil {
+			var transaction *models.HoldingTransaction
+			transaction, err = s.buildTransaction(owner, input, stocks)
+			if err == nil {
+				imported = append(imported, *transaction)
+				continue
+			}
+		}
+		problems = append(problems, fmt.Sprintf("line %d: %v", line, err))
+	}
+	if len(problems) > 0 {
+		if len(problems) > maxImportErrors {
+			problems = append(problems[:maxImportErrors], fmt.Sprintf("and %d more", len(problems)-maxImportErrors))
+		}
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImport, strings.Join(problems, "; "))
+	}
+	if len(imported) == 0 {
+		return nil, fmt.Errorf("%w: no rows", ErrInvalidImport)
+	}
+
+	if err := s.checkCapacity(owner, len(imported)); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.FindByUser(owner, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLedger(append(existing, imported...)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateBatch(imported); err != nil {
+		return nil, err
+	}
+	return imported, nil
+}
+
+func importHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for _, known := range importColumns {
+			if name == known {
+				columns[name] = i
+			}
+		}
+	}
+	for _, required := range importColumns[:3] {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: header needs a %s column", ErrInvalidImport, required)
+		}
+	}
+	return columns, nil
+}
+
+func importRow(record []string, columns map[string]int) (TransactionInput, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	number := func(name string) (float64, error) {
+		raw := strings.ReplaceAll(field(name), ",", "")
+		if raw == "" {
+			return 0, nil
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s %q is not a number", ErrInvalidTransaction, name, field(name))
+		}
+		return v, nil
+	}
+
+	input := TransactionInput{
+		Symbol: field("symbol"),
+		Type:   field("type"),
+		Note:   field("note"),
+	}
+	var err error
+	if input.TradedAt, err = parseImportDate(field("date")); err != nil {
+		return input, err
+	}
+	if input.Quantity, err = number("quantity"); err != nil {
+		return input, err
+	}
+	if input.Price, err = number("price"); err != nil {
+		return input, err
+	}
+	if input.Fee, err = number("fee"); err != nil {
+		return input, err
+	}
+	if input.Amount, err = number("amount"); err != nil {
+		return input, err
+	}
+	return input, nil
+}
+
+func parseImportDate(raw string) (time.Time, error) {
+	for _, layout := range importDateLayouts {
+		if t, err := time.ParseInLocation(layout, raw, bangkok); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: date %q is not YYYY-MM-DD or RFC 3339", ErrInvalidTransaction, raw)
+}
+
+func blankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
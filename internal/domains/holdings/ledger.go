@@ -0,0 +1,124 @@
+package holdings
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+const (
+	MethodFIFO    = "fifo"
+	MethodAverage = "average"
+
+	// epsilon absorbs float drift when comparing share quantities.
+	epsilon = 1e-9
+)
+
+// lot is a FIFO tax lot; cost includes the buy fee spread over the shares.
+type lot struct {
+	quantity float64
+	unitCost float64
+}
+
+// book replays one symbol's transactions under a cost basis method.
+type book struct {
+	method    string
+	lots      []lot
+	quantity  float64
+	cost      float64
+	realized  float64
+	dividends float64
+}
+
+func newBook(method string) *book {
+	return &book{method: method}
+}
+
+// apply books t. Selling more shares than held returns ErrOversold.
+func (b *book) apply(t models.HoldingTransaction) error {
+	switch t.Type {
+	case models.HoldingBuy:
+		cost := t.Quantity*t.Price + t.Fee
+		b.quantity += t.Quantity
+		b.cost += cost
+		if b.method == MethodFIFO {
+			b.lots = append(b.lots, lot{quantity: t.Quantity, unitCost: cost / t.Quantity})
+		}
+	case models.HoldingSell:
+		if t.Quantity > b.quantity+epsilon {
+			return fmt.Errorf("%w: %s sells %g on %s but holds %g",
+				ErrOversold, t.Symbol, t.Quantity, time.Time(t.TradedAt).Format("2006-01-02"), b.quantity)
+		}
+		removed := b.removeCost(t.Quantity)
+		b.realized += t.Quantity*t.Price - t.Fee - removed
+		b.quantity -= t.Quantity
+		b.cost -= removed
+		if b.quantity <= epsilon {
+			b.quantity, b.cost, b.lots = 0, 0, nil
+		}
+	case models.HoldingDividend:
+		b.dividends += t.Amount - t.Fee
+	}
+	return nil
+}
+
+// removeCost takes the cost of quantity shares off the book: the oldest lots
+// first under FIFO, the running average otherwise.
+func (b *book) removeCost(quantity float64) float64 {
+	if b.method != MethodFIFO {
+		if b.quantity <= 0 {
+			return 0
+		}
+		return b.cost / b.quantity * quantity
+	}
+	removed := 0.0
+	for quantity > epsilon && len(b.lots) > 0 {
+		head := &b.lots[0]
+		take := quantity
+		if head.quantity < take {
+			take = head.quantity
+		}
+		removed += take * head.unitCost
+		head.quantity -= take
+		quantity -= take
+		if head.quantity <= epsilon {
+			b.lots = b.lots[1:]
+		}
+	}
+	return removed
+}
+
+func (b *book) avgCost() float64 {
+	if b.quantity <= 0 {
+		return 0
+	}
+	return b.cost / b.quantity
+}
+
+// sortByTrade orders transactions by trade time, keeping insertion order for
+// ties.
+func sortByTrade(transactions []models.HoldingTransaction) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return time.Time(transactions[i].TradedAt).Before(time.Time(transactions[j].TradedAt))
+	})
+}
+
+// checkLedger replays transactions per symbol and reports the first oversell.
+func checkLedger(transactions []models.HoldingTransaction) error {
+	sorted := append([]models.HoldingTransaction(nil), transactions...)
+	sortByTrade(sorted)
+	books := make(map[string]*book)
+	for _, t := range sorted {
+		b, ok := books[t.Symbol]
+		if !ok {
+			b = newBook(MethodAverage)
+			books[t.Symbol] = b
+		}
+		if err := b.apply(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package holdings
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+func trade(kind string, day int, quantity, price, fee float64) models.HoldingTransaction {
+	return models.HoldingTransaction{
+		Symbol:   "AAPL",
+		Type:     kind,
+		Quantity: quantity,
+		Price:    price,
+		Fee:      fee,
+		Currency: "USD",
+		TradedAt: models.NewLocalTime(time.Date(2025, 1, day, 10, 0, 0, 0, bangkok)),
+	}
+}
+
+type LedgerSuite struct {
+	suite.Suite
+}
+
+func (s *LedgerSuite) replay(method string, transactions ...models.HoldingTransaction) *book {
+	b := newBook(method)
+	for _, t := range transactions {
+		s.Require().NoError(b.apply(t))
+	}
+	return b
+}
+
+func (s *LedgerSuite) TestFIFO_SellsOldestLotsFirst() {
+	b := s.replay(MethodFIFO,
+		trade(models.HoldingBuy, 2, 10, 100, 10), // 101 per share
+		trade(models.HoldingBuy, 3, 10, 200, 0),
+		trade(models.HoldingSell, 4, 15, 300, 5),
+	)
+
+	s.InDelta(5, b.quantity, 1e-9)
+	s.InDelta(1000, b.cost, 1e-9)
+	s.InDelta(200, b.avgCost(), 1e-9)
+	s.InDelta(15*300-5-(1010+1000), b.realized, 1e-9)
+}
+
+func (s *LedgerSuite) TestAverage_SellsAtRunningAverage() {
+	b := s.replay(MethodAverage,
+		trade(models.HoldingBuy, 2, 10, 100, 10),
+		trade(models.HoldingBuy, 3, 10, 200, 0),
+		trade(models.HoldingSell, 4, 15, 300, 5),
+	)
+
+	s.InDelta(5, b.quantity, 1e-9)
+	s.InDelta(150.5*5, b.cost, 1e-9)
+	s.InDelta(15*300-5-150.5*15, b.realized, 1e-9)
+}
+
+func (s *LedgerSuite) TestDividendsAreNetOfWithholding() {
+	dividend := trade(models.HoldingDividend, 5, 0, 0, 1.5)
+	dividend.Amount = 10
+
+	b := s.replay(MethodFIFO, trade(models.HoldingBuy, 2, 10, 100, 0), dividend)
+
+	s.InDelta(8.5, b.dividends, 1e-9)
+	s.InDelta(10, b.quantity, 1e-9)
+}
+
+func (s *LedgerSuite) TestFullSellClearsBook() {
+	b := s.replay(MethodFIFO,
+		trade(models.HoldingBuy, 2, 3, 100, 0),
+		trade(models.HoldingSell, 3, 3, 90, 0),
+	)
+
+	s.Zero(b.quantity)
+	s.Zero(b.cost)
+	s.Empty(b.lots)
+	s.InDelta(-30, b.realized, 1e-9)
+}
+
+func (s *LedgerSuite) TestCheckLedger_RejectsOversellInTradeOrder() {
+	err := checkLedger([]models.HoldingTransaction{
+		trade(models.HoldingSell, 3, 5, 100, 0),
+		trade(models.HoldingBuy, 4, 5, 100, 0),
+	})
+	s.True(errors.Is(err, ErrOversold))
+
+	err = checkLedger([]models.HoldingTransaction{
+		trade(models.HoldingSell, 5, 5, 100, 0),
+		trade(models.HoldingBuy, 4, 5, 100, 0),
+	})
+	s.NoError(err)
+}
+
+func TestLedgerSuite(t *testing.T) {
+	suite.Run(t, new(LedgerSuite))
+}
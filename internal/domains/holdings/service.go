@@ -0,0 +1,657 @@
+package holdings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/domains/fx_rates"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+)
+
+const (
+	defaultMaxTransactions = 10000
+	defaultHistoryDays     = 90
+	maxHistoryDays         = 1825
+)
+
+var maxTransactions = getEnvInt("HOLDING_MAX_TRANSACTIONS", defaultMaxTransactions)
+
+var bangkok = time.FixedZone("Asia/Bangkok", 7*60*60)
+
+var (
+	ErrInvalidUser         = errors.New("invalid user id")
+	ErrInvalidTransaction  = errors.New("invalid transaction")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrUnknownSymbol       = errors.New("symbol is not tracked")
+	ErrOversold            = errors.New("sell exceeds holding")
+	ErrTooManyTransactions = errors.New("transaction limit reached")
+	ErrInvalidImport       = errors.New("invalid import")
+	ErrInvalidQuery        = errors.New("invalid query")
+)
+
+type TransactionInput struct {
+	Symbol   string
+	Type     string
+	Quantity float64
+	Price    float64
+	Fee      float64
+	Amount   float64
+	TradedAt time.Time
+	Note     string
+}
+
+// Position is one symbol's holding marked to the latest stored quote. Closed
+// positions are kept for their realized P&L and dividends. LastPrice is nil
+// when the symbol has no quote, in which case the position is valued at cost.
+type Position struct {
+	Symbol               string   `json:"symbol"`
+	Currency             string   `json:"currency"`
+	Quantity             float64  `json:"quantity"`
+	AvgCost              float64  `json:"avg_cost"`
+	CostBasis            float64  `json:"cost_basis"`
+	LastPrice            *float64 `json:"last_price"`
+	MarketValue          float64  `json:"market_value"`
+	UnrealizedPnL        float64  `json:"unrealized_pnl"`
+	UnrealizedPnLPercent float64  `json:"unrealized_pnl_percent"`
+	RealizedPnL          float64  `json:"realized_pnl"`
+	Dividends            float64  `json:"dividends"`
+	FXRate               *float64 `json:"fx_rate,omitempty"`
+}
+
+// CurrencyTotal sums the positions held in one currency.
+type CurrencyTotal struct {
+	Currency      string  `json:"currency"`
+	CostBasis     float64 `json:"cost_basis"`
+	MarketValue   float64 `json:"market_value"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+	RealizedPnL   float64 `json:"realized_pnl"`
+	Dividends     float64 `json:"dividends"`
+}
+
+type PositionsReport struct {
+	Method    string          `json:"method"`
+	Positions []Position      `json:"positions"`
+	Totals    []CurrencyTotal `json:"totals"`
+}
+
+type ValuePoint struct {
+	Date        models.LocalDate `json:"date"`
+	MarketValue float64          `json:"market_value"`
+	CostBasis   float64          `json:"cost_basis"`
+}
+
+type HistorySeries struct {
+	Currency string       `json:"currency"`
+	Points   []ValuePoint `json:"points"`
+}
+
+type HistoryReport struct {
+	Method string           `json:"method"`
+	From   models.LocalDate `json:"from"`
+	To     models.LocalDate `json:"to"`
+	Series []HistorySeries  `json:"series"`
+}
+
+type HoldingService interface {
+	ListTransactions(ctx context.Context, userID, symbol string) ([]models.HoldingTransaction, error)
+	AddTransaction(ctx context.Context, userID string, input TransactionInput) (*models.HoldingTransaction, error)
+	DeleteTransaction(ctx context.Context, userID string, id uuid.UUID) error
+	ImportCSV(ctx context.Context, userID string, data []byte) ([]models.HoldingTransaction, error)
+	Positions(ctx context.Context, userID, method, currency string) (*PositionsReport, error)
+	History(ctx context.Context, userID string, from, to time.Time, method, currency string) (*HistoryReport, error)
+}
+
+type HoldingServiceImpl struct {
+	repo      repository.HoldingRepository
+	stockRepo repository.StockRepository
+	quoteRepo repository.StockQuoteRepository
+	dailyRepo repository.StockDailyRepository
+	fx        fx_rates.FXRateService
+	now       func() time.Time
+}
+
+func NewHoldingService(
+	repo repository.HoldingRepository,
+	stockRepo repository.StockRepository,
+	quoteRepo repository.StockQuoteRepository,
+	dailyRepo repository.StockDailyRepository,
+	fx fx_rates.FXRateService,
+) HoldingService {
+	return &HoldingServiceImpl{
+		repo:      repo,
+		stockRepo: stockRepo,
+		quoteRepo: quoteRepo,
+		dailyRepo: dailyRepo,
+		fx:        fx,
+		now:       time.Now,
+	}
+}
+
+func (s *HoldingServiceImpl) ListTransactions(ctx context.Context, userID, symbol string) ([]models.HoldingTransaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	symbol = strings.TrimSpace(symbol)
+	if symbol != "" {
+		stock, err := s.findStock(symbol)
+		if err != nil {
+			return nil, err
+		}
+		symbol = stock.Symbol
+	}
+	return s.repo.FindByUser(owner, symbol)
+}
+
+// AddTransaction records a trade or dividend after checking that no sell in
+// the symbol's history ends up selling more than was held.
+func (s *HoldingServiceImpl) AddTransaction(ctx context.Context, userID string, input TransactionInput) (*models.HoldingTransaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := s.buildTransaction(owner, input, map[string]*models.Stock{})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCapacity(owner, 1); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.FindByUser(owner, transaction.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLedger(append(existing, *transaction)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(transaction); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func (s *HoldingServiceImpl) DeleteTransaction(ctx context.Context, userID string, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return err
+	}
+	transaction, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTransactionNotFound
+		}
+		return err
+	}
+	if transaction.UserID != owner {
+		return ErrTransactionNotFound
+	}
+	existing, err := s.repo.FindByUser(owner, transaction.Symbol)
+	if err != nil {
+		return err
+	}
+	remaining := make([]models.HoldingTransaction, 0, len(existing))
+	for _, t := range existing {
+		if t.ID != id {
+			remaining = append(remaining, t)
+		}
+	}
+	if err := checkLedger(remaining); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// Positions replays every transaction of the user under method and marks the
+// open quantity to the latest quote. A non-empty currency converts every
+// position at the latest FX rate, leaving a single total.
+func (s *HoldingServiceImpl) Positions(ctx context.Context, userID, method, currency string) (*PositionsReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	method, err = validMethod(method)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := s.repo.FindByUser(owner, "")
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PositionsReport{Method: method, Positions: []Position{}}
+	for _, group := range groupBySymbol(transactions) {
+		b := newBook(method)
+		for _, t := range group.transactions {
+			if err := b.apply(t); err != nil {
+				return nil, err
+			}
+		}
+		position := Position{
+			Symbol:      group.symbol,
+			Currency:    group.currency,
+			Quantity:    b.quantity,
+			AvgCost:     b.avgCost(),
+			CostBasis:   b.cost,
+			MarketValue: b.cost,
+			RealizedPnL: b.realized,
+			Dividends:   b.dividends,
+		}
+		if b.quantity > 0 {
+			quote, err := s.quoteRepo.FindLatestBySymbol(group.symbol)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			if quote != nil {
+				price := quote.PriceCurrent
+				position.LastPrice = &price
+				position.MarketValue = b.quantity * price
+			}
+		}
+		position.UnrealizedPnL = position.MarketValue - position.CostBasis
+		position.UnrealizedPnLPercent = percent(position.UnrealizedPnL, position.CostBasis)
+		report.Positions = append(report.Positions, position)
+	}
+	if strings.TrimSpace(currency) != "" {
+		if err := s.convertPositions(ctx, report.Positions, currency); err != nil {
+			return nil, err
+		}
+	}
+	report.Totals = currencyTotals(report.Positions)
+	return report, nil
+}
+
+func (s *HoldingServiceImpl) convertPositions(ctx context.Context, positions []Position, currency string) error {
+	now := s.now()
+	converter, err := s.fx.Converter(ctx, currency, now, now)
+	if err != nil {
+		return err
+	}
+	for i := range positions {
+		position := &positions[i]
+		rate, err := converter.Rate(position.Currency, now)
+		if err != nil {
+			return fmt.Errorf("%s: %w", position.Symbol, err)
+		}
+		position.Currency = converter.Target()
+		position.FXRate = &rate
+		position.AvgCost *= rate
+		position.CostBasis *= rate
+		position.MarketValue *= rate
+		position.UnrealizedPnL *= rate
+		position.RealizedPnL *= rate
+		position.Dividends *= rate
+		if position.LastPrice != nil {
+			price := *position.LastPrice * rate
+			position.LastPrice = &price
+		}
+	}
+	return nil
+}
+
+func currencyTotals(positions []Position) []CurrencyTotal {
+	totals := make(map[string]*CurrencyTotal)
+	for _, position := range positions {
+		total, ok := totals[position.Currency]
+		if !ok {
+			total = &CurrencyTotal{Currency: position.Currency}
+			totals[position.Currency] = total
+		}
+		total.CostBasis += position.CostBasis
+		total.MarketValue += position.MarketValue
+		total.UnrealizedPnL += position.UnrealizedPnL
+		total.RealizedPnL += position.RealizedPnL
+		total.Dividends += position.Dividends
+	}
+	result := make([]CurrencyTotal, 0, len(totals))
+	for _, currency := range sortedKeys(totals) {
+		result = append(result, *totals[currency])
+	}
+	return result
+}
+
+// History values the holdings at each stored session close between from and
+// to, one series per currency. Sessions without a close for a symbol carry
+// its previous close forward; symbols never priced are valued at cost. A
+// non-empty currency folds the series into one, converting each session at
+// that day's FX rate.
+func (s *HoldingServiceImpl) History(ctx context.Context, userID string, from, to time.Time, method, currency string) (*HistoryReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	method, err = validMethod(method)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err = s.historyRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := s.repo.FindByUser(owner, "")
+	if err != nil {
+		return nil, err
+	}
+
+	type symbolCloses struct {
+		group  symbolGroup
+		seed   *float64
+		closes map[string]float64
+	}
+	var (
+		symbols []symbolCloses
+		dates   = make(map[string]time.Time)
+	)
+	for _, group := range groupBySymbol(transactions) {
+		rows, err := s.dailyRepo.FindBySymbolBetween(group.symbol, from, to)
+		if err != nil {
+			return nil, err
+		}
+		entry := symbolCloses{group: group, closes: make(map[string]float64, len(rows))}
+		for _, row := range rows {
+			day := time.Time(row.TradeDate).In(bangkok)
+			key := day.Format("2006-01-02")
+			entry.closes[key] = row.PriceClose
+			dates[key] = day
+		}
+		previous, err := s.dailyRepo.FindRecentBySymbol(group.symbol, from, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(previous) > 0 {
+			price := previous[0].PriceClose
+			entry.seed = &price
+		}
+		symbols = append(symbols, entry)
+	}
+	keys := make([]string, 0, len(dates))
+	for key := range dates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	series := make(map[string][]ValuePoint)
+	for _, entry := range symbols {
+		currency := entry.group.currency
+		if _, ok := series[currency]; !ok {
+			points := make([]ValuePoint, len(keys))
+			for i, key := range keys {
+				points[i].Date = models.NewLocalDate(dates[key])
+			}
+			series[currency] = points
+		}
+		points := series[currency]
+		b := newBook(method)
+		next := 0
+		last := entry.seed
+		for i, key := range keys {
+			endOfDay := dates[key].AddDate(0, 0, 1)
+			for next < len(entry.group.transactions) && time.Time(entry.group.transactions[next].TradedAt).Before(endOfDay) {
+				if err := b.apply(entry.group.transactions[next]); err != nil {
+					return nil, err
+				}
+				next++
+			}
+			if price, ok := entry.closes[key]; ok {
+				last = &price
+			}
+			if b.quantity <= 0 {
+				continue
+			}
+			value := b.cost
+			if last != nil {
+				value = b.quantity * *last
+			}
+			points[i].MarketValue += value
+			points[i].CostBasis += b.cost
+		}
+	}
+
+	report := &HistoryReport{
+		Method: method,
+		From:   models.NewLocalDate(from),
+		To:     models.NewLocalDate(to),
+		Series: []HistorySeries{},
+	}
+	if strings.TrimSpace(currency) != "" {
+		converted, err := s.convertSeries(ctx, series, keys, dates, from, to, currency)
+		if err != nil {
+			return nil, err
+		}
+		report.Series = append(report.Series, *converted)
+		return report, nil
+	}
+	for _, currency := range sortedKeys(series) {
+		report.Series = append(report.Series, HistorySeries{Currency: currency, Points: series[currency]})
+	}
+	return report, nil
+}
+
+func (s *HoldingServiceImpl) convertSeries(
+	ctx context.Context,
+	series map[string][]ValuePoint,
+	keys []string,
+	dates map[string]time.Time,
+	from, to time.Time,
+	currency string,
+) (*HistorySeries, error) {
+	converter, err := s.fx.Converter(ctx, currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]ValuePoint, len(keys))
+	for i, key := range keys {
+		points[i].Date = models.NewLocalDate(dates[key])
+	}
+	for _, source := range sortedKeys(series) {
+		for i, point := range series[source] {
+			rate, err := converter.Rate(source, dates[keys[i]])
+			if err != nil {
+				return nil, err
+			}
+			points[i].MarketValue += point.MarketValue * rate
+			points[i].CostBasis += point.CostBasis * rate
+		}
+	}
+	return &HistorySeries{Currency: converter.Target(), Points: points}, nil
+}
+
+// historyRange defaults to the last 90 days and snaps both ends to Bangkok
+// calendar days.
+func (s *HoldingServiceImpl) historyRange(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = s.now()
+	}
+	to = startOfDay(to)
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultHistoryDays)
+	}
+	from = startOfDay(from)
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: from must not be after to", ErrInvalidQuery)
+	}
+	if to.Sub(from) > maxHistoryDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: history is limited to %d days", ErrInvalidQuery, maxHistoryDays)
+	}
+	return from, to, nil
+}
+
+// buildTransaction validates input and stamps it with the stock's stored
+// symbol and currency. stocks caches lookups across an import.
+func (s *HoldingServiceImpl) buildTransaction(owner uuid.UUID, input TransactionInput, stocks map[string]*models.Stock) (*models.HoldingTransaction, error) {
+	transaction := &models.HoldingTransaction{
+		UserID:   owner,
+		Type:     strings.ToLower(strings.TrimSpace(input.Type)),
+		Quantity: input.Quantity,
+		Price:    input.Price,
+		Fee:      input.Fee,
+		Amount:   input.Amount,
+		Note:     strings.TrimSpace(input.Note),
+	}
+	for _, v := range []float64{input.Quantity, input.Price, input.Fee, input.Amount} {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+			return nil, fmt.Errorf("%w: amounts must be non-negative numbers", ErrInvalidTransaction)
+		}
+	}
+	switch transaction.Type {
+	case models.HoldingBuy, models.HoldingSell:
+		if input.Quantity <= 0 || input.Price <= 0 {
+			return nil, fmt.Errorf("%w: %s needs a positive quantity and price", ErrInvalidTransaction, transaction.Type)
+		}
+		transaction.Amount = 0
+	case models.HoldingDividend:
+		if input.Amount <= 0 {
+			return nil, fmt.Errorf("%w: dividend needs a positive amount", ErrInvalidTransaction)
+		}
+	default:
+		return nil, fmt.Errorf("%w: type must be buy, sell or dividend", ErrInvalidTransaction)
+	}
+	if input.TradedAt.IsZero() {
+		return nil, fmt.Errorf("%w: traded_at is required", ErrInvalidTransaction)
+	}
+	if input.TradedAt.After(s.now().Add(24 * time.Hour)) {
+		return nil, fmt.Errorf("%w: traded_at is in the future", ErrInvalidTransaction)
+	}
+	if len(transaction.Note) > 500 {
+		return nil, fmt.Errorf("%w: note is limited to 500 characters", ErrInvalidTransaction)
+	}
+	transaction.TradedAt = models.NewLocalTime(input.TradedAt)
+
+	key := strings.ToUpper(strings.TrimSpace(input.Symbol))
+	if key == "" {
+		return nil, fmt.Errorf("%w: symbol is required", ErrInvalidTransaction)
+	}
+	stock, ok := stocks[key]
+	if !ok {
+		var err error
+		stock, err = s.findStock(key)
+		if err != nil {
+			return nil, err
+		}
+		stocks[key] = stock
+	}
+	transaction.Symbol = stock.Symbol
+	transaction.Currency = stock.Currency
+	return transaction, nil
+}
+
+func (s *HoldingServiceImpl) findStock(symbol string) (*models.Stock, error) {
+	stock, err := s.stockRepo.FindBySymbol(strings.TrimSpace(symbol))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, strings.TrimSpace(symbol))
+		}
+		return nil, err
+	}
+	return stock, nil
+}
+
+func (s *HoldingServiceImpl) checkCapacity(owner uuid.UUID, adding int) error {
+	count, err := s.repo.CountByUser(owner)
+	if err != nil {
+		return err
+	}
+	if count+int64(adding) > int64(maxTransactions) {
+		return fmt.Errorf("%w: at most %d transactions", ErrTooManyTransactions, maxTransactions)
+	}
+	return nil
+}
+
+type symbolGroup struct {
+	symbol       string
+	currency     string
+	transactions []models.HoldingTransaction
+}
+
+// groupBySymbol splits trade-ordered transactions per symbol, sorted by symbol.
+func groupBySymbol(transactions []models.HoldingTransaction) []symbolGroup {
+	index := make(map[string]int)
+	var groups []symbolGroup
+	for _, t := range transactions {
+		i, ok := index[t.Symbol]
+		if !ok {
+			i = len(groups)
+			index[t.Symbol] = i
+			groups = append(groups, symbolGroup{symbol: t.Symbol, currency: t.Currency})
+		}
+		groups[i].transactions = append(groups[i].transactions, t)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].symbol < groups[j].symbol })
+	return groups
+}
+
+func validMethod(method string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(method)) {
+	case "", MethodFIFO:
+		return MethodFIFO, nil
+	case MethodAverage:
+		return MethodAverage, nil
+	}
+	return "", fmt.Errorf("%w: method must be fifo or average", ErrInvalidQuery)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func startOfDay(t time.Time) time.Time {
+	local := t.In(bangkok)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, bangkok)
+}
+
+func percent(value, base float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return value / base * 100
+}
+
+func parseUserID(userID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(strings.TrimSpace(userID))
+	if err != nil {
+		return uuid.Nil, ErrInvalidUser
+	}
+	return id, nil
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,270 @@
+package holdings
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/domains/fx_rates"
+	fxratesmock "sun-stockanalysis-api/internal/mocks/domains/fx_rates"
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type HoldingServiceSuite struct {
+	suite.Suite
+	repo      *repositorymock.MockHoldingRepository
+	stockRepo *repositorymock.MockStockRepository
+	quoteRepo *repositorymock.MockStockQuoteRepository
+	dailyRepo *repositorymock.MockStockDailyRepository
+	fx        *fxratesmock.MockFXRateService
+	service   *HoldingServiceImpl
+	userID    uuid.UUID
+}
+
+func (s *HoldingServiceSuite) SetupTest() {
+	s.repo = repositorymock.NewMockHoldingRepository(s.T())
+	s.stockRepo = repositorymock.NewMockStockRepository(s.T())
+	s.quoteRepo = repositorymock.NewMockStockQuoteRepository(s.T())
+	s.dailyRepo = repositorymock.NewMockStockDailyRepository(s.T())
+	s.fx = fxratesmock.NewMockFXRateService(s.T())
+	s.service = NewHoldingService(s.repo, s.stockRepo, s.quoteRepo, s.dailyRepo, s.fx).(*HoldingServiceImpl)
+	s.service.now = func() time.Time { return time.Date(2025, 1, 10, 12, 0, 0, 0, bangkok) }
+	s.userID = uuid.New()
+}
+
+func (s *HoldingServiceSuite) owned(transactions ...models.HoldingTransaction) []models.HoldingTransaction {
+	for i := range transactions {
+		transactions[i].ID = uuid.New()
+		transactions[i].UserID = s.userID
+	}
+	return transactions
+}
+
+func (s *HoldingServiceSuite) TestAddTransaction_StampsStockCurrency() {
+	s.stockRepo.EXPECT().FindBySymbol("AAPL").Return(&models.Stock{Symbol: "AAPL", Currency: "USD"}, nil)
+	s.repo.EXPECT().CountByUser(s.userID).Return(0, nil)
+	s.repo.EXPECT().FindByUser(s.userID, "AAPL").Return(nil, nil)
+	s.repo.EXPECT().Create(mock.Anything).Return(nil)
+
+	transaction, err := s.service.AddTransaction(context.Background(), s.userID.String(), TransactionInput{
+		Symbol: "aapl", Type: "BUY", Quantity: 2, Price: 100, TradedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, bangkok),
+	})
+
+	s.Require().NoError(err)
+	s.Equal("AAPL", transaction.Symbol)
+	s.Equal("USD", transaction.Currency)
+	s.Equal(models.HoldingBuy, transaction.Type)
+}
+
+func (s *HoldingServiceSuite) TestAddTransaction_RejectsOversell() {
+	s.stockRepo.EXPECT().FindBySymbol("AAPL").Return(&models.Stock{Symbol: "AAPL", Currency: "USD"}, nil)
+	s.repo.EXPECT().CountByUser(s.userID).Return(1, nil)
+	s.repo.EXPECT().FindByUser(s.userID, "AAPL").Return(s.owned(trade(models.HoldingBuy, 5, 2, 100, 0)), nil)
+
+	_, err := s.service.AddTransaction(context.Background(), s.userID.String(), TransactionInput{
+		Symbol: "AAPL", Type: "sell", Quantity: 1, Price: 100, TradedAt: time.Date(2025, 1, 4, 0, 0, 0, 0, bangkok),
+	})
+
+	s.True(errors.Is(err, ErrOversold))
+}
+
+func (s *HoldingServiceSuite) TestAddTransaction_Validates() {
+	at := time.Date(2025, 1, 2, 0, 0, 0, 0, bangkok)
+	cases := []TransactionInput{
+		{Symbol: "AAPL", Type: "split", Quantity: 1, Price: 1, TradedAt: at},
+		{Symbol: "AAPL", Type: "buy", Quantity: 0, Price: 1, TradedAt: at},
+		{Symbol: "AAPL", Type: "buy", Quantity: 1, Price: 1, Fee: -1, TradedAt: at},
+		{Symbol: "AAPL", Type: "dividend", TradedAt: at},
+		{Symbol: "AAPL", Type: "buy", Quantity: 1, Price: 1},
+		{Symbol: "AAPL", Type: "buy", Quantity: 1, Price: 1, TradedAt: at.AddDate(0, 1, 0)},
+	}
+	for _, input := range cases {
+		_, err := s.service.AddTransaction(context.Background(), s.userID.String(), input)
+		s.True(errors.Is(err, ErrInvalidTransaction), "%+v", input)
+	}
+}
+
+func (s *HoldingServiceSuite) TestDeleteTransaction_KeepsLaterSellsCovered() {
+	transactions := s.owned(trade(models.HoldingBuy, 2, 2, 100, 0), trade(models.HoldingSell, 3, 2, 110, 0))
+	s.repo.EXPECT().FindByID(transactions[0].ID).Return(&transactions[0], nil)
+	s.repo.EXPECT().FindByUser(s.userID, "AAPL").Return(transactions, nil)
+
+	err := s.service.DeleteTransaction(context.Background(), s.userID.String(), transactions[0].ID)
+
+	s.True(errors.Is(err, ErrOversold))
+}
+
+func (s *HoldingServiceSuite) TestDeleteTransaction_HidesOtherUsersRows() {
+	id := uuid.New()
+	s.repo.EXPECT().FindByID(id).Return(&models.HoldingTransaction{ID: id, UserID: uuid.New()}, nil)
+
+	err := s.service.DeleteTransaction(context.Background(), s.userID.String(), id)
+
+	s.True(errors.Is(err, ErrTransactionNotFound))
+}
+
+func (s *HoldingServiceSuite) TestImportCSV_StoresAllRows() {
+	csv := "Date,Symbol,Type,Quantity,Price,Fee,Amount,Note\n" +
+		"2025-01-02,aapl,buy,10,\"1,000.50\",1,,first\n" +
+		"\n" +
+		"2025-01-03T10:00:00+07:00,AAPL,dividend,,,0.5,5,\n" +
+		"2025-01-04,AAPL,sell,4,1100,1,,\n"
+	s.stockRepo.EXPECT().FindBySymbol("AAPL").Return(&models.Stock{Symbol: "AAPL", Currency: "USD"}, nil).Once()
+	s.repo.EXPECT().CountByUser(s.userID).Return(0, nil)
+	s.repo.EXPECT().FindByUser(s.userID, "").Return(nil, nil)
+	s.repo.EXPECT().CreateBatch(mock.Anything).Return(nil)
+
+	imported, err := s.service.ImportCSV(context.Background(), s.userID.String(), []byte(csv))
+
+	s.Require().NoError(err)
+	s.Require().Len(imported, 3)
+	s.Equal(1000.5, imported[0].Price)
+	s.Equal("first", imported[0].Note)
+	s.Equal(models.HoldingDividend, imported[1].Type)
+	s.Equal(5.0, imported[1].Amount)
+}
+
+func (s *HoldingServiceSuite) TestImportCSV_ReportsEveryBadLine() {
+	csv := "date,symbol,type,quantity,price\n" +
+		"02/01/2025,AAPL,buy,1,1\n" +
+		"2025-01-02,NOPE,buy,1,1\n" +
+		"2025-01-02,AAPL,buy,x,1\n"
+	s.stockRepo.EXPECT().FindBySymbol("NOPE").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.service.ImportCSV(context.Background(), s.userID.String(), []byte(csv))
+
+	s.True(errors.Is(err, ErrInvalidImport))
+	s.Contains(err.Error(), "line 2")
+	s.Contains(err.Error(), "line 3")
+	s.Contains(err.Error(), "line 4")
+}
+
+func (s *HoldingServiceSuite) TestImportCSV_RequiresColumns() {
+	_, err := s.service.ImportCSV(context.Background(), s.userID.String(), []byte("symbol,quantity\nAAPL,1\n"))
+
+	s.True(errors.Is(err, ErrInvalidImport))
+}
+
+func (s *HoldingServiceSuite) TestPositions_MarksToMarketPerCurrency() {
+	ptt := trade(models.HoldingBuy, 2, 100, 30, 0)
+	ptt.Symbol, ptt.Currency = "PTT", "THB"
+	s.repo.EXPECT().FindByUser(s.userID, "").Return(s.owned(
+		trade(models.HoldingBuy, 2, 10, 100, 0),
+		ptt,
+		trade(models.HoldingSell, 3, 5, 120, 0),
+	), nil)
+	s.quoteRepo.EXPECT().FindLatestBySymbol("AAPL").Return(&models.StockQuote{PriceCurrent: 110}, nil)
+	s.quoteRepo.EXPECT().FindLatestBySymbol("PTT").Return(nil, gorm.ErrRecordNotFound)
+
+	report, err := s.service.Positions(context.Background(), s.userID.String(), "", "")
+
+	s.Require().NoError(err)
+	s.Equal(MethodFIFO, report.Method)
+	s.Require().Len(report.Positions, 2)
+	aapl := report.Positions[0]
+	s.Equal("AAPL", aapl.Symbol)
+	s.InDelta(5, aapl.Quantity, 1e-9)
+	s.InDelta(550, aapl.MarketValue, 1e-9)
+	s.InDelta(50, aapl.UnrealizedPnL, 1e-9)
+	s.InDelta(100, aapl.RealizedPnL, 1e-9)
+	s.Nil(report.Positions[1].LastPrice)
+	s.InDelta(3000, report.Positions[1].MarketValue, 1e-9)
+	s.Require().Len(report.Totals, 2)
+	s.Equal("THB", report.Totals[0].Currency)
+	s.Equal("USD", report.Totals[1].Currency)
+}
+
+func (s *HoldingServiceSuite) TestHistory_ValuesHoldingsAtEachClose() {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, bangkok) }
+	s.repo.EXPECT().FindByUser(s.userID, "").Return(s.owned(
+		trade(models.HoldingBuy, 7, 10, 100, 0),
+		trade(models.HoldingSell, 9, 4, 130, 0),
+	), nil)
+	s.dailyRepo.EXPECT().FindBySymbolBetween("AAPL", day(6), day(10)).Return([]models.StockDaily{
+		{PriceClose: 105, TradeDate: models.NewLocalDate(day(6))},
+		{PriceClose: 110, TradeDate: models.NewLocalDate(day(7))},
+		{PriceClose: 120, TradeDate: models.NewLocalDate(day(9))},
+	}, nil)
+	s.dailyRepo.EXPECT().FindRecentBySymbol("AAPL", day(6), 1).Return(nil, nil)
+
+	report, err := s.service.History(context.Background(), s.userID.String(), day(6), day(10), "average", "")
+
+	s.Require().NoError(err)
+	s.Require().Len(report.Series, 1)
+	points := report.Series[0].Points
+	s.Require().Len(points, 3)
+	s.Zero(points[0].MarketValue)
+	s.InDelta(1100, points[1].MarketValue, 1e-9)
+	s.InDelta(1000, points[1].CostBasis, 1e-9)
+	s.InDelta(720, points[2].MarketValue, 1e-9)
+	s.InDelta(600, points[2].CostBasis, 1e-9)
+}
+
+func (s *HoldingServiceSuite) TestHistory_RejectsInvertedRange() {
+	_, err := s.service.History(context.Background(), s.userID.String(),
+		time.Date(2025, 1, 9, 0, 0, 0, 0, bangkok), time.Date(2025, 1, 2, 0, 0, 0, 0, bangkok), "", "")
+
+	s.True(errors.Is(err, ErrInvalidQuery))
+}
+
+func (s *HoldingServiceSuite) TestPositions_ConvertsIntoOneCurrency() {
+	ptt := trade(models.HoldingBuy, 2, 100, 30, 0)
+	ptt.Symbol, ptt.Currency = "PTT", "THB"
+	s.repo.EXPECT().FindByUser(s.userID, "").Return(s.owned(trade(models.HoldingBuy, 2, 10, 100, 0), ptt), nil)
+	s.quoteRepo.EXPECT().FindLatestBySymbol("AAPL").Return(&models.StockQuote{PriceCurrent: 110}, nil)
+	s.quoteRepo.EXPECT().FindLatestBySymbol("PTT").Return(&models.StockQuote{PriceCurrent: 33}, nil)
+	converter := fx_rates.NewConverter("THB", "USD", []models.FXRate{
+		{Base: "USD", Quote: "THB", Rate: 36, RateDate: models.NewLocalDate(s.service.now())},
+	}, nil)
+	s.fx.EXPECT().Converter(mock.Anything, "THB", mock.Anything, mock.Anything).Return(converter, nil)
+
+	report, err := s.service.Positions(context.Background(), s.userID.String(), "", "THB")
+
+	s.Require().NoError(err)
+	s.Require().Len(report.Positions, 2)
+	s.Equal("THB", report.Positions[0].Currency)
+	s.Equal(36.0, *report.Positions[0].FXRate)
+	s.InDelta(1100*36, report.Positions[0].MarketValue, 1e-6)
+	s.Equal(1.0, *report.Positions[1].FXRate)
+	s.Require().Len(report.Totals, 1)
+	s.Equal("THB", report.Totals[0].Currency)
+	s.InDelta(1100*36+3300, report.Totals[0].MarketValue, 1e-6)
+	s.InDelta(1000*36+3000, report.Totals[0].CostBasis, 1e-6)
+}
+
+func (s *HoldingServiceSuite) TestHistory_ConvertsEachSessionAtItsRate() {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, bangkok) }
+	s.repo.EXPECT().FindByUser(s.userID, "").Return(s.owned(trade(models.HoldingBuy, 6, 10, 100, 0)), nil)
+	s.dailyRepo.EXPECT().FindBySymbolBetween("AAPL", day(6), day(8)).Return([]models.StockDaily{
+		{PriceClose: 100, TradeDate: models.NewLocalDate(day(6))},
+		{PriceClose: 110, TradeDate: models.NewLocalDate(day(8))},
+	}, nil)
+	s.dailyRepo.EXPECT().FindRecentBySymbol("AAPL", day(6), 1).Return(nil, nil)
+	converter := fx_rates.NewConverter("THB", "USD", []models.FXRate{
+		{Base: "USD", Quote: "THB", Rate: 35, RateDate: models.NewLocalDate(day(5))},
+		{Base: "USD", Quote: "THB", Rate: 36, RateDate: models.NewLocalDate(day(7))},
+	}, nil)
+	s.fx.EXPECT().Converter(mock.Anything, "THB", day(6), day(8)).Return(converter, nil)
+
+	report, err := s.service.History(context.Background(), s.userID.String(), day(6), day(8), "", "THB")
+
+	s.Require().NoError(err)
+	s.Require().Len(report.Series, 1)
+	s.Equal("THB", report.Series[0].Currency)
+	points := report.Series[0].Points
+	s.Require().Len(points, 2)
+	s.InDelta(1000*35, points[0].MarketValue, 1e-6)
+	s.InDelta(1100*36, points[1].MarketValue, 1e-6)
+	s.InDelta(1000*36, points[1].CostBasis, 1e-6)
+}
+
+func TestHoldingServiceSuite(t *testing.T) {
+	suite.Run(t, new(HoldingServiceSuite))
+}
@@ -0,0 +1,304 @@
+package market_calendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// Embed the IANA database so exchange time zones resolve on minimal
+	// container images without /usr/share/zoneinfo.
+	_ "time/tzdata"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+const (
+	PhaseClosed     = "closed"
+	PhasePreMarket  = "pre-market"
+	PhaseRegular    = "regular"
+	PhasePostMarket = "post-market"
+
+	// Quotes are polled from shortly before the open until shortly after the
+	// close so the opening and closing prints are captured.
+	quoteWindowLead = 30 * time.Minute
+	quoteWindowLag  = 30 * time.Minute
+
+	// maxSessionGap bounds the search for the next session, long enough for
+	// multi-week closures such as lunar new year on some exchanges.
+	maxSessionGap = 31
+	dateLayout    = "2006-01-02"
+)
+
+// Session is one trading day of a calendar. Times are in the exchange time
+// zone; PreOpen and PostClose are nil when the calendar has no extended
+// session or the day closes early.
+type Session struct {
+	Calendar   string           `json:"calendar"`
+	TradeDate  models.LocalDate `json:"trade_date"`
+	PreOpen    *time.Time       `json:"pre_open,omitempty"`
+	Open       time.Time        `json:"open"`
+	Close      time.Time        `json:"close"`
+	PostClose  *time.Time       `json:"post_close,omitempty"`
+	EarlyClose bool             `json:"early_close"`
+	Holiday    string           `json:"holiday,omitempty"`
+}
+
+// IsOpen reports whether at falls in the regular session.
+func (s Session) IsOpen(at time.Time) bool {
+	return !at.Before(s.Open) && at.Before(s.Close)
+}
+
+// QuoteWindow is the span during which quotes for the session are polled and
+// aggregated into the session's daily row.
+func (s Session) QuoteWindow() (time.Time, time.Time) {
+	return s.Open.Add(-quoteWindowLead), s.Close.Add(quoteWindowLag)
+}
+
+// Phase names the part of the session at falls in.
+func (s Session) Phase(at time.Time) string {
+	switch {
+	case s.IsOpen(at):
+		return PhaseRegular
+	case s.PreOpen != nil && !at.Before(*s.PreOpen) && at.Before(s.Open):
+		return PhasePreMarket
+	case s.PostClose != nil && !at.Before(s.Close) && at.Before(*s.PostClose):
+		return PhasePostMarket
+	default:
+		return PhaseClosed
+	}
+}
+
+type clock struct {
+	minutes int
+	set     bool
+}
+
+func (c clock) on(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return time.Date(year, month, day, c.minutes/60, c.minutes%60, 0, 0, loc)
+}
+
+// Calendar resolves the sessions of one exchange from its definition and
+// holidays.
+type Calendar struct {
+	Code     string
+	Name     string
+	Location *time.Location
+
+	preOpen   clock
+	open      clock
+	close     clock
+	postClose clock
+	weekdays  [7]bool
+	holidays  map[string]models.MarketHoliday
+}
+
+// NewCalendar validates def and indexes holidays by date.
+func NewCalendar(def models.MarketCalendar, holidays []models.MarketHoliday) (*Calendar, error) {
+	loc, err := time.LoadLocation(strings.TrimSpace(def.Timezone))
+	if err != nil || strings.TrimSpace(def.Timezone) == "" {
+		return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidCalendar, def.Timezone)
+	}
+	c := &Calendar{
+		Code:     def.Code,
+		Name:     def.Name,
+		Location: loc,
+		holidays: make(map[string]models.MarketHoliday, len(holidays)),
+	}
+	fields := []struct {
+		name     string
+		value    string
+		target   *clock
+		required bool
+	}{
+		{"pre_open", def.PreOpen, &c.preOpen, false},
+		{"open", def.Open, &c.open, true},
+		{"close", def.Close, &c.close, true},
+		{"post_close", def.PostClose, &c.postClose, false},
+	}
+	for _, field := range fields {
+		if strings.TrimSpace(field.value) == "" {
+			if field.required {
+				return nil, fmt.Errorf("%w: %s is required", ErrInvalidCalendar, field.name)
+			}
+			continue
+		}
+		parsed, err := parseClock(field.value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidCalendar, field.name, err)
+		}
+		*field.target = parsed
+	}
+	if c.close.minutes <= c.open.minutes {
+		return nil, fmt.Errorf("%w: close must be after open", ErrInvalidCalendar)
+	}
+	if c.preOpen.set && c.preOpen.minutes > c.open.minutes {
+		return nil, fmt.Errorf("%w: pre_open must not be after open", ErrInvalidCalendar)
+	}
+	if c.postClose.set && c.postClose.minutes < c.close.minutes {
+		return nil, fmt.Errorf("%w: post_close must not be before close", ErrInvalidCalendar)
+	}
+	if c.weekdays, err = parseWeekdays(def.Weekdays); err != nil {
+		return nil, fmt.Errorf("%w: weekdays: %v", ErrInvalidCalendar, err)
+	}
+	for _, holiday := range holidays {
+		c.holidays[time.Time(holiday.Date).Format(dateLayout)] = holiday
+	}
+	return c, nil
+}
+
+// SessionOn returns the session held on the calendar date of date, read in
+// date's own location. It reports false on weekends and full-day holidays.
+func (c *Calendar) SessionOn(date time.Time) (Session, bool) {
+	year, month, day := date.Date()
+	if !c.weekdays[time.Date(year, month, day, 12, 0, 0, 0, time.UTC).Weekday()] {
+		return Session{}, false
+	}
+	key := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Format(dateLayout)
+	holiday, isHoliday := c.holidays[key]
+	closeAt := c.close
+	if isHoliday {
+		if holiday.EarlyClose == "" {
+			return Session{}, false
+		}
+		early, err := parseClock(holiday.EarlyClose)
+		if err != nil || early.minutes <= c.open.minutes {
+			return Session{}, false
+		}
+		if early.minutes < closeAt.minutes {
+			closeAt = early
+		}
+	}
+
+	session := Session{
+		Calendar:  c.Code,
+		TradeDate: models.NewLocalDate(time.Date(year, month, day, 0, 0, 0, 0, bangkok)),
+		Open:      c.open.on(year, month, day, c.Location),
+		Close:     closeAt.on(year, month, day, c.Location),
+	}
+	if isHoliday {
+		session.EarlyClose = closeAt.minutes < c.close.minutes
+		session.Holiday = holiday.Name
+	}
+	if c.preOpen.set {
+		preOpen := c.preOpen.on(year, month, day, c.Location)
+		session.PreOpen = &preOpen
+	}
+	if c.postClose.set && !session.EarlyClose {
+		postClose := c.postClose.on(year, month, day, c.Location)
+		session.PostClose = &postClose
+	}
+	return session, true
+}
+
+// Sessions lists the sessions held on the calendar dates from through to
+// inclusive, read in each argument's own location.
+func (c *Calendar) Sessions(from, to time.Time) []Session {
+	start := civilDate(from)
+	end := civilDate(to)
+	var sessions []Session
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if session, ok := c.SessionOn(day); ok {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// SessionAt returns the session whose quote window contains at.
+func (c *Calendar) SessionAt(at time.Time) (Session, bool) {
+	local := civilDate(at.In(c.Location))
+	for offset := -1; offset <= 1; offset++ {
+		session, ok := c.SessionOn(local.AddDate(0, 0, offset))
+		if !ok {
+			continue
+		}
+		start, end := session.QuoteWindow()
+		if !at.Before(start) && at.Before(end) {
+			return session, true
+		}
+	}
+	return Session{}, false
+}
+
+// Next returns the first session that has not closed by at, which is the
+// current session while the market is open.
+func (c *Calendar) Next(at time.Time) (Session, bool) {
+	local := civilDate(at.In(c.Location))
+	for offset := 0; offset <= maxSessionGap; offset++ {
+		session, ok := c.SessionOn(local.AddDate(0, 0, offset))
+		if ok && at.Before(session.Close) {
+			return session, true
+		}
+	}
+	return Session{}, false
+}
+
+// Previous returns the last session that closed at or before at.
+func (c *Calendar) Previous(at time.Time) (Session, bool) {
+	local := civilDate(at.In(c.Location))
+	for offset := 0; offset <= maxSessionGap; offset++ {
+		session, ok := c.SessionOn(local.AddDate(0, 0, -offset))
+		if ok && !at.Before(session.Close) {
+			return session, true
+		}
+	}
+	return Session{}, false
+}
+
+// Phase names the part of the trading day at falls in.
+func (c *Calendar) Phase(at time.Time) string {
+	session, ok := c.SessionOn(at.In(c.Location))
+	if !ok {
+		return PhaseClosed
+	}
+	return session.Phase(at)
+}
+
+// civilDate drops the clock of t but keeps its calendar date, pinned to UTC
+// so that day arithmetic is not skewed by DST transitions.
+func civilDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func parseClock(value string) (clock, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return clock{}, fmt.Errorf("%q is not HH:MM", value)
+	}
+	return clock{minutes: parsed.Hour()*60 + parsed.Minute(), set: true}, nil
+}
+
+func parseWeekdays(value string) ([7]bool, error) {
+	var weekdays [7]bool
+	if strings.TrimSpace(value) == "" {
+		value = defaultWeekdays
+	}
+	count := 0
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 0 || n > 6 {
+			return weekdays, fmt.Errorf("%q is not a weekday number 0-6", part)
+		}
+		if !weekdays[n] {
+			count++
+		}
+		weekdays[n] = true
+	}
+	if count == 0 {
+		return weekdays, fmt.Errorf("no trading days")
+	}
+	return weekdays, nil
+}
+
+// formatWeekdays renders weekdays in the canonical ascending form stored on
+// the calendar.
+func formatWeekdays(weekdays [7]bool) string {
+	parts := make([]string, 0, len(weekdays))
+	for day, trading := range weekdays {
+		if trading {
+			parts = append(parts, strconv.Itoa(day))
+		}
+	}
+	return strings.Join(parts, ",")
+}
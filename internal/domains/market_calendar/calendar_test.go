@@ -0,0 +1,117 @@
+package market_calendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type CalendarSuite struct {
+	suite.Suite
+	us *Calendar
+}
+
+func (s *CalendarSuite) SetupTest() {
+	s.us = s.newCalendar(defaultCalendars[0],
+		models.MarketHoliday{Date: day(2024, 7, 4), Name: "Independence Day"},
+		models.MarketHoliday{Date: day(2024, 7, 3), Name: "Independence Day eve", EarlyClose: "13:00"},
+	)
+}
+
+func (s *CalendarSuite) newCalendar(def models.MarketCalendar, holidays ...models.MarketHoliday) *Calendar {
+	calendar, err := NewCalendar(def, holidays)
+	s.Require().NoError(err)
+	return calendar
+}
+
+func day(year int, month time.Month, d int) models.LocalDate {
+	return models.NewLocalDate(time.Date(year, month, d, 0, 0, 0, 0, bangkok))
+}
+
+func (s *CalendarSuite) TestSessionOn_FollowsDaylightSaving() {
+	winter, ok := s.us.SessionOn(time.Date(2024, 1, 8, 0, 0, 0, 0, bangkok))
+	s.Require().True(ok)
+	s.Equal(time.Date(2024, 1, 8, 14, 30, 0, 0, time.UTC), winter.Open.UTC())
+	s.Equal(time.Date(2024, 1, 8, 21, 0, 0, 0, time.UTC), winter.Close.UTC())
+
+	summer, ok := s.us.SessionOn(time.Date(2024, 7, 8, 0, 0, 0, 0, bangkok))
+	s.Require().True(ok)
+	s.Equal(time.Date(2024, 7, 8, 13, 30, 0, 0, time.UTC), summer.Open.UTC())
+	s.Equal(time.Date(2024, 7, 8, 20, 0, 0, 0, time.UTC), summer.Close.UTC())
+	s.Require().NotNil(summer.PreOpen)
+	s.Require().NotNil(summer.PostClose)
+	s.Equal(time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC), summer.PostClose.UTC())
+	s.True(time.Time(summer.TradeDate).Equal(time.Time(day(2024, 7, 8))))
+}
+
+func (s *CalendarSuite) TestSessionOn_SkipsWeekendsAndHolidays() {
+	_, ok := s.us.SessionOn(time.Date(2024, 7, 6, 0, 0, 0, 0, bangkok))
+	s.False(ok)
+	_, ok = s.us.SessionOn(time.Date(2024, 7, 4, 0, 0, 0, 0, bangkok))
+	s.False(ok)
+
+	early, ok := s.us.SessionOn(time.Date(2024, 7, 3, 0, 0, 0, 0, bangkok))
+	s.Require().True(ok)
+	s.True(early.EarlyClose)
+	s.Equal("Independence Day eve", early.Holiday)
+	s.Equal(time.Date(2024, 7, 3, 17, 0, 0, 0, time.UTC), early.Close.UTC())
+	s.Nil(early.PostClose)
+}
+
+func (s *CalendarSuite) TestSessions_ListsTradingDays() {
+	sessions := s.us.Sessions(time.Date(2024, 7, 1, 0, 0, 0, 0, bangkok), time.Date(2024, 7, 7, 0, 0, 0, 0, bangkok))
+
+	s.Require().Len(sessions, 4)
+	s.True(time.Time(sessions[2].TradeDate).Equal(time.Time(day(2024, 7, 3))))
+	s.True(time.Time(sessions[3].TradeDate).Equal(time.Time(day(2024, 7, 5))))
+}
+
+func (s *CalendarSuite) TestSessionAt_CoversQuoteWindowAcrossMidnightBangkok() {
+	// 03:15 Bangkok on Tuesday is 16:15 EDT on Monday, inside the 30 minute lag.
+	session, ok := s.us.SessionAt(time.Date(2024, 7, 9, 3, 15, 0, 0, bangkok))
+	s.Require().True(ok)
+	s.True(time.Time(session.TradeDate).Equal(time.Time(day(2024, 7, 8))))
+
+	_, ok = s.us.SessionAt(time.Date(2024, 7, 9, 3, 30, 0, 0, bangkok))
+	s.False(ok)
+}
+
+func (s *CalendarSuite) TestNextAndPrevious_SpanHolidays() {
+	at := time.Date(2024, 7, 3, 18, 0, 0, 0, time.UTC)
+
+	next, ok := s.us.Next(at)
+	s.Require().True(ok)
+	s.True(time.Time(next.TradeDate).Equal(time.Time(day(2024, 7, 5))))
+
+	previous, ok := s.us.Previous(at)
+	s.Require().True(ok)
+	s.True(time.Time(previous.TradeDate).Equal(time.Time(day(2024, 7, 3))))
+}
+
+func (s *CalendarSuite) TestPhase() {
+	s.Equal(PhasePreMarket, s.us.Phase(time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)))
+	s.Equal(PhaseRegular, s.us.Phase(time.Date(2024, 7, 8, 15, 0, 0, 0, time.UTC)))
+	s.Equal(PhasePostMarket, s.us.Phase(time.Date(2024, 7, 8, 21, 0, 0, 0, time.UTC)))
+	s.Equal(PhaseClosed, s.us.Phase(time.Date(2024, 7, 6, 15, 0, 0, 0, time.UTC)))
+}
+
+func (s *CalendarSuite) TestNewCalendar_RejectsInvalidDefinitions() {
+	cases := []models.MarketCalendar{
+		{Code: "X", Timezone: "Mars/Olympus", Open: "09:00", Close: "16:00"},
+		{Code: "X", Timezone: "UTC", Open: "9am", Close: "16:00"},
+		{Code: "X", Timezone: "UTC", Open: "16:00", Close: "09:00"},
+		{Code: "X", Timezone: "UTC", Open: "09:00", Close: "16:00", Weekdays: "1,8"},
+	}
+	for _, def := range cases {
+		_, err := NewCalendar(def, nil)
+		s.True(errors.Is(err, ErrInvalidCalendar), def)
+	}
+}
+
+func TestCalendarSuite(t *testing.T) {
+	suite.Run(t, new(CalendarSuite))
+}
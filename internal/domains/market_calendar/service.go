@@ -0,0 +1,594 @@
+package market_calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/marketdata"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+const (
+	// DefaultCalendarCode governs symbols whose exchange has no calendar.
+	DefaultCalendarCode = "US"
+
+	defaultWeekdays         = "1,2,3,4,5"
+	defaultCacheSeconds     = 300
+	defaultHolidaySyncHours = 24
+	maxSessionRangeDays     = 366
+)
+
+var (
+	cacheTTL         = time.Duration(getEnvInt("MARKET_CALENDAR_CACHE_SECONDS", defaultCacheSeconds)) * time.Second
+	holidaySyncEvery = time.Duration(getEnvInt("MARKET_HOLIDAY_SYNC_HOURS", defaultHolidaySyncHours)) * time.Hour
+	bangkok          = time.FixedZone("Asia/Bangkok", 7*60*60)
+)
+
+var (
+	ErrCalendarNotFound       = errors.New("market calendar not found")
+	ErrInvalidCalendar        = errors.New("invalid market calendar")
+	ErrInvalidHoliday         = errors.New("invalid market holiday")
+	ErrHolidayNotFound        = errors.New("market holiday not found")
+	ErrExchangeNotFound       = errors.New("exchange not found")
+	ErrInvalidRange           = errors.New("invalid date range")
+	ErrHolidaySyncUnsupported = errors.New("market calendar has no provider code")
+)
+
+// defaultCalendars are created on startup when missing. Existing rows are
+// left untouched so admin edits survive restarts.
+var defaultCalendars = []models.MarketCalendar{
+	{
+		Code:         "US",
+		Name:         "US equities (NYSE, Nasdaq)",
+		Timezone:     "America/New_York",
+		PreOpen:      "04:00",
+		Open:         "09:30",
+		Close:        "16:00",
+		PostClose:    "20:00",
+		Weekdays:     defaultWeekdays,
+		ProviderCode: "US",
+	},
+	{
+		Code:         "SET",
+		Name:         "Stock Exchange of Thailand",
+		Timezone:     "Asia/Bangkok",
+		Open:         "10:00",
+		Close:        "16:30",
+		Weekdays:     defaultWeekdays,
+		ProviderCode: "BK",
+	},
+}
+
+// CalendarStatus describes a calendar together with its current phase and
+// the session that is open now or opens next.
+type CalendarStatus struct {
+	models.MarketCalendar
+	Phase   string   `json:"phase"`
+	Session *Session `json:"session,omitempty"`
+}
+
+type HolidayInput struct {
+	Date       time.Time
+	Name       string
+	EarlyClose string
+}
+
+// HolidaySyncResult reports the holidays stored by one ingestion run.
+type HolidaySyncResult struct {
+	Calendar string `json:"calendar"`
+	Source   string `json:"source"`
+	Count    int    `json:"count"`
+}
+
+type MarketCalendarService interface {
+	Start(ctx context.Context)
+	EnsureDefaults(ctx context.Context) error
+	List(ctx context.Context) ([]CalendarStatus, error)
+	SaveCalendar(ctx context.Context, def models.MarketCalendar) (*models.MarketCalendar, error)
+	Calendar(ctx context.Context, code string) (*Calendar, error)
+	CalendarForSymbol(ctx context.Context, symbol string) (*Calendar, error)
+	CalendarsInUse(ctx context.Context) ([]*Calendar, error)
+	InQuoteWindow(ctx context.Context, symbol string, at time.Time) bool
+	Sessions(ctx context.Context, code string, from, to time.Time) ([]Session, error)
+	Holidays(ctx context.Context, code string, from, to time.Time) ([]models.MarketHoliday, error)
+	SetHoliday(ctx context.Context, code string, input HolidayInput) (*models.MarketHoliday, error)
+	DeleteHoliday(ctx context.Context, code string, date time.Time) error
+	SyncHolidays(ctx context.Context, code string) (*HolidaySyncResult, error)
+	ListExchanges(ctx context.Context) ([]models.MasterExchange, error)
+	SetExchangeCalendar(ctx context.Context, id uuid.UUID, code string) (*models.MasterExchange, error)
+}
+
+type MarketCalendarServiceImpl struct {
+	repo     repository.MarketCalendarRepository
+	provider marketdata.Provider
+	now      func() time.Time
+	log      *logger.Logger
+
+	mu        sync.Mutex
+	loadedAt  time.Time
+	calendars map[string]*Calendar
+	symbols   map[string]string
+}
+
+func NewMarketCalendarService(
+	repo repository.MarketCalendarRepository,
+	provider marketdata.Provider,
+	log *logger.Logger,
+) MarketCalendarService {
+	return &MarketCalendarServiceImpl{
+		repo:     repo,
+		provider: provider,
+		now:      time.Now,
+		log:      log,
+	}
+}
+
+func (s *MarketCalendarServiceImpl) Start(ctx context.Context) {
+	go s.runScheduler(ctx)
+}
+
+// runScheduler ingests holidays for every calendar with a provider code on
+// start and then every MARKET_HOLIDAY_SYNC_HOURS.
+func (s *MarketCalendarServiceImpl) runScheduler(ctx context.Context) {
+	for {
+		calendars, err := s.repo.FindAll()
+		if err != nil {
+			s.logf("market_calendar: list calendars failed: %v", err)
+		}
+		for _, calendar := range calendars {
+			if calendar.ProviderCode == "" {
+				continue
+			}
+			result, err := s.SyncHolidays(ctx, calendar.Code)
+			if err != nil {
+				s.logf("market_calendar: %s holiday sync failed: %v", calendar.Code, err)
+				continue
+			}
+			s.logf("market_calendar: stored %d %s holidays from %s", result.Count, result.Calendar, result.Source)
+		}
+
+		timer := time.NewTimer(holidaySyncEvery)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *MarketCalendarServiceImpl) EnsureDefaults(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, calendar := range defaultCalendars {
+		calendar := calendar
+		if err := s.repo.EnsureCalendar(&calendar); err != nil {
+			return fmt.Errorf("ensure calendar %s: %w", calendar.Code, err)
+		}
+	}
+	s.invalidate()
+	return nil
+}
+
+func (s *MarketCalendarServiceImpl) List(ctx context.Context) ([]CalendarStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	defs, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	statuses := make([]CalendarStatus, 0, len(defs))
+	for _, def := range defs {
+		status := CalendarStatus{MarketCalendar: def, Phase: PhaseClosed}
+		if calendar, err := s.Calendar(ctx, def.Code); err == nil {
+			status.Phase = calendar.Phase(now)
+			if session, ok := calendar.Next(now); ok {
+				status.Session = &session
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// SaveCalendar creates or replaces a calendar definition after checking that
+// it resolves to valid sessions.
+func (s *MarketCalendarServiceImpl) SaveCalendar(ctx context.Context, def models.MarketCalendar) (*models.MarketCalendar, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	code, err := normalizeCode(def.Code)
+	if err != nil {
+		return nil, err
+	}
+	def.Code = code
+	def.Name = strings.TrimSpace(def.Name)
+	if def.Name == "" {
+		def.Name = code
+	}
+	def.Timezone = strings.TrimSpace(def.Timezone)
+	def.ProviderCode = strings.ToUpper(strings.TrimSpace(def.ProviderCode))
+	calendar, err := NewCalendar(def, nil)
+	if err != nil {
+		return nil, err
+	}
+	def.Weekdays = formatWeekdays(calendar.weekdays)
+	if err := s.repo.SaveCalendar(&def); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return s.repo.FindByCode(code)
+}
+
+func (s *MarketCalendarServiceImpl) Calendar(ctx context.Context, code string) (*Calendar, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	code, err := normalizeCode(code)
+	if err != nil {
+		return nil, err
+	}
+	calendars, _, err := s.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	calendar, ok := calendars[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCalendarNotFound, code)
+	}
+	return calendar, nil
+}
+
+// CalendarForSymbol resolves the calendar of symbol's exchange, falling back
+// to the default calendar when the exchange is unmapped or its calendar is
+// missing.
+func (s *MarketCalendarServiceImpl) CalendarForSymbol(ctx context.Context, symbol string) (*Calendar, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	calendars, symbols, err := s.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return resolve(calendars, symbols[strings.ToUpper(strings.TrimSpace(symbol))])
+}
+
+// CalendarsInUse lists the calendars that govern at least one active symbol,
+// ordered by code.
+func (s *MarketCalendarServiceImpl) CalendarsInUse(ctx context.Context) ([]*Calendar, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	calendars, symbols, err := s.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]*Calendar)
+	for _, code := range symbols {
+		calendar, err := resolve(calendars, code)
+		if err != nil {
+			return nil, err
+		}
+		seen[calendar.Code] = calendar
+	}
+	inUse := make([]*Calendar, 0, len(seen))
+	for _, calendar := range seen {
+		inUse = append(inUse, calendar)
+	}
+	sort.Slice(inUse, func(i, j int) bool { return inUse[i].Code < inUse[j].Code })
+	return inUse, nil
+}
+
+// InQuoteWindow reports whether symbol's calendar is within a session's quote
+// window at the given time. It fails open so that a calendar lookup error
+// never silences polling.
+func (s *MarketCalendarServiceImpl) InQuoteWindow(ctx context.Context, symbol string, at time.Time) bool {
+	calendar, err := s.CalendarForSymbol(ctx, symbol)
+	if err != nil {
+		s.logf("market_calendar: resolve %s failed: %v", symbol, err)
+		return true
+	}
+	_, ok := calendar.SessionAt(at)
+	return ok
+}
+
+func (s *MarketCalendarServiceImpl) Sessions(ctx context.Context, code string, from, to time.Time) ([]Session, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+	calendar, err := s.Calendar(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	sessions := calendar.Sessions(from, to)
+	if sessions == nil {
+		sessions = []Session{}
+	}
+	return sessions, nil
+}
+
+func (s *MarketCalendarServiceImpl) Holidays(ctx context.Context, code string, from, to time.Time) ([]models.MarketHoliday, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+	calendar, err := s.Calendar(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := s.repo.FindHolidays(calendar.Code)
+	if err != nil {
+		return nil, err
+	}
+	start, end := civilDate(from), civilDate(to)
+	filtered := make([]models.MarketHoliday, 0, len(holidays))
+	for _, holiday := range holidays {
+		day := civilDate(time.Time(holiday.Date))
+		if day.Before(start) || day.After(end) {
+			continue
+		}
+		filtered = append(filtered, holiday)
+	}
+	return filtered, nil
+}
+
+// SetHoliday records a manual override for one day: a full closure when
+// EarlyClose is empty, otherwise a session ending at that local time. Manual
+// overrides take precedence over ingested holidays.
+func (s *MarketCalendarServiceImpl) SetHoliday(ctx context.Context, code string, input HolidayInput) (*models.MarketHoliday, error) {
+	calendar, err := s.Calendar(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if input.Date.IsZero() {
+		return nil, fmt.Errorf("%w: date is required", ErrInvalidHoliday)
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidHoliday)
+	}
+	earlyClose := strings.TrimSpace(input.EarlyClose)
+	if earlyClose != "" {
+		parsed, err := parseClock(earlyClose)
+		if err != nil {
+			return nil, fmt.Errorf("%w: early_close: %v", ErrInvalidHoliday, err)
+		}
+		if parsed.minutes <= calendar.open.minutes || parsed.minutes >= calendar.close.minutes {
+			return nil, fmt.Errorf("%w: early_close must fall within the regular session", ErrInvalidHoliday)
+		}
+		earlyClose = fmt.Sprintf("%02d:%02d", parsed.minutes/60, parsed.minutes%60)
+	}
+	holiday := &models.MarketHoliday{
+		CalendarCode: calendar.Code,
+		Date:         holidayDate(input.Date),
+		Name:         name,
+		EarlyClose:   earlyClose,
+		Source:       models.HolidaySourceManual,
+	}
+	if err := s.repo.UpsertHoliday(holiday); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return holiday, nil
+}
+
+func (s *MarketCalendarServiceImpl) DeleteHoliday(ctx context.Context, code string, date time.Time) error {
+	calendar, err := s.Calendar(ctx, code)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteHoliday(calendar.Code, time.Time(holidayDate(date))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: %s %s", ErrHolidayNotFound, calendar.Code, date.Format(dateLayout))
+		}
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// SyncHolidays ingests the provider's holidays for the calendar. Days that
+// carry a manual override are left as they are.
+func (s *MarketCalendarServiceImpl) SyncHolidays(ctx context.Context, code string) (*HolidaySyncResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	code, err := normalizeCode(code)
+	if err != nil {
+		return nil, err
+	}
+	def, err := s.repo.FindByCode(code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrCalendarNotFound, code)
+		}
+		return nil, err
+	}
+	if def.ProviderCode == "" {
+		return nil, fmt.Errorf("%w: %s", ErrHolidaySyncUnsupported, code)
+	}
+	ingested, err := s.provider.MarketHolidays(ctx, def.ProviderCode)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]models.MarketHoliday, 0, len(ingested))
+	for _, item := range ingested {
+		date, err := time.ParseInLocation(dateLayout, strings.TrimSpace(item.Date), bangkok)
+		if err != nil {
+			s.logf("market_calendar: skip %s holiday %q with date %q", code, item.Name, item.Date)
+			continue
+		}
+		name := strings.TrimSpace(item.Name)
+		if name == "" {
+			name = "Holiday"
+		}
+		rows = append(rows, models.MarketHoliday{
+			CalendarCode: code,
+			Date:         models.NewLocalDate(date),
+			Name:         name,
+			EarlyClose:   earlyCloseFromHours(item.TradingHours),
+			Source:       models.HolidaySourceProvider,
+		})
+	}
+	if err := s.repo.UpsertProviderHolidays(rows); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return &HolidaySyncResult{Calendar: code, Source: s.provider.Name(), Count: len(rows)}, nil
+}
+
+func (s *MarketCalendarServiceImpl) ListExchanges(ctx context.Context) ([]models.MasterExchange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.repo.FindExchanges()
+}
+
+func (s *MarketCalendarServiceImpl) SetExchangeCalendar(ctx context.Context, id uuid.UUID, code string) (*models.MasterExchange, error) {
+	calendar, err := s.Calendar(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	exchange, err := s.repo.SetExchangeCalendar(id, calendar.Code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrExchangeNotFound, id)
+		}
+		return nil, err
+	}
+	s.invalidate()
+	return exchange, nil
+}
+
+// snapshot returns the cached calendars and symbol mappings, reloading them
+// once they are older than MARKET_CALENDAR_CACHE_SECONDS.
+func (s *MarketCalendarServiceImpl) snapshot() (map[string]*Calendar, map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.calendars != nil && s.now().Sub(s.loadedAt) < cacheTTL {
+		return s.calendars, s.symbols, nil
+	}
+
+	defs, err := s.repo.FindAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	calendars := make(map[string]*Calendar, len(defs))
+	for _, def := range defs {
+		holidays, err := s.repo.FindHolidays(def.Code)
+		if err != nil {
+			return nil, nil, err
+		}
+		calendar, err := NewCalendar(def, holidays)
+		if err != nil {
+			s.logf("market_calendar: skip calendar %s: %v", def.Code, err)
+			continue
+		}
+		calendars[def.Code] = calendar
+	}
+	rows, err := s.repo.FindSymbolCalendars()
+	if err != nil {
+		return nil, nil, err
+	}
+	symbols := make(map[string]string, len(rows))
+	for _, row := range rows {
+		symbols[strings.ToUpper(row.Symbol)] = row.CalendarCode
+	}
+
+	s.calendars, s.symbols, s.loadedAt = calendars, symbols, s.now()
+	return calendars, symbols, nil
+}
+
+func (s *MarketCalendarServiceImpl) invalidate() {
+	s.mu.Lock()
+	s.calendars, s.symbols = nil, nil
+	s.mu.Unlock()
+}
+
+func (s *MarketCalendarServiceImpl) logf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Infof(format, args...)
+	}
+}
+
+func resolve(calendars map[string]*Calendar, code string) (*Calendar, error) {
+	if calendar, ok := calendars[code]; ok {
+		return calendar, nil
+	}
+	if calendar, ok := calendars[DefaultCalendarCode]; ok {
+		return calendar, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrCalendarNotFound, DefaultCalendarCode)
+}
+
+// normalizeCode upper-cases a calendar code and rejects anything other than
+// letters, digits, '-' and '_'.
+func normalizeCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" || len(code) > 16 {
+		return "", fmt.Errorf("%w: code %q", ErrInvalidCalendar, code)
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return "", fmt.Errorf("%w: code %q", ErrInvalidCalendar, code)
+		}
+	}
+	return code, nil
+}
+
+func validateRange(from, to time.Time) error {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return fmt.Errorf("%w: from must not be after to", ErrInvalidRange)
+	}
+	if civilDate(to).Sub(civilDate(from)) > maxSessionRangeDays*24*time.Hour {
+		return fmt.Errorf("%w: at most %d days", ErrInvalidRange, maxSessionRangeDays)
+	}
+	return nil
+}
+
+// holidayDate keeps the calendar date of date as a LocalDate.
+func holidayDate(date time.Time) models.LocalDate {
+	year, month, day := date.Date()
+	return models.NewLocalDate(time.Date(year, month, day, 0, 0, 0, 0, bangkok))
+}
+
+// earlyCloseFromHours extracts the closing time from a shortened session such
+// as "09:30-13:00". An empty or unparsable value means a full closure.
+func earlyCloseFromHours(hours string) string {
+	hours = strings.TrimSpace(hours)
+	if hours == "" {
+		return ""
+	}
+	parts := strings.Split(hours, "-")
+	parsed, err := parseClock(parts[len(parts)-1])
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", parsed.minutes/60, parsed.minutes%60)
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return fallback
+	}
+	return n
+}
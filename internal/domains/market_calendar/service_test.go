@@ -0,0 +1,201 @@
+package market_calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/marketdata"
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+)
+
+type MarketCalendarServiceSuite struct {
+	suite.Suite
+	repo    *repositorymock.MockMarketCalendarRepository
+	service *MarketCalendarServiceImpl
+	now     time.Time
+}
+
+func (s *MarketCalendarServiceSuite) SetupTest() {
+	s.repo = repositorymock.NewMockMarketCalendarRepository(s.T())
+	provider := marketdata.NewReplayProviderFromFixture(marketdata.ReplayFixture{
+		Holidays: map[string][]marketdata.MarketHoliday{
+			"US": {
+				{Name: "Independence Day", Date: "2024-07-04"},
+				{Name: "Independence Day eve", Date: "2024-07-03", TradingHours: "09:30-13:00"},
+				{Name: "Broken", Date: "07/05/2024"},
+			},
+		},
+	})
+	s.service = NewMarketCalendarService(s.repo, provider, nil).(*MarketCalendarServiceImpl)
+	// Monday 8 July 2024, 15:00 UTC: the US regular session is open.
+	s.now = time.Date(2024, 7, 8, 15, 0, 0, 0, time.UTC)
+	s.service.now = func() time.Time { return s.now }
+}
+
+func (s *MarketCalendarServiceSuite) expectSnapshot(symbols ...repository.SymbolCalendar) {
+	s.repo.EXPECT().FindAll().Return(defaultCalendars, nil).Once()
+	s.repo.EXPECT().FindHolidays("US").Return(nil, nil).Once()
+	s.repo.EXPECT().FindHolidays("SET").Return(nil, nil).Once()
+	s.repo.EXPECT().FindSymbolCalendars().Return(symbols, nil).Once()
+}
+
+func (s *MarketCalendarServiceSuite) TestCalendarForSymbol_FallsBackToDefault() {
+	s.expectSnapshot(
+		repository.SymbolCalendar{Symbol: "PTT", CalendarCode: "SET"},
+		repository.SymbolCalendar{Symbol: "aapl", CalendarCode: ""},
+	)
+
+	set, err := s.service.CalendarForSymbol(context.Background(), "ptt")
+	s.Require().NoError(err)
+	s.Equal("SET", set.Code)
+
+	us, err := s.service.CalendarForSymbol(context.Background(), "AAPL")
+	s.Require().NoError(err)
+	s.Equal("US", us.Code)
+
+	unknown, err := s.service.CalendarForSymbol(context.Background(), "MSFT")
+	s.Require().NoError(err)
+	s.Equal("US", unknown.Code)
+}
+
+func (s *MarketCalendarServiceSuite) TestCalendarsInUse_AndQuoteWindow() {
+	s.expectSnapshot(
+		repository.SymbolCalendar{Symbol: "PTT", CalendarCode: "SET"},
+		repository.SymbolCalendar{Symbol: "AAPL", CalendarCode: "US"},
+		repository.SymbolCalendar{Symbol: "MSFT", CalendarCode: "US"},
+	)
+
+	calendars, err := s.service.CalendarsInUse(context.Background())
+	s.Require().NoError(err)
+	s.Require().Len(calendars, 2)
+	s.Equal("SET", calendars[0].Code)
+	s.Equal("US", calendars[1].Code)
+
+	// 22:00 Bangkok: Nasdaq is trading, SET closed at 16:30.
+	s.True(s.service.InQuoteWindow(context.Background(), "AAPL", s.now))
+	s.False(s.service.InQuoteWindow(context.Background(), "PTT", s.now))
+}
+
+func (s *MarketCalendarServiceSuite) TestSnapshot_ReloadsAfterInvalidation() {
+	s.expectSnapshot()
+	_, err := s.service.Calendar(context.Background(), "us")
+	s.Require().NoError(err)
+	_, err = s.service.Calendar(context.Background(), "US")
+	s.Require().NoError(err)
+
+	s.repo.EXPECT().UpsertHoliday(mock.Anything).Return(nil)
+	_, err = s.service.SetHoliday(context.Background(), "US", HolidayInput{Date: s.now, Name: "Closure"})
+	s.Require().NoError(err)
+
+	s.expectSnapshot()
+	_, err = s.service.Calendar(context.Background(), "US")
+	s.Require().NoError(err)
+}
+
+func (s *MarketCalendarServiceSuite) TestSetHoliday_StoresManualOverride() {
+	s.expectSnapshot()
+	s.repo.EXPECT().UpsertHoliday(mock.Anything).RunAndReturn(func(h *models.MarketHoliday) error {
+		s.Equal("US", h.CalendarCode)
+		s.Equal("13:00", h.EarlyClose)
+		s.Equal(models.HolidaySourceManual, h.Source)
+		s.Equal("2024-11-29", time.Time(h.Date).Format("2006-01-02"))
+		return nil
+	})
+
+	_, err := s.service.SetHoliday(context.Background(), "us", HolidayInput{
+		Date:       time.Date(2024, 11, 29, 0, 0, 0, 0, bangkok),
+		Name:       " Black Friday ",
+		EarlyClose: "13:00",
+	})
+
+	s.Require().NoError(err)
+}
+
+func (s *MarketCalendarServiceSuite) TestSetHoliday_RejectsEarlyCloseOutsideSession() {
+	s.expectSnapshot()
+
+	_, err := s.service.SetHoliday(context.Background(), "US", HolidayInput{Date: s.now, Name: "Odd", EarlyClose: "17:00"})
+	s.True(errors.Is(err, ErrInvalidHoliday))
+
+	_, err = s.service.SetHoliday(context.Background(), "XX", HolidayInput{Date: s.now, Name: "Odd"})
+	s.True(errors.Is(err, ErrCalendarNotFound))
+}
+
+func (s *MarketCalendarServiceSuite) TestDeleteHoliday_NotFound() {
+	s.expectSnapshot()
+	s.repo.EXPECT().DeleteHoliday("US", mock.Anything).Return(gorm.ErrRecordNotFound)
+
+	err := s.service.DeleteHoliday(context.Background(), "US", s.now)
+
+	s.True(errors.Is(err, ErrHolidayNotFound))
+}
+
+func (s *MarketCalendarServiceSuite) TestSyncHolidays_MapsTradingHoursAndSkipsBadDates() {
+	us := defaultCalendars[0]
+	s.repo.EXPECT().FindByCode("US").Return(&us, nil)
+	s.repo.EXPECT().UpsertProviderHolidays(mock.Anything).RunAndReturn(func(rows []models.MarketHoliday) error {
+		s.Require().Len(rows, 2)
+		s.Equal("Independence Day", rows[0].Name)
+		s.Empty(rows[0].EarlyClose)
+		s.Equal("13:00", rows[1].EarlyClose)
+		for _, row := range rows {
+			s.Equal(models.HolidaySourceProvider, row.Source)
+		}
+		return nil
+	})
+
+	result, err := s.service.SyncHolidays(context.Background(), "us")
+
+	s.Require().NoError(err)
+	s.Equal(&HolidaySyncResult{Calendar: "US", Source: marketdata.ProviderReplay, Count: 2}, result)
+}
+
+func (s *MarketCalendarServiceSuite) TestSyncHolidays_RequiresProviderCode() {
+	custom := models.MarketCalendar{Code: "LSE", Timezone: "Europe/London", Open: "08:00", Close: "16:30"}
+	s.repo.EXPECT().FindByCode("LSE").Return(&custom, nil)
+
+	_, err := s.service.SyncHolidays(context.Background(), "LSE")
+
+	s.True(errors.Is(err, ErrHolidaySyncUnsupported))
+}
+
+func (s *MarketCalendarServiceSuite) TestSaveCalendar_NormalizesDefinition() {
+	s.repo.EXPECT().SaveCalendar(mock.Anything).RunAndReturn(func(c *models.MarketCalendar) error {
+		s.Equal("LSE", c.Code)
+		s.Equal("LSE", c.Name)
+		s.Equal("1,2,3,4,5", c.Weekdays)
+		return nil
+	})
+	s.repo.EXPECT().FindByCode("LSE").Return(&models.MarketCalendar{Code: "LSE"}, nil)
+
+	_, err := s.service.SaveCalendar(context.Background(), models.MarketCalendar{
+		Code: "lse", Timezone: "Europe/London", Open: "08:00", Close: "16:30", Weekdays: "5,4,3,2,1",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.service.SaveCalendar(context.Background(), models.MarketCalendar{Code: "bad code"})
+	s.True(errors.Is(err, ErrInvalidCalendar))
+}
+
+func (s *MarketCalendarServiceSuite) TestSetExchangeCalendar_UnknownExchange() {
+	s.expectSnapshot()
+	id := uuid.New()
+	s.repo.EXPECT().SetExchangeCalendar(id, "SET").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.service.SetExchangeCalendar(context.Background(), id, "set")
+
+	s.True(errors.Is(err, ErrExchangeNotFound))
+}
+
+func TestMarketCalendarServiceSuite(t *testing.T) {
+	suite.Run(t, new(MarketCalendarServiceSuite))
+}
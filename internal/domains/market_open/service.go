@@ -2,51 +2,30 @@ package market_open
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"gorm.io/gorm"
 
+	"sun-stockanalysis-api/internal/domains/market_calendar"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/internal/repository"
 	"sun-stockanalysis-api/pkg/logger"
-
-	"github.com/google/uuid"
 )
 
-const (
-	defaultMarketStatusURL = "https://finnhub.io/api/v1/stock/market-status?exchange=US"
-	defaultPollSeconds     = 60
-	defaultStopHour        = 4
-	defaultStopMinute      = 30
-	defaultSchedulerHour   = 20
-	defaultSchedulerMinute = 25
-)
+const defaultPollSeconds = 60
 
-var (
-	marketStatusURL = getEnvString("MARKET_STATUS_URL", defaultMarketStatusURL)
-	pollInterval    = time.Duration(getEnvInt("MARKET_POLL_SECONDS", defaultPollSeconds)) * time.Second
-	stopHour        = getEnvInt("MARKET_STOP_HOUR", defaultStopHour)
-	stopMinute      = getEnvInt("MARKET_STOP_MINUTE", defaultStopMinute)
-	schedulerHour   = getEnvInt("MARKET_SCHEDULER_HOUR", defaultSchedulerHour)
-	schedulerMinute = getEnvInt("MARKET_SCHEDULER_MINUTE", defaultSchedulerMinute)
-)
+var pollInterval = time.Duration(getEnvInt("MARKET_POLL_SECONDS", defaultPollSeconds)) * time.Second
 
 type MarketOpenService interface {
 	Start(ctx context.Context)
 }
 
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
-}
-
 type StockQuoteService interface {
 	Start(ctx context.Context)
 	RunOnce(ctx context.Context)
@@ -54,7 +33,12 @@ type StockQuoteService interface {
 }
 
 type StockDailyService interface {
-	BuildForWindow(ctx context.Context, start, end time.Time) error
+	BuildForSession(ctx context.Context, session market_calendar.Session) error
+}
+
+// Calendars lists the market calendars that govern at least one symbol.
+type Calendars interface {
+	CalendarsInUse(ctx context.Context) ([]*market_calendar.Calendar, error)
 }
 
 type MarketOpenNotifier interface {
@@ -64,216 +48,196 @@ type MarketOpenNotifier interface {
 
 type MarketOpenServiceImpl struct {
 	repo         repository.MarketOpenRepository
-	httpClient   HTTPClient
-	finnhubToken string
+	calendars    Calendars
 	quoteService StockQuoteService
 	dailyService StockDailyService
 	notifier     MarketOpenNotifier
 	log          *logger.Logger
+	now          func() time.Time
+
+	// open holds the session currently open on each calendar code and
+	// pollers counts them; the quote poller runs while any market is open.
+	// seen marks the calendars checked since this replica took over.
+	open    map[string]market_calendar.Session
+	seen    map[string]bool
+	pollers int
+
+	// done is closed once the most recently started run has exited.
+	mu   sync.Mutex
+	done chan struct{}
 }
 
 func NewMarketOpenService(
 	repo repository.MarketOpenRepository,
-	httpClient HTTPClient,
-	finnhubToken string,
+	calendars Calendars,
 	quoteService StockQuoteService,
 	dailyService StockDailyService,
 	notifier MarketOpenNotifier,
 	log *logger.Logger,
 ) MarketOpenService {
-	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 10 * time.Second}
-	}
 	return &MarketOpenServiceImpl{
 		repo:         repo,
-		httpClient:   httpClient,
-		finnhubToken: finnhubToken,
+		calendars:    calendars,
 		quoteService: quoteService,
 		dailyService: dailyService,
 		notifier:     notifier,
 		log:          log,
+		now:          time.Now,
+		open:         make(map[string]market_calendar.Session),
+		seen:         make(map[string]bool),
 	}
 }
 
+// Start runs the scheduler until ctx is done. When leadership flaps, the run
+// of the previous term may still be closing a session or cleaning up, so the
+// new run waits for it to exit before touching the shared state.
 func (s *MarketOpenServiceImpl) Start(ctx context.Context) {
-	go s.runScheduler(ctx)
-}
-
-func (s *MarketOpenServiceImpl) runScheduler(ctx context.Context) {
-	now := time.Now()
-	if shouldRunOnStartup(now, time.Local) {
-		if s.log != nil {
-			s.log.Infof("market scheduler: start runDailyPolling immediately on startup (now=%s)", now.In(time.Local).Format(time.RFC3339))
+	s.mu.Lock()
+	previous := s.done
+	done := make(chan struct{})
+	s.done = done
+	s.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		if previous != nil {
+			<-previous
 		}
-		s.runDailyPolling(ctx)
-	} else {
-		if s.log != nil {
-			wait := nextRunDuration(schedulerHour, schedulerMinute, time.Local)
-			s.log.Infof("market scheduler: wait for scheduled run (now=%s next_in=%s schedule=%02d:%02d)", now.In(time.Local).Format(time.RFC3339), wait.String(), schedulerHour, schedulerMinute)
-		}
-	}
-
-	for {
-		wait := nextRunDuration(schedulerHour, schedulerMinute, time.Local)
-		timer := time.NewTimer(wait)
-		select {
-		case <-ctx.Done():
-			timer.Stop()
+		if ctx.Err() != nil {
 			return
-		case <-timer.C:
-		}
-
-		if s.log != nil {
-			s.log.Infof("market scheduler: start runDailyPolling on schedule (now=%s schedule=%02d:%02d)", time.Now().In(time.Local).Format(time.RFC3339), schedulerHour, schedulerMinute)
 		}
-		s.runDailyPolling(ctx)
-	}
+		s.run(ctx)
+	}()
 }
 
-func (s *MarketOpenServiceImpl) runDailyPolling(ctx context.Context) {
-	quoteStarted := false
-	postHandled := false
-
-	for {
-		if shouldStopForDay(time.Now()) {
-			return
+// run checks every calendar in use each MARKET_POLL_SECONDS and reacts to its
+// sessions opening and closing.
+func (s *MarketOpenServiceImpl) run(ctx context.Context) {
+	defer func() {
+		// Losing leadership cancels ctx mid-session. Stop the quote poller so
+		// that a later Start on this replica is not ignored.
+		if s.pollers > 0 && s.quoteService != nil {
+			s.quoteService.Stop()
 		}
+		s.open = make(map[string]market_calendar.Session)
+		s.seen = make(map[string]bool)
+		s.pollers = 0
+	}()
 
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		s.tick(ctx)
 		select {
 		case <-ctx.Done():
 			return
-		default:
+		case <-ticker.C:
 		}
+	}
+}
 
-		status, err := s.fetchMarketStatus()
-		if err != nil {
-			return
-		}
-		if status == nil {
+func (s *MarketOpenServiceImpl) tick(ctx context.Context) {
+	calendars, err := s.calendars.CalendarsInUse(ctx)
+	if err != nil {
+		s.logf("market scheduler: list calendars failed: %v", err)
+		return
+	}
+	now := s.now()
+	for _, calendar := range calendars {
+		if ctx.Err() != nil {
 			return
 		}
-
-		session := strings.ToLower(strings.TrimSpace(status.session()))
-		isOpen := status.isOpen()
-		s.logStatus(status)
+		session, ok := calendar.Next(now)
+		isOpen := ok && session.IsOpen(now)
+		current, wasOpen := s.open[calendar.Code]
+		firstCheck := !s.seen[calendar.Code]
+		s.seen[calendar.Code] = true
 		switch {
-		case session == "pre-market":
-			sleepContext(ctx, pollInterval)
-			continue
-		case session == "regular" && isOpen:
-			_ = s.ensureOpenRecord(status)
-			if s.quoteService != nil && !quoteStarted {
-				s.quoteService.Start(ctx)
-				quoteStarted = true
-				if s.notifier != nil {
-					s.notifier.NotifyMarketOpen("The market is open. Prices are being updated.")
-				}
-			}
-			sleepContext(ctx, pollInterval)
-			continue
-		case session == "post-market" || (session == "regular" && !isOpen):
-			_ = s.updateCloseRecord(status)
-			if s.quoteService != nil && !postHandled {
-				s.quoteService.RunOnce(ctx)
-				s.quoteService.Stop()
-				postHandled = true
-				if s.notifier != nil {
-					s.notifier.NotifyMarketClose("ตลาดปิดแล้ว")
-				}
-				if s.dailyService != nil {
-					start, end := metricsWindow(time.Now())
-					_ = s.dailyService.BuildForWindow(ctx, start, end)
-				}
+		case isOpen && !wasOpen:
+			s.handleOpen(ctx, session, now)
+		case wasOpen && !(isOpen && time.Time(current.TradeDate).Equal(time.Time(session.TradeDate))):
+			s.handleClose(ctx, current, now)
+			if isOpen {
+				s.handleOpen(ctx, session, now)
 			}
-			sleepContext(ctx, pollInterval)
-			continue
-		default:
-			sleepContext(ctx, pollInterval)
-			continue
+		case !isOpen && firstCheck:
+			s.catchUp(ctx, calendar, now)
 		}
 	}
 }
 
-func (s *MarketOpenServiceImpl) logStatus(status *finnhubMarketStatusResponse) {
-	correlationID := uuid.NewString()
-	session := ""
-	exchange := ""
-	isOpen := false
-	timestamp := int64(0)
-	timezone := ""
-	if status != nil {
-		session = status.session()
-		exchange = status.Exchange
-		isOpen = status.IsOpen
-		timestamp = status.T
-		timezone = status.Timezone
+// catchUp finishes a session that closed shortly before this replica took
+// over, so a restart or failover at the close does not lose the daily rows.
+func (s *MarketOpenServiceImpl) catchUp(ctx context.Context, calendar *market_calendar.Calendar, now time.Time) {
+	session, ok := calendar.Previous(now)
+	if !ok {
+		return
 	}
-	message := fmt.Sprintf("market status: session=%s exchange=%s isOpen=%t t=%d timezone=%s",
-		session,
-		exchange,
-		isOpen,
-		timestamp,
-		timezone,
-	)
-	if s.log != nil {
-		s.log.With("correlation_id", correlationID).Infof(message)
+	if _, end := session.QuoteWindow(); !now.Before(end) {
 		return
 	}
-	_ = correlationID
-}
-
-type finnhubMarketStatusResponse struct {
-	Exchange string  `json:"exchange"`
-	Holiday  *string `json:"holiday"`
-	IsOpen   bool    `json:"isOpen"`
-	Session  *string `json:"session"`
-	T        int64   `json:"t"`
-	Timezone string  `json:"timezone"`
-}
-
-func (r *finnhubMarketStatusResponse) session() string {
-	if r == nil || r.Session == nil {
-		return ""
+	s.logf("market scheduler: %s session %s closed before startup, finishing it", session.Calendar, formatDate(session.TradeDate))
+	if s.quoteService != nil {
+		s.quoteService.RunOnce(ctx)
+	}
+	if err := s.updateCloseRecord(session, now); err != nil {
+		s.logf("market scheduler: record %s close failed: %v", session.Calendar, err)
+	}
+	if s.dailyService != nil {
+		if err := s.dailyService.BuildForSession(ctx, session); err != nil {
+			s.logf("market scheduler: build %s daily failed: %v", session.Calendar, err)
+		}
 	}
-	return *r.Session
 }
 
-func (r *finnhubMarketStatusResponse) isOpen() bool {
-	if r == nil {
-		return false
+// handleOpen records the session, starts the quote poller if no other market
+// is already driving it and announces the open.
+func (s *MarketOpenServiceImpl) handleOpen(ctx context.Context, session market_calendar.Session, now time.Time) {
+	s.logf("market scheduler: %s session %s open (now=%s)", session.Calendar, formatDate(session.TradeDate), now.Format(time.RFC3339))
+	if err := s.ensureOpenRecord(session, now); err != nil {
+		s.logf("market scheduler: record %s open failed: %v", session.Calendar, err)
+	}
+	s.open[session.Calendar] = session
+	if s.quoteService != nil {
+		if s.pollers == 0 {
+			s.quoteService.Start(ctx)
+		}
+		s.pollers++
+	}
+	if s.notifier != nil {
+		s.notifier.NotifyMarketOpen(fmt.Sprintf("The %s market is open. Prices are being updated.", session.Calendar))
 	}
-	return r.IsOpen
 }
 
-func (s *MarketOpenServiceImpl) fetchMarketStatus() (*finnhubMarketStatusResponse, error) {
-	req, err := http.NewRequest(http.MethodGet, marketStatusURL, nil)
-	if err != nil {
-		return nil, err
+// handleClose takes a final quote snapshot, stops the poller once no market
+// remains open, closes the record, announces the close and builds the
+// session's daily rows.
+func (s *MarketOpenServiceImpl) handleClose(ctx context.Context, session market_calendar.Session, now time.Time) {
+	s.logf("market scheduler: %s session %s closed (now=%s)", session.Calendar, formatDate(session.TradeDate), now.Format(time.RFC3339))
+	delete(s.open, session.Calendar)
+	if s.quoteService != nil {
+		s.quoteService.RunOnce(ctx)
+		s.pollers--
+		if s.pollers <= 0 {
+			s.pollers = 0
+			s.quoteService.Stop()
+		}
 	}
-	req.Header.Set("X-Finnhub-Token", s.finnhubToken)
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	if err := s.updateCloseRecord(session, now); err != nil {
+		s.logf("market scheduler: record %s close failed: %v", session.Calendar, err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("finnhub market-status request failed: %s", strings.TrimSpace(string(body)))
+	if s.notifier != nil {
+		s.notifier.NotifyMarketClose(fmt.Sprintf("ตลาด %s ปิดแล้ว", session.Calendar))
 	}
-
-	var result *finnhubMarketStatusResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if s.dailyService != nil {
+		if err := s.dailyService.BuildForSession(ctx, session); err != nil {
+			s.logf("market scheduler: build %s daily failed: %v", session.Calendar, err)
+		}
 	}
-	return result, nil
 }
 
-func (s *MarketOpenServiceImpl) ensureOpenRecord(status *finnhubMarketStatusResponse) error {
-	tradeDate, openAt := tradeDateAndTime(status)
-	tradeDate = tradeDateForMarketWindow(openAt)
-	_, err := s.repo.FindByTradeDate(tradeDate)
+func (s *MarketOpenServiceImpl) ensureOpenRecord(session market_calendar.Session, openAt time.Time) error {
+	_, err := s.repo.FindByTradeDate(session.Calendar, time.Time(session.TradeDate))
 	if err == nil {
 		return nil
 	}
@@ -282,17 +246,16 @@ func (s *MarketOpenServiceImpl) ensureOpenRecord(status *finnhubMarketStatusResp
 	}
 
 	record := &models.MarketOpen{
-		TradeDate:    models.NewLocalDate(tradeDate),
+		Exchange:     session.Calendar,
+		TradeDate:    session.TradeDate,
 		IsTradingDay: true,
 		OpenAt:       models.NewLocalTime(openAt),
 	}
 	return s.repo.Create(record)
 }
 
-func (s *MarketOpenServiceImpl) updateCloseRecord(status *finnhubMarketStatusResponse) error {
-	tradeDate, closeAt := tradeDateAndTime(status)
-	tradeDate = tradeDateForMarketWindow(closeAt)
-	record, err := s.repo.FindByTradeDate(tradeDate)
+func (s *MarketOpenServiceImpl) updateCloseRecord(session market_calendar.Session, closeAt time.Time) error {
+	record, err := s.repo.FindByTradeDate(session.Calendar, time.Time(session.TradeDate))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil
@@ -302,107 +265,14 @@ func (s *MarketOpenServiceImpl) updateCloseRecord(status *finnhubMarketStatusRes
 	return s.repo.UpdateCloseAt(record.ID, closeAt, false)
 }
 
-func tradeDateAndTime(status *finnhubMarketStatusResponse) (time.Time, time.Time) {
-	if status == nil {
-		now := time.Now()
-		date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		return date, now
-	}
-
-	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
-	timestamp := status.T
-	if timestamp <= 0 {
-		now := time.Now().In(loc)
-		date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-		return date, now
-	}
-
-	at := time.Unix(timestamp, 0).In(loc)
-	date := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, loc)
-	return date, at
-}
-
-func tradeDateForMarketWindow(at time.Time) time.Time {
-	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
-	t := at.In(loc)
-	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
-	if t.Hour() < 4 {
-		return date.AddDate(0, 0, -1)
-	}
-	return date
-}
-
-func shouldStopForDay(now time.Time) bool {
-	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
-	current := now.In(loc)
-	stopAt := windowStopAt(current, loc)
-	return !current.Before(stopAt)
-}
-
-func metricsWindow(now time.Time) (time.Time, time.Time) {
-	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
-	current := now.In(loc)
-	start := time.Date(current.Year(), current.Month(), current.Day(), 20, 0, 0, 0, loc).Add(-24 * time.Hour)
-	end := time.Date(current.Year(), current.Month(), current.Day(), 4, 30, 0, 0, loc)
-	return start, end
-}
-
-func nextRunDuration(hour, minute int, loc *time.Location) time.Duration {
-	now := time.Now().In(loc)
-	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, loc)
-	if !next.After(now) {
-		next = next.Add(24 * time.Hour)
-	}
-	return time.Until(next)
-}
-
-func shouldRunOnStartup(now time.Time, loc *time.Location) bool {
-	current := now.In(loc)
-	scheduledToday := time.Date(current.Year(), current.Month(), current.Day(), schedulerHour, schedulerMinute, 0, 0, loc)
-	stopToday := time.Date(current.Year(), current.Month(), current.Day(), stopHour, stopMinute, 0, 0, loc)
-
-	// 00:00..stop belongs to the previous day window, so run immediately.
-	if current.Before(stopToday) {
-		return true
-	}
-	// Between stop and scheduler => wait until scheduler time.
-	if current.Before(scheduledToday) {
-		return false
-	}
-	// At/after scheduler => run immediately.
-	return true
-}
-
-func windowStopAt(current time.Time, loc *time.Location) time.Time {
-	stopToday := time.Date(current.Year(), current.Month(), current.Day(), stopHour, stopMinute, 0, 0, loc)
-	scheduledToday := time.Date(current.Year(), current.Month(), current.Day(), schedulerHour, schedulerMinute, 0, 0, loc)
-
-	// 00:00..stop => stop at today's stop.
-	if current.Before(stopToday) {
-		return stopToday
-	}
-	// At/after scheduler => stop at next day's stop.
-	if !current.Before(scheduledToday) {
-		return stopToday.Add(24 * time.Hour)
-	}
-	// Between stop and scheduler => outside window (already stopped).
-	return stopToday
-}
-
-func sleepContext(ctx context.Context, d time.Duration) {
-	timer := time.NewTimer(d)
-	defer timer.Stop()
-	select {
-	case <-ctx.Done():
-	case <-timer.C:
+func (s *MarketOpenServiceImpl) logf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Infof(format, args...)
 	}
 }
 
-func getEnvString(key, fallback string) string {
-	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
-		return v
-	}
-	return fallback
+func formatDate(date models.LocalDate) string {
+	return time.Time(date).Format("2006-01-02")
 }
 
 func getEnvInt(key string, fallback int) int {
@@ -411,7 +281,7 @@ func getEnvInt(key string, fallback int) int {
 		return fallback
 	}
 	n, err := strconv.Atoi(raw)
-	if err != nil || n < 0 {
+	if err != nil || n <= 0 {
 		return fallback
 	}
 	return n
@@ -0,0 +1,67 @@
+package market_open
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/domains/market_calendar"
+)
+
+// blockingCalendars holds the first listing until release is closed, standing
+// in for a leader term that is still busy when leadership flaps.
+type blockingCalendars struct {
+	mu      sync.Mutex
+	calls   int
+	release chan struct{}
+}
+
+func (c *blockingCalendars) CalendarsInUse(context.Context) ([]*market_calendar.Calendar, error) {
+	c.mu.Lock()
+	c.calls++
+	first := c.calls == 1
+	c.mu.Unlock()
+	if first {
+		<-c.release
+	}
+	return nil, nil
+}
+
+func (c *blockingCalendars) callCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+type MarketOpenServiceSuite struct {
+	suite.Suite
+	calendars *blockingCalendars
+	service   MarketOpenService
+}
+
+func (s *MarketOpenServiceSuite) SetupTest() {
+	s.calendars = &blockingCalendars{release: make(chan struct{})}
+	s.service = NewMarketOpenService(nil, s.calendars, nil, nil, nil, nil)
+}
+
+func (s *MarketOpenServiceSuite) TestStart_WaitsForPreviousRunToExit() {
+	first, cancelFirst := context.WithCancel(context.Background())
+	s.service.Start(first)
+	s.Eventually(func() bool { return s.calendars.callCount() == 1 }, time.Second, time.Millisecond)
+
+	cancelFirst()
+	second, cancelSecond := context.WithCancel(context.Background())
+	defer cancelSecond()
+	s.service.Start(second)
+	s.Never(func() bool { return s.calendars.callCount() > 1 }, 50*time.Millisecond, time.Millisecond)
+
+	close(s.calendars.release)
+	s.Eventually(func() bool { return s.calendars.callCount() == 2 }, time.Second, time.Millisecond)
+}
+
+func TestMarketOpenServiceSuite(t *testing.T) {
+	suite.Run(t, new(MarketOpenServiceSuite))
+}
@@ -0,0 +1,682 @@
+package portfolios
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/domains/fx_rates"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+const (
+	defaultMaxPortfolios = 10
+	defaultInitialCash   = 100000
+	defaultOrderLimit    = 100
+	defaultCurrency      = "USD"
+	maxOrderLimit        = 500
+
+	// epsilon absorbs float drift when comparing quantities and cash.
+	epsilon = 1e-9
+)
+
+var (
+	maxPortfolios = getEnvInt("PORTFOLIO_MAX_PER_USER", defaultMaxPortfolios)
+	initialCash   = getEnvFloat("PORTFOLIO_DEFAULT_CASH", defaultInitialCash)
+	// Paper portfolios settle every fill at the raw quote price, so cash and
+	// P&L are denominated in the currency of the quoted markets.
+	portfolioCurrency = strings.ToUpper(getEnvString("PORTFOLIO_CURRENCY", defaultCurrency))
+)
+
+var (
+	ErrInvalidUser         = errors.New("invalid user id")
+	ErrPortfolioNotFound   = errors.New("portfolio not found")
+	ErrInvalidPortfolio    = errors.New("invalid portfolio")
+	ErrTooManyPortfolios   = errors.New("portfolio limit reached")
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrInvalidOrder        = errors.New("invalid order")
+	ErrOrderNotOpen        = errors.New("order is not open")
+	ErrUnknownSymbol       = errors.New("symbol is not tracked")
+	ErrNoQuote             = errors.New("no quote available for symbol")
+	ErrInsufficientCash    = errors.New("insufficient cash")
+	ErrInsufficientHolding = errors.New("insufficient position")
+)
+
+type OrderInput struct {
+	Symbol     string
+	Side       string
+	Type       string
+	Quantity   float64
+	LimitPrice *float64
+}
+
+// PositionValue is a position marked to the latest stored quote. LastPrice is
+// nil when the symbol has no quote yet, in which case the position is valued
+// at cost.
+type PositionValue struct {
+	Symbol               string   `json:"symbol"`
+	Quantity             float64  `json:"quantity"`
+	AvgCost              float64  `json:"avg_cost"`
+	CostBasis            float64  `json:"cost_basis"`
+	LastPrice            *float64 `json:"last_price"`
+	MarketValue          float64  `json:"market_value"`
+	UnrealizedPnL        float64  `json:"unrealized_pnl"`
+	UnrealizedPnLPercent float64  `json:"unrealized_pnl_percent"`
+}
+
+// Summary values a portfolio in Currency. FXRate is set when the amounts were
+// converted from PORTFOLIO_CURRENCY on request.
+type Summary struct {
+	Currency           string           `json:"currency"`
+	FXRate             *float64         `json:"fx_rate,omitempty"`
+	Portfolio          models.Portfolio `json:"portfolio"`
+	Positions          []PositionValue  `json:"positions"`
+	MarketValue        float64          `json:"market_value"`
+	Equity             float64          `json:"equity"`
+	RealizedPnL        float64          `json:"realized_pnl"`
+	UnrealizedPnL      float64          `json:"unrealized_pnl"`
+	TotalReturn        float64          `json:"total_return"`
+	TotalReturnPercent float64          `json:"total_return_percent"`
+}
+
+type PortfolioService interface {
+	List(ctx context.Context, userID string) ([]models.Portfolio, error)
+	Create(ctx context.Context, userID, name string, cash *float64) (*models.Portfolio, error)
+	Summary(ctx context.Context, userID string, id uuid.UUID, currency string) (*Summary, error)
+	Delete(ctx context.Context, userID string, id uuid.UUID) error
+	PlaceOrder(ctx context.Context, userID string, id uuid.UUID, input OrderInput) (*models.PortfolioOrder, error)
+	CancelOrder(ctx context.Context, userID string, id, orderID uuid.UUID) (*models.PortfolioOrder, error)
+	ListOrders(ctx context.Context, userID string, id uuid.UUID, status string, limit int) ([]models.PortfolioOrder, error)
+	NotifyQuote(quote *models.StockQuote)
+}
+
+type PortfolioServiceImpl struct {
+	repo      repository.PortfolioRepository
+	stockRepo repository.StockRepository
+	quoteRepo repository.StockQuoteRepository
+	fx        fx_rates.FXRateService
+	log       *logger.Logger
+}
+
+func NewPortfolioService(
+	repo repository.PortfolioRepository,
+	stockRepo repository.StockRepository,
+	quoteRepo repository.StockQuoteRepository,
+	fx fx_rates.FXRateService,
+	log *logger.Logger,
+) PortfolioService {
+	return &PortfolioServiceImpl{
+		repo:      repo,
+		stockRepo: stockRepo,
+		quoteRepo: quoteRepo,
+		fx:        fx,
+		log:       log,
+	}
+}
+
+func (s *PortfolioServiceImpl) List(ctx context.Context, userID string) ([]models.Portfolio, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByUser(owner)
+}
+
+// Create opens a portfolio funded with cash, or PORTFOLIO_DEFAULT_CASH when
+// cash is nil.
+func (s *PortfolioServiceImpl) Create(ctx context.Context, userID, name string, cash *float64) (*models.Portfolio, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 128 {
+		return nil, fmt.Errorf("%w: name must be 1-128 characters", ErrInvalidPortfolio)
+	}
+	funding := initialCash
+	if cash != nil {
+		funding = *cash
+	}
+	if funding <= 0 || math.IsInf(funding, 0) || math.IsNaN(funding) {
+		return nil, fmt.Errorf("%w: initial cash must be positive", ErrInvalidPortfolio)
+	}
+	existing, err := s.repo.FindByUser(owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxPortfolios {
+		return nil, ErrTooManyPortfolios
+	}
+
+	portfolio := &models.Portfolio{
+		UserID:      owner,
+		Name:        name,
+		InitialCash: funding,
+		Cash:        funding,
+	}
+	if err := s.repo.Create(portfolio); err != nil {
+		return nil, err
+	}
+	return portfolio, nil
+}
+
+// Summary marks the portfolio to market, converting every amount at the latest
+// FX rate when currency is set.
+func (s *PortfolioServiceImpl) Summary(ctx context.Context, userID string, id uuid.UUID, currency string) (*Summary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	portfolio, err := s.findOwned(owner, id)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := s.repo.FindPositions(portfolio.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		Currency:    portfolioCurrency,
+		Portfolio:   *portfolio,
+		Positions:   make([]PositionValue, 0, len(positions)),
+		RealizedPnL: portfolio.RealizedPnL,
+	}
+	for _, position := range positions {
+		value := PositionValue{
+			Symbol:    position.Symbol,
+			Quantity:  position.Quantity,
+			AvgCost:   position.AvgCost,
+			CostBasis: position.Quantity * position.AvgCost,
+		}
+		value.MarketValue = value.CostBasis
+		quote, err := s.quoteRepo.FindLatestBySymbol(position.Symbol)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if quote != nil {
+			price := quote.PriceCurrent
+			value.LastPrice = &price
+			value.MarketValue = position.Quantity * price
+		}
+		value.UnrealizedPnL = value.MarketValue - value.CostBasis
+		value.UnrealizedPnLPercent = percent(value.UnrealizedPnL, value.CostBasis)
+
+		summary.Positions = append(summary.Positions, value)
+		summary.MarketValue += value.MarketValue
+		summary.UnrealizedPnL += value.UnrealizedPnL
+	}
+	summary.Equity = portfolio.Cash + summary.MarketValue
+	summary.TotalReturn = summary.Equity - portfolio.InitialCash
+	summary.TotalReturnPercent = percent(summary.TotalReturn, portfolio.InitialCash)
+	if strings.TrimSpace(currency) != "" {
+		if err := s.convertSummary(ctx, summary, currency); err != nil {
+			return nil, err
+		}
+	}
+	return summary, nil
+}
+
+func (s *PortfolioServiceImpl) convertSummary(ctx context.Context, summary *Summary, currency string) error {
+	now := time.Now()
+	converter, err := s.fx.Converter(ctx, currency, now, now)
+	if err != nil {
+		return err
+	}
+	rate, err := converter.Rate(summary.Currency, now)
+	if err != nil {
+		return err
+	}
+	summary.Currency = converter.Target()
+	summary.FXRate = &rate
+	summary.Portfolio.InitialCash *= rate
+	summary.Portfolio.Cash *= rate
+	summary.Portfolio.RealizedPnL *= rate
+	for i := range summary.Positions {
+		position := &summary.Positions[i]
+		position.AvgCost *= rate
+		position.CostBasis *= rate
+		position.MarketValue *= rate
+		position.UnrealizedPnL *= rate
+		if position.LastPrice != nil {
+			price := *position.LastPrice * rate
+			position.LastPrice = &price
+		}
+	}
+	summary.MarketValue *= rate
+	summary.Equity *= rate
+	summary.RealizedPnL *= rate
+	summary.UnrealizedPnL *= rate
+	summary.TotalReturn *= rate
+	return nil
+}
+
+func (s *PortfolioServiceImpl) Delete(ctx context.Context, userID string, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.findOwned(owner, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// PlaceOrder records an order and fills it straight away when it is
+// marketable against the latest quote: market orders always, limit orders
+// when the quote is at or through the limit. Other limit orders stay open
+// until NotifyQuote sees a crossing quote.
+func (s *PortfolioServiceImpl) PlaceOrder(ctx context.Context, userID string, id uuid.UUID, input OrderInput) (*models.PortfolioOrder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := validOrder(input)
+	if err != nil {
+		return nil, err
+	}
+	order.Symbol, err = s.trackedSymbol(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	portfolio, err := s.findOwned(owner, id)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := s.quoteRepo.FindLatestBySymbol(order.Symbol)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if quote == nil && order.Type == models.OrderTypeMarket {
+		return nil, fmt.Errorf("%w: %s", ErrNoQuote, order.Symbol)
+	}
+
+	order.PortfolioID = portfolio.ID
+	order.Status = models.OrderStatusOpen
+	err = s.repo.Transaction(func(tx repository.PortfolioRepository) error {
+		locked, err := tx.LockByID(portfolio.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkBuyingPower(tx, locked, order, quote); err != nil {
+			return err
+		}
+		if err := tx.CreateOrder(order); err != nil {
+			return err
+		}
+		if quote != nil && marketable(order, quote.PriceCurrent) {
+			return fill(tx, locked, order, quote)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (s *PortfolioServiceImpl) CancelOrder(ctx context.Context, userID string, id, orderID uuid.UUID) (*models.PortfolioOrder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	portfolio, err := s.findOwned(owner, id)
+	if err != nil {
+		return nil, err
+	}
+	var order *models.PortfolioOrder
+	err = s.repo.Transaction(func(tx repository.PortfolioRepository) error {
+		if _, err := tx.LockByID(portfolio.ID); err != nil {
+			return err
+		}
+		order, err = tx.FindOrderByID(orderID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
+		}
+		if order.PortfolioID != portfolio.ID {
+			return ErrOrderNotFound
+		}
+		if order.Status != models.OrderStatusOpen {
+			return fmt.Errorf("%w: order is %s", ErrOrderNotOpen, order.Status)
+		}
+		order.Status = models.OrderStatusCanceled
+		return tx.UpdateOrder(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// ListOrders returns the order history of a portfolio, newest first.
+func (s *PortfolioServiceImpl) ListOrders(ctx context.Context, userID string, id uuid.UUID, status string, limit int) ([]models.PortfolioOrder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	status = strings.ToLower(strings.TrimSpace(status))
+	switch status {
+	case "", models.OrderStatusOpen, models.OrderStatusFilled, models.OrderStatusCanceled, models.OrderStatusRejected:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidOrder, status)
+	}
+	if limit <= 0 {
+		limit = defaultOrderLimit
+	}
+	if limit > maxOrderLimit {
+		limit = maxOrderLimit
+	}
+	if _, err := s.findOwned(owner, id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindOrders(id, status, limit)
+}
+
+// NotifyQuote fills the open limit orders a new quote crosses. It is wired
+// in front of the cluster fan-out so only the replica that ingested the quote
+// fills orders.
+func (s *PortfolioServiceImpl) NotifyQuote(quote *models.StockQuote) {
+	if quote == nil {
+		return
+	}
+	orders, err := s.repo.FindOpenOrdersBySymbol(quote.Symbol)
+	if err != nil {
+		s.warnf("portfolio open orders lookup failed symbol=%s: %v", quote.Symbol, err)
+		return
+	}
+	for _, open := range orders {
+		if !marketable(&open, quote.PriceCurrent) {
+			continue
+		}
+		orderID := open.ID
+		err := s.repo.Transaction(func(tx repository.PortfolioRepository) error {
+			portfolio, err := tx.LockByID(open.PortfolioID)
+			if err != nil {
+				return err
+			}
+			// Re-read under the lock: the order may have been canceled or
+			// filled since the scan.
+			order, err := tx.FindOrderByID(orderID)
+			if err != nil {
+				return err
+			}
+			if order.Status != models.OrderStatusOpen {
+				return nil
+			}
+			return fill(tx, portfolio, order, quote)
+		})
+		if err != nil {
+			s.warnf("portfolio order fill failed order=%s: %v", orderID, err)
+		}
+	}
+}
+
+// checkBuyingPower rejects orders the portfolio could not settle once its
+// other open orders fill. Open buy limits reserve quantity*limit cash and
+// open sells reserve shares.
+func checkBuyingPower(tx repository.PortfolioRepository, portfolio *models.Portfolio, order *models.PortfolioOrder, quote *models.StockQuote) error {
+	open, err := tx.FindOrders(portfolio.ID, models.OrderStatusOpen, 0)
+	if err != nil {
+		return err
+	}
+	if order.Side == models.OrderSideBuy {
+		reserved := 0.0
+		for _, o := range open {
+			if o.Side == models.OrderSideBuy && o.LimitPrice != nil {
+				reserved += o.Quantity * *o.LimitPrice
+			}
+		}
+		price := quote.PriceCurrent
+		if order.Type == models.OrderTypeLimit {
+			price = *order.LimitPrice
+		}
+		if order.Quantity*price > portfolio.Cash-reserved+epsilon {
+			return fmt.Errorf("%w: need %.2f, available %.2f", ErrInsufficientCash, order.Quantity*price, portfolio.Cash-reserved)
+		}
+		return nil
+	}
+
+	position, err := tx.FindPosition(portfolio.ID, order.Symbol)
+	if err != nil {
+		return err
+	}
+	held := 0.0
+	if position != nil {
+		held = position.Quantity
+	}
+	for _, o := range open {
+		if o.Side == models.OrderSideSell && o.Symbol == order.Symbol {
+			held -= o.Quantity
+		}
+	}
+	if order.Quantity > held+epsilon {
+		return fmt.Errorf("%w: need %g %s, available %g", ErrInsufficientHolding, order.Quantity, order.Symbol, math.Max(held, 0))
+	}
+	return nil
+}
+
+// fill settles order at the quote price, moving cash, the position and
+// realized P&L. An order the portfolio can no longer settle is rejected
+// instead.
+func fill(tx repository.PortfolioRepository, portfolio *models.Portfolio, order *models.PortfolioOrder, quote *models.StockQuote) error {
+	price := quote.PriceCurrent
+	position, err := tx.FindPosition(portfolio.ID, order.Symbol)
+	if err != nil {
+		return err
+	}
+
+	switch order.Side {
+	case models.OrderSideBuy:
+		cost := order.Quantity * price
+		if cost > portfolio.Cash+epsilon {
+			return reject(tx, order, "insufficient cash at fill")
+		}
+		if position == nil {
+			position = &models.PortfolioPosition{PortfolioID: portfolio.ID, Symbol: order.Symbol}
+		}
+		quantity := position.Quantity + order.Quantity
+		position.AvgCost = (position.Quantity*position.AvgCost + cost) / quantity
+		position.Quantity = quantity
+		portfolio.Cash -= cost
+		if err := tx.SavePosition(position); err != nil {
+			return err
+		}
+	case models.OrderSideSell:
+		if position == nil || order.Quantity > position.Quantity+epsilon {
+			return reject(tx, order, "insufficient position at fill")
+		}
+		realized := (price - position.AvgCost) * order.Quantity
+		order.RealizedPnL = &realized
+		portfolio.Cash += order.Quantity * price
+		portfolio.RealizedPnL += realized
+		position.Quantity -= order.Quantity
+		if position.Quantity <= epsilon {
+			err = tx.DeletePosition(portfolio.ID, order.Symbol)
+		} else {
+			err = tx.SavePosition(position)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	filledAt := models.NewLocalTime(time.Now())
+	quoteID := quote.ID
+	order.Status = models.OrderStatusFilled
+	order.FilledPrice = &price
+	order.FilledAt = &filledAt
+	order.QuoteID = &quoteID
+	if err := tx.UpdateBalances(portfolio); err != nil {
+		return err
+	}
+	return tx.UpdateOrder(order)
+}
+
+func reject(tx repository.PortfolioRepository, order *models.PortfolioOrder, reason string) error {
+	order.Status = models.OrderStatusRejected
+	order.RejectReason = reason
+	return tx.UpdateOrder(order)
+}
+
+// marketable reports whether order would execute at price.
+func marketable(order *models.PortfolioOrder, price float64) bool {
+	if order.Type == models.OrderTypeMarket || order.LimitPrice == nil {
+		return true
+	}
+	if order.Side == models.OrderSideBuy {
+		return price <= *order.LimitPrice
+	}
+	return price >= *order.LimitPrice
+}
+
+func validOrder(input OrderInput) (*models.PortfolioOrder, error) {
+	order := &models.PortfolioOrder{
+		Symbol:   strings.ToUpper(strings.TrimSpace(input.Symbol)),
+		Side:     strings.ToLower(strings.TrimSpace(input.Side)),
+		Type:     strings.ToLower(strings.TrimSpace(input.Type)),
+		Quantity: input.Quantity,
+	}
+	if order.Symbol == "" {
+		return nil, fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	}
+	if order.Side != models.OrderSideBuy && order.Side != models.OrderSideSell {
+		return nil, fmt.Errorf("%w: side must be buy or sell", ErrInvalidOrder)
+	}
+	if order.Type == "" {
+		order.Type = models.OrderTypeMarket
+	}
+	if !(order.Quantity > 0) || math.IsInf(order.Quantity, 0) {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidOrder)
+	}
+	switch order.Type {
+	case models.OrderTypeMarket:
+		if input.LimitPrice != nil {
+			return nil, fmt.Errorf("%w: market orders take no limit price", ErrInvalidOrder)
+		}
+	case models.OrderTypeLimit:
+		if input.LimitPrice == nil || !(*input.LimitPrice > 0) || math.IsInf(*input.LimitPrice, 0) {
+			return nil, fmt.Errorf("%w: limit orders need a positive limit price", ErrInvalidOrder)
+		}
+		limit := *input.LimitPrice
+		order.LimitPrice = &limit
+	default:
+		return nil, fmt.Errorf("%w: type must be market or limit", ErrInvalidOrder)
+	}
+	return order, nil
+}
+
+// findOwned loads a portfolio and hides other users' portfolios behind
+// ErrPortfolioNotFound.
+func (s *PortfolioServiceImpl) findOwned(owner, id uuid.UUID) (*models.Portfolio, error) {
+	portfolio, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPortfolioNotFound
+		}
+		return nil, err
+	}
+	if portfolio.UserID != owner {
+		return nil, ErrPortfolioNotFound
+	}
+	return portfolio, nil
+}
+
+// trackedSymbol maps symbol onto the stored spelling of an active stock.
+func (s *PortfolioServiceImpl) trackedSymbol(symbol string) (string, error) {
+	all, err := s.stockRepo.ListSymbols()
+	if err != nil {
+		return "", err
+	}
+	for _, tracked := range all {
+		if strings.EqualFold(tracked, symbol) {
+			return tracked, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+}
+
+func (s *PortfolioServiceImpl) warnf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Warnf(format, args...)
+	}
+}
+
+func percent(value, base float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return value / base * 100
+}
+
+func parseUserID(userID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(strings.TrimSpace(userID))
+	if err != nil {
+		return uuid.Nil, ErrInvalidUser
+	}
+	return id, nil
+}
+
+func getEnvString(key, fallback string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.ParseFloat(raw, 64)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
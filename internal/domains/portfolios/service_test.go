@@ -0,0 +1,319 @@
+package portfolios
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/domains/fx_rates"
+	fxratesmock "sun-stockanalysis-api/internal/mocks/domains/fx_rates"
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+)
+
+type PortfolioServiceSuite struct {
+	suite.Suite
+	repo      *repositorymock.MockPortfolioRepository
+	stockRepo *repositorymock.MockStockRepository
+	quoteRepo *repositorymock.MockStockQuoteRepository
+	fx        *fxratesmock.MockFXRateService
+	service   PortfolioService
+	userID    uuid.UUID
+	portfolio *models.Portfolio
+}
+
+func (s *PortfolioServiceSuite) SetupTest() {
+	s.repo = repositorymock.NewMockPortfolioRepository(s.T())
+	s.stockRepo = repositorymock.NewMockStockRepository(s.T())
+	s.quoteRepo = repositorymock.NewMockStockQuoteRepository(s.T())
+	s.fx = fxratesmock.NewMockFXRateService(s.T())
+	s.service = NewPortfolioService(s.repo, s.stockRepo, s.quoteRepo, s.fx, nil)
+	s.userID = uuid.New()
+	s.portfolio = &models.Portfolio{ID: uuid.New(), UserID: s.userID, Name: "Paper", InitialCash: 10000, Cash: 10000}
+}
+
+func (s *PortfolioServiceSuite) expectTransaction() {
+	s.repo.EXPECT().Transaction(mock.Anything).RunAndReturn(func(fn func(repository.PortfolioRepository) error) error {
+		return fn(s.repo)
+	})
+}
+
+func (s *PortfolioServiceSuite) expectOrderSetup(symbol string, price float64) *models.StockQuote {
+	quote := &models.StockQuote{ID: uuid.New(), Symbol: symbol, PriceCurrent: price}
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL", "TSLA"}, nil)
+	s.repo.EXPECT().FindByID(s.portfolio.ID).Return(s.portfolio, nil)
+	s.quoteRepo.EXPECT().FindLatestBySymbol(symbol).Return(quote, nil)
+	s.expectTransaction()
+	s.repo.EXPECT().LockByID(s.portfolio.ID).Return(s.portfolio, nil)
+	return quote
+}
+
+func (s *PortfolioServiceSuite) TestCreate_UsesDefaultCash() {
+	s.repo.EXPECT().FindByUser(s.userID).Return(nil, nil)
+	s.repo.EXPECT().Create(mock.Anything).Return(nil)
+
+	portfolio, err := s.service.Create(context.Background(), s.userID.String(), " Paper ", nil)
+
+	s.Require().NoError(err)
+	s.Equal("Paper", portfolio.Name)
+	s.Equal(initialCash, portfolio.InitialCash)
+	s.Equal(initialCash, portfolio.Cash)
+}
+
+func (s *PortfolioServiceSuite) TestCreate_EnforcesLimit() {
+	s.repo.EXPECT().FindByUser(s.userID).Return(make([]models.Portfolio, maxPortfolios), nil)
+
+	_, err := s.service.Create(context.Background(), s.userID.String(), "Paper", nil)
+
+	s.True(errors.Is(err, ErrTooManyPortfolios))
+}
+
+func (s *PortfolioServiceSuite) TestPlaceOrder_MarketBuyFillsAtLatestQuote() {
+	quote := s.expectOrderSetup("AAPL", 100)
+	s.repo.EXPECT().FindOrders(s.portfolio.ID, models.OrderStatusOpen, 0).Return(nil, nil)
+	s.repo.EXPECT().CreateOrder(mock.Anything).Return(nil)
+	s.repo.EXPECT().FindPosition(s.portfolio.ID, "AAPL").Return(&models.PortfolioPosition{
+		PortfolioID: s.portfolio.ID, Symbol: "AAPL", Quantity: 10, AvgCost: 80,
+	}, nil)
+	s.repo.EXPECT().SavePosition(mock.Anything).RunAndReturn(func(p *models.PortfolioPosition) error {
+		s.InDelta(20, p.Quantity, 1e-9)
+		s.InDelta(90, p.AvgCost, 1e-9)
+		return nil
+	})
+	s.repo.EXPECT().UpdateBalances(s.portfolio).Return(nil)
+	s.repo.EXPECT().UpdateOrder(mock.Anything).Return(nil)
+
+	order, err := s.service.PlaceOrder(context.Background(), s.userID.String(), s.portfolio.ID, OrderInput{
+		Symbol: "aapl", Side: "BUY", Quantity: 10,
+	})
+
+	s.Require().NoError(err)
+	s.Equal(models.OrderStatusFilled, order.Status)
+	s.Equal(models.OrderTypeMarket, order.Type)
+	s.Equal("AAPL", order.Symbol)
+	s.Equal(100.0, *order.FilledPrice)
+	s.Equal(quote.ID, *order.QuoteID)
+	s.InDelta(9000, s.portfolio.Cash, 1e-9)
+}
+
+func (s *PortfolioServiceSuite) TestPlaceOrder_BuyCountsCashReservedByOpenLimits() {
+	s.expectOrderSetup("AAPL", 100)
+	limit := 50.0
+	s.repo.EXPECT().FindOrders(s.portfolio.ID, models.OrderStatusOpen, 0).Return([]models.PortfolioOrder{
+		{Symbol: "TSLA", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 150, LimitPrice: &limit},
+	}, nil)
+
+	_, err := s.service.PlaceOrder(context.Background(), s.userID.String(), s.portfolio.ID, OrderInput{
+		Symbol: "AAPL", Side: "buy", Quantity: 30,
+	})
+
+	s.True(errors.Is(err, ErrInsufficientCash))
+}
+
+func (s *PortfolioServiceSuite) TestPlaceOrder_LimitBelowMarketStaysOpen() {
+	s.expectOrderSetup("AAPL", 100)
+	s.repo.EXPECT().FindOrders(s.portfolio.ID, models.OrderStatusOpen, 0).Return(nil, nil)
+	s.repo.EXPECT().CreateOrder(mock.Anything).Return(nil)
+	limit := 95.0
+
+	order, err := s.service.PlaceOrder(context.Background(), s.userID.String(), s.portfolio.ID, OrderInput{
+		Symbol: "AAPL", Side: "buy", Type: "limit", Quantity: 10, LimitPrice: &limit,
+	})
+
+	s.Require().NoError(err)
+	s.Equal(models.OrderStatusOpen, order.Status)
+	s.Nil(order.FilledPrice)
+	s.Equal(10000.0, s.portfolio.Cash)
+}
+
+func (s *PortfolioServiceSuite) TestPlaceOrder_SellRealizesProfitAndClosesPosition() {
+	s.expectOrderSetup("AAPL", 120)
+	position := &models.PortfolioPosition{PortfolioID: s.portfolio.ID, Symbol: "AAPL", Quantity: 10, AvgCost: 100}
+	s.repo.EXPECT().FindOrders(s.portfolio.ID, models.OrderStatusOpen, 0).Return(nil, nil)
+	s.repo.EXPECT().FindPosition(s.portfolio.ID, "AAPL").Return(position, nil)
+	s.repo.EXPECT().CreateOrder(mock.Anything).Return(nil)
+	s.repo.EXPECT().DeletePosition(s.portfolio.ID, "AAPL").Return(nil)
+	s.repo.EXPECT().UpdateBalances(s.portfolio).Return(nil)
+	s.repo.EXPECT().UpdateOrder(mock.Anything).Return(nil)
+
+	order, err := s.service.PlaceOrder(context.Background(), s.userID.String(), s.portfolio.ID, OrderInput{
+		Symbol: "AAPL", Side: "sell", Quantity: 10,
+	})
+
+	s.Require().NoError(err)
+	s.Equal(models.OrderStatusFilled, order.Status)
+	s.InDelta(200, *order.RealizedPnL, 1e-9)
+	s.InDelta(11200, s.portfolio.Cash, 1e-9)
+	s.InDelta(200, s.portfolio.RealizedPnL, 1e-9)
+}
+
+func (s *PortfolioServiceSuite) TestPlaceOrder_SellMoreThanHeldIsRejected() {
+	s.expectOrderSetup("AAPL", 120)
+	s.repo.EXPECT().FindOrders(s.portfolio.ID, models.OrderStatusOpen, 0).Return([]models.PortfolioOrder{
+		{Symbol: "AAPL", Side: models.OrderSideSell, Quantity: 8},
+	}, nil)
+	s.repo.EXPECT().FindPosition(s.portfolio.ID, "AAPL").Return(&models.PortfolioPosition{Quantity: 10, AvgCost: 100}, nil)
+
+	_, err := s.service.PlaceOrder(context.Background(), s.userID.String(), s.portfolio.ID, OrderInput{
+		Symbol: "AAPL", Side: "sell", Quantity: 5,
+	})
+
+	s.True(errors.Is(err, ErrInsufficientHolding))
+}
+
+func (s *PortfolioServiceSuite) TestPlaceOrder_MarketWithoutQuoteFails() {
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL"}, nil)
+	s.repo.EXPECT().FindByID(s.portfolio.ID).Return(s.portfolio, nil)
+	s.quoteRepo.EXPECT().FindLatestBySymbol("AAPL").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.service.PlaceOrder(context.Background(), s.userID.String(), s.portfolio.ID, OrderInput{
+		Symbol: "AAPL", Side: "buy", Quantity: 1,
+	})
+
+	s.True(errors.Is(err, ErrNoQuote))
+}
+
+func (s *PortfolioServiceSuite) TestPlaceOrder_ValidatesInput() {
+	cases := []OrderInput{
+		{Symbol: "AAPL", Side: "hold", Quantity: 1},
+		{Symbol: "AAPL", Side: "buy", Quantity: 0},
+		{Symbol: "AAPL", Side: "buy", Type: "limit", Quantity: 1},
+		{Symbol: "AAPL", Side: "buy", Type: "stop", Quantity: 1},
+	}
+	for _, input := range cases {
+		_, err := s.service.PlaceOrder(context.Background(), s.userID.String(), s.portfolio.ID, input)
+		s.True(errors.Is(err, ErrInvalidOrder), "%+v", input)
+	}
+}
+
+func (s *PortfolioServiceSuite) TestPlaceOrder_HidesOtherUsersPortfolio() {
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL"}, nil)
+	s.repo.EXPECT().FindByID(s.portfolio.ID).Return(s.portfolio, nil)
+
+	_, err := s.service.PlaceOrder(context.Background(), uuid.NewString(), s.portfolio.ID, OrderInput{
+		Symbol: "AAPL", Side: "buy", Quantity: 1,
+	})
+
+	s.True(errors.Is(err, ErrPortfolioNotFound))
+}
+
+func (s *PortfolioServiceSuite) TestCancelOrder_RequiresOpenOrder() {
+	order := &models.PortfolioOrder{ID: uuid.New(), PortfolioID: s.portfolio.ID, Status: models.OrderStatusFilled}
+	s.repo.EXPECT().FindByID(s.portfolio.ID).Return(s.portfolio, nil)
+	s.expectTransaction()
+	s.repo.EXPECT().LockByID(s.portfolio.ID).Return(s.portfolio, nil)
+	s.repo.EXPECT().FindOrderByID(order.ID).Return(order, nil)
+
+	_, err := s.service.CancelOrder(context.Background(), s.userID.String(), s.portfolio.ID, order.ID)
+
+	s.True(errors.Is(err, ErrOrderNotOpen))
+}
+
+func (s *PortfolioServiceSuite) TestNotifyQuote_FillsCrossedLimitOrders() {
+	buyLimit, sellLimit := 101.0, 150.0
+	crossed := models.PortfolioOrder{
+		ID: uuid.New(), PortfolioID: s.portfolio.ID, Symbol: "AAPL", Side: models.OrderSideBuy,
+		Type: models.OrderTypeLimit, Quantity: 10, LimitPrice: &buyLimit, Status: models.OrderStatusOpen,
+	}
+	resting := models.PortfolioOrder{
+		ID: uuid.New(), PortfolioID: s.portfolio.ID, Symbol: "AAPL", Side: models.OrderSideSell,
+		Type: models.OrderTypeLimit, Quantity: 10, LimitPrice: &sellLimit, Status: models.OrderStatusOpen,
+	}
+	quote := &models.StockQuote{ID: uuid.New(), Symbol: "AAPL", PriceCurrent: 100}
+	s.repo.EXPECT().FindOpenOrdersBySymbol("AAPL").Return([]models.PortfolioOrder{crossed, resting}, nil)
+	s.expectTransaction()
+	s.repo.EXPECT().LockByID(s.portfolio.ID).Return(s.portfolio, nil)
+	current := crossed
+	s.repo.EXPECT().FindOrderByID(crossed.ID).Return(&current, nil)
+	s.repo.EXPECT().FindPosition(s.portfolio.ID, "AAPL").Return(nil, nil)
+	s.repo.EXPECT().SavePosition(mock.Anything).Return(nil)
+	s.repo.EXPECT().UpdateBalances(s.portfolio).Return(nil)
+	s.repo.EXPECT().UpdateOrder(&current).Return(nil)
+
+	s.service.NotifyQuote(quote)
+
+	s.Equal(models.OrderStatusFilled, current.Status)
+	s.Equal(100.0, *current.FilledPrice)
+	s.InDelta(9000, s.portfolio.Cash, 1e-9)
+}
+
+func (s *PortfolioServiceSuite) TestNotifyQuote_SkipsOrdersCanceledSinceScan() {
+	limit := 101.0
+	open := models.PortfolioOrder{
+		ID: uuid.New(), PortfolioID: s.portfolio.ID, Symbol: "AAPL", Side: models.OrderSideBuy,
+		Type: models.OrderTypeLimit, Quantity: 10, LimitPrice: &limit, Status: models.OrderStatusOpen,
+	}
+	s.repo.EXPECT().FindOpenOrdersBySymbol("AAPL").Return([]models.PortfolioOrder{open}, nil)
+	s.expectTransaction()
+	s.repo.EXPECT().LockByID(s.portfolio.ID).Return(s.portfolio, nil)
+	s.repo.EXPECT().FindOrderByID(open.ID).Return(&models.PortfolioOrder{ID: open.ID, Status: models.OrderStatusCanceled}, nil)
+
+	s.service.NotifyQuote(&models.StockQuote{Symbol: "AAPL", PriceCurrent: 100})
+
+	s.Equal(10000.0, s.portfolio.Cash)
+}
+
+func (s *PortfolioServiceSuite) TestSummary_MarksPositionsToLatestQuote() {
+	s.portfolio.Cash = 5000
+	s.portfolio.RealizedPnL = 50
+	s.repo.EXPECT().FindByID(s.portfolio.ID).Return(s.portfolio, nil)
+	s.repo.EXPECT().FindPositions(s.portfolio.ID).Return([]models.PortfolioPosition{
+		{Symbol: "AAPL", Quantity: 10, AvgCost: 100},
+		{Symbol: "TSLA", Quantity: 20, AvgCost: 200},
+	}, nil)
+	s.quoteRepo.EXPECT().FindLatestBySymbol("AAPL").Return(&models.StockQuote{PriceCurrent: 110}, nil)
+	s.quoteRepo.EXPECT().FindLatestBySymbol("TSLA").Return(nil, gorm.ErrRecordNotFound)
+
+	summary, err := s.service.Summary(context.Background(), s.userID.String(), s.portfolio.ID, "")
+
+	s.Require().NoError(err)
+	s.Equal("USD", summary.Currency)
+	s.Nil(summary.FXRate)
+	s.Require().Len(summary.Positions, 2)
+	s.InDelta(100, summary.Positions[0].UnrealizedPnL, 1e-9)
+	s.InDelta(10, summary.Positions[0].UnrealizedPnLPercent, 1e-9)
+	s.Nil(summary.Positions[1].LastPrice)
+	s.InDelta(4000, summary.Positions[1].MarketValue, 1e-9)
+	s.InDelta(5100, summary.MarketValue, 1e-9)
+	s.InDelta(10100, summary.Equity, 1e-9)
+	s.InDelta(100, summary.TotalReturn, 1e-9)
+	s.InDelta(1, summary.TotalReturnPercent, 1e-9)
+	s.Equal(50.0, summary.RealizedPnL)
+}
+
+func (s *PortfolioServiceSuite) TestSummary_ConvertsToRequestedCurrency() {
+	s.portfolio.Cash = 5000
+	s.repo.EXPECT().FindByID(s.portfolio.ID).Return(s.portfolio, nil)
+	s.repo.EXPECT().FindPositions(s.portfolio.ID).Return([]models.PortfolioPosition{
+		{Symbol: "AAPL", Quantity: 10, AvgCost: 100},
+	}, nil)
+	s.quoteRepo.EXPECT().FindLatestBySymbol("AAPL").Return(&models.StockQuote{PriceCurrent: 110}, nil)
+	converter := fx_rates.NewConverter("THB", "USD", []models.FXRate{
+		{Base: "USD", Quote: "THB", Rate: 36, RateDate: models.NewLocalDate(time.Now())},
+	}, nil)
+	s.fx.EXPECT().Converter(mock.Anything, "thb", mock.Anything, mock.Anything).Return(converter, nil)
+
+	summary, err := s.service.Summary(context.Background(), s.userID.String(), s.portfolio.ID, "thb")
+
+	s.Require().NoError(err)
+	s.Equal("THB", summary.Currency)
+	s.Require().NotNil(summary.FXRate)
+	s.Equal(36.0, *summary.FXRate)
+	s.InDelta(5000*36, summary.Portfolio.Cash, 1e-6)
+	s.InDelta(110*36, *summary.Positions[0].LastPrice, 1e-6)
+	s.InDelta(1100*36, summary.MarketValue, 1e-6)
+	s.InDelta(6100*36, summary.Equity, 1e-6)
+	s.InDelta(10, summary.Positions[0].UnrealizedPnLPercent, 1e-9)
+	s.Equal(5000.0, s.portfolio.Cash)
+}
+
+func TestPortfolioServiceSuite(t *testing.T) {
+	suite.Run(t, new(PortfolioServiceSuite))
+}
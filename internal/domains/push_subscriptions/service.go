@@ -27,12 +27,17 @@ type SaveSubscriptionInput struct {
 	UserAgent string
 }
 
+// WatchlistFilter reports whether a user follows any of the given symbols.
+type WatchlistFilter interface {
+	Watches(userID uuid.UUID, symbols ...string) (bool, error)
+}
+
 type PushSubscriptionService interface {
 	GetPublicKey(ctx context.Context) (string, error)
 	Save(ctx context.Context, userID string, input SaveSubscriptionInput) error
 	Delete(ctx context.Context, userID, deviceID string) error
 	Notify(event *models.AlertEvent, message string)
-	NotifyCompanyNewsReady(message string)
+	NotifyCompanyNewsReady(message string, symbols []string)
 	NotifyMarketOpen(message string)
 	NotifyMarketClose(message string)
 	StartSimulation(ctx context.Context, interval time.Duration, message string)
@@ -40,14 +45,15 @@ type PushSubscriptionService interface {
 
 type PushSubscriptionServiceImpl struct {
 	subRepo        repository.PushSubscriptionRepository
+	watchlists     WatchlistFilter
 	vapidPublicKey string
 	vapidPrivate   string
 	subject        string
-	triggerScore   int
 }
 
 func NewPushSubscriptionService(
 	subRepo repository.PushSubscriptionRepository,
+	watchlists WatchlistFilter,
 	pushCfg *configurations.Push,
 ) (PushSubscriptionService, error) {
 	if subRepo == nil {
@@ -55,18 +61,15 @@ func NewPushSubscriptionService(
 	}
 
 	service := &PushSubscriptionServiceImpl{
-		subRepo:      subRepo,
-		subject:      "admin@example.com",
-		triggerScore: 4,
+		subRepo:    subRepo,
+		watchlists: watchlists,
+		subject:    "admin@example.com",
 	}
 
 	if pushCfg != nil {
 		if strings.TrimSpace(pushCfg.Subject) != "" {
 			service.subject = strings.TrimSpace(pushCfg.Subject)
 		}
-		if pushCfg.TriggerScore > 0 {
-			service.triggerScore = pushCfg.TriggerScore
-		}
 		service.vapidPublicKey = strings.TrimSpace(pushCfg.VAPIDPublicKey)
 		service.vapidPrivate = strings.TrimSpace(pushCfg.VAPIDPrivateKey)
 	}
@@ -160,12 +163,13 @@ func (s *PushSubscriptionServiceImpl) Delete(ctx context.Context, userID, device
 	return s.subRepo.DeleteByUserAndDevice(userUUID, strings.TrimSpace(deviceID))
 }
 
+// Notify pushes an alert event to the devices of the rule's owner when the
+// owner follows the event's symbol.
 func (s *PushSubscriptionServiceImpl) Notify(event *models.AlertEvent, message string) {
-	if event == nil {
+	if event == nil || event.UserID == uuid.Nil {
 		return
 	}
-	score := int(event.ScoreEMA)
-	if score != s.triggerScore && score != -s.triggerScore {
+	if !s.follows(event.UserID, map[uuid.UUID]bool{}, event.Symbol) {
 		return
 	}
 
@@ -174,10 +178,17 @@ func (s *PushSubscriptionServiceImpl) Notify(event *models.AlertEvent, message s
 		return
 	}
 
-	s.sendToSubscriptions("Stock Alert", payload)
+	subscriptions, err := s.subRepo.ListActiveByUser(event.UserID)
+	if err != nil {
+		log.Printf("push notify result title=%s user_id=%s err=%v", "Stock Alert", event.UserID, err)
+		return
+	}
+	s.send("Stock Alert", payload, subscriptions)
 }
 
-func (s *PushSubscriptionServiceImpl) NotifyCompanyNewsReady(message string) {
+// NotifyCompanyNewsReady pushes the news digest to users who follow at least
+// one of symbols.
+func (s *PushSubscriptionServiceImpl) NotifyCompanyNewsReady(message string, symbols []string) {
 	if strings.TrimSpace(message) == "" {
 		message = "ข่าววันนี้มาเเล้ว"
 	}
@@ -185,7 +196,19 @@ func (s *PushSubscriptionServiceImpl) NotifyCompanyNewsReady(message string) {
 	if err != nil {
 		return
 	}
-	s.sendToSubscriptions("Company News", payload)
+	subscriptions, err := s.subRepo.ListActive()
+	if err != nil {
+		log.Printf("push notify result title=%s err=%v", "Company News", err)
+		return
+	}
+	followed := make(map[uuid.UUID]bool)
+	recipients := make([]models.PushSubscription, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if s.follows(sub.UserID, followed, symbols...) {
+			recipients = append(recipients, sub)
+		}
+	}
+	s.send("Company News", payload, recipients)
 }
 
 func (s *PushSubscriptionServiceImpl) NotifyMarketOpen(message string) {
@@ -256,6 +279,25 @@ func (s *PushSubscriptionServiceImpl) buildPopupPayload(title string, event *mod
 	})
 }
 
+// follows reports whether userID follows any of symbols, memoising answers in
+// cache. Users follow everything when no watchlist filter is configured or the
+// lookup fails.
+func (s *PushSubscriptionServiceImpl) follows(userID uuid.UUID, cache map[uuid.UUID]bool, symbols ...string) bool {
+	if s.watchlists == nil || len(symbols) == 0 {
+		return true
+	}
+	if ok, cached := cache[userID]; cached {
+		return ok
+	}
+	ok, err := s.watchlists.Watches(userID, symbols...)
+	if err != nil {
+		log.Printf("push watchlist lookup failed user_id=%s err=%v", userID, err)
+		ok = true
+	}
+	cache[userID] = ok
+	return ok
+}
+
 type pushSendResult struct {
 	total   int
 	success int
@@ -266,13 +308,17 @@ type pushSendResult struct {
 }
 
 func (s *PushSubscriptionServiceImpl) sendToSubscriptions(title string, payload []byte) pushSendResult {
-	result := pushSendResult{}
 	subscriptions, err := s.subRepo.ListActive()
 	if err != nil {
-		result.err = err
+		result := pushSendResult{err: err}
 		log.Printf("push notify result title=%s err=%v", title, result.err)
 		return result
 	}
+	return s.send(title, payload, subscriptions)
+}
+
+func (s *PushSubscriptionServiceImpl) send(title string, payload []byte, subscriptions []models.PushSubscription) pushSendResult {
+	result := pushSendResult{}
 	if len(subscriptions) == 0 {
 		log.Printf("push notify result title=%s total=0 message=no_active_subscriptions", title)
 		return result
@@ -1,15 +1,13 @@
 package stock
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 
+	"sun-stockanalysis-api/internal/domains/backfill"
+	"sun-stockanalysis-api/internal/marketdata"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/internal/repository"
 )
@@ -20,24 +18,17 @@ type StockService interface {
 	ListAll() ([]models.Stock, error)
 }
 
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
-}
-
 type StockServiceImpl struct {
-	repo         repository.StockRepository
-	httpClient   HTTPClient
-	finnhubToken string
+	repo     repository.StockRepository
+	provider marketdata.Provider
+	backfill backfill.BackfillService
 }
 
-func NewStockService(repo repository.StockRepository, httpClient HTTPClient, finnhubToken string) StockService {
-	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 10 * time.Second}
-	}
+func NewStockService(repo repository.StockRepository, provider marketdata.Provider, backfillService backfill.BackfillService) StockService {
 	return &StockServiceImpl{
-		repo:         repo,
-		httpClient:   httpClient,
-		finnhubToken: finnhubToken,
+		repo:     repo,
+		provider: provider,
+		backfill: backfillService,
 	}
 }
 
@@ -46,12 +37,13 @@ func (s *StockServiceImpl) GetStock(id uuid.UUID) (*models.Stock, error) {
 }
 
 func (s *StockServiceImpl) CreateStock(input CreateStockInput) error {
-	profile, err := s.fetchProfile(input.Body.Symbol)
+	ctx := context.Background()
+	profile, err := s.provider.Profile(ctx, input.Body.Symbol)
 	if err != nil {
 		return err
 	}
 
-	assetType, err := s.fetchAssetType(input.Body.Symbol)
+	assetType, err := s.fetchAssetType(ctx, input.Body.Symbol)
 	if err != nil {
 		return err
 	}
@@ -61,8 +53,8 @@ func (s *StockServiceImpl) CreateStock(input CreateStockInput) error {
 			return err
 		}
 	}
-	if profile.FinnhubIndustry != "" {
-		if err := s.repo.EnsureMasterSector(profile.FinnhubIndustry); err != nil {
+	if profile.Industry != "" {
+		if err := s.repo.EnsureMasterSector(profile.Industry); err != nil {
 			return err
 		}
 	}
@@ -77,93 +69,41 @@ func (s *StockServiceImpl) CreateStock(input CreateStockInput) error {
 		symbol = input.Body.Symbol
 	}
 
-	return s.repo.Create(&models.Stock{
+	if err := s.repo.Create(&models.Stock{
 		Symbol:    symbol,
 		Name:      profile.Name,
-		Sector:    profile.FinnhubIndustry,
+		Sector:    profile.Industry,
 		Exchange:  profile.Exchange,
 		AssetType: assetType,
 		Currency:  profile.Currency,
-	})
-}
-
-func (s *StockServiceImpl) ListAll() ([]models.Stock, error) {
-	return s.repo.FindAll()
-}
-
-type finnhubProfileResponse struct {
-	Exchange        string `json:"exchange"`
-	FinnhubIndustry string `json:"finnhubIndustry"`
-	Currency        string `json:"currency"`
-	Name            string `json:"name"`
-	Symbol          string `json:"symbol"`
-}
-
-type finnhubSearchResponse struct {
-	Count  int `json:"count"`
-	Result []struct {
-		Symbol string `json:"symbol"`
-		Type   string `json:"type"`
-	} `json:"result"`
-}
-
-func (s *StockServiceImpl) fetchProfile(symbol string) (*finnhubProfileResponse, error) {
-	url := fmt.Sprintf("https://finnhub.io/api/v1/stock/profile2?symbol=%s", symbol)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("X-Finnhub-Token", s.finnhubToken)
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("finnhub profile request failed: %s", strings.TrimSpace(string(body)))
+	}); err != nil {
+		return err
 	}
 
-	var result finnhubProfileResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	// A failed enqueue is not fatal; the backfill can be re-run from the admin endpoint.
+	if s.backfill != nil {
+		_, _ = s.backfill.Enqueue(symbol)
 	}
-	return &result, nil
+	return nil
 }
 
-func (s *StockServiceImpl) fetchAssetType(symbol string) (string, error) {
-	url := fmt.Sprintf("https://finnhub.io/api/v1/search?q=%s&exchange=US", symbol)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return "", err
-	}
-	req.Header.Set("X-Finnhub-Token", s.finnhubToken)
+func (s *StockServiceImpl) ListAll() ([]models.Stock, error) {
+	return s.repo.FindAll()
+}
 
-	resp, err := s.httpClient.Do(req)
+func (s *StockServiceImpl) fetchAssetType(ctx context.Context, symbol string) (string, error) {
+	matches, err := s.provider.SearchSymbols(ctx, symbol, "US")
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("finnhub search request failed: %s", strings.TrimSpace(string(body)))
-	}
-
-	var result finnhubSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
-	}
 
-	for _, item := range result.Result {
+	for _, item := range matches {
 		if strings.EqualFold(item.Symbol, symbol) {
 			return item.Type, nil
 		}
 	}
-	if len(result.Result) > 0 {
-		return result.Result[0].Type, nil
+	if len(matches) > 0 {
+		return matches[0].Type, nil
 	}
 	return "", nil
 }
@@ -0,0 +1,84 @@
+package stock_daily
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+const defaultRebuildMaxDays = 366
+
+var rebuildMaxDays = getEnvInt("STOCK_DAILY_REBUILD_MAX_DAYS", defaultRebuildMaxDays)
+
+var ErrInvalidRange = errors.New("invalid rebuild range")
+
+// RebuildResult reports what a rebuild touched.
+type RebuildResult struct {
+	Symbols    []string `json:"symbols"`
+	Days       int      `json:"days"`
+	Built      int      `json:"built"`
+	Recomputed int      `json:"recomputed"`
+}
+
+// Rebuild regenerates the stock_daily rows of every session from from to to
+// (inclusive trade dates) out of the stored quotes, for symbol or for every
+// active symbol when symbol is empty. Sessions come from each symbol's market
+// calendar, so weekends and holidays are skipped. Rows are upserted, so rebuilding is
+// idempotent. The EMA and indicator columns of each symbol are then replayed
+// over its full history so rows after the range stay consistent.
+func (s *StockDailyServiceImpl) Rebuild(ctx context.Context, symbol string, from, to time.Time) (*RebuildResult, error) {
+	fromDate := time.Time(models.NewLocalDate(from))
+	toDate := time.Time(models.NewLocalDate(to))
+	if toDate.Before(fromDate) {
+		return nil, ErrInvalidRange
+	}
+	days := int(toDate.Sub(fromDate).Hours()/24) + 1
+	if days > rebuildMaxDays {
+		return nil, ErrInvalidRange
+	}
+
+	symbols := []string{strings.TrimSpace(symbol)}
+	if symbols[0] == "" {
+		all, err := s.stockRepo.ListSymbols()
+		if err != nil {
+			return nil, err
+		}
+		symbols = all
+	}
+
+	result := &RebuildResult{Symbols: []string{}, Days: days}
+	for _, sym := range symbols {
+		calendar, err := s.calendars.CalendarForSymbol(ctx, sym)
+		if err != nil {
+			return result, err
+		}
+		built := 0
+		for _, session := range calendar.Sessions(fromDate, toDate) {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			start, end := session.QuoteWindow()
+			ok, err := s.buildDay(sym, session.TradeDate, start, end)
+			if err != nil {
+				return result, err
+			}
+			if ok {
+				built++
+			}
+		}
+		if built == 0 {
+			continue
+		}
+		result.Built += built
+		result.Symbols = append(result.Symbols, sym)
+		recomputed, err := s.RecomputeIndicators(ctx, sym)
+		result.Recomputed += recomputed
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
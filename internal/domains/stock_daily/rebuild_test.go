@@ -0,0 +1,171 @@
+package stock_daily
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/domains/market_calendar"
+	marketcalendarmock "sun-stockanalysis-api/internal/mocks/domains/market_calendar"
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type RebuildSuite struct {
+	suite.Suite
+	stockRepo  *repositorymock.MockStockRepository
+	quoteRepo  *repositorymock.MockStockQuoteRepository
+	metricRepo *repositorymock.MockStockDailyRepository
+	calendars  *marketcalendarmock.MockMarketCalendarService
+	service    StockDailyService
+	loc        *time.Location
+	us         *market_calendar.Calendar
+}
+
+func (s *RebuildSuite) SetupTest() {
+	s.stockRepo = repositorymock.NewMockStockRepository(s.T())
+	s.quoteRepo = repositorymock.NewMockStockQuoteRepository(s.T())
+	s.metricRepo = repositorymock.NewMockStockDailyRepository(s.T())
+	s.calendars = marketcalendarmock.NewMockMarketCalendarService(s.T())
+	s.service = NewStockDailyService(s.stockRepo, s.quoteRepo, s.metricRepo, s.calendars)
+	s.loc = time.FixedZone("Asia/Bangkok", 7*60*60)
+	s.us = s.calendar("US", "America/New_York", "09:30", "16:00")
+}
+
+func (s *RebuildSuite) calendar(code, timezone, open, close string, holidays ...models.MarketHoliday) *market_calendar.Calendar {
+	calendar, err := market_calendar.NewCalendar(models.MarketCalendar{
+		Code:     code,
+		Timezone: timezone,
+		Open:     open,
+		Close:    close,
+	}, holidays)
+	s.Require().NoError(err)
+	return calendar
+}
+
+func sessionQuote(price float64, volume *float64) models.StockQuote {
+	return models.StockQuote{Symbol: "AAPL", PriceCurrent: price, Volume: volume}
+}
+
+func (s *RebuildSuite) TestRebuild_UpsertsTrueOHLCVAndRecomputes() {
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, s.loc)
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, s.us.Location)
+	end := time.Date(2024, 3, 4, 16, 30, 0, 0, s.us.Location)
+	v1, v2 := 100.0, 50.0
+
+	s.calendars.EXPECT().CalendarForSymbol(mock.Anything, "AAPL").Return(s.us, nil)
+	s.quoteRepo.EXPECT().FindBySymbolBetween("AAPL", start, end).Return([]models.StockQuote{
+		sessionQuote(10, &v1), sessionQuote(12, nil), sessionQuote(9, &v2), sessionQuote(11, nil),
+	}, nil)
+	s.metricRepo.EXPECT().FindRecentBySymbol("AAPL", day, indicatorHistory).Return([]models.StockDaily{
+		{Symbol: "AAPL", PriceClose: 8, EMA20: 8, EMA100: 8},
+	}, nil)
+	s.metricRepo.EXPECT().Upsert(mock.Anything).RunAndReturn(func(m *models.StockDaily) error {
+		s.Equal(10.0, m.PriceOpen)
+		s.Equal(12.0, m.PriceHigh)
+		s.Equal(9.0, m.PriceLow)
+		s.Equal(11.0, m.PriceClose)
+		s.Equal(8.0, m.PricePrevClose)
+		s.Equal(3.0, m.DeltaPrice)
+		s.Require().NotNil(m.ChangePrice)
+		s.InDelta(3.0, *m.ChangePrice, 1e-9)
+		s.Require().NotNil(m.Volume)
+		s.Equal(150.0, *m.Volume)
+		s.True(time.Time(m.TradeDate).Equal(day))
+		return nil
+	})
+	s.metricRepo.EXPECT().FindHistoryBySymbol("AAPL").Return(nil, nil)
+
+	result, err := s.service.Rebuild(context.Background(), "AAPL", day, day)
+
+	s.Require().NoError(err)
+	s.Equal(1, result.Days)
+	s.Equal(1, result.Built)
+	s.Equal([]string{"AAPL"}, result.Symbols)
+}
+
+func (s *RebuildSuite) TestRebuild_SkipsDaysWithoutQuotes() {
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, s.loc)
+	to := time.Date(2024, 3, 5, 0, 0, 0, 0, s.loc)
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL"}, nil)
+	s.calendars.EXPECT().CalendarForSymbol(mock.Anything, "AAPL").Return(s.us, nil)
+	s.quoteRepo.EXPECT().FindBySymbolBetween("AAPL", mock.Anything, mock.Anything).Return(nil, nil).Times(2)
+
+	result, err := s.service.Rebuild(context.Background(), "", from, to)
+
+	s.Require().NoError(err)
+	s.Equal(2, result.Days)
+	s.Zero(result.Built)
+	s.Empty(result.Symbols)
+}
+
+func (s *RebuildSuite) TestRebuild_ReturnsRepositoryErrors() {
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, s.loc)
+	dbErr := errors.New("connection reset")
+	s.calendars.EXPECT().CalendarForSymbol(mock.Anything, "AAPL").Return(s.us, nil)
+	s.quoteRepo.EXPECT().FindBySymbolBetween("AAPL", mock.Anything, mock.Anything).Return(nil, dbErr).Once()
+
+	_, err := s.service.Rebuild(context.Background(), "AAPL", day, day)
+	s.ErrorIs(err, dbErr)
+
+	s.quoteRepo.EXPECT().FindBySymbolBetween("AAPL", mock.Anything, mock.Anything).
+		Return([]models.StockQuote{sessionQuote(10, nil)}, nil).Once()
+	s.metricRepo.EXPECT().FindRecentBySymbol("AAPL", day, indicatorHistory).Return(nil, dbErr)
+
+	_, err = s.service.Rebuild(context.Background(), "AAPL", day, day)
+	s.ErrorIs(err, dbErr)
+}
+
+func (s *RebuildSuite) TestRebuild_SkipsWeekendsAndHolidays() {
+	holiday := models.MarketHoliday{Date: models.NewLocalDate(time.Date(2024, 3, 4, 0, 0, 0, 0, s.loc)), Name: "Closure"}
+	calendar := s.calendar("US", "America/New_York", "09:30", "16:00", holiday)
+	from := time.Date(2024, 3, 2, 0, 0, 0, 0, s.loc)
+	to := time.Date(2024, 3, 5, 0, 0, 0, 0, s.loc)
+	s.calendars.EXPECT().CalendarForSymbol(mock.Anything, "AAPL").Return(calendar, nil)
+	s.quoteRepo.EXPECT().FindBySymbolBetween("AAPL",
+		time.Date(2024, 3, 5, 9, 0, 0, 0, calendar.Location),
+		time.Date(2024, 3, 5, 16, 30, 0, 0, calendar.Location),
+	).Return(nil, nil).Once()
+
+	result, err := s.service.Rebuild(context.Background(), "AAPL", from, to)
+
+	s.Require().NoError(err)
+	s.Equal(4, result.Days)
+	s.Zero(result.Built)
+}
+
+func (s *RebuildSuite) TestBuildForSession_OnlyBuildsSymbolsOnTheCalendar() {
+	set := s.calendar("SET", "Asia/Bangkok", "10:00", "16:30")
+	session, ok := s.us.SessionOn(time.Date(2024, 7, 1, 0, 0, 0, 0, s.loc))
+	s.Require().True(ok)
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL", "PTT"}, nil)
+	s.calendars.EXPECT().CalendarForSymbol(mock.Anything, "AAPL").Return(s.us, nil)
+	s.calendars.EXPECT().CalendarForSymbol(mock.Anything, "PTT").Return(set, nil)
+	// 09:00-16:30 EDT is 20:00 Bangkok to 03:30 the next morning.
+	s.quoteRepo.EXPECT().FindBySymbolBetween("AAPL", mock.Anything, mock.Anything).
+		RunAndReturn(func(_ string, start, end time.Time) ([]models.StockQuote, error) {
+			s.True(start.Equal(time.Date(2024, 7, 1, 20, 0, 0, 0, s.loc)))
+			s.True(end.Equal(time.Date(2024, 7, 2, 3, 30, 0, 0, s.loc)))
+			return nil, nil
+		})
+
+	s.Require().NoError(s.service.BuildForSession(context.Background(), session))
+}
+
+func (s *RebuildSuite) TestRebuild_RejectsInvalidRange() {
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, s.loc)
+
+	_, err := s.service.Rebuild(context.Background(), "AAPL", from, from.AddDate(0, 0, -1))
+	s.True(errors.Is(err, ErrInvalidRange))
+
+	_, err = s.service.Rebuild(context.Background(), "AAPL", from, from.AddDate(0, 0, rebuildMaxDays))
+	s.True(errors.Is(err, ErrInvalidRange))
+}
+
+func TestRebuildSuite(t *testing.T) {
+	suite.Run(t, new(RebuildSuite))
+}
@@ -0,0 +1,50 @@
+package stock_daily
+
+import (
+	"context"
+	"strings"
+
+	"sun-stockanalysis-api/internal/indicators"
+	"sun-stockanalysis-api/internal/models"
+)
+
+// RecomputeIndicators rebuilds the stored EMA and indicator columns of every
+// stock_daily row for symbol, or for every active symbol when symbol is empty,
+// replaying the history oldest trade date first. It returns the number of rows
+// updated.
+func (s *StockDailyServiceImpl) RecomputeIndicators(ctx context.Context, symbol string) (int, error) {
+	symbols := []string{strings.TrimSpace(symbol)}
+	if symbols[0] == "" {
+		all, err := s.stockRepo.ListSymbols()
+		if err != nil {
+			return 0, err
+		}
+		symbols = all
+	}
+
+	updated := 0
+	for _, sym := range symbols {
+		metrics, err := s.metricRepo.FindHistoryBySymbol(sym)
+		if err != nil {
+			return updated, err
+		}
+		ind := indicators.NewSet(indicatorConfig)
+		var prev *models.StockDaily
+		for i := range metrics {
+			if err := ctx.Err(); err != nil {
+				return updated, err
+			}
+			metric := &metrics[i]
+			metric.EMA20, metric.EMA100 = nextEMAs(prev, metric.PriceClose)
+			metric.EMATrend = emaTrend(metric.EMA20, metric.EMA100)
+			snapshot := ind.Update(indicators.Bar{High: metric.PriceHigh, Low: metric.PriceLow, Close: metric.PriceClose})
+			metric.TechnicalIndicators = models.NewTechnicalIndicators(snapshot, ind.State())
+			if err := s.metricRepo.UpdateIndicators(metric); err != nil {
+				return updated, err
+			}
+			prev = metric
+			updated++
+		}
+	}
+	return updated, nil
+}
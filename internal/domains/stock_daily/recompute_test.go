@@ -0,0 +1,72 @@
+package stock_daily
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/indicators"
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type RecomputeIndicatorsSuite struct {
+	suite.Suite
+	stockRepo  *repositorymock.MockStockRepository
+	metricRepo *repositorymock.MockStockDailyRepository
+	service    StockDailyService
+}
+
+func (s *RecomputeIndicatorsSuite) SetupTest() {
+	s.stockRepo = repositorymock.NewMockStockRepository(s.T())
+	s.metricRepo = repositorymock.NewMockStockDailyRepository(s.T())
+	s.service = NewStockDailyService(s.stockRepo, nil, s.metricRepo, nil)
+}
+
+func (s *RecomputeIndicatorsSuite) TestRecomputeIndicators_ReplaysHistoryWithStandardAlpha() {
+	closes := []float64{10, 11, 12, 11}
+	history := make([]models.StockDaily, len(closes))
+	for i, c := range closes {
+		history[i] = models.StockDaily{Symbol: "AAPL", PriceClose: c, EMA20: 999, EMA100: 999}
+	}
+	s.metricRepo.EXPECT().FindHistoryBySymbol("AAPL").Return(history, nil)
+
+	ema20 := indicators.NewEMA(emaPeriod20)
+	ema100 := indicators.NewEMA(emaPeriod100)
+	for i, c := range closes {
+		want20 := ema20.Update(c)
+		want100 := ema100.Update(c)
+		s.metricRepo.EXPECT().UpdateIndicators(mock.MatchedBy(func(m *models.StockDaily) bool {
+			return m.PriceClose == c
+		})).RunAndReturn(func(m *models.StockDaily) error {
+			s.InDelta(want20, m.EMA20, 1e-9)
+			s.InDelta(want100, m.EMA100, 1e-9)
+			s.Equal(emaTrend(want20, want100), m.EMATrend)
+			s.Equal(i+1, m.IndicatorSamples)
+			s.Nil(m.RSI14)
+			return nil
+		}).Once()
+	}
+
+	updated, err := s.service.RecomputeIndicators(context.Background(), "AAPL")
+
+	s.Require().NoError(err)
+	s.Equal(len(closes), updated)
+}
+
+func (s *RecomputeIndicatorsSuite) TestRecomputeIndicators_AllSymbols() {
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL", "TSLA"}, nil)
+	s.metricRepo.EXPECT().FindHistoryBySymbol("AAPL").Return(nil, nil)
+	s.metricRepo.EXPECT().FindHistoryBySymbol("TSLA").Return(nil, nil)
+
+	updated, err := s.service.RecomputeIndicators(context.Background(), "")
+
+	s.Require().NoError(err)
+	s.Zero(updated)
+}
+
+func TestRecomputeIndicatorsSuite(t *testing.T) {
+	suite.Run(t, new(RecomputeIndicatorsSuite))
+}
@@ -0,0 +1,94 @@
+package stock_daily
+
+import (
+	"context"
+	"time"
+
+	"sun-stockanalysis-api/internal/indicators"
+	"sun-stockanalysis-api/internal/marketdata"
+	"sun-stockanalysis-api/internal/models"
+)
+
+// SeedFromCandles stores historical daily candles as stock_daily rows, oldest
+// first, continuing the EMA chain and indicators from the latest stored row. Days at or before
+// the latest stored trade date are ignored, so seeding is safe to repeat.
+func (s *StockDailyServiceImpl) SeedFromCandles(ctx context.Context, symbol string, candles []marketdata.Candle) (int, error) {
+	recent, err := s.metricRepo.FindRecentBySymbol(symbol, time.Time{}, indicatorHistory)
+	if err != nil {
+		return 0, err
+	}
+	var prev *models.StockDaily
+	var after time.Time
+	if len(recent) > 0 {
+		prev = &recent[0]
+		after = time.Time(prev.TradeDate)
+	}
+	ind := resumeIndicators(recent)
+
+	inserted := 0
+	var lastClose float64
+	if prev != nil {
+		lastClose = prev.PriceClose
+	}
+	for _, candle := range candles {
+		if err := ctx.Err(); err != nil {
+			return inserted, err
+		}
+		if candle.Close <= 0 {
+			continue
+		}
+		tradeDate := candleTradeDate(candle.Time)
+		if !after.IsZero() && !time.Time(tradeDate).After(after) {
+			lastClose = candle.Close
+			continue
+		}
+
+		var changePrice, changePercent *float64
+		if lastClose > 0 {
+			change := candle.Close - lastClose
+			percent := change / lastClose * 100
+			changePrice = &change
+			changePercent = &percent
+		}
+
+		volume := candle.Volume
+		ema20, ema100 := nextEMAs(prev, candle.Close)
+		snapshot := ind.Update(indicators.Bar{High: candle.High, Low: candle.Low, Close: candle.Close})
+
+		metric := &models.StockDaily{
+			Symbol:         symbol,
+			PriceAverage:   (candle.High + candle.Low + candle.Close) / 3,
+			PriceHigh:      candle.High,
+			PriceLow:       candle.Low,
+			PriceOpen:      candle.Open,
+			PriceClose:     candle.Close,
+			PricePrevClose: lastClose,
+			ChangePrice:    changePrice,
+			ChangePercent:  changePercent,
+			DeltaPrice:     candle.High - candle.Low,
+			Volume:         &volume,
+			EMA20:          ema20,
+			EMA100:         ema100,
+			EMATrend:       emaTrend(ema20, ema100),
+			TradeDate:      tradeDate,
+			CreatedAt:      models.NewLocalTime(time.Time(tradeDate)),
+
+			TechnicalIndicators: models.NewTechnicalIndicators(snapshot, ind.State()),
+		}
+		if err := s.metricRepo.Upsert(metric); err != nil {
+			return inserted, err
+		}
+		prev = metric
+		lastClose = candle.Close
+		inserted++
+	}
+	return inserted, nil
+}
+
+// candleTradeDate maps a provider daily candle, stamped at midnight UTC of the
+// exchange session, onto the trade date stored in stock_daily.
+func candleTradeDate(t time.Time) models.LocalDate {
+	utc := t.UTC()
+	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
+	return models.NewLocalDate(time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, loc))
+}
@@ -8,8 +8,9 @@ import (
 	"strings"
 	"time"
 
-	"gorm.io/gorm"
-
+	"sun-stockanalysis-api/internal/domains/market_calendar"
+	"sun-stockanalysis-api/internal/indicators"
+	"sun-stockanalysis-api/internal/marketdata"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/internal/repository"
 )
@@ -19,42 +20,61 @@ const (
 	defaultEMAPeriod100 = 100
 )
 
+var (
+	indicatorConfig  = indicators.DefaultConfig()
+	indicatorHistory = indicatorConfig.BollingerPeriod - 1
+)
+
 var (
 	emaPeriod20  = getEnvInt("STOCK_DAILY_EMA_PERIOD20", defaultEMAPeriod20)
 	emaPeriod100 = getEnvInt("STOCK_DAILY_EMA_PERIOD100", defaultEMAPeriod100)
 )
 
 type StockDailyService interface {
-	BuildForWindow(ctx context.Context, start, end time.Time) error
+	BuildForSession(ctx context.Context, session market_calendar.Session) error
 	ListBySymbol(ctx context.Context, symbol string) ([]models.StockDaily, error)
+	SeedFromCandles(ctx context.Context, symbol string, candles []marketdata.Candle) (int, error)
+	RecomputeIndicators(ctx context.Context, symbol string) (int, error)
+	Rebuild(ctx context.Context, symbol string, from, to time.Time) (*RebuildResult, error)
+}
+
+// Calendars resolves the market calendar that governs a symbol.
+type Calendars interface {
+	CalendarForSymbol(ctx context.Context, symbol string) (*market_calendar.Calendar, error)
 }
 
 type StockDailyServiceImpl struct {
 	stockRepo  repository.StockRepository
 	quoteRepo  repository.StockQuoteRepository
 	metricRepo repository.StockDailyRepository
+	calendars  Calendars
 }
 
 func NewStockDailyService(
 	stockRepo repository.StockRepository,
 	quoteRepo repository.StockQuoteRepository,
 	metricRepo repository.StockDailyRepository,
+	calendars Calendars,
 ) StockDailyService {
 	return &StockDailyServiceImpl{
 		stockRepo:  stockRepo,
 		quoteRepo:  quoteRepo,
 		metricRepo: metricRepo,
+		calendars:  calendars,
 	}
 }
 
-func (s *StockDailyServiceImpl) BuildForWindow(ctx context.Context, start, end time.Time) error {
+// BuildForSession summarises the quotes stored in the session's quote window
+// into one stock_daily row per active symbol traded on the session's
+// calendar. Rows are upserted on (symbol, trade date), so running it twice
+// for the same session is safe.
+func (s *StockDailyServiceImpl) BuildForSession(ctx context.Context, session market_calendar.Session) error {
 	symbols, err := s.stockRepo.ListSymbols()
 	if err != nil || len(symbols) == 0 {
 		return err
 	}
 
-	tradeDate := tradeDateFromEnd(end)
-
+	start, end := session.QuoteWindow()
 	for _, symbol := range symbols {
 		select {
 		case <-ctx.Done():
@@ -62,48 +82,85 @@ func (s *StockDailyServiceImpl) BuildForWindow(ctx context.Context, start, end t
 		default:
 		}
 
-		quotes, err := s.quoteRepo.FindBySymbolBetween(symbol, start, end)
-		if err != nil || len(quotes) == 0 {
+		calendar, err := s.calendars.CalendarForSymbol(ctx, symbol)
+		if err != nil {
+			return err
+		}
+		if calendar.Code != session.Calendar {
 			continue
 		}
+		if _, err := s.buildDay(symbol, session.TradeDate, start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		first := quotes[0]
-		last := quotes[len(quotes)-1]
+// buildDay upserts the stock_daily row of symbol for tradeDate from the quotes
+// stored between start and end. It reports false when there were no quotes.
+// The previous close and the EMA/indicator chain continue from the latest row
+// traded before tradeDate.
+func (s *StockDailyServiceImpl) buildDay(symbol string, tradeDate models.LocalDate, start, end time.Time) (bool, error) {
+	quotes, err := s.quoteRepo.FindBySymbolBetween(symbol, start, end)
+	if err != nil {
+		return false, err
+	}
+	if len(quotes) == 0 {
+		return false, nil
+	}
 
-		avg, high, low := summarizePrices(quotes)
+	first := quotes[0]
+	last := quotes[len(quotes)-1]
+	closePrice := last.PriceCurrent
 
-		ema20 := s.calculateEMA(symbol, last.PriceCurrent, emaPeriod20)
-		ema100 := s.calculateEMA(symbol, last.PriceCurrent, emaPeriod100)
-		emaTrend := 0
-		if ema20 > ema100 {
-			emaTrend = 1
-		} else if ema20 < ema100 {
-			emaTrend = -1
-		} else if ema20 == ema100 {
-			emaTrend = 0
-		}
+	avg, high, low := summarizePrices(quotes)
 
-		metric := &models.StockDaily{
-			Symbol:         symbol,
-			PriceAverage:   avg,
-			PriceHigh:      high,
-			PriceLow:       low,
-			PriceOpen:      first.PriceCurrent,
-			PricePrevClose: last.PriceCurrent,
-			ChangePrice:    last.ChangePrice,
-			ChangePercent:  last.ChangePercent,
-			DeltaPrice:     high - low,
-			EMA20:          ema20,
-			EMA100:         ema100,
-			TradeDate:      tradeDate,
-			EMATrend:       emaTrend,
-		}
+	recent, err := s.metricRepo.FindRecentBySymbol(symbol, time.Time(tradeDate), indicatorHistory)
+	if err != nil {
+		return false, err
+	}
+	var prev *models.StockDaily
+	if len(recent) > 0 {
+		prev = &recent[0]
+	}
 
-		if err := s.metricRepo.Create(metric); err != nil {
-			return err
-		}
+	changePrice, changePercent := last.ChangePrice, last.ChangePercent
+	prevClose := previousClose(prev, last)
+	if prevClose > 0 {
+		change := closePrice - prevClose
+		percent := change / prevClose * 100
+		changePrice = &change
+		changePercent = &percent
 	}
-	return nil
+
+	ema20, ema100 := nextEMAs(prev, closePrice)
+	ind := resumeIndicators(recent)
+	snapshot := ind.Update(indicators.Bar{High: high, Low: low, Close: closePrice})
+
+	metric := &models.StockDaily{
+		Symbol:         symbol,
+		PriceAverage:   avg,
+		PriceHigh:      high,
+		PriceLow:       low,
+		PriceOpen:      first.PriceCurrent,
+		PriceClose:     closePrice,
+		PricePrevClose: prevClose,
+		ChangePrice:    changePrice,
+		ChangePercent:  changePercent,
+		DeltaPrice:     high - low,
+		Volume:         sumVolume(quotes),
+		EMA20:          ema20,
+		EMA100:         ema100,
+		TradeDate:      tradeDate,
+		EMATrend:       emaTrend(ema20, ema100),
+
+		TechnicalIndicators: models.NewTechnicalIndicators(snapshot, ind.State()),
+	}
+
+	if err := s.metricRepo.Upsert(metric); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (s *StockDailyServiceImpl) ListBySymbol(ctx context.Context, symbol string) ([]models.StockDaily, error) {
@@ -119,30 +176,50 @@ func (s *StockDailyServiceImpl) ListBySymbol(ctx context.Context, symbol string)
 	return s.metricRepo.FindPreviousBySymbol(symbol)
 }
 
-func (s *StockDailyServiceImpl) calculateEMA(symbol string, current float64, period int) float64 {
-	if period <= 1 {
-		return current
+// nextEMAs continues the EMA20/EMA100 chain stored on prev with a new close.
+// The first row of a symbol seeds both averages with its close.
+func nextEMAs(prev *models.StockDaily, current float64) (float64, float64) {
+	if prev == nil {
+		return current, current
 	}
-	prev, err := s.metricRepo.FindLatestBySymbol(symbol)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return current
-		}
-		return current
+	return indicators.NextEMA(prev.EMA20, current, emaPeriod20),
+		indicators.NextEMA(prev.EMA100, current, emaPeriod100)
+}
+
+// previousClose returns the close of the previous session: the close stored on
+// prev or, for a symbol's first row, the one implied by the last quote's change.
+func previousClose(prev *models.StockDaily, last models.StockQuote) float64 {
+	if prev != nil && prev.PriceClose > 0 {
+		return prev.PriceClose
 	}
-	if prev == nil {
-		return current
+	if last.ChangePrice != nil {
+		return last.PriceCurrent - *last.ChangePrice
+	}
+	return 0
+}
+
+// resumeIndicators rebuilds the indicator set from the most recent rows,
+// newest first.
+func resumeIndicators(recent []models.StockDaily) *indicators.Set {
+	if len(recent) == 0 {
+		return indicators.NewSet(indicatorConfig)
 	}
-	emaPrev := current
-	switch period {
-	case emaPeriod20:
-		emaPrev = prev.EMA20
-	case emaPeriod100:
-		emaPrev = prev.EMA100
+	closes := make([]float64, 0, len(recent))
+	for i := len(recent) - 1; i >= 0; i-- {
+		closes = append(closes, recent[i].PriceClose)
 	}
+	latest := recent[0]
+	return indicators.ResumeSet(indicatorConfig, latest.IndicatorState(latest.PriceClose), closes)
+}
 
-	alpha := 2.0 / float64(period-1)
-	return alpha*current + (1-alpha)*emaPrev
+func emaTrend(ema20, ema100 float64) int {
+	if ema20 > ema100 {
+		return 1
+	}
+	if ema20 < ema100 {
+		return -1
+	}
+	return 0
 }
 
 func summarizePrices(quotes []models.StockQuote) (avg float64, high float64, low float64) {
@@ -166,12 +243,21 @@ func summarizePrices(quotes []models.StockQuote) (avg float64, high float64, low
 	return avg, high, low
 }
 
-func tradeDateFromEnd(end time.Time) models.LocalDate {
-	loc := time.FixedZone("Asia/Bangkok", 7*60*60)
-	endLocal := end.In(loc)
-	yesterday := endLocal.AddDate(0, 0, -1)
-	date := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, loc)
-	return models.NewLocalDate(date)
+// sumVolume totals the volume of the quotes that carry one, or returns nil
+// when none do.
+func sumVolume(quotes []models.StockQuote) *float64 {
+	var total float64
+	found := false
+	for _, q := range quotes {
+		if q.Volume != nil {
+			total += *q.Volume
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &total
 }
 
 func getEnvInt(key string, fallback int) int {
@@ -0,0 +1,181 @@
+package stock_quotes
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultQuoteWorkers = 4
+)
+
+var quoteWorkers = getEnvInt("QUOTE_WORKERS", defaultQuoteWorkers)
+
+const (
+	SymbolStatusFetched = "fetched"
+	SymbolStatusFailed  = "failed"
+	SymbolStatusSkipped = "skipped"
+)
+
+var (
+	errNoPrice      = errors.New("provider returned no price")
+	errMarketClosed = errors.New("outside trading session")
+)
+
+// SymbolResult is the outcome of one symbol in a polling cycle.
+type SymbolResult struct {
+	Symbol     string `json:"symbol"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// CycleResult summarises one polling cycle across every active symbol.
+type CycleResult struct {
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	DurationMs int64          `json:"duration_ms"`
+	Fetched    int            `json:"fetched"`
+	Failed     int            `json:"failed"`
+	Skipped    int            `json:"skipped"`
+	Error      string         `json:"error,omitempty"`
+	Symbols    []SymbolResult `json:"symbols"`
+}
+
+// fetchAndStoreAll fetches every active symbol through a bounded worker pool.
+// Symbols whose market is outside its session are reported as skipped.
+// Request pacing is left to the provider's rate limiter.
+func (s *StockQuoteServiceImpl) fetchAndStoreAll(ctx context.Context) *CycleResult {
+	result := &CycleResult{StartedAt: time.Now()}
+	defer s.finishCycle(result)
+
+	symbols, err := s.stockRepo.ListSymbols()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	workers := s.workers
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(symbols) {
+		workers = len(symbols)
+	}
+
+	jobs := make(chan string)
+	results := make(chan SymbolResult, len(symbols))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for symbol := range jobs {
+				results <- s.fetchAndStoreSymbol(ctx, symbol)
+			}
+		}()
+	}
+
+	for _, symbol := range symbols {
+		symbol = strings.TrimSpace(symbol)
+		if symbol == "" {
+			continue
+		}
+		if ctx.Err() != nil {
+			results <- SymbolResult{Symbol: symbol, Status: SymbolStatusSkipped, Error: ctx.Err().Error()}
+			continue
+		}
+		if s.sessions != nil && !s.sessions.InQuoteWindow(ctx, symbol, result.StartedAt) {
+			results <- SymbolResult{Symbol: symbol, Status: SymbolStatusSkipped, Error: errMarketClosed.Error()}
+			continue
+		}
+		jobs <- symbol
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	for r := range results {
+		switch r.Status {
+		case SymbolStatusFetched:
+			result.Fetched++
+		case SymbolStatusFailed:
+			result.Failed++
+		case SymbolStatusSkipped:
+			result.Skipped++
+		}
+		result.Symbols = append(result.Symbols, r)
+	}
+	sort.Slice(result.Symbols, func(i, j int) bool {
+		return result.Symbols[i].Symbol < result.Symbols[j].Symbol
+	})
+	return result
+}
+
+func (s *StockQuoteServiceImpl) fetchAndStoreSymbol(ctx context.Context, symbol string) SymbolResult {
+	started := time.Now()
+	result := SymbolResult{Symbol: symbol}
+	defer func() {
+		result.DurationMs = time.Since(started).Milliseconds()
+	}()
+
+	if ctx.Err() != nil {
+		result.Status = SymbolStatusSkipped
+		result.Error = ctx.Err().Error()
+		return result
+	}
+	quote, err := s.fetchQuote(ctx, symbol)
+	if err == nil && (quote == nil || quote.Current == 0) {
+		err = errNoPrice
+	}
+	if err != nil {
+		result.Status = SymbolStatusFailed
+		if errors.Is(err, errNoPrice) {
+			result.Status = SymbolStatusSkipped
+		}
+		result.Error = err.Error()
+		return result
+	}
+	changePrice := quote.Change
+	changePercent := quote.ChangePercent
+	if err := s.storeQuote(ctx, symbol, quote.Current, &changePrice, &changePercent, nil, time.Now()); err != nil {
+		result.Status = SymbolStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = SymbolStatusFetched
+	return result
+}
+
+func (s *StockQuoteServiceImpl) finishCycle(result *CycleResult) {
+	result.FinishedAt = time.Now()
+	result.DurationMs = result.FinishedAt.Sub(result.StartedAt).Milliseconds()
+
+	s.cycleMu.Lock()
+	s.lastCycle = result
+	s.cycleMu.Unlock()
+
+	if result.Error != "" {
+		s.warnf("stock_quotes: cycle failed: %s", result.Error)
+		return
+	}
+	s.logf("stock_quotes: cycle done in %dms fetched=%d failed=%d skipped=%d",
+		result.DurationMs, result.Fetched, result.Failed, result.Skipped)
+	for _, r := range result.Symbols {
+		if r.Status != SymbolStatusFetched {
+			s.warnf("stock_quotes: %s %s: %s", r.Symbol, r.Status, r.Error)
+		}
+	}
+}
+
+// LastCycle returns the result of the most recent polling cycle, or nil when
+// no cycle has run yet.
+func (s *StockQuoteServiceImpl) LastCycle() *CycleResult {
+	s.cycleMu.Lock()
+	defer s.cycleMu.Unlock()
+	return s.lastCycle
+}
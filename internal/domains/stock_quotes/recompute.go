@@ -0,0 +1,60 @@
+package stock_quotes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"sun-stockanalysis-api/internal/indicators"
+	"sun-stockanalysis-api/internal/models"
+)
+
+// RecomputeIndicators rebuilds the stored EMA and indicator columns of every
+// quote for symbol, or for every active symbol when symbol is empty, replaying
+// the history oldest first. It returns the number of rows updated.
+func (s *StockQuoteServiceImpl) RecomputeIndicators(ctx context.Context, symbol string) (int, error) {
+	symbols := []string{strings.TrimSpace(symbol)}
+	if symbols[0] == "" {
+		all, err := s.stockRepo.ListSymbols()
+		if err != nil {
+			return 0, err
+		}
+		symbols = all
+	}
+
+	updated := 0
+	for _, sym := range symbols {
+		n, err := s.recomputeSymbol(ctx, sym)
+		updated += n
+		if err != nil {
+			return updated, err
+		}
+	}
+	return updated, nil
+}
+
+func (s *StockQuoteServiceImpl) recomputeSymbol(ctx context.Context, symbol string) (int, error) {
+	quotes, err := s.quoteRepo.FindBySymbolBetween(symbol, time.Time{}, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	var prev *models.StockQuote
+	ind := indicators.NewSet(indicatorConfig)
+	for i := range quotes {
+		if err := ctx.Err(); err != nil {
+			return updated, err
+		}
+		q := &quotes[i]
+		next := s.buildQuote(symbol, q.PriceCurrent, q.ChangePrice, q.ChangePercent, q.Volume, time.Time(q.CreatedAt), prev, ind)
+		next.ID = q.ID
+		next.CreatedAt = q.CreatedAt
+		if err := s.quoteRepo.UpdateIndicators(next); err != nil {
+			return updated, err
+		}
+		prev = next
+		updated++
+	}
+	return updated, nil
+}
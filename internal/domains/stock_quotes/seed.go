@@ -0,0 +1,59 @@
+package stock_quotes
+
+import (
+	"context"
+	"time"
+
+	"sun-stockanalysis-api/internal/marketdata"
+	"sun-stockanalysis-api/internal/models"
+)
+
+// SeedFromCandles stores historical intraday candles as quotes so the EMA chain
+// is warm before live ingestion starts. Candles at or before the latest stored
+// quote are ignored, so seeding is safe to repeat. Seeded quotes are not pushed
+// to subscribers or the alert engine.
+func (s *StockQuoteServiceImpl) SeedFromCandles(ctx context.Context, symbol string, candles []marketdata.Candle) (int, error) {
+	recent, err := s.quoteRepo.FindRecentBySymbol(symbol, indicatorHistory)
+	if err != nil {
+		return 0, err
+	}
+	var prev *models.StockQuote
+	var after time.Time
+	if len(recent) > 0 {
+		prev = &recent[0]
+		after = time.Time(prev.CreatedAt)
+	}
+	ind := resumeIndicators(recent)
+
+	inserted := 0
+	var lastClose float64
+	for _, candle := range candles {
+		if err := ctx.Err(); err != nil {
+			return inserted, err
+		}
+		if candle.Close <= 0 {
+			continue
+		}
+		if !after.IsZero() && !candle.Time.Truncate(time.Minute).After(after) {
+			lastClose = candle.Close
+			continue
+		}
+
+		var changePrice, changePercent *float64
+		if lastClose > 0 {
+			change := candle.Close - lastClose
+			percent := change / lastClose * 100
+			changePrice = &change
+			changePercent = &percent
+		}
+		volume := candle.Volume
+		quote := s.buildQuote(symbol, candle.Close, changePrice, changePercent, &volume, candle.Time, prev, ind)
+		if err := s.quoteRepo.Create(quote); err != nil {
+			return inserted, err
+		}
+		prev = quote
+		lastClose = candle.Close
+		inserted++
+	}
+	return inserted, nil
+}
@@ -2,37 +2,42 @@ package stock_quotes
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
 	"math"
-	"net/http"
-	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"gorm.io/gorm"
-
 	"sun-stockanalysis-api/internal/domains/alert_events"
+	"sun-stockanalysis-api/internal/indicators"
+	"sun-stockanalysis-api/internal/marketdata"
 	"sun-stockanalysis-api/internal/models"
 	"sun-stockanalysis-api/internal/realtime"
 	"sun-stockanalysis-api/internal/repository"
+	"sun-stockanalysis-api/pkg/logger"
 )
 
 const (
-	defaultQuoteURL        = "https://finnhub.io/api/v1/quote"
 	defaultQuotePollSec    = 60
 	defaultQuoteTimeoutSec = 10
 	defaultEMAPeriod20     = 20
 	defaultEMAPeriod100    = 100
+	defaultQuoteIngestMode = IngestModePoll
+)
+
+const (
+	IngestModePoll   = "poll"
+	IngestModeStream = "stream"
 )
 
 var (
-	quoteURL     = getEnvString("QUOTE_URL", defaultQuoteURL)
+	indicatorConfig  = indicators.DefaultConfig()
+	indicatorHistory = indicatorConfig.BollingerPeriod - 1
+)
+
+var (
+	ingestMode   = strings.ToLower(getEnvString("QUOTE_INGEST_MODE", defaultQuoteIngestMode))
 	quotePoll    = time.Duration(getEnvInt("QUOTE_POLL_SECONDS", defaultQuotePollSec)) * time.Second
 	quoteTimeout = time.Duration(getEnvInt("QUOTE_TIMEOUT_SECONDS", defaultQuoteTimeoutSec)) * time.Second
 	emaPeriod20  = getEnvInt("EMA_PERIOD20", defaultEMAPeriod20)
@@ -44,10 +49,15 @@ type StockQuoteService interface {
 	RunOnce(ctx context.Context)
 	Stop()
 	List(ctx context.Context, symbol string) ([]models.StockQuote, error)
+	LastCycle() *CycleResult
+	SeedFromCandles(ctx context.Context, symbol string, candles []marketdata.Candle) (int, error)
+	RecomputeIndicators(ctx context.Context, symbol string) (int, error)
 }
 
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
+// SessionChecker reports whether a symbol's market is trading, so that closed
+// markets are not polled.
+type SessionChecker interface {
+	InQuoteWindow(ctx context.Context, symbol string, at time.Time) bool
 }
 
 type StockQuoteServiceImpl struct {
@@ -55,12 +65,17 @@ type StockQuoteServiceImpl struct {
 	quoteRepo     repository.StockQuoteRepository
 	alertService  alert_events.AlertEventService
 	notifier      realtime.StockQuoteNotifier
-	httpClient    HTTPClient
-	finnhubToken  string
+	provider      marketdata.Provider
+	sessions      SessionChecker
+	log           *logger.Logger
+	ingestMode    string
 	pollInterval  time.Duration
 	requestTimout time.Duration
+	workers       int
 	mu            sync.Mutex
 	cancel        context.CancelFunc
+	cycleMu       sync.Mutex
+	lastCycle     *CycleResult
 }
 
 func NewStockQuoteService(
@@ -68,21 +83,22 @@ func NewStockQuoteService(
 	quoteRepo repository.StockQuoteRepository,
 	alertService alert_events.AlertEventService,
 	notifier realtime.StockQuoteNotifier,
-	httpClient HTTPClient,
-	finnhubToken string,
+	provider marketdata.Provider,
+	sessions SessionChecker,
+	log *logger.Logger,
 ) StockQuoteService {
-	if httpClient == nil {
-		httpClient = &http.Client{Timeout: quoteTimeout}
-	}
 	return &StockQuoteServiceImpl{
 		stockRepo:     stockRepo,
 		quoteRepo:     quoteRepo,
 		alertService:  alertService,
 		notifier:      notifier,
-		httpClient:    httpClient,
-		finnhubToken:  finnhubToken,
+		provider:      provider,
+		sessions:      sessions,
+		log:           log,
+		ingestMode:    ingestMode,
 		pollInterval:  quotePoll,
 		requestTimout: quoteTimeout,
+		workers:       quoteWorkers,
 	}
 }
 
@@ -126,149 +142,156 @@ func (s *StockQuoteServiceImpl) List(ctx context.Context, symbol string) ([]mode
 }
 
 func (s *StockQuoteServiceImpl) run(ctx context.Context) {
+	if s.ingestMode == IngestModeStream {
+		streamer, ok := s.provider.(marketdata.Streamer)
+		if ok {
+			s.runStreaming(ctx, streamer)
+			return
+		}
+		s.logf("stock_quotes: provider %s does not support streaming, falling back to polling", s.provider.Name())
+	}
+	s.runPolling(ctx, 0)
+}
+
+// runPolling fetches every active symbol on each poll tick. A positive limit
+// bounds how long polling runs, which is used while a stream is reconnecting.
+func (s *StockQuoteServiceImpl) runPolling(ctx context.Context, limit time.Duration) {
 	ticker := time.NewTicker(s.pollInterval)
 	defer ticker.Stop()
 
+	var deadline <-chan time.Time
+	if limit > 0 {
+		timer := time.NewTimer(limit)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-deadline:
+			return
 		case <-ticker.C:
 		}
 		s.fetchAndStoreAll(ctx)
 	}
 }
 
-type finnhubQuoteResponse struct {
-	C  float64 `json:"c"`
-	D  float64 `json:"d"`
-	DP float64 `json:"dp"`
-	H  float64 `json:"h"`
-	L  float64 `json:"l"`
-	O  float64 `json:"o"`
-	PC float64 `json:"pc"`
-	T  int64   `json:"t"`
+func (s *StockQuoteServiceImpl) fetchQuote(ctx context.Context, symbol string) (*marketdata.Quote, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, s.requestTimout)
+	defer cancel()
+	return s.provider.Quote(reqCtx, symbol)
 }
 
-func (s *StockQuoteServiceImpl) fetchQuote(symbol string) (*finnhubQuoteResponse, error) {
-	reqURL, err := url.Parse(quoteURL)
+// storeQuote derives the EMA signals for a new price, persists it and fans it
+// out to the realtime notifier and the alert engine.
+func (s *StockQuoteServiceImpl) storeQuote(
+	ctx context.Context,
+	symbol string,
+	price float64,
+	changePrice *float64,
+	changePercent *float64,
+	volume *float64,
+	at time.Time,
+) error {
+	recent, err := s.quoteRepo.FindRecentBySymbol(symbol, indicatorHistory)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	q := reqURL.Query()
-	q.Set("symbol", symbol)
-	reqURL.RawQuery = q.Encode()
-
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
-	if err != nil {
-		return nil, err
+	var prev *models.StockQuote
+	if len(recent) > 0 {
+		prev = &recent[0]
 	}
-	req.Header.Set("X-Finnhub-Token", s.finnhubToken)
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	newQuote := s.buildQuote(symbol, price, changePrice, changePercent, volume, at, prev, resumeIndicators(recent))
+	if err := s.quoteRepo.Create(newQuote); err != nil {
+		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("finnhub quote request failed: %s", strings.TrimSpace(string(body)))
+	if s.notifier != nil {
+		s.notifier.NotifyQuote(newQuote)
 	}
-
-	var result *finnhubQuoteResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if s.alertService != nil {
+		_ = s.alertService.BuildForSymbol(ctx, symbol)
 	}
-	return result, nil
+	return nil
 }
 
-func (s *StockQuoteServiceImpl) fetchAndStoreAll(ctx context.Context) {
-	symbols, err := s.stockRepo.ListSymbols()
-	if err != nil || len(symbols) == 0 {
-		return
+// buildQuote computes the EMA signals for price, continuing the chain from
+// prev, and advances ind with the new price.
+func (s *StockQuoteServiceImpl) buildQuote(
+	symbol string,
+	price float64,
+	changePrice *float64,
+	changePercent *float64,
+	volume *float64,
+	at time.Time,
+	prev *models.StockQuote,
+	ind *indicators.Set,
+) *models.StockQuote {
+	ema20, ema100 := price, price
+	if prev != nil {
+		ema20 = indicators.NextEMA(prev.EMA20, price, emaPeriod20)
+		ema100 = indicators.NextEMA(prev.EMA100, price, emaPeriod100)
+	}
+	tanhEMA := math.Tanh(ema20-ema100) / 5.0
+	changeEMA20 := 0.0
+	changeTanhEMA := 0.0
+	emaTrend := 0
+	if ema20 > ema100 {
+		emaTrend = 1
+	} else if ema20 < ema100 {
+		emaTrend = -1
+	} else if ema20 == ema100 {
+		emaTrend = 0
 	}
+	if prev != nil {
+		changeEMA20 = ema20 - prev.EMA20
+		changeTanhEMA = tanhEMA - prev.TanhEMA
+	}
+	snapshot := ind.Update(indicators.Bar{High: price, Low: price, Close: price})
+	createdAt := at.In(time.FixedZone("Asia/Bangkok", 7*60*60)).Truncate(time.Minute)
+	return &models.StockQuote{
+		Symbol:        symbol,
+		PriceCurrent:  price,
+		ChangePrice:   changePrice,
+		ChangePercent: changePercent,
+		Volume:        volume,
+		EMA20:         ema20,
+		EMA100:        ema100,
+		TanhEMA:       tanhEMA,
+		ChangeEMA20:   changeEMA20,
+		ChangeTanhEMA: changeTanhEMA,
+		EMATrend:      emaTrend,
+		CreatedAt:     models.NewLocalTime(createdAt),
 
-	for _, symbol := range symbols {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-		symbol = strings.TrimSpace(symbol)
-		if symbol == "" {
-			continue
-		}
-		quote, err := s.fetchQuote(symbol)
-		if err != nil || quote == nil {
-			continue
-		}
-		prev, err := s.quoteRepo.FindLatestBySymbol(symbol)
-		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-			continue
-		}
-		ema20 := s.calculateEMA(quote.C, emaPeriod20, prev)
-		ema100 := s.calculateEMA(quote.C, emaPeriod100, prev)
-		tanhEMA := math.Tanh(ema20-ema100) / 5.0
-		changeEMA20 := 0.0
-		changeTanhEMA := 0.0
-		emaTrend := 0
-		if ema20 > ema100 {
-			emaTrend = 1
-		} else if ema20 < ema100 {
-			emaTrend = -1
-		} else if ema20 == ema100 {
-			emaTrend = 0
-		}
-		if prev != nil {
-			changeEMA20 = ema20 - prev.EMA20
-			changeTanhEMA = tanhEMA - prev.TanhEMA
-		}
-		createdAt := time.Now().In(time.FixedZone("Asia/Bangkok", 7*60*60)).Truncate(time.Minute)
-		changePrice := quote.D
-		changePercent := quote.DP
-		newQuote := &models.StockQuote{
-			Symbol:        symbol,
-			PriceCurrent:  quote.C,
-			ChangePrice:   &changePrice,
-			ChangePercent: &changePercent,
-			EMA20:         ema20,
-			EMA100:        ema100,
-			TanhEMA:       tanhEMA,
-			ChangeEMA20:   changeEMA20,
-			ChangeTanhEMA: changeTanhEMA,
-			EMATrend:      emaTrend,
-			CreatedAt:     models.NewLocalTime(createdAt.Truncate(time.Minute)),
-		}
-		if err := s.quoteRepo.Create(newQuote); err != nil {
-			continue
-		}
-		if s.notifier != nil {
-			s.notifier.NotifyQuote(newQuote)
-		}
-		if s.alertService != nil {
-			_ = s.alertService.BuildForSymbol(ctx, symbol)
-		}
+		TechnicalIndicators: models.NewTechnicalIndicators(snapshot, ind.State()),
 	}
 }
 
-func (s *StockQuoteServiceImpl) calculateEMA(current float64, period int, prev *models.StockQuote) float64 {
-	if period <= 1 {
-		return current
+// resumeIndicators rebuilds the indicator set from the most recent quotes,
+// newest first.
+func resumeIndicators(recent []models.StockQuote) *indicators.Set {
+	if len(recent) == 0 {
+		return indicators.NewSet(indicatorConfig)
 	}
-	emaPrev := current
-	if prev == nil {
-		return current
+	closes := make([]float64, 0, len(recent))
+	for i := len(recent) - 1; i >= 0; i-- {
+		closes = append(closes, recent[i].PriceCurrent)
 	}
-	switch period {
-	case emaPeriod20:
-		emaPrev = prev.EMA20
-	case emaPeriod100:
-		emaPrev = prev.EMA100
+	latest := recent[0]
+	return indicators.ResumeSet(indicatorConfig, latest.IndicatorState(latest.PriceCurrent), closes)
+}
+
+func (s *StockQuoteServiceImpl) logf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Infof(format, args...)
 	}
+}
 
-	alpha := 2.0 / float64(period-1)
-	return alpha*current + (1-alpha)*emaPrev
+func (s *StockQuoteServiceImpl) warnf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Warnf(format, args...)
+	}
 }
 
 func getEnvString(key, fallback string) string {
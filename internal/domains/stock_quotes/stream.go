@@ -0,0 +1,282 @@
+package stock_quotes
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"sun-stockanalysis-api/internal/marketdata"
+)
+
+const (
+	defaultSymbolRefreshSec   = 60
+	defaultStreamReconnectSec = 5
+	maxStreamReconnectDelay   = 5 * time.Minute
+	barFlushInterval          = time.Second
+)
+
+var (
+	symbolRefresh   = time.Duration(getEnvInt("QUOTE_SYMBOL_REFRESH_SECONDS", defaultSymbolRefreshSec)) * time.Second
+	streamReconnect = time.Duration(getEnvInt("QUOTE_STREAM_RECONNECT_SECONDS", defaultStreamReconnectSec)) * time.Second
+)
+
+// minuteBar is the OHLCV summary of the trades seen for a symbol in one minute.
+type minuteBar struct {
+	Symbol string
+	Minute time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// minuteAggregator folds individual trades into one bar per symbol per minute.
+// flushed remembers the last minute handed out per symbol so a late trade
+// cannot open a second bar for a minute that was already written.
+type minuteAggregator struct {
+	bars    map[string]*minuteBar
+	flushed map[string]time.Time
+}
+
+func newMinuteAggregator() *minuteAggregator {
+	return &minuteAggregator{
+		bars:    make(map[string]*minuteBar),
+		flushed: make(map[string]time.Time),
+	}
+}
+
+// Add records a trade and returns the previous bar for the symbol when the
+// trade starts a new minute. Late trades for an already closed minute are
+// folded into the current bar, or dropped when no bar is open because the
+// closed minute was already flushed.
+func (a *minuteAggregator) Add(trade marketdata.Trade) *minuteBar {
+	minute := trade.Timestamp.Truncate(time.Minute)
+	bar, ok := a.bars[trade.Symbol]
+	if ok && minute.After(bar.Minute) {
+		a.bars[trade.Symbol] = newMinuteBar(trade, minute)
+		a.markFlushed(bar)
+		return bar
+	}
+	if !ok {
+		if last, seen := a.flushed[trade.Symbol]; seen && !minute.After(last) {
+			return nil
+		}
+		a.bars[trade.Symbol] = newMinuteBar(trade, minute)
+		return nil
+	}
+	if trade.Price > bar.High {
+		bar.High = trade.Price
+	}
+	if trade.Price < bar.Low {
+		bar.Low = trade.Price
+	}
+	bar.Close = trade.Price
+	bar.Volume += trade.Volume
+	return nil
+}
+
+// FlushBefore removes and returns every bar whose minute started before cutoff.
+func (a *minuteAggregator) FlushBefore(cutoff time.Time) []minuteBar {
+	var out []minuteBar
+	for symbol, bar := range a.bars {
+		if bar.Minute.Before(cutoff) {
+			out = append(out, *bar)
+			delete(a.bars, symbol)
+			a.markFlushed(bar)
+		}
+	}
+	sortBars(out)
+	return out
+}
+
+// Flush removes and returns the open bar for a symbol, if any.
+func (a *minuteAggregator) Flush(symbol string) *minuteBar {
+	bar, ok := a.bars[symbol]
+	if !ok {
+		return nil
+	}
+	delete(a.bars, symbol)
+	a.markFlushed(bar)
+	return bar
+}
+
+// FlushAll removes and returns every open bar.
+func (a *minuteAggregator) FlushAll() []minuteBar {
+	out := make([]minuteBar, 0, len(a.bars))
+	for _, bar := range a.bars {
+		out = append(out, *bar)
+		a.markFlushed(bar)
+	}
+	a.bars = make(map[string]*minuteBar)
+	sortBars(out)
+	return out
+}
+
+func (a *minuteAggregator) markFlushed(bar *minuteBar) {
+	if last, ok := a.flushed[bar.Symbol]; !ok || bar.Minute.After(last) {
+		a.flushed[bar.Symbol] = bar.Minute
+	}
+}
+
+func newMinuteBar(trade marketdata.Trade, minute time.Time) *minuteBar {
+	return &minuteBar{
+		Symbol: trade.Symbol,
+		Minute: minute,
+		Open:   trade.Price,
+		High:   trade.Price,
+		Low:    trade.Price,
+		Close:  trade.Price,
+		Volume: trade.Volume,
+	}
+}
+
+func sortBars(bars []minuteBar) {
+	sort.Slice(bars, func(i, j int) bool {
+		if bars[i].Minute.Equal(bars[j].Minute) {
+			return bars[i].Symbol < bars[j].Symbol
+		}
+		return bars[i].Minute.Before(bars[j].Minute)
+	})
+}
+
+// runStreaming ingests quotes from the provider's trade feed. While the feed is
+// down the service falls back to REST polling and reconnects with backoff.
+func (s *StockQuoteServiceImpl) runStreaming(ctx context.Context, streamer marketdata.Streamer) {
+	delay := streamReconnect
+	var lastPoll time.Time
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		stream, err := streamer.StreamTrades(ctx)
+		if err == nil {
+			connectedAt := time.Now()
+			s.logf("stock_quotes: trade stream connected")
+			err = s.consumeStream(ctx, stream)
+			_ = stream.Close()
+			if ctx.Err() != nil {
+				return
+			}
+			if time.Since(connectedAt) > maxStreamReconnectDelay {
+				delay = streamReconnect
+			}
+		}
+		s.warnf("stock_quotes: trade stream unavailable, polling for %s: %v", delay, err)
+
+		if time.Since(lastPoll) >= s.pollInterval {
+			s.fetchAndStoreAll(ctx)
+			lastPoll = time.Now()
+		}
+		s.runPolling(ctx, delay)
+
+		delay *= 2
+		if delay > maxStreamReconnectDelay {
+			delay = maxStreamReconnectDelay
+		}
+	}
+}
+
+// consumeStream keeps the subscription in sync with the active stock list and
+// stores one quote per symbol per minute until the stream or ctx ends.
+func (s *StockQuoteServiceImpl) consumeStream(ctx context.Context, stream marketdata.TradeStream) error {
+	agg := newMinuteAggregator()
+	subscribed := make(map[string]struct{})
+	prevClose := make(map[string]float64)
+
+	store := func(bar minuteBar) {
+		var changePrice, changePercent *float64
+		if prev := prevClose[bar.Symbol]; prev > 0 {
+			change := bar.Close - prev
+			percent := change / prev * 100
+			changePrice = &change
+			changePercent = &percent
+		}
+		volume := bar.Volume
+		if err := s.storeQuote(ctx, bar.Symbol, bar.Close, changePrice, changePercent, &volume, bar.Minute); err != nil {
+			s.warnf("stock_quotes: store %s bar failed: %v", bar.Symbol, err)
+		}
+	}
+
+	syncSymbols := func() error {
+		symbols, err := s.stockRepo.ListSymbols()
+		if err != nil {
+			s.warnf("stock_quotes: list symbols failed: %v", err)
+			return nil
+		}
+		wanted := make(map[string]struct{}, len(symbols))
+		for _, symbol := range symbols {
+			symbol = strings.TrimSpace(symbol)
+			if symbol == "" {
+				continue
+			}
+			wanted[symbol] = struct{}{}
+			if _, ok := subscribed[symbol]; ok {
+				continue
+			}
+			if err := stream.Subscribe(symbol); err != nil {
+				return err
+			}
+			subscribed[symbol] = struct{}{}
+			if quote, err := s.fetchQuote(ctx, symbol); err == nil && quote != nil {
+				prevClose[symbol] = quote.PrevClose
+			}
+		}
+		for symbol := range subscribed {
+			if _, ok := wanted[symbol]; ok {
+				continue
+			}
+			if err := stream.Unsubscribe(symbol); err != nil {
+				return err
+			}
+			delete(subscribed, symbol)
+			delete(prevClose, symbol)
+			if bar := agg.Flush(symbol); bar != nil {
+				store(*bar)
+			}
+		}
+		return nil
+	}
+
+	if err := syncSymbols(); err != nil {
+		return err
+	}
+
+	flushTicker := time.NewTicker(barFlushInterval)
+	defer flushTicker.Stop()
+	refreshTicker := time.NewTicker(symbolRefresh)
+	defer refreshTicker.Stop()
+
+	trades := stream.Trades()
+	for {
+		select {
+		case <-ctx.Done():
+			for _, bar := range agg.FlushAll() {
+				store(bar)
+			}
+			return ctx.Err()
+		case trade, ok := <-trades:
+			if !ok {
+				for _, bar := range agg.FlushAll() {
+					store(bar)
+				}
+				return stream.Err()
+			}
+			if _, ok := subscribed[trade.Symbol]; !ok {
+				continue
+			}
+			if bar := agg.Add(trade); bar != nil {
+				store(*bar)
+			}
+		case now := <-flushTicker.C:
+			for _, bar := range agg.FlushBefore(now.Truncate(time.Minute)) {
+				store(bar)
+			}
+		case <-refreshTicker.C:
+			if err := syncSymbols(); err != nil {
+				return err
+			}
+		}
+	}
+}
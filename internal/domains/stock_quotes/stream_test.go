@@ -0,0 +1,84 @@
+package stock_quotes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/marketdata"
+)
+
+type MinuteAggregatorSuite struct {
+	suite.Suite
+	agg  *minuteAggregator
+	base time.Time
+}
+
+func (s *MinuteAggregatorSuite) SetupTest() {
+	s.agg = newMinuteAggregator()
+	s.base = time.Date(2025, 1, 2, 14, 30, 0, 0, time.UTC)
+}
+
+func (s *MinuteAggregatorSuite) trade(symbol string, price, volume float64, offset time.Duration) marketdata.Trade {
+	return marketdata.Trade{Symbol: symbol, Price: price, Volume: volume, Timestamp: s.base.Add(offset)}
+}
+
+func (s *MinuteAggregatorSuite) TestAdd_BuildsBarWithinMinute() {
+	s.Nil(s.agg.Add(s.trade("AAPL", 10, 1, 0)))
+	s.Nil(s.agg.Add(s.trade("AAPL", 12, 2, 10*time.Second)))
+	s.Nil(s.agg.Add(s.trade("AAPL", 9, 3, 20*time.Second)))
+	s.Nil(s.agg.Add(s.trade("AAPL", 11, 4, 59*time.Second)))
+
+	bars := s.agg.FlushAll()
+
+	s.Require().Len(bars, 1)
+	s.Equal(minuteBar{Symbol: "AAPL", Minute: s.base, Open: 10, High: 12, Low: 9, Close: 11, Volume: 10}, bars[0])
+}
+
+func (s *MinuteAggregatorSuite) TestAdd_RollsOverOnNewMinute() {
+	s.agg.Add(s.trade("AAPL", 10, 1, 0))
+	s.agg.Add(s.trade("AAPL", 11, 1, 30*time.Second))
+
+	closed := s.agg.Add(s.trade("AAPL", 13, 5, 61*time.Second))
+
+	s.Require().NotNil(closed)
+	s.Equal(s.base, closed.Minute)
+	s.Equal(11.0, closed.Close)
+	s.Equal(2.0, closed.Volume)
+
+	bars := s.agg.FlushAll()
+	s.Require().Len(bars, 1)
+	s.Equal(s.base.Add(time.Minute), bars[0].Minute)
+	s.Equal(13.0, bars[0].Open)
+}
+
+func (s *MinuteAggregatorSuite) TestFlushBefore_KeepsCurrentMinute() {
+	s.agg.Add(s.trade("AAPL", 10, 1, 0))
+	s.agg.Add(s.trade("TSLA", 20, 1, 70*time.Second))
+
+	bars := s.agg.FlushBefore(s.base.Add(time.Minute))
+
+	s.Require().Len(bars, 1)
+	s.Equal("AAPL", bars[0].Symbol)
+	s.Nil(s.agg.Flush("AAPL"))
+	s.NotNil(s.agg.Flush("TSLA"))
+}
+
+func (s *MinuteAggregatorSuite) TestAdd_DropsTradeForAlreadyFlushedMinute() {
+	s.agg.Add(s.trade("AAPL", 10, 1, 10*time.Second))
+	s.Require().Len(s.agg.FlushBefore(s.base.Add(time.Minute)), 1)
+
+	s.Nil(s.agg.Add(s.trade("AAPL", 11, 2, 50*time.Second)))
+
+	s.Empty(s.agg.FlushAll())
+
+	s.agg.Add(s.trade("AAPL", 12, 1, 65*time.Second))
+	bars := s.agg.FlushAll()
+	s.Require().Len(bars, 1)
+	s.Equal(s.base.Add(time.Minute), bars[0].Minute)
+}
+
+func TestMinuteAggregatorSuite(t *testing.T) {
+	suite.Run(t, new(MinuteAggregatorSuite))
+}
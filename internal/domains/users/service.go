@@ -0,0 +1,177 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrInvalidRole  = errors.New("invalid role")
+	ErrLastAdmin    = errors.New("cannot demote the last admin")
+)
+
+// Account is the public view of a user; it never carries the password hash.
+type Account struct {
+	ID            uuid.UUID        `json:"id"`
+	Email         string           `json:"email"`
+	FirstName     string           `json:"first_name"`
+	LastName      string           `json:"last_name"`
+	Role          string           `json:"role"`
+	EmailVerified bool             `json:"email_verified"`
+	MFAEnabled    bool             `json:"mfa_enabled"`
+	LastLoginAt   models.LocalTime `json:"last_login_at"`
+	CreatedAt     models.LocalTime `json:"created_at"`
+}
+
+type UserService interface {
+	List(ctx context.Context) ([]Account, error)
+	SetRole(ctx context.Context, id uuid.UUID, role string) (*Account, error)
+	EnsureAdmin(ctx context.Context, email, password string) error
+}
+
+type UserServiceImpl struct {
+	repo repository.UserRepository
+	log  *logger.Logger
+}
+
+func NewUserService(repo repository.UserRepository, log *logger.Logger) UserService {
+	return &UserServiceImpl{repo: repo, log: log}
+}
+
+func (s *UserServiceImpl) List(ctx context.Context) ([]Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	users, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]Account, 0, len(users))
+	for i := range users {
+		accounts = append(accounts, toAccount(&users[i]))
+	}
+	return accounts, nil
+}
+
+// SetRole promotes or demotes a user. Demoting the only remaining admin is
+// refused so the deployment always keeps one. The new role applies to access
+// tokens issued from the next login or refresh.
+func (s *UserServiceImpl) SetRole(ctx context.Context, id uuid.UUID, role string) (*Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	role, err := normalizeRole(role)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrUserNotFound, id)
+		}
+		return nil, err
+	}
+	if user.Role == role {
+		account := toAccount(user)
+		return &account, nil
+	}
+	if user.Role == models.RoleAdmin {
+		admins, err := s.repo.CountByRole(models.RoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if admins <= 1 {
+			return nil, ErrLastAdmin
+		}
+	}
+	if err := s.repo.UpdateRole(id, role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrUserNotFound, id)
+		}
+		return nil, err
+	}
+	user.Role = role
+	account := toAccount(user)
+	return &account, nil
+}
+
+// EnsureAdmin seeds the configured admin account with password when no user
+// has the email. An existing non-admin account is left alone: registration is
+// open, so whoever registered the address first must not become an admin.
+// Promote it with SetRole instead. An empty email disables seeding.
+func (s *UserServiceImpl) EnsureAdmin(ctx context.Context, email, password string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil
+	}
+	user, err := s.repo.FindByEmail(email)
+	if err == nil {
+		if user.Role == models.RoleAdmin {
+			return nil
+		}
+		s.logf("users: admin %s is already registered as %s; not promoting it", email, user.Role)
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if password == "" {
+		s.logf("users: admin %s does not exist and no admin password is configured", email)
+		return nil
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	s.logf("users: creating admin %s", email)
+	// The operator chose this address, so it does not need verifying.
+	return s.repo.Create(&models.User{
+		Email:           email,
+		Password:        string(hashed),
+		Role:            models.RoleAdmin,
+		IsEmailVerified: true,
+	})
+}
+
+func (s *UserServiceImpl) logf(format string, args ...any) {
+	if s.log != nil {
+		s.log.Infof(format, args...)
+	}
+}
+
+func normalizeRole(role string) (string, error) {
+	role = strings.ToUpper(strings.TrimSpace(role))
+	switch role {
+	case models.RoleUser, models.RoleAdmin:
+		return role, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidRole, role)
+}
+
+func toAccount(user *models.User) Account {
+	return Account{
+		ID:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Role:          user.Role,
+		EmailVerified: user.IsEmailVerified,
+		MFAEnabled:    user.TOTPEnabled,
+		LastLoginAt:   user.LastLoginAt,
+		CreatedAt:     user.CreatedAt,
+	}
+}
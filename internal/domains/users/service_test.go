@@ -0,0 +1,102 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type UserServiceSuite struct {
+	suite.Suite
+	repo    *repositorymock.MockUserRepository
+	service UserService
+}
+
+func (s *UserServiceSuite) SetupTest() {
+	s.repo = repositorymock.NewMockUserRepository(s.T())
+	s.service = NewUserService(s.repo, nil)
+}
+
+func (s *UserServiceSuite) TestSetRole_PromotesUser() {
+	id := uuid.New()
+	s.repo.EXPECT().FindByID(id).Return(&models.User{ID: id, Email: "a@example.com", Role: models.RoleUser}, nil)
+	s.repo.EXPECT().UpdateRole(id, models.RoleAdmin).Return(nil)
+
+	account, err := s.service.SetRole(context.Background(), id, "admin")
+
+	s.Require().NoError(err)
+	s.Equal(models.RoleAdmin, account.Role)
+	s.Equal("a@example.com", account.Email)
+}
+
+func (s *UserServiceSuite) TestSetRole_KeepsLastAdmin() {
+	id := uuid.New()
+	s.repo.EXPECT().FindByID(id).Return(&models.User{ID: id, Role: models.RoleAdmin}, nil)
+	s.repo.EXPECT().CountByRole(models.RoleAdmin).Return(1, nil)
+
+	_, err := s.service.SetRole(context.Background(), id, models.RoleUser)
+
+	s.True(errors.Is(err, ErrLastAdmin))
+}
+
+func (s *UserServiceSuite) TestSetRole_DemotesWhenOtherAdminsRemain() {
+	id := uuid.New()
+	s.repo.EXPECT().FindByID(id).Return(&models.User{ID: id, Role: models.RoleAdmin}, nil)
+	s.repo.EXPECT().CountByRole(models.RoleAdmin).Return(2, nil)
+	s.repo.EXPECT().UpdateRole(id, models.RoleUser).Return(nil)
+
+	account, err := s.service.SetRole(context.Background(), id, models.RoleUser)
+
+	s.Require().NoError(err)
+	s.Equal(models.RoleUser, account.Role)
+}
+
+func (s *UserServiceSuite) TestSetRole_RejectsUnknownRoleAndUser() {
+	_, err := s.service.SetRole(context.Background(), uuid.New(), "ROOT")
+	s.True(errors.Is(err, ErrInvalidRole))
+
+	id := uuid.New()
+	s.repo.EXPECT().FindByID(id).Return(nil, gorm.ErrRecordNotFound)
+	_, err = s.service.SetRole(context.Background(), id, models.RoleAdmin)
+	s.True(errors.Is(err, ErrUserNotFound))
+}
+
+func (s *UserServiceSuite) TestEnsureAdmin_DoesNotPromoteAlreadyRegisteredUser() {
+	s.repo.EXPECT().FindByEmail("admin@example.com").
+		Return(&models.User{ID: uuid.New(), Role: models.RoleUser, IsEmailVerified: true}, nil)
+
+	s.Require().NoError(s.service.EnsureAdmin(context.Background(), " admin@example.com ", "s3cret"))
+	s.repo.AssertNotCalled(s.T(), "UpdateRole", mock.Anything, mock.Anything)
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *UserServiceSuite) TestEnsureAdmin_CreatesMissingAdmin() {
+	s.repo.EXPECT().FindByEmail("admin@example.com").Return(nil, gorm.ErrRecordNotFound)
+	s.repo.EXPECT().Create(mock.Anything).RunAndReturn(func(user *models.User) error {
+		s.Equal(models.RoleAdmin, user.Role)
+		s.NoError(bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("s3cret")))
+		return nil
+	})
+
+	s.Require().NoError(s.service.EnsureAdmin(context.Background(), "admin@example.com", "s3cret"))
+}
+
+func (s *UserServiceSuite) TestEnsureAdmin_SkipsWithoutEmailOrPassword() {
+	s.Require().NoError(s.service.EnsureAdmin(context.Background(), "", "s3cret"))
+
+	s.repo.EXPECT().FindByEmail("admin@example.com").Return(nil, gorm.ErrRecordNotFound)
+	s.Require().NoError(s.service.EnsureAdmin(context.Background(), "admin@example.com", ""))
+}
+
+func TestUserServiceSuite(t *testing.T) {
+	suite.Run(t, new(UserServiceSuite))
+}
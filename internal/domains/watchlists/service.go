@@ -0,0 +1,363 @@
+package watchlists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+	"sun-stockanalysis-api/internal/repository"
+)
+
+const (
+	defaultMaxWatchlists = 20
+	defaultMaxSymbols    = 100
+)
+
+var (
+	maxWatchlists = getEnvInt("WATCHLIST_MAX_PER_USER", defaultMaxWatchlists)
+	maxSymbols    = getEnvInt("WATCHLIST_MAX_SYMBOLS", defaultMaxSymbols)
+)
+
+var (
+	ErrInvalidUser       = errors.New("invalid user id")
+	ErrWatchlistNotFound = errors.New("watchlist not found")
+	ErrInvalidWatchlist  = errors.New("invalid watchlist")
+	ErrUnknownSymbol     = errors.New("symbol is not tracked")
+	ErrTooManyWatchlists = errors.New("watchlist limit reached")
+)
+
+// Observer is told whenever the set of symbols a user follows may have
+// changed. restricted is false when the user has no watchlist left and
+// follows every symbol again.
+type Observer interface {
+	WatchlistChanged(userID string, symbols []string, restricted bool)
+}
+
+type WatchlistService interface {
+	List(ctx context.Context, userID string) ([]models.Watchlist, error)
+	Get(ctx context.Context, userID string, id uuid.UUID) (*models.Watchlist, error)
+	Create(ctx context.Context, userID, name string, symbols []string) (*models.Watchlist, error)
+	Update(ctx context.Context, userID string, id uuid.UUID, name string, symbols []string) (*models.Watchlist, error)
+	Delete(ctx context.Context, userID string, id uuid.UUID) error
+	AddSymbol(ctx context.Context, userID string, id uuid.UUID, symbol string) (*models.Watchlist, error)
+	RemoveSymbol(ctx context.Context, userID string, id uuid.UUID, symbol string) (*models.Watchlist, error)
+	WatchedSymbols(ctx context.Context, userID string) ([]string, bool, error)
+	Watches(userID uuid.UUID, symbols ...string) (bool, error)
+	Subscribe(observer Observer)
+}
+
+type WatchlistServiceImpl struct {
+	repo      repository.WatchlistRepository
+	stockRepo repository.StockRepository
+	mu        sync.RWMutex
+	observers []Observer
+}
+
+func NewWatchlistService(repo repository.WatchlistRepository, stockRepo repository.StockRepository) WatchlistService {
+	return &WatchlistServiceImpl{
+		repo:      repo,
+		stockRepo: stockRepo,
+	}
+}
+
+func (s *WatchlistServiceImpl) List(ctx context.Context, userID string) ([]models.Watchlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByUser(owner)
+}
+
+func (s *WatchlistServiceImpl) Get(ctx context.Context, userID string, id uuid.UUID) (*models.Watchlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.findOwned(owner, id)
+}
+
+func (s *WatchlistServiceImpl) Create(ctx context.Context, userID, name string, symbols []string) (*models.Watchlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	name, err = validName(name)
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := s.trackedSymbols(symbols)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.FindByUser(owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWatchlists {
+		return nil, ErrTooManyWatchlists
+	}
+
+	watchlist := &models.Watchlist{UserID: owner, Name: name}
+	for _, symbol := range tracked {
+		watchlist.Items = append(watchlist.Items, models.WatchlistItem{Symbol: symbol})
+	}
+	if err := s.repo.Create(watchlist); err != nil {
+		return nil, err
+	}
+	s.notify(owner)
+	return watchlist, nil
+}
+
+// Update renames the watchlist and replaces its symbols.
+func (s *WatchlistServiceImpl) Update(ctx context.Context, userID string, id uuid.UUID, name string, symbols []string) (*models.Watchlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	watchlist, err := s.findOwned(owner, id)
+	if err != nil {
+		return nil, err
+	}
+	watchlist.Name, err = validName(name)
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := s.trackedSymbols(symbols)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateName(watchlist); err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceSymbols(id, tracked); err != nil {
+		return nil, err
+	}
+	s.notify(owner)
+	return s.repo.FindByID(id)
+}
+
+func (s *WatchlistServiceImpl) Delete(ctx context.Context, userID string, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.findOwned(owner, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	s.notify(owner)
+	return nil
+}
+
+func (s *WatchlistServiceImpl) AddSymbol(ctx context.Context, userID string, id uuid.UUID, symbol string) (*models.Watchlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	watchlist, err := s.findOwned(owner, id)
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := s.trackedSymbols([]string{symbol})
+	if err != nil {
+		return nil, err
+	}
+	if len(tracked) == 0 {
+		return nil, ErrUnknownSymbol
+	}
+	if len(watchlist.Items) >= maxSymbols {
+		return nil, fmt.Errorf("%w: at most %d symbols", ErrInvalidWatchlist, maxSymbols)
+	}
+	if err := s.repo.AddSymbol(id, tracked[0]); err != nil {
+		return nil, err
+	}
+	s.notify(owner)
+	return s.repo.FindByID(id)
+}
+
+func (s *WatchlistServiceImpl) RemoveSymbol(ctx context.Context, userID string, id uuid.UUID, symbol string) (*models.Watchlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	watchlist, err := s.findOwned(owner, id)
+	if err != nil {
+		return nil, err
+	}
+	symbol = strings.TrimSpace(symbol)
+	for _, item := range watchlist.Items {
+		if strings.EqualFold(item.Symbol, symbol) {
+			if err := s.repo.RemoveSymbol(id, item.Symbol); err != nil {
+				return nil, err
+			}
+			s.notify(owner)
+			return s.repo.FindByID(id)
+		}
+	}
+	return watchlist, nil
+}
+
+// WatchedSymbols returns the symbols userID follows. restricted is false when
+// the user has no watchlist and follows every symbol.
+func (s *WatchlistServiceImpl) WatchedSymbols(ctx context.Context, userID string) ([]string, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+	owner, err := parseUserID(userID)
+	if err != nil {
+		return nil, false, err
+	}
+	return s.repo.FindSymbolsByUser(owner)
+}
+
+// Watches reports whether userID follows any of symbols.
+func (s *WatchlistServiceImpl) Watches(userID uuid.UUID, symbols ...string) (bool, error) {
+	watched, restricted, err := s.repo.FindSymbolsByUser(userID)
+	if err != nil {
+		return false, err
+	}
+	if !restricted {
+		return true, nil
+	}
+	for _, w := range watched {
+		for _, symbol := range symbols {
+			if strings.EqualFold(w, symbol) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (s *WatchlistServiceImpl) Subscribe(observer Observer) {
+	if observer == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observers = append(s.observers, observer)
+}
+
+func (s *WatchlistServiceImpl) notify(owner uuid.UUID) {
+	s.mu.RLock()
+	observers := append([]Observer(nil), s.observers...)
+	s.mu.RUnlock()
+	if len(observers) == 0 {
+		return
+	}
+	symbols, restricted, err := s.repo.FindSymbolsByUser(owner)
+	if err != nil {
+		return
+	}
+	for _, observer := range observers {
+		observer.WatchlistChanged(owner.String(), symbols, restricted)
+	}
+}
+
+// findOwned loads a watchlist and hides other users' lists behind
+// ErrWatchlistNotFound.
+func (s *WatchlistServiceImpl) findOwned(owner, id uuid.UUID) (*models.Watchlist, error) {
+	watchlist, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWatchlistNotFound
+		}
+		return nil, err
+	}
+	if watchlist.UserID != owner {
+		return nil, ErrWatchlistNotFound
+	}
+	return watchlist, nil
+}
+
+// trackedSymbols maps symbols onto the stored spelling of active stocks,
+// dropping duplicates. Unknown symbols are rejected.
+func (s *WatchlistServiceImpl) trackedSymbols(symbols []string) ([]string, error) {
+	if len(symbols) > maxSymbols {
+		return nil, fmt.Errorf("%w: at most %d symbols", ErrInvalidWatchlist, maxSymbols)
+	}
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+	all, err := s.stockRepo.ListSymbols()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]string, len(all))
+	for _, symbol := range all {
+		known[strings.ToUpper(symbol)] = symbol
+	}
+	seen := make(map[string]struct{}, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		tracked, ok := known[strings.ToUpper(strings.TrimSpace(symbol))]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, strings.TrimSpace(symbol))
+		}
+		if _, dup := seen[tracked]; dup {
+			continue
+		}
+		seen[tracked] = struct{}{}
+		out = append(out, tracked)
+	}
+	return out, nil
+}
+
+func validName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 128 {
+		return "", fmt.Errorf("%w: name must be 1-128 characters", ErrInvalidWatchlist)
+	}
+	return name, nil
+}
+
+func parseUserID(userID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(strings.TrimSpace(userID))
+	if err != nil {
+		return uuid.Nil, ErrInvalidUser
+	}
+	return id, nil
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,124 @@
+package watchlists
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	repositorymock "sun-stockanalysis-api/internal/mocks/repository"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type recordingObserver struct {
+	userIDs    []string
+	symbols    [][]string
+	restricted []bool
+}
+
+func (o *recordingObserver) WatchlistChanged(userID string, symbols []string, restricted bool) {
+	o.userIDs = append(o.userIDs, userID)
+	o.symbols = append(o.symbols, symbols)
+	o.restricted = append(o.restricted, restricted)
+}
+
+type WatchlistServiceSuite struct {
+	suite.Suite
+	repo      *repositorymock.MockWatchlistRepository
+	stockRepo *repositorymock.MockStockRepository
+	observer  *recordingObserver
+	service   WatchlistService
+	userID    uuid.UUID
+}
+
+func (s *WatchlistServiceSuite) SetupTest() {
+	s.repo = repositorymock.NewMockWatchlistRepository(s.T())
+	s.stockRepo = repositorymock.NewMockStockRepository(s.T())
+	s.observer = &recordingObserver{}
+	s.service = NewWatchlistService(s.repo, s.stockRepo)
+	s.service.Subscribe(s.observer)
+	s.userID = uuid.New()
+}
+
+func (s *WatchlistServiceSuite) TestCreate_NormalisesSymbolsAndNotifies() {
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL", "TSLA"}, nil)
+	s.repo.EXPECT().FindByUser(s.userID).Return(nil, nil)
+	s.repo.EXPECT().Create(mock.Anything).RunAndReturn(func(w *models.Watchlist) error {
+		s.Equal("Tech", w.Name)
+		s.Require().Len(w.Items, 2)
+		s.Equal("AAPL", w.Items[0].Symbol)
+		s.Equal("TSLA", w.Items[1].Symbol)
+		return nil
+	})
+	s.repo.EXPECT().FindSymbolsByUser(s.userID).Return([]string{"AAPL", "TSLA"}, true, nil)
+
+	_, err := s.service.Create(context.Background(), s.userID.String(), " Tech ", []string{"aapl", "tsla", "AAPL"})
+
+	s.Require().NoError(err)
+	s.Equal([]string{s.userID.String()}, s.observer.userIDs)
+	s.Equal([]bool{true}, s.observer.restricted)
+}
+
+func (s *WatchlistServiceSuite) TestCreate_RejectsUnknownSymbol() {
+	s.stockRepo.EXPECT().ListSymbols().Return([]string{"AAPL"}, nil)
+
+	_, err := s.service.Create(context.Background(), s.userID.String(), "Tech", []string{"NOPE"})
+
+	s.True(errors.Is(err, ErrUnknownSymbol))
+	s.Empty(s.observer.userIDs)
+}
+
+func (s *WatchlistServiceSuite) TestCreate_EnforcesLimit() {
+	s.repo.EXPECT().FindByUser(s.userID).Return(make([]models.Watchlist, maxWatchlists), nil)
+
+	_, err := s.service.Create(context.Background(), s.userID.String(), "Tech", nil)
+
+	s.True(errors.Is(err, ErrTooManyWatchlists))
+}
+
+func (s *WatchlistServiceSuite) TestDelete_OtherUsersListIsNotFound() {
+	id := uuid.New()
+	s.repo.EXPECT().FindByID(id).Return(&models.Watchlist{ID: id, UserID: uuid.New()}, nil)
+
+	err := s.service.Delete(context.Background(), s.userID.String(), id)
+
+	s.True(errors.Is(err, ErrWatchlistNotFound))
+}
+
+func (s *WatchlistServiceSuite) TestDelete_LastListLiftsRestriction() {
+	id := uuid.New()
+	s.repo.EXPECT().FindByID(id).Return(&models.Watchlist{ID: id, UserID: s.userID}, nil)
+	s.repo.EXPECT().Delete(id).Return(nil)
+	s.repo.EXPECT().FindSymbolsByUser(s.userID).Return(nil, false, nil)
+
+	err := s.service.Delete(context.Background(), s.userID.String(), id)
+
+	s.Require().NoError(err)
+	s.Equal([]bool{false}, s.observer.restricted)
+}
+
+func (s *WatchlistServiceSuite) TestWatches() {
+	restricted := uuid.New()
+	open := uuid.New()
+	s.repo.EXPECT().FindSymbolsByUser(restricted).Return([]string{"AAPL"}, true, nil)
+	s.repo.EXPECT().FindSymbolsByUser(open).Return(nil, false, nil)
+
+	ok, err := s.service.Watches(restricted, "TSLA", "aapl")
+	s.Require().NoError(err)
+	s.True(ok)
+
+	ok, err = s.service.Watches(restricted, "TSLA")
+	s.Require().NoError(err)
+	s.False(ok)
+
+	ok, err = s.service.Watches(open, "TSLA")
+	s.Require().NoError(err)
+	s.True(ok)
+}
+
+func TestWatchlistServiceSuite(t *testing.T) {
+	suite.Run(t, new(WatchlistServiceSuite))
+}
@@ -1,22 +1,37 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/golang-jwt/jwt/v5"
 
 	"sun-stockanalysis-api/internal/authctx"
+	"sun-stockanalysis-api/internal/domains/api_keys"
 	"sun-stockanalysis-api/pkg/response"
 	"sun-stockanalysis-api/pkg/status"
 )
 
-func authMiddleware(secret, issuer string) func(ctx huma.Context, next func(huma.Context)) {
+// APIKeyVerifier resolves the personal API keys that authMiddleware accepts
+// in place of an access token.
+type APIKeyVerifier interface {
+	Verify(ctx context.Context, key string) (*api_keys.Principal, error)
+}
+
+func authMiddleware(secret, issuer string, apiKeys APIKeyVerifier) func(ctx huma.Context, next func(huma.Context)) {
 	return func(ctx huma.Context, next func(huma.Context)) {
+		if key := apiKeyFromRequest(ctx); key != "" {
+			authenticateAPIKey(ctx, next, apiKeys, key)
+			return
+		}
+
 		if secret == "" {
 			writeAuthError(ctx, http.StatusUnauthorized, "auth secret not configured")
 			return
@@ -36,38 +51,132 @@ func authMiddleware(secret, issuer string) func(ctx huma.Context, next func(huma
 			return
 		}
 
-		claims := &jwt.RegisteredClaims{}
-
-		options := []jwt.ParserOption{
-			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
+		claims, err := ParseAccessToken(secret, issuer, tokenString)
+		if err != nil {
+			writeAuthError(ctx, http.StatusUnauthorized, "invalid token")
+			return
 		}
-		if issuer != "" {
-			options = append(options, jwt.WithIssuer(issuer))
+
+		if op := ctx.Operation(); op != nil && !authctx.Allowed(authctx.RolesFromMetadata(op.Metadata), claims.Role) {
+			writeAuthError(ctx, http.StatusForbidden, "insufficient role")
+			return
 		}
 
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method")
-			}
-			return []byte(secret), nil
-		}, options...)
-
-		if err != nil || !token.Valid || claims.Subject == "" {
-			log.Printf(
-				"auth invalid token: err=%v valid=%v sub=%q iss=%q exp=%v iat=%v",
-				err,
-				token != nil && token.Valid,
-				claims.Subject,
-				claims.Issuer,
-				claims.ExpiresAt,
-				claims.IssuedAt,
-			)
-			writeAuthError(ctx, http.StatusUnauthorized, "invalid token")
+		ctx = huma.WithValue(ctx, authctx.UserIDContextKey(), claims.Subject)
+		next(huma.WithValue(ctx, authctx.RoleContextKey(), claims.Role))
+	}
+}
+
+// apiKeyFromRequest returns the API key sent in the X-API-Key header or as a
+// bearer token, or "" when the request carries none.
+func apiKeyFromRequest(ctx huma.Context) string {
+	if key := strings.TrimSpace(ctx.Header("X-API-Key")); key != "" {
+		return key
+	}
+	authHeader := ctx.Header("Authorization")
+	if !strings.HasPrefix(strings.ToLower(authHeader), "bearer ") {
+		return ""
+	}
+	if token := strings.TrimSpace(authHeader[len("bearer "):]); api_keys.IsAPIKey(token) {
+		return token
+	}
+	return ""
+}
+
+// authenticateAPIKey authorizes a request made with an API key. Besides the
+// role check that applies to access tokens, the key's scope must allow the
+// method and the operation must not opt out of API keys.
+func authenticateAPIKey(ctx huma.Context, next func(huma.Context), apiKeys APIKeyVerifier, key string) {
+	if apiKeys == nil {
+		writeAuthError(ctx, http.StatusUnauthorized, "api keys not enabled")
+		return
+	}
+
+	principal, err := apiKeys.Verify(ctx.Context(), key)
+	if err != nil {
+		if errors.Is(err, api_keys.ErrInvalidAPIKey) {
+			writeAuthError(ctx, http.StatusUnauthorized, "invalid api key")
 			return
 		}
+		log.Printf("auth api key verification failed: %v", err)
+		writeAuthError(ctx, http.StatusInternalServerError, "api key verification failed")
+		return
+	}
+
+	op := ctx.Operation()
+	if op != nil && !authctx.APIKeysAllowed(op.Metadata) {
+		writeAuthError(ctx, http.StatusForbidden, "api keys cannot call this operation")
+		return
+	}
+	if !principal.Allows(ctx.Method()) {
+		writeAuthError(ctx, http.StatusForbidden, "api key scope does not allow this method")
+		return
+	}
+	if op != nil && !authctx.Allowed(authctx.RolesFromMetadata(op.Metadata), principal.Role) {
+		writeAuthError(ctx, http.StatusForbidden, "insufficient role")
+		return
+	}
+
+	ctx = huma.WithValue(ctx, authctx.UserIDContextKey(), principal.UserID)
+	next(huma.WithValue(ctx, authctx.RoleContextKey(), principal.Role))
+}
+
+// AccessClaims are the claims createAccessToken signs into every access
+// token. Role reflects the user's role when the token was issued.
+type AccessClaims struct {
+	jwt.RegisteredClaims
+	Email string `json:"email,omitempty"`
+	Role  string `json:"role,omitempty"`
+}
+
+// VerifyAccessToken validates an HS256 access token and returns its subject
+// and expiry. The expiry is zero when the token carries no exp claim.
+func VerifyAccessToken(secret, issuer, tokenString string) (string, time.Time, error) {
+	claims, err := ParseAccessToken(secret, issuer, tokenString)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	return claims.Subject, expiresAt, nil
+}
+
+// ParseAccessToken validates an HS256 access token and returns its claims.
+func ParseAccessToken(secret, issuer, tokenString string) (*AccessClaims, error) {
+	if secret == "" {
+		return nil, errors.New("auth secret not configured")
+	}
+	claims := &AccessClaims{}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method")
+		}
+		return []byte(secret), nil
+	}, options...)
 
-		next(huma.WithValue(ctx, authctx.UserIDContextKey(), claims.Subject))
+	if err != nil || !token.Valid || claims.Subject == "" {
+		log.Printf(
+			"auth invalid token: err=%v valid=%v sub=%q iss=%q exp=%v iat=%v",
+			err,
+			token != nil && token.Valid,
+			claims.Subject,
+			claims.Issuer,
+			claims.ExpiresAt,
+			claims.IssuedAt,
+		)
+		return nil, errors.New("invalid token")
 	}
+	return claims, nil
 }
 
 func writeAuthError(ctx huma.Context, statusCode int, message string) {
@@ -81,7 +190,7 @@ func statusForHTTP(httpStatus int) (string, string) {
 	switch httpStatus {
 	case http.StatusBadRequest:
 		return status.CodeInvalidParam, status.MsgInvalidParam
-	case http.StatusUnauthorized:
+	case http.StatusUnauthorized, http.StatusForbidden:
 		return status.CodeUnauthorized, status.MsgUnauthorized
 	case http.StatusRequestTimeout:
 		return status.CodeRequestTimeout, status.MsgRequestTimeout
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/authctx"
+	"sun-stockanalysis-api/internal/domains/api_keys"
+	apikeysmock "sun-stockanalysis-api/internal/mocks/domains/api_keys"
+	"sun-stockanalysis-api/internal/models"
+)
+
+const testAPIKey = "ssa_1a2b3c4d_secret"
+
+const (
+	testSecret = "test-secret"
+	testIssuer = "test-issuer"
+)
+
+type roleOutput struct {
+	Body struct {
+		UserID string `json:"user_id"`
+		Role   string `json:"role"`
+	}
+}
+
+type AuthMiddlewareSuite struct {
+	suite.Suite
+	api     humatest.TestAPI
+	apiKeys *apikeysmock.MockAPIKeyService
+}
+
+func (s *AuthMiddlewareSuite) SetupTest() {
+	_, s.api = humatest.New(s.T())
+	s.apiKeys = apikeysmock.NewMockAPIKeyService(s.T())
+	protected := huma.NewGroup(s.api, "")
+	protected.UseMiddleware(authMiddleware(testSecret, testIssuer, s.apiKeys))
+
+	whoami := func(ctx context.Context, _ *struct{}) (*roleOutput, error) {
+		out := &roleOutput{}
+		out.Body.UserID, _ = authctx.UserIDFromContext(ctx)
+		out.Body.Role, _ = authctx.RoleFromContext(ctx)
+		return out, nil
+	}
+	huma.Register(protected, huma.Operation{Method: http.MethodGet, Path: "/whoami"}, whoami)
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/admin/whoami",
+		Metadata: map[string]any{authctx.RolesMetadataKey: []string{models.RoleAdmin}},
+	}, whoami)
+	huma.Register(protected, huma.Operation{Method: http.MethodPost, Path: "/whoami"}, whoami)
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/session/whoami",
+		Metadata: map[string]any{authctx.APIKeysMetadataKey: false},
+	}, whoami)
+}
+
+func (s *AuthMiddlewareSuite) expectAPIKey(scope, role string) {
+	s.apiKeys.EXPECT().Verify(mock.Anything, testAPIKey).
+		Return(&api_keys.Principal{UserID: "user-2", Role: role, Scope: scope}, nil)
+}
+
+func (s *AuthMiddlewareSuite) token(role string) string {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  "user-1",
+		"iss":  testIssuer,
+		"exp":  time.Now().Add(time.Minute).Unix(),
+		"role": role,
+	}).SignedString([]byte(testSecret))
+	s.Require().NoError(err)
+	return "Authorization: Bearer " + signed
+}
+
+func (s *AuthMiddlewareSuite) TestPutsRoleInContext() {
+	resp := s.api.Get("/whoami", s.token(models.RoleUser))
+
+	s.Equal(http.StatusOK, resp.Code)
+	s.JSONEq(`{"user_id":"user-1","role":"USER"}`, resp.Body.String())
+}
+
+func (s *AuthMiddlewareSuite) TestRejectsRoleNotAllowedByOperation() {
+	resp := s.api.Get("/admin/whoami", s.token(models.RoleUser))
+	s.Equal(http.StatusForbidden, resp.Code)
+
+	resp = s.api.Get("/admin/whoami", s.token(""))
+	s.Equal(http.StatusForbidden, resp.Code)
+
+	resp = s.api.Get("/admin/whoami", s.token(models.RoleAdmin))
+	s.Equal(http.StatusOK, resp.Code)
+}
+
+func (s *AuthMiddlewareSuite) TestRejectsMissingToken() {
+	resp := s.api.Get("/admin/whoami")
+
+	s.Equal(http.StatusUnauthorized, resp.Code)
+}
+
+func (s *AuthMiddlewareSuite) TestAcceptsAPIKeyHeaderAndBearer() {
+	s.expectAPIKey(models.APIKeyScopeRead, models.RoleUser)
+
+	resp := s.api.Get("/whoami", "X-API-Key: "+testAPIKey)
+	s.Equal(http.StatusOK, resp.Code)
+	s.JSONEq(`{"user_id":"user-2","role":"USER"}`, resp.Body.String())
+
+	resp = s.api.Get("/whoami", "Authorization: Bearer "+testAPIKey)
+	s.Equal(http.StatusOK, resp.Code)
+}
+
+func (s *AuthMiddlewareSuite) TestReadScopeRejectsWrites() {
+	s.expectAPIKey(models.APIKeyScopeRead, models.RoleUser)
+
+	resp := s.api.Post("/whoami", "X-API-Key: "+testAPIKey)
+
+	s.Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *AuthMiddlewareSuite) TestWriteScopeAllowsWrites() {
+	s.expectAPIKey(models.APIKeyScopeWrite, models.RoleUser)
+
+	resp := s.api.Post("/whoami", "X-API-Key: "+testAPIKey)
+
+	s.Equal(http.StatusOK, resp.Code)
+}
+
+func (s *AuthMiddlewareSuite) TestAPIKeyStillNeedsRole() {
+	s.expectAPIKey(models.APIKeyScopeWrite, models.RoleUser)
+
+	resp := s.api.Get("/admin/whoami", "X-API-Key: "+testAPIKey)
+
+	s.Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *AuthMiddlewareSuite) TestSessionOnlyOperationRejectsAPIKey() {
+	s.expectAPIKey(models.APIKeyScopeWrite, models.RoleUser)
+
+	resp := s.api.Get("/session/whoami", "X-API-Key: "+testAPIKey)
+	s.Equal(http.StatusForbidden, resp.Code)
+
+	resp = s.api.Get("/session/whoami", s.token(models.RoleUser))
+	s.Equal(http.StatusOK, resp.Code)
+}
+
+func (s *AuthMiddlewareSuite) TestRejectsInvalidAPIKey() {
+	s.apiKeys.EXPECT().Verify(mock.Anything, testAPIKey).Return(nil, api_keys.ErrInvalidAPIKey)
+
+	resp := s.api.Get("/whoami", "X-API-Key: "+testAPIKey)
+
+	s.Equal(http.StatusUnauthorized, resp.Code)
+}
+
+func TestAuthMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(AuthMiddlewareSuite))
+}
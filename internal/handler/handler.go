@@ -9,19 +9,32 @@ import (
 
 const apiBasePath = "/v1"
 
-func RegisterRoutes(rootApi huma.API, controllers *controllers.Controllers, authSecret, authIssuer string) {
+func RegisterRoutes(rootApi huma.API, controllers *controllers.Controllers, authSecret, authIssuer string, apiKeys APIKeyVerifier) {
 	// rootApi.UseMiddleware(requestIDMiddleware)
 
 	routes.RegisterHealthRoutes(rootApi, controllers)
 	v1Api := huma.NewGroup(rootApi, apiBasePath)
 
-	routes.RegisterAuthRoutes(v1Api, controllers)
-	routes.RegisterStockRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer))
-	routes.RegisterStockQuoteRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer))
-	routes.RegisterStockDailyRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer))
-	routes.RegisterCompanyNewsRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer))
-	routes.RegisterRelationNewsRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer))
-	routes.RegisterPushSubscriptionRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer))
+	routes.RegisterAuthRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterStockRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterStockQuoteRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterStockDailyRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterCandleRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterCompanyNewsRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterRelationNewsRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterAlertRuleRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterWatchlistRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterPortfolioRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterHoldingRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterFXRateRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterMarketCalendarRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterUserRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterAPIKeyRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterBacktestRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterRealtimeRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterPushSubscriptionRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterBackfillRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
+	routes.RegisterIndicatorRoutes(v1Api, controllers, authMiddleware(authSecret, authIssuer, apiKeys))
 }
 
 // func requestIDMiddleware(ctx huma.Context, next func(huma.Context)) {
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/controllers"
+	"sun-stockanalysis-api/internal/domains/api_keys"
+	apikeysmock "sun-stockanalysis-api/internal/mocks/domains/api_keys"
+	"sun-stockanalysis-api/internal/models"
+)
+
+type RegisterRoutesSuite struct {
+	suite.Suite
+}
+
+// Huma panics when two operations register different types under the same
+// schema name, so registering every route catches clashes such as two
+// packages each exporting a Job type.
+func (s *RegisterRoutesSuite) TestRegistersEveryRouteWithoutSchemaClashes() {
+	_, api := humatest.New(s.T())
+
+	s.NotPanics(func() {
+		RegisterRoutes(api, &controllers.Controllers{}, testSecret, testIssuer, nil)
+	})
+	s.NotEmpty(api.OpenAPI().Paths)
+}
+
+func (s *RegisterRoutesSuite) TestRoleChangesRejectAdminAPIKeys() {
+	_, api := humatest.New(s.T())
+	apiKeys := apikeysmock.NewMockAPIKeyService(s.T())
+	apiKeys.EXPECT().Verify(mock.Anything, testAPIKey).
+		Return(&api_keys.Principal{UserID: "user-2", Role: models.RoleAdmin, Scope: models.APIKeyScopeWrite}, nil)
+	RegisterRoutes(api, &controllers.Controllers{}, testSecret, testIssuer, apiKeys)
+
+	for _, action := range []string{"promote", "demote"} {
+		resp := api.Post(apiBasePath+"/admin/users/"+uuid.NewString()+"/"+action, "X-API-Key: "+testAPIKey)
+		s.Equal(http.StatusForbidden, resp.Code, action)
+	}
+}
+
+func TestRegisterRoutesSuite(t *testing.T) {
+	suite.Run(t, new(RegisterRoutesSuite))
+}
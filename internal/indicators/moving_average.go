@@ -0,0 +1,190 @@
+// Package indicators implements streaming technical indicators. Each indicator
+// is fed one sample at a time with Update and is safe to persist by storing
+// its Value and resuming later.
+package indicators
+
+// Indicator is a streaming moving average over a fixed period.
+type Indicator interface {
+	Update(value float64) float64
+	Value() float64
+	Ready() bool
+	Period() int
+}
+
+// Alpha is the standard EMA smoothing factor 2/(period+1).
+func Alpha(period int) float64 {
+	if period < 1 {
+		period = 1
+	}
+	return 2.0 / float64(period+1)
+}
+
+// NextEMA continues an EMA whose last value is prev with a new sample.
+func NextEMA(prev, value float64, period int) float64 {
+	alpha := Alpha(period)
+	return alpha*value + (1-alpha)*prev
+}
+
+// EMA is an exponential moving average seeded with its first sample.
+type EMA struct {
+	period int
+	alpha  float64
+	value  float64
+	count  int
+}
+
+func NewEMA(period int) *EMA {
+	if period < 1 {
+		period = 1
+	}
+	return &EMA{period: period, alpha: Alpha(period)}
+}
+
+// ResumeEMA restores an EMA from a previously stored value.
+func ResumeEMA(period int, value float64) *EMA {
+	ema := NewEMA(period)
+	ema.value = value
+	ema.count = ema.period
+	return ema
+}
+
+func (e *EMA) Update(value float64) float64 {
+	if e.count == 0 {
+		e.value = value
+	} else {
+		e.value = e.alpha*value + (1-e.alpha)*e.value
+	}
+	if e.count < e.period {
+		e.count++
+	}
+	return e.value
+}
+
+func (e *EMA) Value() float64 {
+	return e.value
+}
+
+// Ready reports whether at least period samples have been seen.
+func (e *EMA) Ready() bool {
+	return e.count >= e.period
+}
+
+func (e *EMA) Period() int {
+	return e.period
+}
+
+// window is a fixed-size ring buffer of the most recent samples.
+type window struct {
+	values []float64
+	next   int
+	count  int
+}
+
+func newWindow(period int) window {
+	if period < 1 {
+		period = 1
+	}
+	return window{values: make([]float64, period)}
+}
+
+func (w *window) push(value float64) (evicted float64, full bool) {
+	full = w.count == len(w.values)
+	evicted = w.values[w.next]
+	w.values[w.next] = value
+	w.next = (w.next + 1) % len(w.values)
+	if !full {
+		w.count++
+	}
+	return evicted, full
+}
+
+// each visits the buffered samples oldest first.
+func (w *window) each(fn func(i int, value float64)) {
+	start := 0
+	if w.count == len(w.values) {
+		start = w.next
+	}
+	for i := 0; i < w.count; i++ {
+		fn(i, w.values[(start+i)%len(w.values)])
+	}
+}
+
+// SMA is a simple moving average. Until period samples are seen it averages
+// the samples it has.
+type SMA struct {
+	window window
+	sum    float64
+}
+
+func NewSMA(period int) *SMA {
+	return &SMA{window: newWindow(period)}
+}
+
+func (s *SMA) Update(value float64) float64 {
+	evicted, full := s.window.push(value)
+	s.sum += value
+	if full {
+		s.sum -= evicted
+	}
+	return s.Value()
+}
+
+func (s *SMA) Value() float64 {
+	if s.window.count == 0 {
+		return 0
+	}
+	return s.sum / float64(s.window.count)
+}
+
+func (s *SMA) Ready() bool {
+	return s.window.count == len(s.window.values)
+}
+
+func (s *SMA) Period() int {
+	return len(s.window.values)
+}
+
+// WMA is a linearly weighted moving average; the newest sample has weight n and
+// the oldest weight 1. Until period samples are seen it weights the samples it has.
+type WMA struct {
+	window window
+}
+
+func NewWMA(period int) *WMA {
+	return &WMA{window: newWindow(period)}
+}
+
+func (w *WMA) Update(value float64) float64 {
+	w.window.push(value)
+	return w.Value()
+}
+
+func (w *WMA) Value() float64 {
+	if w.window.count == 0 {
+		return 0
+	}
+	var sum, weights float64
+	w.window.each(func(i int, value float64) {
+		weight := float64(i + 1)
+		sum += weight * value
+		weights += weight
+	})
+	return sum / weights
+}
+
+func (w *WMA) Ready() bool {
+	return w.window.count == len(w.window.values)
+}
+
+func (w *WMA) Period() int {
+	return len(w.window.values)
+}
+
+// Series feeds values through ind and returns its value after each sample.
+func Series(ind Indicator, values []float64) []float64 {
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = ind.Update(v)
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// closes is the ten-day sample series from the StockCharts moving average
+// primer, extended by five sessions. Golden values were computed independently
+// with the textbook formulas.
+var closes = []float64{
+	22.27, 22.19, 22.08, 22.17, 22.18, 22.13, 22.23, 22.43,
+	22.24, 22.29, 22.15, 22.39, 22.38, 22.61, 23.36,
+}
+
+type MovingAverageSuite struct {
+	suite.Suite
+}
+
+func (s *MovingAverageSuite) assertSeries(want, got []float64) {
+	s.Require().Len(got, len(want))
+	for i := range want {
+		s.InDelta(want[i], got[i], 1e-6, "index %d", i)
+	}
+}
+
+func (s *MovingAverageSuite) TestEMA_Golden() {
+	want := []float64{
+		22.27, 22.243333, 22.188889, 22.182593, 22.181728, 22.164486, 22.186324, 22.267549,
+		22.258366, 22.268911, 22.229274, 22.282849, 22.315233, 22.413489, 22.728992,
+	}
+
+	s.assertSeries(want, Series(NewEMA(5), closes))
+}
+
+func (s *MovingAverageSuite) TestSMA_Golden() {
+	want := []float64{
+		22.27, 22.23, 22.18, 22.1775, 22.178, 22.15, 22.158, 22.228,
+		22.242, 22.264, 22.268, 22.3, 22.29, 22.364, 22.578,
+	}
+
+	s.assertSeries(want, Series(NewSMA(5), closes))
+}
+
+func (s *MovingAverageSuite) TestWMA_Golden() {
+	want := []float64{
+		22.27, 22.216667, 22.148333, 22.157, 22.164667, 22.148667, 22.175333, 22.266,
+		22.27, 22.286, 22.248, 22.288667, 22.315333, 22.422, 22.754,
+	}
+
+	s.assertSeries(want, Series(NewWMA(5), closes))
+}
+
+func (s *MovingAverageSuite) TestAlpha_UsesPeriodPlusOne() {
+	s.InDelta(2.0/21.0, Alpha(20), 1e-12)
+	s.InDelta(2.0/101.0, Alpha(100), 1e-12)
+}
+
+func (s *MovingAverageSuite) TestResumeEMA_MatchesUninterruptedRun() {
+	full := NewEMA(5)
+	Series(full, closes)
+
+	head := NewEMA(5)
+	Series(head, closes[:8])
+	resumed := ResumeEMA(5, head.Value())
+	Series(resumed, closes[8:])
+
+	s.InDelta(full.Value(), resumed.Value(), 1e-9)
+	s.True(resumed.Ready())
+}
+
+func (s *MovingAverageSuite) TestReady_AfterPeriodSamples() {
+	for _, ind := range []Indicator{NewEMA(3), NewSMA(3), NewWMA(3)} {
+		ind.Update(1)
+		ind.Update(2)
+		s.False(ind.Ready())
+		ind.Update(3)
+		s.True(ind.Ready())
+		s.Equal(3, ind.Period())
+	}
+}
+
+func TestMovingAverageSuite(t *testing.T) {
+	suite.Run(t, new(MovingAverageSuite))
+}
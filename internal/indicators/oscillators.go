@@ -0,0 +1,234 @@
+package indicators
+
+import "math"
+
+// Bar is one price sample. Quotes that only carry a last price use it for
+// High, Low and Close alike.
+type Bar struct {
+	High  float64
+	Low   float64
+	Close float64
+}
+
+// RSIState is the carry-over needed to resume an RSI.
+type RSIState struct {
+	Count   int
+	Prev    float64
+	AvgGain float64
+	AvgLoss float64
+}
+
+// RSI is Wilder's relative strength index. The first period changes are
+// averaged arithmetically; afterwards Wilder smoothing is applied.
+type RSI struct {
+	period int
+	state  RSIState
+}
+
+func NewRSI(period int) *RSI {
+	return ResumeRSI(period, RSIState{})
+}
+
+func ResumeRSI(period int, state RSIState) *RSI {
+	if period < 1 {
+		period = 1
+	}
+	return &RSI{period: period, state: state}
+}
+
+func (r *RSI) Update(value float64) float64 {
+	st := &r.state
+	if st.Count == 0 {
+		st.Prev = value
+		st.Count = 1
+		return r.Value()
+	}
+	change := value - st.Prev
+	gain := math.Max(change, 0)
+	loss := math.Max(-change, 0)
+	if st.Count <= r.period {
+		n := float64(st.Count)
+		st.AvgGain += (gain - st.AvgGain) / n
+		st.AvgLoss += (loss - st.AvgLoss) / n
+	} else {
+		p := float64(r.period)
+		st.AvgGain = (st.AvgGain*(p-1) + gain) / p
+		st.AvgLoss = (st.AvgLoss*(p-1) + loss) / p
+	}
+	st.Prev = value
+	st.Count++
+	return r.Value()
+}
+
+func (r *RSI) Value() float64 {
+	st := r.state
+	if st.AvgLoss == 0 {
+		if st.AvgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	rs := st.AvgGain / st.AvgLoss
+	return 100 - 100/(1+rs)
+}
+
+// Ready reports whether period price changes have been seen.
+func (r *RSI) Ready() bool {
+	return r.state.Count > r.period
+}
+
+func (r *RSI) State() RSIState {
+	return r.state
+}
+
+// MACDState is the carry-over needed to resume a MACD.
+type MACDState struct {
+	Count  int
+	Fast   float64
+	Slow   float64
+	Signal float64
+}
+
+// MACD is the moving average convergence/divergence of a fast and slow EMA,
+// with an EMA signal line over the MACD line.
+type MACD struct {
+	fast   int
+	slow   int
+	signal int
+	state  MACDState
+}
+
+func NewMACD(fast, slow, signal int) *MACD {
+	return ResumeMACD(fast, slow, signal, MACDState{})
+}
+
+func ResumeMACD(fast, slow, signal int, state MACDState) *MACD {
+	return &MACD{fast: fast, slow: slow, signal: signal, state: state}
+}
+
+// Update returns the MACD line, signal line and histogram.
+func (m *MACD) Update(value float64) (float64, float64, float64) {
+	st := &m.state
+	if st.Count == 0 {
+		st.Fast = value
+		st.Slow = value
+		st.Signal = 0
+	} else {
+		st.Fast = NextEMA(st.Fast, value, m.fast)
+		st.Slow = NextEMA(st.Slow, value, m.slow)
+		st.Signal = NextEMA(st.Signal, st.Fast-st.Slow, m.signal)
+	}
+	st.Count++
+	return m.Value()
+}
+
+func (m *MACD) Value() (float64, float64, float64) {
+	line := m.state.Fast - m.state.Slow
+	return line, m.state.Signal, line - m.state.Signal
+}
+
+// Ready reports whether both the slow EMA and the signal line have warmed up.
+func (m *MACD) Ready() bool {
+	return m.state.Count >= m.slow+m.signal-1
+}
+
+func (m *MACD) State() MACDState {
+	return m.state
+}
+
+// Bollinger computes Bollinger Bands: an SMA middle band with upper and lower
+// bands k population standard deviations away.
+type Bollinger struct {
+	k      float64
+	window window
+}
+
+func NewBollinger(period int, k float64) *Bollinger {
+	return &Bollinger{k: k, window: newWindow(period)}
+}
+
+// ResumeBollinger restores the bands from the most recent closes, oldest first.
+func ResumeBollinger(period int, k float64, closes []float64) *Bollinger {
+	b := NewBollinger(period, k)
+	for _, c := range closes {
+		b.window.push(c)
+	}
+	return b
+}
+
+// Update returns the middle, upper and lower bands.
+func (b *Bollinger) Update(value float64) (float64, float64, float64) {
+	b.window.push(value)
+	return b.Value()
+}
+
+func (b *Bollinger) Value() (float64, float64, float64) {
+	if b.window.count == 0 {
+		return 0, 0, 0
+	}
+	n := float64(b.window.count)
+	var sum float64
+	b.window.each(func(_ int, v float64) { sum += v })
+	mean := sum / n
+	var sq float64
+	b.window.each(func(_ int, v float64) { sq += (v - mean) * (v - mean) })
+	dev := math.Sqrt(sq/n) * b.k
+	return mean, mean + dev, mean - dev
+}
+
+func (b *Bollinger) Ready() bool {
+	return b.window.count == len(b.window.values)
+}
+
+// ATRState is the carry-over needed to resume an ATR.
+type ATRState struct {
+	Count     int
+	PrevClose float64
+	ATR       float64
+}
+
+// ATR is Wilder's average true range.
+type ATR struct {
+	period int
+	state  ATRState
+}
+
+func NewATR(period int) *ATR {
+	return ResumeATR(period, ATRState{})
+}
+
+func ResumeATR(period int, state ATRState) *ATR {
+	if period < 1 {
+		period = 1
+	}
+	return &ATR{period: period, state: state}
+}
+
+func (a *ATR) Update(bar Bar) float64 {
+	st := &a.state
+	tr := bar.High - bar.Low
+	if st.Count > 0 {
+		tr = math.Max(tr, math.Max(math.Abs(bar.High-st.PrevClose), math.Abs(bar.Low-st.PrevClose)))
+	}
+	if st.Count < a.period {
+		st.ATR += (tr - st.ATR) / float64(st.Count+1)
+	} else {
+		p := float64(a.period)
+		st.ATR = (st.ATR*(p-1) + tr) / p
+	}
+	st.PrevClose = bar.Close
+	st.Count++
+	return st.ATR
+}
+
+func (a *ATR) Value() float64 {
+	return a.state.ATR
+}
+
+func (a *ATR) Ready() bool {
+	return a.state.Count >= a.period
+}
+
+func (a *ATR) State() ATRState {
+	return a.state
+}
@@ -0,0 +1,148 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// wilderCloses is the RSI worksheet series from Wilder's "New Concepts in
+// Technical Trading Systems" as reproduced by StockCharts.
+var wilderCloses = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08, 45.89,
+	46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64, 46.21, 46.25,
+	45.71, 46.45, 45.78, 45.35, 44.03, 44.18, 44.22, 44.57, 43.42, 42.66, 43.13,
+}
+
+type OscillatorSuite struct {
+	suite.Suite
+}
+
+func (s *OscillatorSuite) TestRSI_Golden() {
+	want := []float64{
+		70.464135, 66.249619, 66.480942, 69.346853, 66.294713, 57.915021, 62.880718,
+		63.208789, 56.011585, 62.339929, 54.670971, 50.386815, 40.019424, 41.492635,
+		41.90243, 45.499497, 37.322778, 33.090483, 37.788772,
+	}
+
+	rsi := NewRSI(14)
+	var got []float64
+	for _, c := range wilderCloses {
+		value := rsi.Update(c)
+		if rsi.Ready() {
+			got = append(got, value)
+		}
+	}
+
+	s.Require().Len(got, len(want))
+	for i := range want {
+		s.InDelta(want[i], got[i], 1e-6, "index %d", i)
+	}
+}
+
+func (s *OscillatorSuite) TestRSI_ResumeMatchesUninterruptedRun() {
+	full := NewRSI(14)
+	for _, c := range wilderCloses {
+		full.Update(c)
+	}
+
+	head := NewRSI(14)
+	for _, c := range wilderCloses[:10] {
+		head.Update(c)
+	}
+	resumed := ResumeRSI(14, head.State())
+	for _, c := range wilderCloses[10:] {
+		resumed.Update(c)
+	}
+
+	s.InDelta(full.Value(), resumed.Value(), 1e-9)
+}
+
+func (s *OscillatorSuite) TestMACD_Golden() {
+	macd := NewMACD(3, 6, 4)
+	var line, signal, hist float64
+	for i, c := range wilderCloses {
+		line, signal, hist = macd.Update(c)
+		if i == 8 {
+			s.True(macd.Ready())
+			s.InDelta(0.374168, line, 1e-6)
+			s.InDelta(0.260246, signal, 1e-6)
+			s.InDelta(0.113922, hist, 1e-6)
+		}
+	}
+
+	s.InDelta(-0.437745, line, 1e-6)
+	s.InDelta(-0.43524, signal, 1e-6)
+	s.InDelta(-0.002505, hist, 1e-6)
+}
+
+func (s *OscillatorSuite) TestBollinger_Golden() {
+	bands := NewBollinger(20, 2)
+	var middle, upper, lower float64
+	for i, c := range wilderCloses {
+		middle, upper, lower = bands.Update(c)
+		if i == 19 {
+			s.True(bands.Ready())
+			s.InDelta(45.409, middle, 1e-6)
+			s.InDelta(47.115328, upper, 1e-6)
+			s.InDelta(43.702672, lower, 1e-6)
+		}
+	}
+
+	s.InDelta(45.241, middle, 1e-6)
+	s.InDelta(47.62015, upper, 1e-6)
+	s.InDelta(42.86185, lower, 1e-6)
+}
+
+func (s *OscillatorSuite) TestATR_Golden() {
+	highs := []float64{48.70, 48.72, 48.90, 48.87, 48.82, 49.05, 49.20, 49.35, 49.92, 50.19, 50.12, 49.66, 49.88, 50.19, 50.36, 50.57, 50.65, 50.43}
+	lows := []float64{47.79, 48.14, 48.39, 48.37, 48.24, 48.64, 48.94, 48.86, 49.50, 49.87, 49.20, 48.90, 49.43, 49.73, 49.26, 50.09, 50.30, 49.21}
+	closes := []float64{48.16, 48.61, 48.75, 48.63, 48.74, 49.03, 49.07, 49.32, 49.91, 50.13, 49.53, 49.50, 49.75, 50.03, 50.31, 50.52, 50.41, 49.34}
+	want := []float64{0.554286, 0.593265, 0.585175, 0.568377, 0.614921}
+
+	atr := NewATR(14)
+	var got []float64
+	for i := range closes {
+		value := atr.Update(Bar{High: highs[i], Low: lows[i], Close: closes[i]})
+		if atr.Ready() {
+			got = append(got, value)
+		}
+	}
+
+	s.Require().Len(got, len(want))
+	for i := range want {
+		s.InDelta(want[i], got[i], 1e-6, "index %d", i)
+	}
+}
+
+func (s *OscillatorSuite) TestSet_ResumeMatchesUninterruptedRun() {
+	cfg := DefaultConfig()
+	full := NewSet(cfg)
+	var want Snapshot
+	for _, c := range wilderCloses {
+		want = full.Update(Bar{High: c, Low: c, Close: c})
+	}
+
+	head := NewSet(cfg)
+	split := 25
+	for _, c := range wilderCloses[:split] {
+		head.Update(Bar{High: c, Low: c, Close: c})
+	}
+	resumed := ResumeSet(cfg, head.State(), wilderCloses[split-cfg.BollingerPeriod+1:split])
+	var got Snapshot
+	for _, c := range wilderCloses[split:] {
+		got = resumed.Update(Bar{High: c, Low: c, Close: c})
+	}
+
+	s.Require().NotNil(got.RSI)
+	s.Require().NotNil(got.BollingerMiddle)
+	s.Require().NotNil(got.ATR)
+	s.InDelta(*want.RSI, *got.RSI, 1e-9)
+	s.InDelta(full.State().MACDSignal, resumed.State().MACDSignal, 1e-9)
+	s.InDelta(*want.BollingerUpper, *got.BollingerUpper, 1e-9)
+	s.InDelta(*want.ATR, *got.ATR, 1e-9)
+}
+
+func TestOscillatorSuite(t *testing.T) {
+	suite.Run(t, new(OscillatorSuite))
+}
@@ -0,0 +1,135 @@
+package indicators
+
+// Config holds the periods used by Set.
+type Config struct {
+	RSIPeriod       int
+	MACDFast        int
+	MACDSlow        int
+	MACDSignal      int
+	BollingerPeriod int
+	BollingerK      float64
+	ATRPeriod       int
+}
+
+// DefaultConfig is RSI(14), MACD(12,26,9), Bollinger(20,2) and ATR(14).
+func DefaultConfig() Config {
+	return Config{
+		RSIPeriod:       14,
+		MACDFast:        12,
+		MACDSlow:        26,
+		MACDSignal:      9,
+		BollingerPeriod: 20,
+		BollingerK:      2,
+		ATRPeriod:       14,
+	}
+}
+
+// State is everything except the Bollinger window needed to resume a Set.
+// All indicators in a Set see the same samples, so one count is enough.
+type State struct {
+	Samples    int
+	PrevClose  float64
+	RSIAvgGain float64
+	RSIAvgLoss float64
+	MACDFast   float64
+	MACDSlow   float64
+	MACDSignal float64
+	ATR        float64
+}
+
+// Snapshot holds indicator values after an update. A value is nil until its
+// indicator has seen enough samples.
+type Snapshot struct {
+	RSI             *float64
+	MACD            *float64
+	MACDSignal      *float64
+	MACDHistogram   *float64
+	BollingerMiddle *float64
+	BollingerUpper  *float64
+	BollingerLower  *float64
+	ATR             *float64
+}
+
+// Set updates RSI, MACD, Bollinger Bands and ATR together.
+type Set struct {
+	rsi       *RSI
+	macd      *MACD
+	bollinger *Bollinger
+	atr       *ATR
+}
+
+func NewSet(cfg Config) *Set {
+	return ResumeSet(cfg, State{}, nil)
+}
+
+// ResumeSet restores a Set from stored state and the most recent closes,
+// oldest first, which refill the Bollinger window.
+func ResumeSet(cfg Config, state State, closes []float64) *Set {
+	return &Set{
+		rsi: ResumeRSI(cfg.RSIPeriod, RSIState{
+			Count:   state.Samples,
+			Prev:    state.PrevClose,
+			AvgGain: state.RSIAvgGain,
+			AvgLoss: state.RSIAvgLoss,
+		}),
+		macd: ResumeMACD(cfg.MACDFast, cfg.MACDSlow, cfg.MACDSignal, MACDState{
+			Count:  state.Samples,
+			Fast:   state.MACDFast,
+			Slow:   state.MACDSlow,
+			Signal: state.MACDSignal,
+		}),
+		bollinger: ResumeBollinger(cfg.BollingerPeriod, cfg.BollingerK, closes),
+		atr: ResumeATR(cfg.ATRPeriod, ATRState{
+			Count:     state.Samples,
+			PrevClose: state.PrevClose,
+			ATR:       state.ATR,
+		}),
+	}
+}
+
+func (s *Set) Update(bar Bar) Snapshot {
+	s.rsi.Update(bar.Close)
+	s.macd.Update(bar.Close)
+	s.bollinger.Update(bar.Close)
+	s.atr.Update(bar)
+	return s.Snapshot()
+}
+
+func (s *Set) Snapshot() Snapshot {
+	var snap Snapshot
+	if s.rsi.Ready() {
+		snap.RSI = ptr(s.rsi.Value())
+	}
+	if s.macd.Ready() {
+		line, signal, hist := s.macd.Value()
+		snap.MACD, snap.MACDSignal, snap.MACDHistogram = ptr(line), ptr(signal), ptr(hist)
+	}
+	if s.bollinger.Ready() {
+		middle, upper, lower := s.bollinger.Value()
+		snap.BollingerMiddle, snap.BollingerUpper, snap.BollingerLower = ptr(middle), ptr(upper), ptr(lower)
+	}
+	if s.atr.Ready() {
+		snap.ATR = ptr(s.atr.Value())
+	}
+	return snap
+}
+
+func (s *Set) State() State {
+	rsi := s.rsi.State()
+	macd := s.macd.State()
+	atr := s.atr.State()
+	return State{
+		Samples:    rsi.Count,
+		PrevClose:  rsi.Prev,
+		RSIAvgGain: rsi.AvgGain,
+		RSIAvgLoss: rsi.AvgLoss,
+		MACDFast:   macd.Fast,
+		MACDSlow:   macd.Slow,
+		MACDSignal: macd.Signal,
+		ATR:        atr.ATR,
+	}
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
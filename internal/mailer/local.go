@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FileMailer writes every message as an .eml file into a directory, so local
+// runs can open verification and reset links without an SMTP server.
+type FileMailer struct {
+	dir  string
+	from string
+	now  func() time.Time
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("mail dir: %w", err)
+	}
+	return &FileMailer{dir: dir, from: from, now: time.Now}, nil
+}
+
+func (m *FileMailer) Name() string {
+	return DriverFile
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := m.now()
+	body, err := buildMessage(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405.000000000"), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	return os.WriteFile(filepath.Join(m.dir, name), body, 0o600)
+}
+
+// LogMailer writes messages to the application log instead of sending them.
+type LogMailer struct {
+	log *logger.Logger
+}
+
+func NewLogMailer(log *logger.Logger) *LogMailer {
+	return &LogMailer{log: log}
+}
+
+func (m *LogMailer) Name() string {
+	return DriverLog
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if m.log != nil {
+		m.log.Infof("mail to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Text)
+	}
+	return nil
+}
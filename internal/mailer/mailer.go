@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"sun-stockanalysis-api/internal/configurations"
+	"sun-stockanalysis-api/pkg/logger"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+	DriverLog  = "log"
+)
+
+// Message is a plain-text email to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+// Mailer delivers transactional email such as verification and password
+// reset links.
+type Mailer interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewMailer builds the mailer selected by mail.driver. Without a driver it
+// logs messages instead of sending them, which suits local runs.
+func NewMailer(cfg *configurations.Mail, log *logger.Logger) (Mailer, error) {
+	kind := DriverLog
+	if cfg != nil && strings.TrimSpace(cfg.Driver) != "" {
+		kind = strings.ToLower(strings.TrimSpace(cfg.Driver))
+	}
+
+	switch kind {
+	case DriverSMTP:
+		if cfg == nil || strings.TrimSpace(cfg.Host) == "" || strings.TrimSpace(cfg.From) == "" {
+			return nil, errors.New("mail.host and mail.from are required for the smtp driver")
+		}
+		return NewSMTPMailer(cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.From), nil
+	case DriverFile:
+		if cfg == nil || strings.TrimSpace(cfg.Dir) == "" {
+			return nil, errors.New("mail.dir is required for the file driver")
+		}
+		return NewFileMailer(strings.TrimSpace(cfg.Dir), mailFrom(cfg))
+	case DriverLog:
+		return NewLogMailer(log), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", kind)
+	}
+}
+
+func mailFrom(cfg *configurations.Mail) string {
+	if cfg != nil && strings.TrimSpace(cfg.From) != "" {
+		return strings.TrimSpace(cfg.From)
+	}
+	return "no-reply@localhost"
+}
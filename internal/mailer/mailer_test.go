@@ -0,0 +1,89 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/configurations"
+)
+
+type MailerSuite struct {
+	suite.Suite
+}
+
+func (s *MailerSuite) TestBuildMessageEncodesUTF8() {
+	msg := Message{To: "user@example.com", Subject: "รีเซ็ตรหัสผ่าน", Text: "สวัสดี\nhttps://app.example.com/reset-password?token=abc"}
+	sentAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	raw, err := buildMessage("Sun <no-reply@example.com>", msg, sentAt)
+	s.Require().NoError(err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	s.Require().NoError(err)
+	s.Equal("user@example.com", parsed.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	s.Require().NoError(err)
+	s.Equal(msg.Subject, subject)
+	date, err := parsed.Header.Date()
+	s.Require().NoError(err)
+	s.True(date.Equal(sentAt))
+
+	encoded, err := io.ReadAll(parsed.Body)
+	s.Require().NoError(err)
+	body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	s.Require().NoError(err)
+	s.Equal(msg.Text, string(body))
+}
+
+func (s *MailerSuite) TestBuildMessageRejectsInvalidRecipient() {
+	_, err := buildMessage("no-reply@example.com", Message{To: "not an address"}, time.Now())
+
+	s.Error(err)
+}
+
+func (s *MailerSuite) TestFileMailerWritesEML() {
+	dir := s.T().TempDir()
+	m, err := NewFileMailer(filepath.Join(dir, "mail"), "no-reply@example.com")
+	s.Require().NoError(err)
+
+	s.Require().NoError(m.Send(context.Background(), Message{To: "user@example.com", Subject: "Hello", Text: "Hi"}))
+
+	files, err := os.ReadDir(filepath.Join(dir, "mail"))
+	s.Require().NoError(err)
+	s.Require().Len(files, 1)
+	s.True(strings.HasSuffix(files[0].Name(), "-user_example.com.eml"), files[0].Name())
+}
+
+func (s *MailerSuite) TestNewMailerSelectsDriver() {
+	m, err := NewMailer(nil, nil)
+	s.Require().NoError(err)
+	s.Equal(DriverLog, m.Name())
+
+	m, err = NewMailer(&configurations.Mail{Driver: "SMTP", Host: "smtp.example.com", From: "no-reply@example.com"}, nil)
+	s.Require().NoError(err)
+	s.Equal(DriverSMTP, m.Name())
+
+	_, err = NewMailer(&configurations.Mail{Driver: "smtp"}, nil)
+	s.Error(err)
+
+	_, err = NewMailer(&configurations.Mail{Driver: "file"}, nil)
+	s.Error(err)
+
+	_, err = NewMailer(&configurations.Mail{Driver: "pigeon"}, nil)
+	s.Error(err)
+}
+
+func TestMailerSuite(t *testing.T) {
+	suite.Run(t, new(MailerSuite))
+}
@@ -0,0 +1,377 @@
+package marketdata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFinnhubBaseURL    = "https://finnhub.io/api/v1"
+	defaultFinnhubTimeoutSec = 10
+	defaultFinnhubRateLimit  = 60
+	defaultFinnhubRetries    = 3
+	finnhubRetryBackoff      = time.Second
+)
+
+var (
+	finnhubBaseURL = getEnvString("FINNHUB_BASE_URL", defaultFinnhubBaseURL)
+	finnhubTimeout = time.Duration(getEnvInt("FINNHUB_TIMEOUT_SECONDS", defaultFinnhubTimeoutSec)) * time.Second
+	finnhubRate    = getEnvInt("FINNHUB_RATE_LIMIT_PER_MINUTE", defaultFinnhubRateLimit)
+	finnhubRetries = getEnvInt("FINNHUB_MAX_RETRIES", defaultFinnhubRetries)
+)
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type FinnhubProvider struct {
+	httpClient HTTPClient
+	token      string
+	baseURL    string
+	limiter    *RateLimiter
+	maxRetries int
+}
+
+func NewFinnhubProvider(httpClient HTTPClient, token string) *FinnhubProvider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: finnhubTimeout}
+	}
+	return &FinnhubProvider{
+		httpClient: httpClient,
+		token:      token,
+		baseURL:    strings.TrimSuffix(finnhubBaseURL, "/"),
+		limiter:    NewRateLimiter(finnhubRate),
+		maxRetries: finnhubRetries,
+	}
+}
+
+func (p *FinnhubProvider) Name() string {
+	return ProviderFinnhub
+}
+
+type finnhubQuoteResponse struct {
+	C  float64 `json:"c"`
+	D  float64 `json:"d"`
+	DP float64 `json:"dp"`
+	H  float64 `json:"h"`
+	L  float64 `json:"l"`
+	O  float64 `json:"o"`
+	PC float64 `json:"pc"`
+	T  int64   `json:"t"`
+}
+
+func (p *FinnhubProvider) Quote(ctx context.Context, symbol string) (*Quote, error) {
+	var result *finnhubQuoteResponse
+	if err := p.get(ctx, "quote", "/quote", url.Values{"symbol": {symbol}}, &result); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ErrNotFound
+	}
+	return &Quote{
+		Symbol:        symbol,
+		Current:       result.C,
+		Change:        result.D,
+		ChangePercent: result.DP,
+		High:          result.H,
+		Low:           result.L,
+		Open:          result.O,
+		PrevClose:     result.PC,
+		Timestamp:     unixOrZero(result.T),
+	}, nil
+}
+
+type finnhubProfileResponse struct {
+	Exchange        string `json:"exchange"`
+	FinnhubIndustry string `json:"finnhubIndustry"`
+	Currency        string `json:"currency"`
+	Name            string `json:"name"`
+	Symbol          string `json:"ticker"`
+}
+
+func (p *FinnhubProvider) Profile(ctx context.Context, symbol string) (*Profile, error) {
+	var result finnhubProfileResponse
+	if err := p.get(ctx, "profile", "/stock/profile2", url.Values{"symbol": {symbol}}, &result); err != nil {
+		return nil, err
+	}
+	return &Profile{
+		Symbol:   result.Symbol,
+		Name:     result.Name,
+		Exchange: result.Exchange,
+		Industry: result.FinnhubIndustry,
+		Currency: result.Currency,
+	}, nil
+}
+
+type finnhubSearchResponse struct {
+	Count  int `json:"count"`
+	Result []struct {
+		Symbol      string `json:"symbol"`
+		Description string `json:"description"`
+		Type        string `json:"type"`
+	} `json:"result"`
+}
+
+func (p *FinnhubProvider) SearchSymbols(ctx context.Context, query, exchange string) ([]SymbolMatch, error) {
+	params := url.Values{"q": {query}}
+	if exchange != "" {
+		params.Set("exchange", exchange)
+	}
+	var result finnhubSearchResponse
+	if err := p.get(ctx, "search", "/search", params, &result); err != nil {
+		return nil, err
+	}
+	matches := make([]SymbolMatch, 0, len(result.Result))
+	for _, item := range result.Result {
+		matches = append(matches, SymbolMatch{
+			Symbol:      item.Symbol,
+			Description: item.Description,
+			Type:        item.Type,
+		})
+	}
+	return matches, nil
+}
+
+type finnhubMarketStatusResponse struct {
+	Exchange string  `json:"exchange"`
+	Holiday  *string `json:"holiday"`
+	IsOpen   bool    `json:"isOpen"`
+	Session  *string `json:"session"`
+	T        int64   `json:"t"`
+	Timezone string  `json:"timezone"`
+}
+
+func (p *FinnhubProvider) MarketStatus(ctx context.Context, exchange string) (*MarketStatus, error) {
+	var result *finnhubMarketStatusResponse
+	if err := p.get(ctx, "market-status", "/stock/market-status", url.Values{"exchange": {exchange}}, &result); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ErrNotFound
+	}
+	status := &MarketStatus{
+		Exchange:  result.Exchange,
+		IsOpen:    result.IsOpen,
+		Timestamp: unixOrZero(result.T),
+		Timezone:  result.Timezone,
+	}
+	if result.Holiday != nil {
+		status.Holiday = *result.Holiday
+	}
+	if result.Session != nil {
+		status.Session = *result.Session
+	}
+	return status, nil
+}
+
+type finnhubCompanyNewsResponse struct {
+	Datetime int64  `json:"datetime"`
+	Headline string `json:"headline"`
+	Source   string `json:"source"`
+	Summary  string `json:"summary"`
+	URL      string `json:"url"`
+	Related  string `json:"related"`
+}
+
+func (p *FinnhubProvider) CompanyNews(ctx context.Context, symbol string, from, to time.Time) ([]NewsItem, error) {
+	params := url.Values{
+		"symbol": {symbol},
+		"from":   {from.Format("2006-01-02")},
+		"to":     {to.Format("2006-01-02")},
+	}
+	var result []finnhubCompanyNewsResponse
+	if err := p.get(ctx, "company-news", "/company-news", params, &result); err != nil {
+		return nil, err
+	}
+	items := make([]NewsItem, 0, len(result))
+	for _, n := range result {
+		items = append(items, NewsItem{
+			Symbol:      n.Related,
+			Headline:    n.Headline,
+			Source:      n.Source,
+			Summary:     n.Summary,
+			URL:         n.URL,
+			PublishedAt: unixOrZero(n.Datetime),
+		})
+	}
+	return items, nil
+}
+
+type finnhubCandleResponse struct {
+	C []float64 `json:"c"`
+	H []float64 `json:"h"`
+	L []float64 `json:"l"`
+	O []float64 `json:"o"`
+	S string    `json:"s"`
+	T []int64   `json:"t"`
+	V []float64 `json:"v"`
+}
+
+func (p *FinnhubProvider) Candles(ctx context.Context, symbol, resolution string, from, to time.Time) ([]Candle, error) {
+	params := url.Values{
+		"symbol":     {symbol},
+		"resolution": {resolution},
+		"from":       {strconv.FormatInt(from.Unix(), 10)},
+		"to":         {strconv.FormatInt(to.Unix(), 10)},
+	}
+	var result finnhubCandleResponse
+	if err := p.get(ctx, "candle", "/stock/candle", params, &result); err != nil {
+		return nil, err
+	}
+	if result.S == "no_data" {
+		return []Candle{}, nil
+	}
+	n := len(result.T)
+	if len(result.O) != n || len(result.H) != n || len(result.L) != n || len(result.C) != n {
+		return nil, fmt.Errorf("finnhub candle response has mismatched series for %s", symbol)
+	}
+	candles := make([]Candle, 0, n)
+	for i := 0; i < n; i++ {
+		candle := Candle{
+			Time:  time.Unix(result.T[i], 0),
+			Open:  result.O[i],
+			High:  result.H[i],
+			Low:   result.L[i],
+			Close: result.C[i],
+		}
+		if i < len(result.V) {
+			candle.Volume = result.V[i]
+		}
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+type finnhubFXRatesResponse struct {
+	Base  string             `json:"base"`
+	Quote map[string]float64 `json:"quote"`
+}
+
+func (p *FinnhubProvider) FXRates(ctx context.Context, base string) (*FXRates, error) {
+	var result *finnhubFXRatesResponse
+	if err := p.get(ctx, "forex-rates", "/forex/rates", url.Values{"base": {base}}, &result); err != nil {
+		return nil, err
+	}
+	if result == nil || len(result.Quote) == 0 {
+		return nil, ErrNotFound
+	}
+	rates := &FXRates{
+		Base:      strings.ToUpper(base),
+		Rates:     make(map[string]float64, len(result.Quote)),
+		Timestamp: time.Now(),
+	}
+	for currency, rate := range result.Quote {
+		rates.Rates[strings.ToUpper(currency)] = rate
+	}
+	return rates, nil
+}
+
+type finnhubMarketHolidayResponse struct {
+	Data []struct {
+		EventName   string `json:"eventName"`
+		AtDate      string `json:"atDate"`
+		TradingHour string `json:"tradingHour"`
+	} `json:"data"`
+	Exchange string `json:"exchange"`
+}
+
+func (p *FinnhubProvider) MarketHolidays(ctx context.Context, exchange string) ([]MarketHoliday, error) {
+	var result finnhubMarketHolidayResponse
+	if err := p.get(ctx, "market-holiday", "/stock/market-holiday", url.Values{"exchange": {exchange}}, &result); err != nil {
+		return nil, err
+	}
+	holidays := make([]MarketHoliday, 0, len(result.Data))
+	for _, item := range result.Data {
+		holidays = append(holidays, MarketHoliday{
+			Exchange:     exchange,
+			Name:         item.EventName,
+			Date:         item.AtDate,
+			TradingHours: item.TradingHour,
+		})
+	}
+	return holidays, nil
+}
+
+// get performs a rate-limited GET. A 429 pauses the shared limiter for the
+// Retry-After period and the request is retried up to maxRetries times.
+func (p *FinnhubProvider) get(ctx context.Context, name, path string, params url.Values, out any) error {
+	reqURL, err := url.Parse(p.baseURL + path)
+	if err != nil {
+		return err
+	}
+	reqURL.RawQuery = params.Encode()
+
+	for attempt := 0; ; attempt++ {
+		if err := p.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Finnhub-Token", p.token)
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			wait := retryAfter(resp.Header.Get("Retry-After"), time.Now(), finnhubRetryBackoff<<attempt)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if attempt >= p.maxRetries {
+				return fmt.Errorf("finnhub %s request failed: %w", name, ErrRateLimited)
+			}
+			p.limiter.PauseUntil(time.Now().Add(wait))
+			continue
+		}
+
+		err = decodeFinnhubResponse(resp, name, out)
+		resp.Body.Close()
+		return err
+	}
+}
+
+func decodeFinnhubResponse(resp *http.Response, name string, out any) error {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("finnhub %s request failed: %s", name, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func unixOrZero(ts int64) time.Time {
+	if ts <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(ts, 0)
+}
+
+func getEnvString(key, fallback string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
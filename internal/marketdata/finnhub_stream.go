@@ -0,0 +1,161 @@
+package marketdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fasthttp/websocket"
+)
+
+const (
+	defaultFinnhubStreamURL = "wss://ws.finnhub.io"
+	finnhubStreamBuffer     = 1024
+	finnhubWriteTimeout     = 10 * time.Second
+)
+
+var finnhubStreamURL = getEnvString("FINNHUB_STREAM_URL", defaultFinnhubStreamURL)
+
+type finnhubStreamMessage struct {
+	Type string `json:"type"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		S string  `json:"s"`
+		P float64 `json:"p"`
+		T int64   `json:"t"`
+		V float64 `json:"v"`
+	} `json:"data"`
+}
+
+type finnhubTradeStream struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	trades  chan Trade
+	done    chan struct{}
+	errMu   sync.Mutex
+	err     error
+	once    sync.Once
+}
+
+func (p *FinnhubProvider) StreamTrades(ctx context.Context) (TradeStream, error) {
+	streamURL, err := url.Parse(finnhubStreamURL)
+	if err != nil {
+		return nil, err
+	}
+	q := streamURL.Query()
+	q.Set("token", p.token)
+	streamURL.RawQuery = q.Encode()
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, streamURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("finnhub stream dial failed: %w", err)
+	}
+
+	stream := &finnhubTradeStream{
+		conn:   conn,
+		trades: make(chan Trade, finnhubStreamBuffer),
+		done:   make(chan struct{}),
+	}
+	go stream.readLoop()
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = stream.Close()
+		case <-stream.done:
+		}
+	}()
+	return stream, nil
+}
+
+func (s *finnhubTradeStream) Subscribe(symbols ...string) error {
+	return s.send("subscribe", symbols)
+}
+
+func (s *finnhubTradeStream) Unsubscribe(symbols ...string) error {
+	return s.send("unsubscribe", symbols)
+}
+
+func (s *finnhubTradeStream) Trades() <-chan Trade {
+	return s.trades
+}
+
+func (s *finnhubTradeStream) Err() error {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	return s.err
+}
+
+func (s *finnhubTradeStream) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.done)
+		err = s.conn.Close()
+	})
+	return err
+}
+
+func (s *finnhubTradeStream) send(action string, symbols []string) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	for _, symbol := range symbols {
+		symbol = strings.TrimSpace(symbol)
+		if symbol == "" {
+			continue
+		}
+		_ = s.conn.SetWriteDeadline(time.Now().Add(finnhubWriteTimeout))
+		if err := s.conn.WriteJSON(map[string]string{"type": action, "symbol": symbol}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *finnhubTradeStream) readLoop() {
+	defer close(s.trades)
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			s.setErr(err)
+			return
+		}
+
+		var msg finnhubStreamMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		switch msg.Type {
+		case "trade":
+			for _, d := range msg.Data {
+				trade := Trade{
+					Symbol:    d.S,
+					Price:     d.P,
+					Volume:    d.V,
+					Timestamp: time.UnixMilli(d.T),
+				}
+				select {
+				case s.trades <- trade:
+				case <-s.done:
+					return
+				}
+			}
+		case "error":
+			s.setErr(errors.New("finnhub stream error: " + msg.Msg))
+			_ = s.Close()
+			return
+		}
+	}
+}
+
+func (s *finnhubTradeStream) setErr(err error) {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
@@ -0,0 +1,164 @@
+package marketdata
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type stubHTTPClient struct {
+	responses []*http.Response
+	calls     int
+}
+
+func (c *stubHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	resp := c.responses[c.calls]
+	c.calls++
+	return resp, nil
+}
+
+func stubResponse(status int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+type FinnhubProviderSuite struct {
+	suite.Suite
+	client   *stubHTTPClient
+	provider *FinnhubProvider
+}
+
+func (s *FinnhubProviderSuite) SetupTest() {
+	s.client = &stubHTTPClient{}
+	s.provider = NewFinnhubProvider(s.client, "token")
+	s.provider.baseURL = "https://finnhub.test/api/v1"
+}
+
+func (s *FinnhubProviderSuite) TestQuote_RetriesAfterTooManyRequests() {
+	s.client.responses = []*http.Response{
+		stubResponse(http.StatusTooManyRequests, "", http.Header{"Retry-After": {"0"}}),
+		stubResponse(http.StatusOK, `{"c":101.5,"d":1.5,"dp":1.5,"pc":100}`, nil),
+	}
+
+	quote, err := s.provider.Quote(context.Background(), "AAPL")
+
+	s.Require().NoError(err)
+	s.Equal(101.5, quote.Current)
+	s.Equal(2, s.client.calls)
+}
+
+func (s *FinnhubProviderSuite) TestQuote_GivesUpAfterMaxRetries() {
+	s.provider.maxRetries = 1
+	s.client.responses = []*http.Response{
+		stubResponse(http.StatusTooManyRequests, "", http.Header{"Retry-After": {"0"}}),
+		stubResponse(http.StatusTooManyRequests, "", http.Header{"Retry-After": {"0"}}),
+	}
+
+	_, err := s.provider.Quote(context.Background(), "AAPL")
+
+	s.ErrorIs(err, ErrRateLimited)
+	s.Equal(2, s.client.calls)
+}
+
+func (s *FinnhubProviderSuite) TestFXRates_UppercasesCurrencies() {
+	s.client.responses = []*http.Response{
+		stubResponse(http.StatusOK, `{"base":"USD","quote":{"thb":36.1,"EUR":0.9}}`, nil),
+	}
+
+	rates, err := s.provider.FXRates(context.Background(), "usd")
+
+	s.Require().NoError(err)
+	s.Equal("USD", rates.Base)
+	s.Equal(map[string]float64{"THB": 36.1, "EUR": 0.9}, rates.Rates)
+}
+
+func (s *FinnhubProviderSuite) TestMarketHolidays_MapsEvents() {
+	s.client.responses = []*http.Response{
+		stubResponse(http.StatusOK, `{"exchange":"US","data":[{"eventName":"Christmas","atDate":"2025-12-25","tradingHour":""},{"eventName":"Christmas Eve","atDate":"2025-12-24","tradingHour":"09:30-13:00"}]}`, nil),
+	}
+
+	holidays, err := s.provider.MarketHolidays(context.Background(), "US")
+
+	s.Require().NoError(err)
+	s.Equal([]MarketHoliday{
+		{Exchange: "US", Name: "Christmas", Date: "2025-12-25"},
+		{Exchange: "US", Name: "Christmas Eve", Date: "2025-12-24", TradingHours: "09:30-13:00"},
+	}, holidays)
+}
+
+func TestFinnhubProviderSuite(t *testing.T) {
+	suite.Run(t, new(FinnhubProviderSuite))
+}
+
+type RateLimiterSuite struct {
+	suite.Suite
+	now     time.Time
+	limiter *RateLimiter
+}
+
+func (s *RateLimiterSuite) SetupTest() {
+	s.now = time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC)
+	s.limiter = NewRateLimiter(60)
+	s.limiter.now = func() time.Time { return s.now }
+	s.limiter.last = s.now
+}
+
+func (s *RateLimiterSuite) TestReserve_DrainsBucketThenRefills() {
+	for i := 0; i < 10; i++ {
+		s.Zero(s.limiter.reserve())
+	}
+	s.Equal(1200*time.Millisecond, s.limiter.reserve())
+
+	s.now = s.now.Add(1200 * time.Millisecond)
+	s.Zero(s.limiter.reserve())
+}
+
+func (s *RateLimiterSuite) TestReserve_StaysWithinQuotaPerMinute() {
+	s.Equal(60, s.allowedWithin(time.Minute))
+
+	s.now = s.now.Add(10 * time.Minute)
+	s.Equal(60, s.allowedWithin(time.Minute))
+}
+
+// allowedWithin counts the requests a greedy caller gets through during the
+// window [now, now+d], then leaves the clock at the end of the window.
+func (s *RateLimiterSuite) allowedWithin(d time.Duration) int {
+	end := s.now.Add(d)
+	allowed := 0
+	for {
+		for s.limiter.reserve() == 0 {
+			allowed++
+		}
+		s.now = s.now.Add(100 * time.Millisecond)
+		if s.now.After(end) {
+			return allowed
+		}
+	}
+}
+
+func (s *RateLimiterSuite) TestPauseUntil_BlocksTokens() {
+	s.limiter.PauseUntil(s.now.Add(30 * time.Second))
+
+	s.Equal(30*time.Second, s.limiter.reserve())
+}
+
+func (s *RateLimiterSuite) TestRetryAfter_ParsesSecondsAndDates() {
+	s.Equal(5*time.Second, retryAfter("5", s.now, time.Second))
+	s.Equal(10*time.Second, retryAfter(s.now.Add(10*time.Second).Format(http.TimeFormat), s.now, time.Second))
+	s.Equal(time.Second, retryAfter("soon", s.now, time.Second))
+}
+
+func TestRateLimiterSuite(t *testing.T) {
+	suite.Run(t, new(RateLimiterSuite))
+}
@@ -0,0 +1,58 @@
+package marketdata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// LoadFXRatesFile reads a static FX table used when the provider cannot
+// supply rates. The file holds a single FXRates object, for example
+// {"base": "USD", "rates": {"THB": 36.2}}.
+func LoadFXRatesFile(path string) (*FXRates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+
+	var file FXRates
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	base := normalizeSymbol(file.Base)
+	if base == "" {
+		return nil, errors.New("fx rates file requires a base currency")
+	}
+	rates := &FXRates{Base: base, Rates: make(map[string]float64, len(file.Rates)), Timestamp: file.Timestamp}
+	for currency, rate := range file.Rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("fx rates file has a non-positive rate for %s", currency)
+		}
+		rates.Rates[normalizeSymbol(currency)] = rate
+	}
+	return rates, nil
+}
+
+// Rebase expresses the rates against another currency listed in the table.
+func (r *FXRates) Rebase(base string) (*FXRates, error) {
+	base = normalizeSymbol(base)
+	if r.Base == base {
+		return r, nil
+	}
+	pivot, ok := r.Rates[base]
+	if !ok || pivot <= 0 {
+		return nil, fmt.Errorf("%w: no %s rate against %s", ErrNotFound, base, r.Base)
+	}
+	rebased := &FXRates{Base: base, Rates: make(map[string]float64, len(r.Rates)), Timestamp: r.Timestamp}
+	rebased.Rates[r.Base] = 1 / pivot
+	for currency, rate := range r.Rates {
+		if currency == base {
+			continue
+		}
+		rebased.Rates[currency] = rate / pivot
+	}
+	return rebased, nil
+}
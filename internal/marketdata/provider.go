@@ -0,0 +1,151 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sun-stockanalysis-api/internal/configurations"
+)
+
+const (
+	ProviderFinnhub = "finnhub"
+	ProviderReplay  = "replay"
+)
+
+// Candle resolutions accepted by Provider.Candles.
+const (
+	Resolution1Min  = "1"
+	Resolution5Min  = "5"
+	Resolution15Min = "15"
+	Resolution30Min = "30"
+	Resolution60Min = "60"
+	ResolutionDay   = "D"
+	ResolutionWeek  = "W"
+	ResolutionMonth = "M"
+)
+
+var (
+	ErrNotFound    = errors.New("market data not found")
+	ErrRateLimited = errors.New("market data rate limit exceeded")
+)
+
+// Provider is the vendor-neutral source of market data used by the domain services.
+type Provider interface {
+	Name() string
+	Quote(ctx context.Context, symbol string) (*Quote, error)
+	Profile(ctx context.Context, symbol string) (*Profile, error)
+	SearchSymbols(ctx context.Context, query, exchange string) ([]SymbolMatch, error)
+	MarketStatus(ctx context.Context, exchange string) (*MarketStatus, error)
+	CompanyNews(ctx context.Context, symbol string, from, to time.Time) ([]NewsItem, error)
+	Candles(ctx context.Context, symbol, resolution string, from, to time.Time) ([]Candle, error)
+	FXRates(ctx context.Context, base string) (*FXRates, error)
+	MarketHolidays(ctx context.Context, exchange string) ([]MarketHoliday, error)
+}
+
+type Quote struct {
+	Symbol        string    `json:"symbol"`
+	Current       float64   `json:"price"`
+	Change        float64   `json:"change"`
+	ChangePercent float64   `json:"change_percent"`
+	High          float64   `json:"high"`
+	Low           float64   `json:"low"`
+	Open          float64   `json:"open"`
+	PrevClose     float64   `json:"prev_close"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+type Profile struct {
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name"`
+	Exchange string `json:"exchange"`
+	Industry string `json:"industry"`
+	Currency string `json:"currency"`
+}
+
+type SymbolMatch struct {
+	Symbol      string `json:"symbol"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+type MarketStatus struct {
+	Exchange  string    `json:"exchange"`
+	Holiday   string    `json:"holiday"`
+	IsOpen    bool      `json:"is_open"`
+	Session   string    `json:"session"`
+	Timestamp time.Time `json:"timestamp"`
+	Timezone  string    `json:"timezone"`
+}
+
+type NewsItem struct {
+	Symbol      string    `json:"symbol"`
+	Headline    string    `json:"headline"`
+	Source      string    `json:"source"`
+	Summary     string    `json:"summary"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Candle is an OHLCV bar starting at Time. Candles are returned oldest first.
+type Candle struct {
+	Time   time.Time `json:"time"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"`
+}
+
+// MarketHoliday is a day the exchange is closed or closes early. Date is the
+// exchange-local date (YYYY-MM-DD); TradingHours is empty for a full closure
+// and holds the shortened session, e.g. "09:30-13:00", otherwise.
+type MarketHoliday struct {
+	Exchange     string `json:"exchange"`
+	Name         string `json:"name"`
+	Date         string `json:"date"`
+	TradingHours string `json:"trading_hours"`
+}
+
+// FXRates quotes how many units of each currency one unit of Base buys.
+type FXRates struct {
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// IsValidResolution reports whether resolution is one of the supported candle resolutions.
+func IsValidResolution(resolution string) bool {
+	switch resolution {
+	case Resolution1Min, Resolution5Min, Resolution15Min, Resolution30Min, Resolution60Min,
+		ResolutionDay, ResolutionWeek, ResolutionMonth:
+		return true
+	}
+	return false
+}
+
+// NewProvider builds the provider selected by configuration, defaulting to Finnhub.
+func NewProvider(marketDataCfg *configurations.MarketData, finnhubCfg *configurations.Finnhub) (Provider, error) {
+	kind := ProviderFinnhub
+	if marketDataCfg != nil && strings.TrimSpace(marketDataCfg.Provider) != "" {
+		kind = strings.ToLower(strings.TrimSpace(marketDataCfg.Provider))
+	}
+
+	switch kind {
+	case ProviderFinnhub:
+		token := ""
+		if finnhubCfg != nil {
+			token = finnhubCfg.Token
+		}
+		return NewFinnhubProvider(nil, token), nil
+	case ProviderReplay:
+		if marketDataCfg == nil || strings.TrimSpace(marketDataCfg.ReplayFile) == "" {
+			return nil, errors.New("marketData.replayFile is required for the replay provider")
+		}
+		return NewReplayProvider(strings.TrimSpace(marketDataCfg.ReplayFile))
+	default:
+		return nil, fmt.Errorf("unknown market data provider %q", kind)
+	}
+}
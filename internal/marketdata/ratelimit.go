@@ -0,0 +1,111 @@
+package marketdata
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every request made through a
+// provider. The bucket holds a small burst of perMinute/6 tokens and refills
+// at the remaining rate, so no 60 second window ever lets more than perMinute
+// requests through, not even the first minute or the one after an idle spell.
+type RateLimiter struct {
+	mu          sync.Mutex
+	capacity    float64
+	tokens      float64
+	ratePerSec  float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+func NewRateLimiter(perMinute int) *RateLimiter {
+	if perMinute <= 0 {
+		perMinute = 1
+	}
+	burst := perMinute / 6
+	now := time.Now
+	return &RateLimiter{
+		capacity:   float64(max(1, burst)),
+		tokens:     float64(burst),
+		ratePerSec: float64(perMinute-burst) / 60.0,
+		last:       now(),
+		now:        now,
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// PauseUntil stops handing out tokens until t, e.g. after the upstream answered
+// 429 with a Retry-After header.
+func (l *RateLimiter) PauseUntil(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+	}
+}
+
+// reserve takes a token if one is available and otherwise reports how long the
+// caller should wait before trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed > 0 {
+		l.tokens += elapsed * l.ratePerSec
+		if l.tokens > l.capacity {
+			l.tokens = l.capacity
+		}
+		l.last = now
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	missing := 1 - l.tokens
+	return time.Duration(missing / l.ratePerSec * float64(time.Second))
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP
+// date. It returns fallback when the header is absent or malformed.
+func retryAfter(header string, now time.Time, fallback time.Duration) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return fallback
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return fallback
+}
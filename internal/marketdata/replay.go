@@ -0,0 +1,237 @@
+package marketdata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReplayFixture is the on-disk format read by ReplayProvider.
+// Quotes and market statuses are replayed in order, one entry per call,
+// wrapping around once the sequence is exhausted. Candles are keyed by symbol
+// and then resolution. FX rates are keyed by base currency and holidays by
+// exchange.
+type ReplayFixture struct {
+	Profiles     map[string]Profile             `json:"profiles"`
+	Symbols      map[string][]SymbolMatch       `json:"symbols"`
+	Quotes       map[string][]Quote             `json:"quotes"`
+	MarketStatus []MarketStatus                 `json:"market_status"`
+	News         map[string][]NewsItem          `json:"news"`
+	Candles      map[string]map[string][]Candle `json:"candles"`
+	FXRates      map[string]map[string]float64  `json:"fx_rates"`
+	Holidays     map[string][]MarketHoliday     `json:"market_holidays"`
+}
+
+type ReplayProvider struct {
+	mu           sync.Mutex
+	fixture      ReplayFixture
+	quoteCursor  map[string]int
+	statusCursor int
+	now          func() time.Time
+}
+
+func NewReplayProvider(path string) (*ReplayProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+
+	var fixture ReplayFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, err
+	}
+	return NewReplayProviderFromFixture(fixture), nil
+}
+
+func NewReplayProviderFromFixture(fixture ReplayFixture) *ReplayProvider {
+	return &ReplayProvider{
+		fixture:     normalizeFixture(fixture),
+		quoteCursor: make(map[string]int),
+		now:         time.Now,
+	}
+}
+
+func (p *ReplayProvider) Name() string {
+	return ProviderReplay
+}
+
+func (p *ReplayProvider) Quote(ctx context.Context, symbol string) (*Quote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	key := normalizeSymbol(symbol)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	quotes := p.fixture.Quotes[key]
+	if len(quotes) == 0 {
+		return nil, ErrNotFound
+	}
+	idx := p.quoteCursor[key] % len(quotes)
+	p.quoteCursor[key] = idx + 1
+
+	quote := quotes[idx]
+	if quote.Symbol == "" {
+		quote.Symbol = symbol
+	}
+	if quote.Timestamp.IsZero() {
+		quote.Timestamp = p.now()
+	}
+	return &quote, nil
+}
+
+func (p *ReplayProvider) Profile(ctx context.Context, symbol string) (*Profile, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	profile, ok := p.fixture.Profiles[normalizeSymbol(symbol)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &profile, nil
+}
+
+func (p *ReplayProvider) SearchSymbols(ctx context.Context, query, _ string) ([]SymbolMatch, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	matches := p.fixture.Symbols[normalizeSymbol(query)]
+	return append([]SymbolMatch(nil), matches...), nil
+}
+
+func (p *ReplayProvider) MarketStatus(ctx context.Context, exchange string) (*MarketStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.fixture.MarketStatus) == 0 {
+		return nil, ErrNotFound
+	}
+	idx := p.statusCursor % len(p.fixture.MarketStatus)
+	p.statusCursor = idx + 1
+
+	status := p.fixture.MarketStatus[idx]
+	if status.Exchange == "" {
+		status.Exchange = exchange
+	}
+	if status.Timestamp.IsZero() {
+		status.Timestamp = p.now()
+	}
+	return &status, nil
+}
+
+func (p *ReplayProvider) CompanyNews(ctx context.Context, symbol string, from, to time.Time) ([]NewsItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	items := p.fixture.News[normalizeSymbol(symbol)]
+	result := make([]NewsItem, 0, len(items))
+	for _, item := range items {
+		if !item.PublishedAt.IsZero() && (item.PublishedAt.Before(from) || item.PublishedAt.After(to.AddDate(0, 0, 1))) {
+			continue
+		}
+		if item.Symbol == "" {
+			item.Symbol = symbol
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (p *ReplayProvider) Candles(ctx context.Context, symbol, resolution string, from, to time.Time) ([]Candle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	candles := p.fixture.Candles[normalizeSymbol(symbol)][resolution]
+	result := make([]Candle, 0, len(candles))
+	for _, candle := range candles {
+		if candle.Time.Before(from) || candle.Time.After(to) {
+			continue
+		}
+		result = append(result, candle)
+	}
+	return result, nil
+}
+
+func (p *ReplayProvider) FXRates(ctx context.Context, base string) (*FXRates, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	key := normalizeSymbol(base)
+	quoted := p.fixture.FXRates[key]
+	if len(quoted) == 0 {
+		return nil, ErrNotFound
+	}
+	rates := &FXRates{Base: key, Rates: make(map[string]float64, len(quoted)), Timestamp: p.now()}
+	for currency, rate := range quoted {
+		rates.Rates[currency] = rate
+	}
+	return rates, nil
+}
+
+func (p *ReplayProvider) MarketHolidays(ctx context.Context, exchange string) ([]MarketHoliday, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	holidays := p.fixture.Holidays[normalizeSymbol(exchange)]
+	result := make([]MarketHoliday, 0, len(holidays))
+	for _, holiday := range holidays {
+		if holiday.Exchange == "" {
+			holiday.Exchange = exchange
+		}
+		result = append(result, holiday)
+	}
+	return result, nil
+}
+
+func normalizeFixture(fixture ReplayFixture) ReplayFixture {
+	normalized := ReplayFixture{
+		Profiles:     make(map[string]Profile, len(fixture.Profiles)),
+		Symbols:      make(map[string][]SymbolMatch, len(fixture.Symbols)),
+		Quotes:       make(map[string][]Quote, len(fixture.Quotes)),
+		MarketStatus: fixture.MarketStatus,
+		News:         make(map[string][]NewsItem, len(fixture.News)),
+		Candles:      make(map[string]map[string][]Candle, len(fixture.Candles)),
+		FXRates:      make(map[string]map[string]float64, len(fixture.FXRates)),
+		Holidays:     make(map[string][]MarketHoliday, len(fixture.Holidays)),
+	}
+	for k, v := range fixture.Profiles {
+		normalized.Profiles[normalizeSymbol(k)] = v
+	}
+	for k, v := range fixture.Symbols {
+		normalized.Symbols[normalizeSymbol(k)] = v
+	}
+	for k, v := range fixture.Quotes {
+		normalized.Quotes[normalizeSymbol(k)] = v
+	}
+	for k, v := range fixture.News {
+		normalized.News[normalizeSymbol(k)] = v
+	}
+	for k, v := range fixture.Candles {
+		normalized.Candles[normalizeSymbol(k)] = v
+	}
+	for k, v := range fixture.FXRates {
+		rates := make(map[string]float64, len(v))
+		for currency, rate := range v {
+			rates[normalizeSymbol(currency)] = rate
+		}
+		normalized.FXRates[normalizeSymbol(k)] = rates
+	}
+	for k, v := range fixture.Holidays {
+		normalized.Holidays[normalizeSymbol(k)] = v
+	}
+	return normalized
+}
+
+func normalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
@@ -0,0 +1,117 @@
+package marketdata
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultReplayTradeIntervalMs = 1000
+	defaultReplayTradeVolume     = 100
+)
+
+var replayTradeInterval = time.Duration(getEnvInt("REPLAY_TRADE_INTERVAL_MS", defaultReplayTradeIntervalMs)) * time.Millisecond
+
+// replayTradeStream turns the fixture quote sequence into a trade feed, emitting
+// one trade per subscribed symbol on every tick.
+type replayTradeStream struct {
+	provider *ReplayProvider
+	mu       sync.Mutex
+	symbols  map[string]string
+	trades   chan Trade
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (p *ReplayProvider) StreamTrades(ctx context.Context) (TradeStream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stream := &replayTradeStream{
+		provider: p,
+		symbols:  make(map[string]string),
+		trades:   make(chan Trade, 64),
+		done:     make(chan struct{}),
+	}
+	go stream.run(ctx)
+	return stream, nil
+}
+
+func (s *replayTradeStream) Subscribe(symbols ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, symbol := range symbols {
+		if key := normalizeSymbol(symbol); key != "" {
+			s.symbols[key] = symbol
+		}
+	}
+	return nil
+}
+
+func (s *replayTradeStream) Unsubscribe(symbols ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, symbol := range symbols {
+		delete(s.symbols, normalizeSymbol(symbol))
+	}
+	return nil
+}
+
+func (s *replayTradeStream) Trades() <-chan Trade {
+	return s.trades
+}
+
+func (s *replayTradeStream) Err() error {
+	return nil
+}
+
+func (s *replayTradeStream) Close() error {
+	s.once.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+func (s *replayTradeStream) run(ctx context.Context) {
+	defer close(s.trades)
+	ticker := time.NewTicker(replayTradeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		symbols := make([]string, 0, len(s.symbols))
+		for _, symbol := range s.symbols {
+			symbols = append(symbols, symbol)
+		}
+		s.mu.Unlock()
+
+		for _, symbol := range symbols {
+			quote, err := s.provider.Quote(ctx, symbol)
+			if err != nil {
+				continue
+			}
+			trade := Trade{
+				Symbol:    symbol,
+				Price:     quote.Current,
+				Volume:    defaultReplayTradeVolume,
+				Timestamp: s.provider.now(),
+			}
+			select {
+			case s.trades <- trade:
+			case <-ctx.Done():
+				return
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package marketdata
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplayProviderSuite struct {
+	suite.Suite
+	provider *ReplayProvider
+}
+
+func (s *ReplayProviderSuite) SetupTest() {
+	provider, err := NewReplayProvider("testdata/replay.json")
+	s.Require().NoError(err)
+	s.provider = provider
+}
+
+func (s *ReplayProviderSuite) TestQuote_ReplaysInOrderAndWraps() {
+	ctx := context.Background()
+
+	prices := make([]float64, 0, 4)
+	for i := 0; i < 4; i++ {
+		quote, err := s.provider.Quote(ctx, "aapl")
+		s.Require().NoError(err)
+		prices = append(prices, quote.Current)
+		s.Equal("aapl", quote.Symbol)
+		s.False(quote.Timestamp.IsZero())
+	}
+
+	s.Equal([]float64{190.10, 190.45, 189.80, 190.10}, prices)
+}
+
+func (s *ReplayProviderSuite) TestQuote_UnknownSymbol() {
+	quote, err := s.provider.Quote(context.Background(), "MSFT")
+
+	s.Nil(quote)
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *ReplayProviderSuite) TestProfileAndSearch() {
+	ctx := context.Background()
+
+	profile, err := s.provider.Profile(ctx, "TSLA")
+	s.Require().NoError(err)
+	s.Equal("Tesla Inc", profile.Name)
+	s.Equal("USD", profile.Currency)
+
+	matches, err := s.provider.SearchSymbols(ctx, "TSLA", "US")
+	s.Require().NoError(err)
+	s.Require().Len(matches, 1)
+	s.Equal("Common Stock", matches[0].Type)
+}
+
+func (s *ReplayProviderSuite) TestMarketStatus_Sequence() {
+	ctx := context.Background()
+
+	first, err := s.provider.MarketStatus(ctx, "US")
+	s.Require().NoError(err)
+	second, err := s.provider.MarketStatus(ctx, "US")
+	s.Require().NoError(err)
+
+	s.Equal("pre-market", first.Session)
+	s.Equal("regular", second.Session)
+	s.True(second.IsOpen)
+}
+
+func (s *ReplayProviderSuite) TestCompanyNews_FillsSymbol() {
+	now := time.Now()
+
+	items, err := s.provider.CompanyNews(context.Background(), "AAPL", now, now)
+
+	s.Require().NoError(err)
+	s.Require().Len(items, 1)
+	s.Equal("AAPL", items[0].Symbol)
+}
+
+func (s *ReplayProviderSuite) TestCandles_FiltersByRange() {
+	from := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	candles, err := s.provider.Candles(context.Background(), "aapl", ResolutionDay, from, to)
+
+	s.Require().NoError(err)
+	s.Require().Len(candles, 2)
+	s.Equal(190.00, candles[0].Close)
+	s.Equal(189.60, candles[1].Close)
+
+	none, err := s.provider.Candles(context.Background(), "TSLA", ResolutionDay, from, to)
+	s.Require().NoError(err)
+	s.Empty(none)
+}
+
+func (s *ReplayProviderSuite) TestFXRates_ByBase() {
+	rates, err := s.provider.FXRates(context.Background(), "usd")
+	s.Require().NoError(err)
+	s.Equal("USD", rates.Base)
+	s.Equal(36.25, rates.Rates["THB"])
+
+	_, err = s.provider.FXRates(context.Background(), "THB")
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *ReplayProviderSuite) TestLoadFXRatesFile_Rebase() {
+	rates, err := LoadFXRatesFile("testdata/fx_rates.json")
+	s.Require().NoError(err)
+	s.Equal("USD", rates.Base)
+
+	thb, err := rates.Rebase("thb")
+	s.Require().NoError(err)
+	s.Equal("THB", thb.Base)
+	s.InDelta(1.0/36, thb.Rates["USD"], 1e-12)
+	s.InDelta(0.92/36, thb.Rates["EUR"], 1e-12)
+	s.NotContains(thb.Rates, "THB")
+
+	_, err = rates.Rebase("XYZ")
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *ReplayProviderSuite) TestMarketHolidays_ByExchange() {
+	holidays, err := s.provider.MarketHolidays(context.Background(), "us")
+	s.Require().NoError(err)
+	s.Require().Len(holidays, 3)
+	s.Equal("us", holidays[0].Exchange)
+	s.Equal("2025-11-28", holidays[2].Date)
+	s.Equal("09:30-13:00", holidays[2].TradingHours)
+
+	holidays, err = s.provider.MarketHolidays(context.Background(), "SET")
+	s.Require().NoError(err)
+	s.Empty(holidays)
+}
+
+func TestReplayProviderSuite(t *testing.T) {
+	suite.Run(t, new(ReplayProviderSuite))
+}
@@ -0,0 +1,29 @@
+package marketdata
+
+import (
+	"context"
+	"time"
+)
+
+// Trade is a single print received from a real-time feed.
+type Trade struct {
+	Symbol    string    `json:"symbol"`
+	Price     float64   `json:"price"`
+	Volume    float64   `json:"volume"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// TradeStream is a live trade subscription. Trades is closed when the stream
+// ends, after which Err reports why.
+type TradeStream interface {
+	Subscribe(symbols ...string) error
+	Unsubscribe(symbols ...string) error
+	Trades() <-chan Trade
+	Err() error
+	Close() error
+}
+
+// Streamer is implemented by providers that offer a real-time trade feed.
+type Streamer interface {
+	StreamTrades(ctx context.Context) (TradeStream, error)
+}
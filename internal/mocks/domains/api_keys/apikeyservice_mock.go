@@ -0,0 +1,267 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package api_keys_mock
+
+import (
+	context "context"
+	api_keys "sun-stockanalysis-api/internal/domains/api_keys"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "sun-stockanalysis-api/internal/models"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockAPIKeyService is an autogenerated mock type for the APIKeyService type
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+type MockAPIKeyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAPIKeyService) EXPECT() *MockAPIKeyService_Expecter {
+	return &MockAPIKeyService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, userID, input
+func (_m *MockAPIKeyService) Create(ctx context.Context, userID string, input api_keys.CreateInput) (*api_keys.CreatedAPIKey, error) {
+	ret := _m.Called(ctx, userID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *api_keys.CreatedAPIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api_keys.CreateInput) (*api_keys.CreatedAPIKey, error)); ok {
+		return rf(ctx, userID, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api_keys.CreateInput) *api_keys.CreatedAPIKey); ok {
+		r0 = rf(ctx, userID, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api_keys.CreatedAPIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api_keys.CreateInput) error); ok {
+		r1 = rf(ctx, userID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAPIKeyService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - input api_keys.CreateInput
+func (_e *MockAPIKeyService_Expecter) Create(ctx interface{}, userID interface{}, input interface{}) *MockAPIKeyService_Create_Call {
+	return &MockAPIKeyService_Create_Call{Call: _e.mock.On("Create", ctx, userID, input)}
+}
+
+func (_c *MockAPIKeyService_Create_Call) Run(run func(ctx context.Context, userID string, input api_keys.CreateInput)) *MockAPIKeyService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api_keys.CreateInput))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyService_Create_Call) Return(_a0 *api_keys.CreatedAPIKey, _a1 error) *MockAPIKeyService_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyService_Create_Call) RunAndReturn(run func(context.Context, string, api_keys.CreateInput) (*api_keys.CreatedAPIKey, error)) *MockAPIKeyService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, userID
+func (_m *MockAPIKeyService) List(ctx context.Context, userID string) ([]models.APIKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.APIKey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.APIKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockAPIKeyService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAPIKeyService_Expecter) List(ctx interface{}, userID interface{}) *MockAPIKeyService_List_Call {
+	return &MockAPIKeyService_List_Call{Call: _e.mock.On("List", ctx, userID)}
+}
+
+func (_c *MockAPIKeyService_List_Call) Run(run func(ctx context.Context, userID string)) *MockAPIKeyService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyService_List_Call) Return(_a0 []models.APIKey, _a1 error) *MockAPIKeyService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyService_List_Call) RunAndReturn(run func(context.Context, string) ([]models.APIKey, error)) *MockAPIKeyService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, userID, id
+func (_m *MockAPIKeyService) Revoke(ctx context.Context, userID string, id uuid.UUID) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAPIKeyService_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockAPIKeyService_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - id uuid.UUID
+func (_e *MockAPIKeyService_Expecter) Revoke(ctx interface{}, userID interface{}, id interface{}) *MockAPIKeyService_Revoke_Call {
+	return &MockAPIKeyService_Revoke_Call{Call: _e.mock.On("Revoke", ctx, userID, id)}
+}
+
+func (_c *MockAPIKeyService_Revoke_Call) Run(run func(ctx context.Context, userID string, id uuid.UUID)) *MockAPIKeyService_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyService_Revoke_Call) Return(_a0 error) *MockAPIKeyService_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyService_Revoke_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) error) *MockAPIKeyService_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: ctx, key
+func (_m *MockAPIKeyService) Verify(ctx context.Context, key string) (*api_keys.Principal, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *api_keys.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api_keys.Principal, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api_keys.Principal); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api_keys.Principal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyService_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockAPIKeyService_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockAPIKeyService_Expecter) Verify(ctx interface{}, key interface{}) *MockAPIKeyService_Verify_Call {
+	return &MockAPIKeyService_Verify_Call{Call: _e.mock.On("Verify", ctx, key)}
+}
+
+func (_c *MockAPIKeyService_Verify_Call) Run(run func(ctx context.Context, key string)) *MockAPIKeyService_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyService_Verify_Call) Return(_a0 *api_keys.Principal, _a1 error) *MockAPIKeyService_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyService_Verify_Call) RunAndReturn(run func(context.Context, string) (*api_keys.Principal, error)) *MockAPIKeyService_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAPIKeyService creates a new instance of MockAPIKeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIKeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIKeyService {
+	mock := &MockAPIKeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
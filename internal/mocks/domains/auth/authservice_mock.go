@@ -3,9 +3,12 @@
 package auth_mock
 
 import (
+	context "context"
 	auth "sun-stockanalysis-api/internal/domains/auth"
 
 	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
 )
 
 // MockAuthService is an autogenerated mock type for the AuthService type
@@ -21,6 +24,220 @@ func (_m *MockAuthService) EXPECT() *MockAuthService_Expecter {
 	return &MockAuthService_Expecter{mock: &_m.Mock}
 }
 
+// ConfirmTOTP provides a mock function with given fields: ctx, userID, input
+func (_m *MockAuthService) ConfirmTOTP(ctx context.Context, userID string, input auth.TOTPCodeInput) (*auth.RecoveryCodes, error) {
+	ret := _m.Called(ctx, userID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmTOTP")
+	}
+
+	var r0 *auth.RecoveryCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.TOTPCodeInput) (*auth.RecoveryCodes, error)); ok {
+		return rf(ctx, userID, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.TOTPCodeInput) *auth.RecoveryCodes); ok {
+		r0 = rf(ctx, userID, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.RecoveryCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.TOTPCodeInput) error); ok {
+		r1 = rf(ctx, userID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_ConfirmTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmTOTP'
+type MockAuthService_ConfirmTOTP_Call struct {
+	*mock.Call
+}
+
+// ConfirmTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - input auth.TOTPCodeInput
+func (_e *MockAuthService_Expecter) ConfirmTOTP(ctx interface{}, userID interface{}, input interface{}) *MockAuthService_ConfirmTOTP_Call {
+	return &MockAuthService_ConfirmTOTP_Call{Call: _e.mock.On("ConfirmTOTP", ctx, userID, input)}
+}
+
+func (_c *MockAuthService_ConfirmTOTP_Call) Run(run func(ctx context.Context, userID string, input auth.TOTPCodeInput)) *MockAuthService_ConfirmTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(auth.TOTPCodeInput))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ConfirmTOTP_Call) Return(_a0 *auth.RecoveryCodes, _a1 error) *MockAuthService_ConfirmTOTP_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_ConfirmTOTP_Call) RunAndReturn(run func(context.Context, string, auth.TOTPCodeInput) (*auth.RecoveryCodes, error)) *MockAuthService_ConfirmTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, userID, input
+func (_m *MockAuthService) DisableTOTP(ctx context.Context, userID string, input auth.TOTPCodeInput) error {
+	ret := _m.Called(ctx, userID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.TOTPCodeInput) error); ok {
+		r0 = rf(ctx, userID, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthService_DisableTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableTOTP'
+type MockAuthService_DisableTOTP_Call struct {
+	*mock.Call
+}
+
+// DisableTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - input auth.TOTPCodeInput
+func (_e *MockAuthService_Expecter) DisableTOTP(ctx interface{}, userID interface{}, input interface{}) *MockAuthService_DisableTOTP_Call {
+	return &MockAuthService_DisableTOTP_Call{Call: _e.mock.On("DisableTOTP", ctx, userID, input)}
+}
+
+func (_c *MockAuthService_DisableTOTP_Call) Run(run func(ctx context.Context, userID string, input auth.TOTPCodeInput)) *MockAuthService_DisableTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(auth.TOTPCodeInput))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_DisableTOTP_Call) Return(_a0 error) *MockAuthService_DisableTOTP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_DisableTOTP_Call) RunAndReturn(run func(context.Context, string, auth.TOTPCodeInput) error) *MockAuthService_DisableTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, userID
+func (_m *MockAuthService) EnrollTOTP(ctx context.Context, userID string) (*auth.TOTPEnrollment, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollTOTP")
+	}
+
+	var r0 *auth.TOTPEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.TOTPEnrollment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.TOTPEnrollment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.TOTPEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_EnrollTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollTOTP'
+type MockAuthService_EnrollTOTP_Call struct {
+	*mock.Call
+}
+
+// EnrollTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuthService_Expecter) EnrollTOTP(ctx interface{}, userID interface{}) *MockAuthService_EnrollTOTP_Call {
+	return &MockAuthService_EnrollTOTP_Call{Call: _e.mock.On("EnrollTOTP", ctx, userID)}
+}
+
+func (_c *MockAuthService_EnrollTOTP_Call) Run(run func(ctx context.Context, userID string)) *MockAuthService_EnrollTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_EnrollTOTP_Call) Return(_a0 *auth.TOTPEnrollment, _a1 error) *MockAuthService_EnrollTOTP_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_EnrollTOTP_Call) RunAndReturn(run func(context.Context, string) (*auth.TOTPEnrollment, error)) *MockAuthService_EnrollTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForgotPassword provides a mock function with given fields: ctx, input
+func (_m *MockAuthService) ForgotPassword(ctx context.Context, input auth.EmailInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.EmailInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthService_ForgotPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgotPassword'
+type MockAuthService_ForgotPassword_Call struct {
+	*mock.Call
+}
+
+// ForgotPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input auth.EmailInput
+func (_e *MockAuthService_Expecter) ForgotPassword(ctx interface{}, input interface{}) *MockAuthService_ForgotPassword_Call {
+	return &MockAuthService_ForgotPassword_Call{Call: _e.mock.On("ForgotPassword", ctx, input)}
+}
+
+func (_c *MockAuthService_ForgotPassword_Call) Run(run func(ctx context.Context, input auth.EmailInput)) *MockAuthService_ForgotPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(auth.EmailInput))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ForgotPassword_Call) Return(_a0 error) *MockAuthService_ForgotPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_ForgotPassword_Call) RunAndReturn(run func(context.Context, auth.EmailInput) error) *MockAuthService_ForgotPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function with given fields: input
 func (_m *MockAuthService) Login(input auth.LoginInput) (*auth.LoginResult, error) {
 	ret := _m.Called(input)
@@ -79,6 +296,171 @@ func (_c *MockAuthService_Login_Call) RunAndReturn(run func(auth.LoginInput) (*a
 	return _c
 }
 
+// LoginMFA provides a mock function with given fields: ctx, input
+func (_m *MockAuthService) LoginMFA(ctx context.Context, input auth.LoginMFAInput) (*auth.LoginResult, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginMFA")
+	}
+
+	var r0 *auth.LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.LoginMFAInput) (*auth.LoginResult, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, auth.LoginMFAInput) *auth.LoginResult); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.LoginResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, auth.LoginMFAInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_LoginMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginMFA'
+type MockAuthService_LoginMFA_Call struct {
+	*mock.Call
+}
+
+// LoginMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input auth.LoginMFAInput
+func (_e *MockAuthService_Expecter) LoginMFA(ctx interface{}, input interface{}) *MockAuthService_LoginMFA_Call {
+	return &MockAuthService_LoginMFA_Call{Call: _e.mock.On("LoginMFA", ctx, input)}
+}
+
+func (_c *MockAuthService_LoginMFA_Call) Run(run func(ctx context.Context, input auth.LoginMFAInput)) *MockAuthService_LoginMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(auth.LoginMFAInput))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_LoginMFA_Call) Return(_a0 *auth.LoginResult, _a1 error) *MockAuthService_LoginMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_LoginMFA_Call) RunAndReturn(run func(context.Context, auth.LoginMFAInput) (*auth.LoginResult, error)) *MockAuthService_LoginMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function with given fields: userID, input
+func (_m *MockAuthService) Logout(userID string, input auth.LogoutInput) error {
+	ret := _m.Called(userID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, auth.LogoutInput) error); ok {
+		r0 = rf(userID, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthService_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockAuthService_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - userID string
+//   - input auth.LogoutInput
+func (_e *MockAuthService_Expecter) Logout(userID interface{}, input interface{}) *MockAuthService_Logout_Call {
+	return &MockAuthService_Logout_Call{Call: _e.mock.On("Logout", userID, input)}
+}
+
+func (_c *MockAuthService_Logout_Call) Run(run func(userID string, input auth.LogoutInput)) *MockAuthService_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(auth.LogoutInput))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Logout_Call) Return(_a0 error) *MockAuthService_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_Logout_Call) RunAndReturn(run func(string, auth.LogoutInput) error) *MockAuthService_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MFAStatus provides a mock function with given fields: ctx, userID
+func (_m *MockAuthService) MFAStatus(ctx context.Context, userID string) (*auth.MFAStatus, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MFAStatus")
+	}
+
+	var r0 *auth.MFAStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.MFAStatus, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.MFAStatus); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.MFAStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_MFAStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MFAStatus'
+type MockAuthService_MFAStatus_Call struct {
+	*mock.Call
+}
+
+// MFAStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuthService_Expecter) MFAStatus(ctx interface{}, userID interface{}) *MockAuthService_MFAStatus_Call {
+	return &MockAuthService_MFAStatus_Call{Call: _e.mock.On("MFAStatus", ctx, userID)}
+}
+
+func (_c *MockAuthService_MFAStatus_Call) Run(run func(ctx context.Context, userID string)) *MockAuthService_MFAStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_MFAStatus_Call) Return(_a0 *auth.MFAStatus, _a1 error) *MockAuthService_MFAStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_MFAStatus_Call) RunAndReturn(run func(context.Context, string) (*auth.MFAStatus, error)) *MockAuthService_MFAStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Refresh provides a mock function with given fields: input
 func (_m *MockAuthService) Refresh(input auth.RefreshInput) (*auth.LoginResult, error) {
 	ret := _m.Called(input)
@@ -137,9 +519,69 @@ func (_c *MockAuthService_Refresh_Call) RunAndReturn(run func(auth.RefreshInput)
 	return _c
 }
 
-// Register provides a mock function with given fields: input
-func (_m *MockAuthService) Register(input auth.RegisterInput) (*auth.RegisterResult, error) {
-	ret := _m.Called(input)
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, userID, input
+func (_m *MockAuthService) RegenerateRecoveryCodes(ctx context.Context, userID string, input auth.TOTPCodeInput) (*auth.RecoveryCodes, error) {
+	ret := _m.Called(ctx, userID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateRecoveryCodes")
+	}
+
+	var r0 *auth.RecoveryCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.TOTPCodeInput) (*auth.RecoveryCodes, error)); ok {
+		return rf(ctx, userID, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.TOTPCodeInput) *auth.RecoveryCodes); ok {
+		r0 = rf(ctx, userID, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.RecoveryCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.TOTPCodeInput) error); ok {
+		r1 = rf(ctx, userID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_RegenerateRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateRecoveryCodes'
+type MockAuthService_RegenerateRecoveryCodes_Call struct {
+	*mock.Call
+}
+
+// RegenerateRecoveryCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - input auth.TOTPCodeInput
+func (_e *MockAuthService_Expecter) RegenerateRecoveryCodes(ctx interface{}, userID interface{}, input interface{}) *MockAuthService_RegenerateRecoveryCodes_Call {
+	return &MockAuthService_RegenerateRecoveryCodes_Call{Call: _e.mock.On("RegenerateRecoveryCodes", ctx, userID, input)}
+}
+
+func (_c *MockAuthService_RegenerateRecoveryCodes_Call) Run(run func(ctx context.Context, userID string, input auth.TOTPCodeInput)) *MockAuthService_RegenerateRecoveryCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(auth.TOTPCodeInput))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RegenerateRecoveryCodes_Call) Return(_a0 *auth.RecoveryCodes, _a1 error) *MockAuthService_RegenerateRecoveryCodes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_RegenerateRecoveryCodes_Call) RunAndReturn(run func(context.Context, string, auth.TOTPCodeInput) (*auth.RecoveryCodes, error)) *MockAuthService_RegenerateRecoveryCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: ctx, input
+func (_m *MockAuthService) Register(ctx context.Context, input auth.RegisterInput) (*auth.RegisterResult, error) {
+	ret := _m.Called(ctx, input)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Register")
@@ -147,19 +589,19 @@ func (_m *MockAuthService) Register(input auth.RegisterInput) (*auth.RegisterRes
 
 	var r0 *auth.RegisterResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(auth.RegisterInput) (*auth.RegisterResult, error)); ok {
-		return rf(input)
+	if rf, ok := ret.Get(0).(func(context.Context, auth.RegisterInput) (*auth.RegisterResult, error)); ok {
+		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(auth.RegisterInput) *auth.RegisterResult); ok {
-		r0 = rf(input)
+	if rf, ok := ret.Get(0).(func(context.Context, auth.RegisterInput) *auth.RegisterResult); ok {
+		r0 = rf(ctx, input)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*auth.RegisterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(auth.RegisterInput) error); ok {
-		r1 = rf(input)
+	if rf, ok := ret.Get(1).(func(context.Context, auth.RegisterInput) error); ok {
+		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -173,14 +615,15 @@ type MockAuthService_Register_Call struct {
 }
 
 // Register is a helper method to define mock.On call
+//   - ctx context.Context
 //   - input auth.RegisterInput
-func (_e *MockAuthService_Expecter) Register(input interface{}) *MockAuthService_Register_Call {
-	return &MockAuthService_Register_Call{Call: _e.mock.On("Register", input)}
+func (_e *MockAuthService_Expecter) Register(ctx interface{}, input interface{}) *MockAuthService_Register_Call {
+	return &MockAuthService_Register_Call{Call: _e.mock.On("Register", ctx, input)}
 }
 
-func (_c *MockAuthService_Register_Call) Run(run func(input auth.RegisterInput)) *MockAuthService_Register_Call {
+func (_c *MockAuthService_Register_Call) Run(run func(ctx context.Context, input auth.RegisterInput)) *MockAuthService_Register_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(auth.RegisterInput))
+		run(args[0].(context.Context), args[1].(auth.RegisterInput))
 	})
 	return _c
 }
@@ -190,7 +633,253 @@ func (_c *MockAuthService_Register_Call) Return(_a0 *auth.RegisterResult, _a1 er
 	return _c
 }
 
-func (_c *MockAuthService_Register_Call) RunAndReturn(run func(auth.RegisterInput) (*auth.RegisterResult, error)) *MockAuthService_Register_Call {
+func (_c *MockAuthService_Register_Call) RunAndReturn(run func(context.Context, auth.RegisterInput) (*auth.RegisterResult, error)) *MockAuthService_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendVerification provides a mock function with given fields: ctx, input
+func (_m *MockAuthService) ResendVerification(ctx context.Context, input auth.EmailInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.EmailInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthService_ResendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerification'
+type MockAuthService_ResendVerification_Call struct {
+	*mock.Call
+}
+
+// ResendVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input auth.EmailInput
+func (_e *MockAuthService_Expecter) ResendVerification(ctx interface{}, input interface{}) *MockAuthService_ResendVerification_Call {
+	return &MockAuthService_ResendVerification_Call{Call: _e.mock.On("ResendVerification", ctx, input)}
+}
+
+func (_c *MockAuthService_ResendVerification_Call) Run(run func(ctx context.Context, input auth.EmailInput)) *MockAuthService_ResendVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(auth.EmailInput))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ResendVerification_Call) Return(_a0 error) *MockAuthService_ResendVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_ResendVerification_Call) RunAndReturn(run func(context.Context, auth.EmailInput) error) *MockAuthService_ResendVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: ctx, input
+func (_m *MockAuthService) ResetPassword(ctx context.Context, input auth.ResetPasswordInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.ResetPasswordInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockAuthService_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input auth.ResetPasswordInput
+func (_e *MockAuthService_Expecter) ResetPassword(ctx interface{}, input interface{}) *MockAuthService_ResetPassword_Call {
+	return &MockAuthService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, input)}
+}
+
+func (_c *MockAuthService_ResetPassword_Call) Run(run func(ctx context.Context, input auth.ResetPasswordInput)) *MockAuthService_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(auth.ResetPasswordInput))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ResetPassword_Call) Return(_a0 error) *MockAuthService_ResetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_ResetPassword_Call) RunAndReturn(run func(context.Context, auth.ResetPasswordInput) error) *MockAuthService_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: userID, sessionID
+func (_m *MockAuthService) RevokeSession(userID string, sessionID uuid.UUID) error {
+	ret := _m.Called(userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) error); ok {
+		r0 = rf(userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthService_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockAuthService_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - userID string
+//   - sessionID uuid.UUID
+func (_e *MockAuthService_Expecter) RevokeSession(userID interface{}, sessionID interface{}) *MockAuthService_RevokeSession_Call {
+	return &MockAuthService_RevokeSession_Call{Call: _e.mock.On("RevokeSession", userID, sessionID)}
+}
+
+func (_c *MockAuthService_RevokeSession_Call) Run(run func(userID string, sessionID uuid.UUID)) *MockAuthService_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RevokeSession_Call) Return(_a0 error) *MockAuthService_RevokeSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_RevokeSession_Call) RunAndReturn(run func(string, uuid.UUID) error) *MockAuthService_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sessions provides a mock function with given fields: userID
+func (_m *MockAuthService) Sessions(userID string) ([]auth.UserSession, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sessions")
+	}
+
+	var r0 []auth.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]auth.UserSession, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []auth.UserSession); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_Sessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sessions'
+type MockAuthService_Sessions_Call struct {
+	*mock.Call
+}
+
+// Sessions is a helper method to define mock.On call
+//   - userID string
+func (_e *MockAuthService_Expecter) Sessions(userID interface{}) *MockAuthService_Sessions_Call {
+	return &MockAuthService_Sessions_Call{Call: _e.mock.On("Sessions", userID)}
+}
+
+func (_c *MockAuthService_Sessions_Call) Run(run func(userID string)) *MockAuthService_Sessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Sessions_Call) Return(_a0 []auth.UserSession, _a1 error) *MockAuthService_Sessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_Sessions_Call) RunAndReturn(run func(string) ([]auth.UserSession, error)) *MockAuthService_Sessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, input
+func (_m *MockAuthService) VerifyEmail(ctx context.Context, input auth.VerifyEmailInput) error {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.VerifyEmailInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthService_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type MockAuthService_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input auth.VerifyEmailInput
+func (_e *MockAuthService_Expecter) VerifyEmail(ctx interface{}, input interface{}) *MockAuthService_VerifyEmail_Call {
+	return &MockAuthService_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, input)}
+}
+
+func (_c *MockAuthService_VerifyEmail_Call) Run(run func(ctx context.Context, input auth.VerifyEmailInput)) *MockAuthService_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(auth.VerifyEmailInput))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_VerifyEmail_Call) Return(_a0 error) *MockAuthService_VerifyEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_VerifyEmail_Call) RunAndReturn(run func(context.Context, auth.VerifyEmailInput) error) *MockAuthService_VerifyEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,277 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package backfill_mock
+
+import (
+	context "context"
+	backfill "sun-stockanalysis-api/internal/domains/backfill"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockBackfillService is an autogenerated mock type for the BackfillService type
+type MockBackfillService struct {
+	mock.Mock
+}
+
+type MockBackfillService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBackfillService) EXPECT() *MockBackfillService_Expecter {
+	return &MockBackfillService_Expecter{mock: &_m.Mock}
+}
+
+// Enqueue provides a mock function with given fields: symbol
+func (_m *MockBackfillService) Enqueue(symbol string) (*backfill.Job, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 *backfill.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*backfill.Job, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) *backfill.Job); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*backfill.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillService_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockBackfillService_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockBackfillService_Expecter) Enqueue(symbol interface{}) *MockBackfillService_Enqueue_Call {
+	return &MockBackfillService_Enqueue_Call{Call: _e.mock.On("Enqueue", symbol)}
+}
+
+func (_c *MockBackfillService_Enqueue_Call) Run(run func(symbol string)) *MockBackfillService_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockBackfillService_Enqueue_Call) Return(_a0 *backfill.Job, _a1 error) *MockBackfillService_Enqueue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillService_Enqueue_Call) RunAndReturn(run func(string) (*backfill.Job, error)) *MockBackfillService_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: id
+func (_m *MockBackfillService) Get(id uuid.UUID) (*backfill.Job, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *backfill.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*backfill.Job, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *backfill.Job); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*backfill.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockBackfillService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockBackfillService_Expecter) Get(id interface{}) *MockBackfillService_Get_Call {
+	return &MockBackfillService_Get_Call{Call: _e.mock.On("Get", id)}
+}
+
+func (_c *MockBackfillService_Get_Call) Run(run func(id uuid.UUID)) *MockBackfillService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBackfillService_Get_Call) Return(_a0 *backfill.Job, _a1 error) *MockBackfillService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillService_Get_Call) RunAndReturn(run func(uuid.UUID) (*backfill.Job, error)) *MockBackfillService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with no fields
+func (_m *MockBackfillService) List() ([]backfill.Job, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []backfill.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]backfill.Job, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []backfill.Job); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backfill.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockBackfillService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+func (_e *MockBackfillService_Expecter) List() *MockBackfillService_List_Call {
+	return &MockBackfillService_List_Call{Call: _e.mock.On("List")}
+}
+
+func (_c *MockBackfillService_List_Call) Run(run func()) *MockBackfillService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBackfillService_List_Call) Return(_a0 []backfill.Job, _a1 error) *MockBackfillService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillService_List_Call) RunAndReturn(run func() ([]backfill.Job, error)) *MockBackfillService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *MockBackfillService) Start(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// MockBackfillService_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockBackfillService_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBackfillService_Expecter) Start(ctx interface{}) *MockBackfillService_Start_Call {
+	return &MockBackfillService_Start_Call{Call: _e.mock.On("Start", ctx)}
+}
+
+func (_c *MockBackfillService_Start_Call) Run(run func(ctx context.Context)) *MockBackfillService_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockBackfillService_Start_Call) Return() *MockBackfillService_Start_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBackfillService_Start_Call) RunAndReturn(run func(context.Context)) *MockBackfillService_Start_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Stop provides a mock function with no fields
+func (_m *MockBackfillService) Stop() {
+	_m.Called()
+}
+
+// MockBackfillService_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockBackfillService_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+func (_e *MockBackfillService_Expecter) Stop() *MockBackfillService_Stop_Call {
+	return &MockBackfillService_Stop_Call{Call: _e.mock.On("Stop")}
+}
+
+func (_c *MockBackfillService_Stop_Call) Run(run func()) *MockBackfillService_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBackfillService_Stop_Call) Return() *MockBackfillService_Stop_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBackfillService_Stop_Call) RunAndReturn(run func()) *MockBackfillService_Stop_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockBackfillService creates a new instance of MockBackfillService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBackfillService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBackfillService {
+	mock := &MockBackfillService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,349 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package fx_rates_mock
+
+import (
+	context "context"
+	fx_rates "sun-stockanalysis-api/internal/domains/fx_rates"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "sun-stockanalysis-api/internal/models"
+
+	time "time"
+)
+
+// MockFXRateService is an autogenerated mock type for the FXRateService type
+type MockFXRateService struct {
+	mock.Mock
+}
+
+type MockFXRateService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFXRateService) EXPECT() *MockFXRateService_Expecter {
+	return &MockFXRateService_Expecter{mock: &_m.Mock}
+}
+
+// ConvertDaily provides a mock function with given fields: ctx, rows, target
+func (_m *MockFXRateService) ConvertDaily(ctx context.Context, rows []models.StockDaily, target string) error {
+	ret := _m.Called(ctx, rows, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConvertDaily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.StockDaily, string) error); ok {
+		r0 = rf(ctx, rows, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFXRateService_ConvertDaily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConvertDaily'
+type MockFXRateService_ConvertDaily_Call struct {
+	*mock.Call
+}
+
+// ConvertDaily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rows []models.StockDaily
+//   - target string
+func (_e *MockFXRateService_Expecter) ConvertDaily(ctx interface{}, rows interface{}, target interface{}) *MockFXRateService_ConvertDaily_Call {
+	return &MockFXRateService_ConvertDaily_Call{Call: _e.mock.On("ConvertDaily", ctx, rows, target)}
+}
+
+func (_c *MockFXRateService_ConvertDaily_Call) Run(run func(ctx context.Context, rows []models.StockDaily, target string)) *MockFXRateService_ConvertDaily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.StockDaily), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockFXRateService_ConvertDaily_Call) Return(_a0 error) *MockFXRateService_ConvertDaily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFXRateService_ConvertDaily_Call) RunAndReturn(run func(context.Context, []models.StockDaily, string) error) *MockFXRateService_ConvertDaily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConvertQuotes provides a mock function with given fields: ctx, quotes, target
+func (_m *MockFXRateService) ConvertQuotes(ctx context.Context, quotes []models.StockQuote, target string) error {
+	ret := _m.Called(ctx, quotes, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConvertQuotes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.StockQuote, string) error); ok {
+		r0 = rf(ctx, quotes, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFXRateService_ConvertQuotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConvertQuotes'
+type MockFXRateService_ConvertQuotes_Call struct {
+	*mock.Call
+}
+
+// ConvertQuotes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - quotes []models.StockQuote
+//   - target string
+func (_e *MockFXRateService_Expecter) ConvertQuotes(ctx interface{}, quotes interface{}, target interface{}) *MockFXRateService_ConvertQuotes_Call {
+	return &MockFXRateService_ConvertQuotes_Call{Call: _e.mock.On("ConvertQuotes", ctx, quotes, target)}
+}
+
+func (_c *MockFXRateService_ConvertQuotes_Call) Run(run func(ctx context.Context, quotes []models.StockQuote, target string)) *MockFXRateService_ConvertQuotes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.StockQuote), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockFXRateService_ConvertQuotes_Call) Return(_a0 error) *MockFXRateService_ConvertQuotes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFXRateService_ConvertQuotes_Call) RunAndReturn(run func(context.Context, []models.StockQuote, string) error) *MockFXRateService_ConvertQuotes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Converter provides a mock function with given fields: ctx, target, start, end
+func (_m *MockFXRateService) Converter(ctx context.Context, target string, start time.Time, end time.Time) (*fx_rates.Converter, error) {
+	ret := _m.Called(ctx, target, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Converter")
+	}
+
+	var r0 *fx_rates.Converter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (*fx_rates.Converter, error)); ok {
+		return rf(ctx, target, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) *fx_rates.Converter); ok {
+		r0 = rf(ctx, target, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fx_rates.Converter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, target, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFXRateService_Converter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Converter'
+type MockFXRateService_Converter_Call struct {
+	*mock.Call
+}
+
+// Converter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target string
+//   - start time.Time
+//   - end time.Time
+func (_e *MockFXRateService_Expecter) Converter(ctx interface{}, target interface{}, start interface{}, end interface{}) *MockFXRateService_Converter_Call {
+	return &MockFXRateService_Converter_Call{Call: _e.mock.On("Converter", ctx, target, start, end)}
+}
+
+func (_c *MockFXRateService_Converter_Call) Run(run func(ctx context.Context, target string, start time.Time, end time.Time)) *MockFXRateService_Converter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockFXRateService_Converter_Call) Return(_a0 *fx_rates.Converter, _a1 error) *MockFXRateService_Converter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFXRateService_Converter_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (*fx_rates.Converter, error)) *MockFXRateService_Converter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rates provides a mock function with given fields: ctx, base, at
+func (_m *MockFXRateService) Rates(ctx context.Context, base string, at time.Time) (*fx_rates.RateTable, error) {
+	ret := _m.Called(ctx, base, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rates")
+	}
+
+	var r0 *fx_rates.RateTable
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*fx_rates.RateTable, error)); ok {
+		return rf(ctx, base, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *fx_rates.RateTable); ok {
+		r0 = rf(ctx, base, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fx_rates.RateTable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, base, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFXRateService_Rates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rates'
+type MockFXRateService_Rates_Call struct {
+	*mock.Call
+}
+
+// Rates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - base string
+//   - at time.Time
+func (_e *MockFXRateService_Expecter) Rates(ctx interface{}, base interface{}, at interface{}) *MockFXRateService_Rates_Call {
+	return &MockFXRateService_Rates_Call{Call: _e.mock.On("Rates", ctx, base, at)}
+}
+
+func (_c *MockFXRateService_Rates_Call) Run(run func(ctx context.Context, base string, at time.Time)) *MockFXRateService_Rates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockFXRateService_Rates_Call) Return(_a0 *fx_rates.RateTable, _a1 error) *MockFXRateService_Rates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFXRateService_Rates_Call) RunAndReturn(run func(context.Context, string, time.Time) (*fx_rates.RateTable, error)) *MockFXRateService_Rates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *MockFXRateService) Start(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// MockFXRateService_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockFXRateService_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFXRateService_Expecter) Start(ctx interface{}) *MockFXRateService_Start_Call {
+	return &MockFXRateService_Start_Call{Call: _e.mock.On("Start", ctx)}
+}
+
+func (_c *MockFXRateService_Start_Call) Run(run func(ctx context.Context)) *MockFXRateService_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockFXRateService_Start_Call) Return() *MockFXRateService_Start_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockFXRateService_Start_Call) RunAndReturn(run func(context.Context)) *MockFXRateService_Start_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Sync provides a mock function with given fields: ctx
+func (_m *MockFXRateService) Sync(ctx context.Context) (*fx_rates.SyncResult, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sync")
+	}
+
+	var r0 *fx_rates.SyncResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*fx_rates.SyncResult, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *fx_rates.SyncResult); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fx_rates.SyncResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFXRateService_Sync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sync'
+type MockFXRateService_Sync_Call struct {
+	*mock.Call
+}
+
+// Sync is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFXRateService_Expecter) Sync(ctx interface{}) *MockFXRateService_Sync_Call {
+	return &MockFXRateService_Sync_Call{Call: _e.mock.On("Sync", ctx)}
+}
+
+func (_c *MockFXRateService_Sync_Call) Run(run func(ctx context.Context)) *MockFXRateService_Sync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockFXRateService_Sync_Call) Return(_a0 *fx_rates.SyncResult, _a1 error) *MockFXRateService_Sync_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFXRateService_Sync_Call) RunAndReturn(run func(context.Context) (*fx_rates.SyncResult, error)) *MockFXRateService_Sync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFXRateService creates a new instance of MockFXRateService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFXRateService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFXRateService {
+	mock := &MockFXRateService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
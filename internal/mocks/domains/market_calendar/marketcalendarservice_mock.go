@@ -0,0 +1,870 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package market_calendar_mock
+
+import (
+	context "context"
+	market_calendar "sun-stockanalysis-api/internal/domains/market_calendar"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "sun-stockanalysis-api/internal/models"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockMarketCalendarService is an autogenerated mock type for the MarketCalendarService type
+type MockMarketCalendarService struct {
+	mock.Mock
+}
+
+type MockMarketCalendarService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMarketCalendarService) EXPECT() *MockMarketCalendarService_Expecter {
+	return &MockMarketCalendarService_Expecter{mock: &_m.Mock}
+}
+
+// Calendar provides a mock function with given fields: ctx, code
+func (_m *MockMarketCalendarService) Calendar(ctx context.Context, code string) (*market_calendar.Calendar, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Calendar")
+	}
+
+	var r0 *market_calendar.Calendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*market_calendar.Calendar, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *market_calendar.Calendar); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*market_calendar.Calendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_Calendar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Calendar'
+type MockMarketCalendarService_Calendar_Call struct {
+	*mock.Call
+}
+
+// Calendar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+func (_e *MockMarketCalendarService_Expecter) Calendar(ctx interface{}, code interface{}) *MockMarketCalendarService_Calendar_Call {
+	return &MockMarketCalendarService_Calendar_Call{Call: _e.mock.On("Calendar", ctx, code)}
+}
+
+func (_c *MockMarketCalendarService_Calendar_Call) Run(run func(ctx context.Context, code string)) *MockMarketCalendarService_Calendar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_Calendar_Call) Return(_a0 *market_calendar.Calendar, _a1 error) *MockMarketCalendarService_Calendar_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_Calendar_Call) RunAndReturn(run func(context.Context, string) (*market_calendar.Calendar, error)) *MockMarketCalendarService_Calendar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CalendarForSymbol provides a mock function with given fields: ctx, symbol
+func (_m *MockMarketCalendarService) CalendarForSymbol(ctx context.Context, symbol string) (*market_calendar.Calendar, error) {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CalendarForSymbol")
+	}
+
+	var r0 *market_calendar.Calendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*market_calendar.Calendar, error)); ok {
+		return rf(ctx, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *market_calendar.Calendar); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*market_calendar.Calendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_CalendarForSymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CalendarForSymbol'
+type MockMarketCalendarService_CalendarForSymbol_Call struct {
+	*mock.Call
+}
+
+// CalendarForSymbol is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+func (_e *MockMarketCalendarService_Expecter) CalendarForSymbol(ctx interface{}, symbol interface{}) *MockMarketCalendarService_CalendarForSymbol_Call {
+	return &MockMarketCalendarService_CalendarForSymbol_Call{Call: _e.mock.On("CalendarForSymbol", ctx, symbol)}
+}
+
+func (_c *MockMarketCalendarService_CalendarForSymbol_Call) Run(run func(ctx context.Context, symbol string)) *MockMarketCalendarService_CalendarForSymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_CalendarForSymbol_Call) Return(_a0 *market_calendar.Calendar, _a1 error) *MockMarketCalendarService_CalendarForSymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_CalendarForSymbol_Call) RunAndReturn(run func(context.Context, string) (*market_calendar.Calendar, error)) *MockMarketCalendarService_CalendarForSymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CalendarsInUse provides a mock function with given fields: ctx
+func (_m *MockMarketCalendarService) CalendarsInUse(ctx context.Context) ([]*market_calendar.Calendar, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CalendarsInUse")
+	}
+
+	var r0 []*market_calendar.Calendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*market_calendar.Calendar, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*market_calendar.Calendar); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*market_calendar.Calendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_CalendarsInUse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CalendarsInUse'
+type MockMarketCalendarService_CalendarsInUse_Call struct {
+	*mock.Call
+}
+
+// CalendarsInUse is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMarketCalendarService_Expecter) CalendarsInUse(ctx interface{}) *MockMarketCalendarService_CalendarsInUse_Call {
+	return &MockMarketCalendarService_CalendarsInUse_Call{Call: _e.mock.On("CalendarsInUse", ctx)}
+}
+
+func (_c *MockMarketCalendarService_CalendarsInUse_Call) Run(run func(ctx context.Context)) *MockMarketCalendarService_CalendarsInUse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_CalendarsInUse_Call) Return(_a0 []*market_calendar.Calendar, _a1 error) *MockMarketCalendarService_CalendarsInUse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_CalendarsInUse_Call) RunAndReturn(run func(context.Context) ([]*market_calendar.Calendar, error)) *MockMarketCalendarService_CalendarsInUse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteHoliday provides a mock function with given fields: ctx, code, date
+func (_m *MockMarketCalendarService) DeleteHoliday(ctx context.Context, code string, date time.Time) error {
+	ret := _m.Called(ctx, code, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteHoliday")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, code, date)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMarketCalendarService_DeleteHoliday_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteHoliday'
+type MockMarketCalendarService_DeleteHoliday_Call struct {
+	*mock.Call
+}
+
+// DeleteHoliday is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - date time.Time
+func (_e *MockMarketCalendarService_Expecter) DeleteHoliday(ctx interface{}, code interface{}, date interface{}) *MockMarketCalendarService_DeleteHoliday_Call {
+	return &MockMarketCalendarService_DeleteHoliday_Call{Call: _e.mock.On("DeleteHoliday", ctx, code, date)}
+}
+
+func (_c *MockMarketCalendarService_DeleteHoliday_Call) Run(run func(ctx context.Context, code string, date time.Time)) *MockMarketCalendarService_DeleteHoliday_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_DeleteHoliday_Call) Return(_a0 error) *MockMarketCalendarService_DeleteHoliday_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_DeleteHoliday_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *MockMarketCalendarService_DeleteHoliday_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureDefaults provides a mock function with given fields: ctx
+func (_m *MockMarketCalendarService) EnsureDefaults(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureDefaults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMarketCalendarService_EnsureDefaults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureDefaults'
+type MockMarketCalendarService_EnsureDefaults_Call struct {
+	*mock.Call
+}
+
+// EnsureDefaults is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMarketCalendarService_Expecter) EnsureDefaults(ctx interface{}) *MockMarketCalendarService_EnsureDefaults_Call {
+	return &MockMarketCalendarService_EnsureDefaults_Call{Call: _e.mock.On("EnsureDefaults", ctx)}
+}
+
+func (_c *MockMarketCalendarService_EnsureDefaults_Call) Run(run func(ctx context.Context)) *MockMarketCalendarService_EnsureDefaults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_EnsureDefaults_Call) Return(_a0 error) *MockMarketCalendarService_EnsureDefaults_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_EnsureDefaults_Call) RunAndReturn(run func(context.Context) error) *MockMarketCalendarService_EnsureDefaults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Holidays provides a mock function with given fields: ctx, code, from, to
+func (_m *MockMarketCalendarService) Holidays(ctx context.Context, code string, from time.Time, to time.Time) ([]models.MarketHoliday, error) {
+	ret := _m.Called(ctx, code, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Holidays")
+	}
+
+	var r0 []models.MarketHoliday
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]models.MarketHoliday, error)); ok {
+		return rf(ctx, code, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []models.MarketHoliday); ok {
+		r0 = rf(ctx, code, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MarketHoliday)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, code, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_Holidays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Holidays'
+type MockMarketCalendarService_Holidays_Call struct {
+	*mock.Call
+}
+
+// Holidays is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockMarketCalendarService_Expecter) Holidays(ctx interface{}, code interface{}, from interface{}, to interface{}) *MockMarketCalendarService_Holidays_Call {
+	return &MockMarketCalendarService_Holidays_Call{Call: _e.mock.On("Holidays", ctx, code, from, to)}
+}
+
+func (_c *MockMarketCalendarService_Holidays_Call) Run(run func(ctx context.Context, code string, from time.Time, to time.Time)) *MockMarketCalendarService_Holidays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_Holidays_Call) Return(_a0 []models.MarketHoliday, _a1 error) *MockMarketCalendarService_Holidays_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_Holidays_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) ([]models.MarketHoliday, error)) *MockMarketCalendarService_Holidays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InQuoteWindow provides a mock function with given fields: ctx, symbol, at
+func (_m *MockMarketCalendarService) InQuoteWindow(ctx context.Context, symbol string, at time.Time) bool {
+	ret := _m.Called(ctx, symbol, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InQuoteWindow")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = rf(ctx, symbol, at)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockMarketCalendarService_InQuoteWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InQuoteWindow'
+type MockMarketCalendarService_InQuoteWindow_Call struct {
+	*mock.Call
+}
+
+// InQuoteWindow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+//   - at time.Time
+func (_e *MockMarketCalendarService_Expecter) InQuoteWindow(ctx interface{}, symbol interface{}, at interface{}) *MockMarketCalendarService_InQuoteWindow_Call {
+	return &MockMarketCalendarService_InQuoteWindow_Call{Call: _e.mock.On("InQuoteWindow", ctx, symbol, at)}
+}
+
+func (_c *MockMarketCalendarService_InQuoteWindow_Call) Run(run func(ctx context.Context, symbol string, at time.Time)) *MockMarketCalendarService_InQuoteWindow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_InQuoteWindow_Call) Return(_a0 bool) *MockMarketCalendarService_InQuoteWindow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_InQuoteWindow_Call) RunAndReturn(run func(context.Context, string, time.Time) bool) *MockMarketCalendarService_InQuoteWindow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockMarketCalendarService) List(ctx context.Context) ([]market_calendar.CalendarStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []market_calendar.CalendarStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]market_calendar.CalendarStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []market_calendar.CalendarStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]market_calendar.CalendarStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockMarketCalendarService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMarketCalendarService_Expecter) List(ctx interface{}) *MockMarketCalendarService_List_Call {
+	return &MockMarketCalendarService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockMarketCalendarService_List_Call) Run(run func(ctx context.Context)) *MockMarketCalendarService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_List_Call) Return(_a0 []market_calendar.CalendarStatus, _a1 error) *MockMarketCalendarService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_List_Call) RunAndReturn(run func(context.Context) ([]market_calendar.CalendarStatus, error)) *MockMarketCalendarService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExchanges provides a mock function with given fields: ctx
+func (_m *MockMarketCalendarService) ListExchanges(ctx context.Context) ([]models.MasterExchange, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExchanges")
+	}
+
+	var r0 []models.MasterExchange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.MasterExchange, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.MasterExchange); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MasterExchange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_ListExchanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExchanges'
+type MockMarketCalendarService_ListExchanges_Call struct {
+	*mock.Call
+}
+
+// ListExchanges is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMarketCalendarService_Expecter) ListExchanges(ctx interface{}) *MockMarketCalendarService_ListExchanges_Call {
+	return &MockMarketCalendarService_ListExchanges_Call{Call: _e.mock.On("ListExchanges", ctx)}
+}
+
+func (_c *MockMarketCalendarService_ListExchanges_Call) Run(run func(ctx context.Context)) *MockMarketCalendarService_ListExchanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_ListExchanges_Call) Return(_a0 []models.MasterExchange, _a1 error) *MockMarketCalendarService_ListExchanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_ListExchanges_Call) RunAndReturn(run func(context.Context) ([]models.MasterExchange, error)) *MockMarketCalendarService_ListExchanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveCalendar provides a mock function with given fields: ctx, def
+func (_m *MockMarketCalendarService) SaveCalendar(ctx context.Context, def models.MarketCalendar) (*models.MarketCalendar, error) {
+	ret := _m.Called(ctx, def)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCalendar")
+	}
+
+	var r0 *models.MarketCalendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.MarketCalendar) (*models.MarketCalendar, error)); ok {
+		return rf(ctx, def)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.MarketCalendar) *models.MarketCalendar); ok {
+		r0 = rf(ctx, def)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MarketCalendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.MarketCalendar) error); ok {
+		r1 = rf(ctx, def)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_SaveCalendar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCalendar'
+type MockMarketCalendarService_SaveCalendar_Call struct {
+	*mock.Call
+}
+
+// SaveCalendar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - def models.MarketCalendar
+func (_e *MockMarketCalendarService_Expecter) SaveCalendar(ctx interface{}, def interface{}) *MockMarketCalendarService_SaveCalendar_Call {
+	return &MockMarketCalendarService_SaveCalendar_Call{Call: _e.mock.On("SaveCalendar", ctx, def)}
+}
+
+func (_c *MockMarketCalendarService_SaveCalendar_Call) Run(run func(ctx context.Context, def models.MarketCalendar)) *MockMarketCalendarService_SaveCalendar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.MarketCalendar))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_SaveCalendar_Call) Return(_a0 *models.MarketCalendar, _a1 error) *MockMarketCalendarService_SaveCalendar_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_SaveCalendar_Call) RunAndReturn(run func(context.Context, models.MarketCalendar) (*models.MarketCalendar, error)) *MockMarketCalendarService_SaveCalendar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sessions provides a mock function with given fields: ctx, code, from, to
+func (_m *MockMarketCalendarService) Sessions(ctx context.Context, code string, from time.Time, to time.Time) ([]market_calendar.Session, error) {
+	ret := _m.Called(ctx, code, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sessions")
+	}
+
+	var r0 []market_calendar.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]market_calendar.Session, error)); ok {
+		return rf(ctx, code, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []market_calendar.Session); ok {
+		r0 = rf(ctx, code, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]market_calendar.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, code, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_Sessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sessions'
+type MockMarketCalendarService_Sessions_Call struct {
+	*mock.Call
+}
+
+// Sessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockMarketCalendarService_Expecter) Sessions(ctx interface{}, code interface{}, from interface{}, to interface{}) *MockMarketCalendarService_Sessions_Call {
+	return &MockMarketCalendarService_Sessions_Call{Call: _e.mock.On("Sessions", ctx, code, from, to)}
+}
+
+func (_c *MockMarketCalendarService_Sessions_Call) Run(run func(ctx context.Context, code string, from time.Time, to time.Time)) *MockMarketCalendarService_Sessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_Sessions_Call) Return(_a0 []market_calendar.Session, _a1 error) *MockMarketCalendarService_Sessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_Sessions_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) ([]market_calendar.Session, error)) *MockMarketCalendarService_Sessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetExchangeCalendar provides a mock function with given fields: ctx, id, code
+func (_m *MockMarketCalendarService) SetExchangeCalendar(ctx context.Context, id uuid.UUID, code string) (*models.MasterExchange, error) {
+	ret := _m.Called(ctx, id, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetExchangeCalendar")
+	}
+
+	var r0 *models.MasterExchange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*models.MasterExchange, error)); ok {
+		return rf(ctx, id, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *models.MasterExchange); ok {
+		r0 = rf(ctx, id, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MasterExchange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, id, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_SetExchangeCalendar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetExchangeCalendar'
+type MockMarketCalendarService_SetExchangeCalendar_Call struct {
+	*mock.Call
+}
+
+// SetExchangeCalendar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - code string
+func (_e *MockMarketCalendarService_Expecter) SetExchangeCalendar(ctx interface{}, id interface{}, code interface{}) *MockMarketCalendarService_SetExchangeCalendar_Call {
+	return &MockMarketCalendarService_SetExchangeCalendar_Call{Call: _e.mock.On("SetExchangeCalendar", ctx, id, code)}
+}
+
+func (_c *MockMarketCalendarService_SetExchangeCalendar_Call) Run(run func(ctx context.Context, id uuid.UUID, code string)) *MockMarketCalendarService_SetExchangeCalendar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_SetExchangeCalendar_Call) Return(_a0 *models.MasterExchange, _a1 error) *MockMarketCalendarService_SetExchangeCalendar_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_SetExchangeCalendar_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*models.MasterExchange, error)) *MockMarketCalendarService_SetExchangeCalendar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetHoliday provides a mock function with given fields: ctx, code, input
+func (_m *MockMarketCalendarService) SetHoliday(ctx context.Context, code string, input market_calendar.HolidayInput) (*models.MarketHoliday, error) {
+	ret := _m.Called(ctx, code, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetHoliday")
+	}
+
+	var r0 *models.MarketHoliday
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, market_calendar.HolidayInput) (*models.MarketHoliday, error)); ok {
+		return rf(ctx, code, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, market_calendar.HolidayInput) *models.MarketHoliday); ok {
+		r0 = rf(ctx, code, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MarketHoliday)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, market_calendar.HolidayInput) error); ok {
+		r1 = rf(ctx, code, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_SetHoliday_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetHoliday'
+type MockMarketCalendarService_SetHoliday_Call struct {
+	*mock.Call
+}
+
+// SetHoliday is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - input market_calendar.HolidayInput
+func (_e *MockMarketCalendarService_Expecter) SetHoliday(ctx interface{}, code interface{}, input interface{}) *MockMarketCalendarService_SetHoliday_Call {
+	return &MockMarketCalendarService_SetHoliday_Call{Call: _e.mock.On("SetHoliday", ctx, code, input)}
+}
+
+func (_c *MockMarketCalendarService_SetHoliday_Call) Run(run func(ctx context.Context, code string, input market_calendar.HolidayInput)) *MockMarketCalendarService_SetHoliday_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(market_calendar.HolidayInput))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_SetHoliday_Call) Return(_a0 *models.MarketHoliday, _a1 error) *MockMarketCalendarService_SetHoliday_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_SetHoliday_Call) RunAndReturn(run func(context.Context, string, market_calendar.HolidayInput) (*models.MarketHoliday, error)) *MockMarketCalendarService_SetHoliday_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *MockMarketCalendarService) Start(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// MockMarketCalendarService_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockMarketCalendarService_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMarketCalendarService_Expecter) Start(ctx interface{}) *MockMarketCalendarService_Start_Call {
+	return &MockMarketCalendarService_Start_Call{Call: _e.mock.On("Start", ctx)}
+}
+
+func (_c *MockMarketCalendarService_Start_Call) Run(run func(ctx context.Context)) *MockMarketCalendarService_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_Start_Call) Return() *MockMarketCalendarService_Start_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMarketCalendarService_Start_Call) RunAndReturn(run func(context.Context)) *MockMarketCalendarService_Start_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SyncHolidays provides a mock function with given fields: ctx, code
+func (_m *MockMarketCalendarService) SyncHolidays(ctx context.Context, code string) (*market_calendar.HolidaySyncResult, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncHolidays")
+	}
+
+	var r0 *market_calendar.HolidaySyncResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*market_calendar.HolidaySyncResult, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *market_calendar.HolidaySyncResult); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*market_calendar.HolidaySyncResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarService_SyncHolidays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncHolidays'
+type MockMarketCalendarService_SyncHolidays_Call struct {
+	*mock.Call
+}
+
+// SyncHolidays is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+func (_e *MockMarketCalendarService_Expecter) SyncHolidays(ctx interface{}, code interface{}) *MockMarketCalendarService_SyncHolidays_Call {
+	return &MockMarketCalendarService_SyncHolidays_Call{Call: _e.mock.On("SyncHolidays", ctx, code)}
+}
+
+func (_c *MockMarketCalendarService_SyncHolidays_Call) Run(run func(ctx context.Context, code string)) *MockMarketCalendarService_SyncHolidays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarService_SyncHolidays_Call) Return(_a0 *market_calendar.HolidaySyncResult, _a1 error) *MockMarketCalendarService_SyncHolidays_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarService_SyncHolidays_Call) RunAndReturn(run func(context.Context, string) (*market_calendar.HolidaySyncResult, error)) *MockMarketCalendarService_SyncHolidays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMarketCalendarService creates a new instance of MockMarketCalendarService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMarketCalendarService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMarketCalendarService {
+	mock := &MockMarketCalendarService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
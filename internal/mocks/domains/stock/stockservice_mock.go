@@ -129,6 +129,63 @@ func (_c *MockStockService_GetStock_Call) RunAndReturn(run func(uuid.UUID) (*mod
 	return _c
 }
 
+// ListAll provides a mock function with no fields
+func (_m *MockStockService) ListAll() ([]models.Stock, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAll")
+	}
+
+	var r0 []models.Stock
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Stock, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.Stock); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Stock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockService_ListAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAll'
+type MockStockService_ListAll_Call struct {
+	*mock.Call
+}
+
+// ListAll is a helper method to define mock.On call
+func (_e *MockStockService_Expecter) ListAll() *MockStockService_ListAll_Call {
+	return &MockStockService_ListAll_Call{Call: _e.mock.On("ListAll")}
+}
+
+func (_c *MockStockService_ListAll_Call) Run(run func()) *MockStockService_ListAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStockService_ListAll_Call) Return(_a0 []models.Stock, _a1 error) *MockStockService_ListAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockService_ListAll_Call) RunAndReturn(run func() ([]models.Stock, error)) *MockStockService_ListAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockStockService creates a new instance of MockStockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockStockService(t interface {
@@ -0,0 +1,326 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package stock_daily_mock
+
+import (
+	context "context"
+	market_calendar "sun-stockanalysis-api/internal/domains/market_calendar"
+	marketdata "sun-stockanalysis-api/internal/marketdata"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "sun-stockanalysis-api/internal/models"
+
+	stock_daily "sun-stockanalysis-api/internal/domains/stock_daily"
+
+	time "time"
+)
+
+// MockStockDailyService is an autogenerated mock type for the StockDailyService type
+type MockStockDailyService struct {
+	mock.Mock
+}
+
+type MockStockDailyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStockDailyService) EXPECT() *MockStockDailyService_Expecter {
+	return &MockStockDailyService_Expecter{mock: &_m.Mock}
+}
+
+// BuildForSession provides a mock function with given fields: ctx, session
+func (_m *MockStockDailyService) BuildForSession(ctx context.Context, session market_calendar.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildForSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, market_calendar.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStockDailyService_BuildForSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildForSession'
+type MockStockDailyService_BuildForSession_Call struct {
+	*mock.Call
+}
+
+// BuildForSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session market_calendar.Session
+func (_e *MockStockDailyService_Expecter) BuildForSession(ctx interface{}, session interface{}) *MockStockDailyService_BuildForSession_Call {
+	return &MockStockDailyService_BuildForSession_Call{Call: _e.mock.On("BuildForSession", ctx, session)}
+}
+
+func (_c *MockStockDailyService_BuildForSession_Call) Run(run func(ctx context.Context, session market_calendar.Session)) *MockStockDailyService_BuildForSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(market_calendar.Session))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyService_BuildForSession_Call) Return(_a0 error) *MockStockDailyService_BuildForSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStockDailyService_BuildForSession_Call) RunAndReturn(run func(context.Context, market_calendar.Session) error) *MockStockDailyService_BuildForSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBySymbol provides a mock function with given fields: ctx, symbol
+func (_m *MockStockDailyService) ListBySymbol(ctx context.Context, symbol string) ([]models.StockDaily, error) {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBySymbol")
+	}
+
+	var r0 []models.StockDaily
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.StockDaily, error)); ok {
+		return rf(ctx, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.StockDaily); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockDaily)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyService_ListBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBySymbol'
+type MockStockDailyService_ListBySymbol_Call struct {
+	*mock.Call
+}
+
+// ListBySymbol is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+func (_e *MockStockDailyService_Expecter) ListBySymbol(ctx interface{}, symbol interface{}) *MockStockDailyService_ListBySymbol_Call {
+	return &MockStockDailyService_ListBySymbol_Call{Call: _e.mock.On("ListBySymbol", ctx, symbol)}
+}
+
+func (_c *MockStockDailyService_ListBySymbol_Call) Run(run func(ctx context.Context, symbol string)) *MockStockDailyService_ListBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyService_ListBySymbol_Call) Return(_a0 []models.StockDaily, _a1 error) *MockStockDailyService_ListBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyService_ListBySymbol_Call) RunAndReturn(run func(context.Context, string) ([]models.StockDaily, error)) *MockStockDailyService_ListBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rebuild provides a mock function with given fields: ctx, symbol, from, to
+func (_m *MockStockDailyService) Rebuild(ctx context.Context, symbol string, from time.Time, to time.Time) (*stock_daily.RebuildResult, error) {
+	ret := _m.Called(ctx, symbol, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rebuild")
+	}
+
+	var r0 *stock_daily.RebuildResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (*stock_daily.RebuildResult, error)); ok {
+		return rf(ctx, symbol, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) *stock_daily.RebuildResult); ok {
+		r0 = rf(ctx, symbol, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stock_daily.RebuildResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, symbol, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyService_Rebuild_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rebuild'
+type MockStockDailyService_Rebuild_Call struct {
+	*mock.Call
+}
+
+// Rebuild is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockStockDailyService_Expecter) Rebuild(ctx interface{}, symbol interface{}, from interface{}, to interface{}) *MockStockDailyService_Rebuild_Call {
+	return &MockStockDailyService_Rebuild_Call{Call: _e.mock.On("Rebuild", ctx, symbol, from, to)}
+}
+
+func (_c *MockStockDailyService_Rebuild_Call) Run(run func(ctx context.Context, symbol string, from time.Time, to time.Time)) *MockStockDailyService_Rebuild_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyService_Rebuild_Call) Return(_a0 *stock_daily.RebuildResult, _a1 error) *MockStockDailyService_Rebuild_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyService_Rebuild_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (*stock_daily.RebuildResult, error)) *MockStockDailyService_Rebuild_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecomputeIndicators provides a mock function with given fields: ctx, symbol
+func (_m *MockStockDailyService) RecomputeIndicators(ctx context.Context, symbol string) (int, error) {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecomputeIndicators")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyService_RecomputeIndicators_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecomputeIndicators'
+type MockStockDailyService_RecomputeIndicators_Call struct {
+	*mock.Call
+}
+
+// RecomputeIndicators is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+func (_e *MockStockDailyService_Expecter) RecomputeIndicators(ctx interface{}, symbol interface{}) *MockStockDailyService_RecomputeIndicators_Call {
+	return &MockStockDailyService_RecomputeIndicators_Call{Call: _e.mock.On("RecomputeIndicators", ctx, symbol)}
+}
+
+func (_c *MockStockDailyService_RecomputeIndicators_Call) Run(run func(ctx context.Context, symbol string)) *MockStockDailyService_RecomputeIndicators_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyService_RecomputeIndicators_Call) Return(_a0 int, _a1 error) *MockStockDailyService_RecomputeIndicators_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyService_RecomputeIndicators_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockStockDailyService_RecomputeIndicators_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SeedFromCandles provides a mock function with given fields: ctx, symbol, candles
+func (_m *MockStockDailyService) SeedFromCandles(ctx context.Context, symbol string, candles []marketdata.Candle) (int, error) {
+	ret := _m.Called(ctx, symbol, candles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SeedFromCandles")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []marketdata.Candle) (int, error)); ok {
+		return rf(ctx, symbol, candles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []marketdata.Candle) int); ok {
+		r0 = rf(ctx, symbol, candles)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []marketdata.Candle) error); ok {
+		r1 = rf(ctx, symbol, candles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyService_SeedFromCandles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SeedFromCandles'
+type MockStockDailyService_SeedFromCandles_Call struct {
+	*mock.Call
+}
+
+// SeedFromCandles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+//   - candles []marketdata.Candle
+func (_e *MockStockDailyService_Expecter) SeedFromCandles(ctx interface{}, symbol interface{}, candles interface{}) *MockStockDailyService_SeedFromCandles_Call {
+	return &MockStockDailyService_SeedFromCandles_Call{Call: _e.mock.On("SeedFromCandles", ctx, symbol, candles)}
+}
+
+func (_c *MockStockDailyService_SeedFromCandles_Call) Run(run func(ctx context.Context, symbol string, candles []marketdata.Candle)) *MockStockDailyService_SeedFromCandles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]marketdata.Candle))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyService_SeedFromCandles_Call) Return(_a0 int, _a1 error) *MockStockDailyService_SeedFromCandles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyService_SeedFromCandles_Call) RunAndReturn(run func(context.Context, string, []marketdata.Candle) (int, error)) *MockStockDailyService_SeedFromCandles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStockDailyService creates a new instance of MockStockDailyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStockDailyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStockDailyService {
+	mock := &MockStockDailyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
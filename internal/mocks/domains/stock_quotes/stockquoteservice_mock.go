@@ -0,0 +1,360 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package stock_quotes_mock
+
+import (
+	context "context"
+	marketdata "sun-stockanalysis-api/internal/marketdata"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "sun-stockanalysis-api/internal/models"
+
+	stock_quotes "sun-stockanalysis-api/internal/domains/stock_quotes"
+)
+
+// MockStockQuoteService is an autogenerated mock type for the StockQuoteService type
+type MockStockQuoteService struct {
+	mock.Mock
+}
+
+type MockStockQuoteService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStockQuoteService) EXPECT() *MockStockQuoteService_Expecter {
+	return &MockStockQuoteService_Expecter{mock: &_m.Mock}
+}
+
+// LastCycle provides a mock function with no fields
+func (_m *MockStockQuoteService) LastCycle() *stock_quotes.CycleResult {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastCycle")
+	}
+
+	var r0 *stock_quotes.CycleResult
+	if rf, ok := ret.Get(0).(func() *stock_quotes.CycleResult); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stock_quotes.CycleResult)
+		}
+	}
+
+	return r0
+}
+
+// MockStockQuoteService_LastCycle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastCycle'
+type MockStockQuoteService_LastCycle_Call struct {
+	*mock.Call
+}
+
+// LastCycle is a helper method to define mock.On call
+func (_e *MockStockQuoteService_Expecter) LastCycle() *MockStockQuoteService_LastCycle_Call {
+	return &MockStockQuoteService_LastCycle_Call{Call: _e.mock.On("LastCycle")}
+}
+
+func (_c *MockStockQuoteService_LastCycle_Call) Run(run func()) *MockStockQuoteService_LastCycle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteService_LastCycle_Call) Return(_a0 *stock_quotes.CycleResult) *MockStockQuoteService_LastCycle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStockQuoteService_LastCycle_Call) RunAndReturn(run func() *stock_quotes.CycleResult) *MockStockQuoteService_LastCycle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, symbol
+func (_m *MockStockQuoteService) List(ctx context.Context, symbol string) ([]models.StockQuote, error) {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.StockQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.StockQuote, error)); ok {
+		return rf(ctx, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.StockQuote); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockStockQuoteService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+func (_e *MockStockQuoteService_Expecter) List(ctx interface{}, symbol interface{}) *MockStockQuoteService_List_Call {
+	return &MockStockQuoteService_List_Call{Call: _e.mock.On("List", ctx, symbol)}
+}
+
+func (_c *MockStockQuoteService_List_Call) Run(run func(ctx context.Context, symbol string)) *MockStockQuoteService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteService_List_Call) Return(_a0 []models.StockQuote, _a1 error) *MockStockQuoteService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteService_List_Call) RunAndReturn(run func(context.Context, string) ([]models.StockQuote, error)) *MockStockQuoteService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecomputeIndicators provides a mock function with given fields: ctx, symbol
+func (_m *MockStockQuoteService) RecomputeIndicators(ctx context.Context, symbol string) (int, error) {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecomputeIndicators")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteService_RecomputeIndicators_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecomputeIndicators'
+type MockStockQuoteService_RecomputeIndicators_Call struct {
+	*mock.Call
+}
+
+// RecomputeIndicators is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+func (_e *MockStockQuoteService_Expecter) RecomputeIndicators(ctx interface{}, symbol interface{}) *MockStockQuoteService_RecomputeIndicators_Call {
+	return &MockStockQuoteService_RecomputeIndicators_Call{Call: _e.mock.On("RecomputeIndicators", ctx, symbol)}
+}
+
+func (_c *MockStockQuoteService_RecomputeIndicators_Call) Run(run func(ctx context.Context, symbol string)) *MockStockQuoteService_RecomputeIndicators_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteService_RecomputeIndicators_Call) Return(_a0 int, _a1 error) *MockStockQuoteService_RecomputeIndicators_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteService_RecomputeIndicators_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockStockQuoteService_RecomputeIndicators_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunOnce provides a mock function with given fields: ctx
+func (_m *MockStockQuoteService) RunOnce(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// MockStockQuoteService_RunOnce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunOnce'
+type MockStockQuoteService_RunOnce_Call struct {
+	*mock.Call
+}
+
+// RunOnce is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStockQuoteService_Expecter) RunOnce(ctx interface{}) *MockStockQuoteService_RunOnce_Call {
+	return &MockStockQuoteService_RunOnce_Call{Call: _e.mock.On("RunOnce", ctx)}
+}
+
+func (_c *MockStockQuoteService_RunOnce_Call) Run(run func(ctx context.Context)) *MockStockQuoteService_RunOnce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteService_RunOnce_Call) Return() *MockStockQuoteService_RunOnce_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStockQuoteService_RunOnce_Call) RunAndReturn(run func(context.Context)) *MockStockQuoteService_RunOnce_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SeedFromCandles provides a mock function with given fields: ctx, symbol, candles
+func (_m *MockStockQuoteService) SeedFromCandles(ctx context.Context, symbol string, candles []marketdata.Candle) (int, error) {
+	ret := _m.Called(ctx, symbol, candles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SeedFromCandles")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []marketdata.Candle) (int, error)); ok {
+		return rf(ctx, symbol, candles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []marketdata.Candle) int); ok {
+		r0 = rf(ctx, symbol, candles)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []marketdata.Candle) error); ok {
+		r1 = rf(ctx, symbol, candles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteService_SeedFromCandles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SeedFromCandles'
+type MockStockQuoteService_SeedFromCandles_Call struct {
+	*mock.Call
+}
+
+// SeedFromCandles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+//   - candles []marketdata.Candle
+func (_e *MockStockQuoteService_Expecter) SeedFromCandles(ctx interface{}, symbol interface{}, candles interface{}) *MockStockQuoteService_SeedFromCandles_Call {
+	return &MockStockQuoteService_SeedFromCandles_Call{Call: _e.mock.On("SeedFromCandles", ctx, symbol, candles)}
+}
+
+func (_c *MockStockQuoteService_SeedFromCandles_Call) Run(run func(ctx context.Context, symbol string, candles []marketdata.Candle)) *MockStockQuoteService_SeedFromCandles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]marketdata.Candle))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteService_SeedFromCandles_Call) Return(_a0 int, _a1 error) *MockStockQuoteService_SeedFromCandles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteService_SeedFromCandles_Call) RunAndReturn(run func(context.Context, string, []marketdata.Candle) (int, error)) *MockStockQuoteService_SeedFromCandles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *MockStockQuoteService) Start(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// MockStockQuoteService_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockStockQuoteService_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStockQuoteService_Expecter) Start(ctx interface{}) *MockStockQuoteService_Start_Call {
+	return &MockStockQuoteService_Start_Call{Call: _e.mock.On("Start", ctx)}
+}
+
+func (_c *MockStockQuoteService_Start_Call) Run(run func(ctx context.Context)) *MockStockQuoteService_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteService_Start_Call) Return() *MockStockQuoteService_Start_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStockQuoteService_Start_Call) RunAndReturn(run func(context.Context)) *MockStockQuoteService_Start_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Stop provides a mock function with no fields
+func (_m *MockStockQuoteService) Stop() {
+	_m.Called()
+}
+
+// MockStockQuoteService_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockStockQuoteService_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+func (_e *MockStockQuoteService_Expecter) Stop() *MockStockQuoteService_Stop_Call {
+	return &MockStockQuoteService_Stop_Call{Call: _e.mock.On("Stop")}
+}
+
+func (_c *MockStockQuoteService_Stop_Call) Run(run func()) *MockStockQuoteService_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteService_Stop_Call) Return() *MockStockQuoteService_Stop_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStockQuoteService_Stop_Call) RunAndReturn(run func()) *MockStockQuoteService_Stop_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockStockQuoteService creates a new instance of MockStockQuoteService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStockQuoteService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStockQuoteService {
+	mock := &MockStockQuoteService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,251 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockAlertEventRepository is an autogenerated mock type for the AlertEventRepository type
+type MockAlertEventRepository struct {
+	mock.Mock
+}
+
+type MockAlertEventRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAlertEventRepository) EXPECT() *MockAlertEventRepository_Expecter {
+	return &MockAlertEventRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: event
+func (_m *MockAlertEventRepository) Create(event *models.AlertEvent) error {
+	ret := _m.Called(event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AlertEvent) error); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAlertEventRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAlertEventRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - event *models.AlertEvent
+func (_e *MockAlertEventRepository_Expecter) Create(event interface{}) *MockAlertEventRepository_Create_Call {
+	return &MockAlertEventRepository_Create_Call{Call: _e.mock.On("Create", event)}
+}
+
+func (_c *MockAlertEventRepository_Create_Call) Run(run func(event *models.AlertEvent)) *MockAlertEventRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.AlertEvent))
+	})
+	return _c
+}
+
+func (_c *MockAlertEventRepository_Create_Call) Return(_a0 error) *MockAlertEventRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAlertEventRepository_Create_Call) RunAndReturn(run func(*models.AlertEvent) error) *MockAlertEventRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBefore provides a mock function with given fields: t
+func (_m *MockAlertEventRepository) DeleteBefore(t time.Time) error {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAlertEventRepository_DeleteBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBefore'
+type MockAlertEventRepository_DeleteBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteBefore is a helper method to define mock.On call
+//   - t time.Time
+func (_e *MockAlertEventRepository_Expecter) DeleteBefore(t interface{}) *MockAlertEventRepository_DeleteBefore_Call {
+	return &MockAlertEventRepository_DeleteBefore_Call{Call: _e.mock.On("DeleteBefore", t)}
+}
+
+func (_c *MockAlertEventRepository_DeleteBefore_Call) Run(run func(t time.Time)) *MockAlertEventRepository_DeleteBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAlertEventRepository_DeleteBefore_Call) Return(_a0 error) *MockAlertEventRepository_DeleteBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAlertEventRepository_DeleteBefore_Call) RunAndReturn(run func(time.Time) error) *MockAlertEventRepository_DeleteBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUser provides a mock function with given fields: userID, limit
+func (_m *MockAlertEventRepository) FindByUser(userID uuid.UUID, limit int) ([]models.AlertEvent, error) {
+	ret := _m.Called(userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUser")
+	}
+
+	var r0 []models.AlertEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int) ([]models.AlertEvent, error)); ok {
+		return rf(userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int) []models.AlertEvent); ok {
+		r0 = rf(userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AlertEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int) error); ok {
+		r1 = rf(userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAlertEventRepository_FindByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUser'
+type MockAlertEventRepository_FindByUser_Call struct {
+	*mock.Call
+}
+
+// FindByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - limit int
+func (_e *MockAlertEventRepository_Expecter) FindByUser(userID interface{}, limit interface{}) *MockAlertEventRepository_FindByUser_Call {
+	return &MockAlertEventRepository_FindByUser_Call{Call: _e.mock.On("FindByUser", userID, limit)}
+}
+
+func (_c *MockAlertEventRepository_FindByUser_Call) Run(run func(userID uuid.UUID, limit int)) *MockAlertEventRepository_FindByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockAlertEventRepository_FindByUser_Call) Return(_a0 []models.AlertEvent, _a1 error) *MockAlertEventRepository_FindByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAlertEventRepository_FindByUser_Call) RunAndReturn(run func(uuid.UUID, int) ([]models.AlertEvent, error)) *MockAlertEventRepository_FindByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUserAfter provides a mock function with given fields: userID, seq, limit
+func (_m *MockAlertEventRepository) FindByUserAfter(userID uuid.UUID, seq int64, limit int) ([]models.AlertEvent, error) {
+	ret := _m.Called(userID, seq, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUserAfter")
+	}
+
+	var r0 []models.AlertEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64, int) ([]models.AlertEvent, error)); ok {
+		return rf(userID, seq, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64, int) []models.AlertEvent); ok {
+		r0 = rf(userID, seq, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AlertEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int64, int) error); ok {
+		r1 = rf(userID, seq, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAlertEventRepository_FindByUserAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUserAfter'
+type MockAlertEventRepository_FindByUserAfter_Call struct {
+	*mock.Call
+}
+
+// FindByUserAfter is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - seq int64
+//   - limit int
+func (_e *MockAlertEventRepository_Expecter) FindByUserAfter(userID interface{}, seq interface{}, limit interface{}) *MockAlertEventRepository_FindByUserAfter_Call {
+	return &MockAlertEventRepository_FindByUserAfter_Call{Call: _e.mock.On("FindByUserAfter", userID, seq, limit)}
+}
+
+func (_c *MockAlertEventRepository_FindByUserAfter_Call) Run(run func(userID uuid.UUID, seq int64, limit int)) *MockAlertEventRepository_FindByUserAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockAlertEventRepository_FindByUserAfter_Call) Return(_a0 []models.AlertEvent, _a1 error) *MockAlertEventRepository_FindByUserAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAlertEventRepository_FindByUserAfter_Call) RunAndReturn(run func(uuid.UUID, int64, int) ([]models.AlertEvent, error)) *MockAlertEventRepository_FindByUserAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAlertEventRepository creates a new instance of MockAlertEventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAlertEventRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAlertEventRepository {
+	mock := &MockAlertEventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
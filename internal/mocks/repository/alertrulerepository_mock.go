@@ -0,0 +1,399 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockAlertRuleRepository is an autogenerated mock type for the AlertRuleRepository type
+type MockAlertRuleRepository struct {
+	mock.Mock
+}
+
+type MockAlertRuleRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAlertRuleRepository) EXPECT() *MockAlertRuleRepository_Expecter {
+	return &MockAlertRuleRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: rule
+func (_m *MockAlertRuleRepository) Create(rule *models.AlertRule) error {
+	ret := _m.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AlertRule) error); ok {
+		r0 = rf(rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAlertRuleRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAlertRuleRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - rule *models.AlertRule
+func (_e *MockAlertRuleRepository_Expecter) Create(rule interface{}) *MockAlertRuleRepository_Create_Call {
+	return &MockAlertRuleRepository_Create_Call{Call: _e.mock.On("Create", rule)}
+}
+
+func (_c *MockAlertRuleRepository_Create_Call) Run(run func(rule *models.AlertRule)) *MockAlertRuleRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.AlertRule))
+	})
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_Create_Call) Return(_a0 error) *MockAlertRuleRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_Create_Call) RunAndReturn(run func(*models.AlertRule) error) *MockAlertRuleRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockAlertRuleRepository) Delete(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAlertRuleRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockAlertRuleRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockAlertRuleRepository_Expecter) Delete(id interface{}) *MockAlertRuleRepository_Delete_Call {
+	return &MockAlertRuleRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockAlertRuleRepository_Delete_Call) Run(run func(id uuid.UUID)) *MockAlertRuleRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_Delete_Call) Return(_a0 error) *MockAlertRuleRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_Delete_Call) RunAndReturn(run func(uuid.UUID) error) *MockAlertRuleRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindActiveBySymbol provides a mock function with given fields: symbol
+func (_m *MockAlertRuleRepository) FindActiveBySymbol(symbol string) ([]models.AlertRule, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveBySymbol")
+	}
+
+	var r0 []models.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.AlertRule, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.AlertRule); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AlertRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAlertRuleRepository_FindActiveBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindActiveBySymbol'
+type MockAlertRuleRepository_FindActiveBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindActiveBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockAlertRuleRepository_Expecter) FindActiveBySymbol(symbol interface{}) *MockAlertRuleRepository_FindActiveBySymbol_Call {
+	return &MockAlertRuleRepository_FindActiveBySymbol_Call{Call: _e.mock.On("FindActiveBySymbol", symbol)}
+}
+
+func (_c *MockAlertRuleRepository_FindActiveBySymbol_Call) Run(run func(symbol string)) *MockAlertRuleRepository_FindActiveBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_FindActiveBySymbol_Call) Return(_a0 []models.AlertRule, _a1 error) *MockAlertRuleRepository_FindActiveBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_FindActiveBySymbol_Call) RunAndReturn(run func(string) ([]models.AlertRule, error)) *MockAlertRuleRepository_FindActiveBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: id
+func (_m *MockAlertRuleRepository) FindByID(id uuid.UUID) (*models.AlertRule, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.AlertRule, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.AlertRule); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AlertRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAlertRuleRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockAlertRuleRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockAlertRuleRepository_Expecter) FindByID(id interface{}) *MockAlertRuleRepository_FindByID_Call {
+	return &MockAlertRuleRepository_FindByID_Call{Call: _e.mock.On("FindByID", id)}
+}
+
+func (_c *MockAlertRuleRepository_FindByID_Call) Run(run func(id uuid.UUID)) *MockAlertRuleRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_FindByID_Call) Return(_a0 *models.AlertRule, _a1 error) *MockAlertRuleRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_FindByID_Call) RunAndReturn(run func(uuid.UUID) (*models.AlertRule, error)) *MockAlertRuleRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUser provides a mock function with given fields: userID
+func (_m *MockAlertRuleRepository) FindByUser(userID uuid.UUID) ([]models.AlertRule, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUser")
+	}
+
+	var r0 []models.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]models.AlertRule, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []models.AlertRule); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AlertRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAlertRuleRepository_FindByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUser'
+type MockAlertRuleRepository_FindByUser_Call struct {
+	*mock.Call
+}
+
+// FindByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *MockAlertRuleRepository_Expecter) FindByUser(userID interface{}) *MockAlertRuleRepository_FindByUser_Call {
+	return &MockAlertRuleRepository_FindByUser_Call{Call: _e.mock.On("FindByUser", userID)}
+}
+
+func (_c *MockAlertRuleRepository_FindByUser_Call) Run(run func(userID uuid.UUID)) *MockAlertRuleRepository_FindByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_FindByUser_Call) Return(_a0 []models.AlertRule, _a1 error) *MockAlertRuleRepository_FindByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_FindByUser_Call) RunAndReturn(run func(uuid.UUID) ([]models.AlertRule, error)) *MockAlertRuleRepository_FindByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkTriggered provides a mock function with given fields: id, at
+func (_m *MockAlertRuleRepository) MarkTriggered(id uuid.UUID, at time.Time) error {
+	ret := _m.Called(id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkTriggered")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) error); ok {
+		r0 = rf(id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAlertRuleRepository_MarkTriggered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkTriggered'
+type MockAlertRuleRepository_MarkTriggered_Call struct {
+	*mock.Call
+}
+
+// MarkTriggered is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - at time.Time
+func (_e *MockAlertRuleRepository_Expecter) MarkTriggered(id interface{}, at interface{}) *MockAlertRuleRepository_MarkTriggered_Call {
+	return &MockAlertRuleRepository_MarkTriggered_Call{Call: _e.mock.On("MarkTriggered", id, at)}
+}
+
+func (_c *MockAlertRuleRepository_MarkTriggered_Call) Run(run func(id uuid.UUID, at time.Time)) *MockAlertRuleRepository_MarkTriggered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_MarkTriggered_Call) Return(_a0 error) *MockAlertRuleRepository_MarkTriggered_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_MarkTriggered_Call) RunAndReturn(run func(uuid.UUID, time.Time) error) *MockAlertRuleRepository_MarkTriggered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: rule
+func (_m *MockAlertRuleRepository) Update(rule *models.AlertRule) error {
+	ret := _m.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AlertRule) error); ok {
+		r0 = rf(rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAlertRuleRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockAlertRuleRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - rule *models.AlertRule
+func (_e *MockAlertRuleRepository_Expecter) Update(rule interface{}) *MockAlertRuleRepository_Update_Call {
+	return &MockAlertRuleRepository_Update_Call{Call: _e.mock.On("Update", rule)}
+}
+
+func (_c *MockAlertRuleRepository_Update_Call) Run(run func(rule *models.AlertRule)) *MockAlertRuleRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.AlertRule))
+	})
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_Update_Call) Return(_a0 error) *MockAlertRuleRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAlertRuleRepository_Update_Call) RunAndReturn(run func(*models.AlertRule) error) *MockAlertRuleRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAlertRuleRepository creates a new instance of MockAlertRuleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAlertRuleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAlertRuleRepository {
+	mock := &MockAlertRuleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
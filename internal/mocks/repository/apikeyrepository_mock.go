@@ -0,0 +1,309 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockAPIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+type MockAPIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepository_Expecter {
+	return &MockAPIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: key
+func (_m *MockAPIKeyRepository) Create(key *models.APIKey) error {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.APIKey) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAPIKeyRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAPIKeyRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - key *models.APIKey
+func (_e *MockAPIKeyRepository_Expecter) Create(key interface{}) *MockAPIKeyRepository_Create_Call {
+	return &MockAPIKeyRepository_Create_Call{Call: _e.mock.On("Create", key)}
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) Run(run func(key *models.APIKey)) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.APIKey))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) Return(_a0 error) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) RunAndReturn(run func(*models.APIKey) error) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindActiveByUser provides a mock function with given fields: userID, now
+func (_m *MockAPIKeyRepository) FindActiveByUser(userID uuid.UUID, now time.Time) ([]models.APIKey, error) {
+	ret := _m.Called(userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByUser")
+	}
+
+	var r0 []models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) ([]models.APIKey, error)); ok {
+		return rf(userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) []models.APIKey); ok {
+		r0 = rf(userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time) error); ok {
+		r1 = rf(userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_FindActiveByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindActiveByUser'
+type MockAPIKeyRepository_FindActiveByUser_Call struct {
+	*mock.Call
+}
+
+// FindActiveByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - now time.Time
+func (_e *MockAPIKeyRepository_Expecter) FindActiveByUser(userID interface{}, now interface{}) *MockAPIKeyRepository_FindActiveByUser_Call {
+	return &MockAPIKeyRepository_FindActiveByUser_Call{Call: _e.mock.On("FindActiveByUser", userID, now)}
+}
+
+func (_c *MockAPIKeyRepository_FindActiveByUser_Call) Run(run func(userID uuid.UUID, now time.Time)) *MockAPIKeyRepository_FindActiveByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_FindActiveByUser_Call) Return(_a0 []models.APIKey, _a1 error) *MockAPIKeyRepository_FindActiveByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_FindActiveByUser_Call) RunAndReturn(run func(uuid.UUID, time.Time) ([]models.APIKey, error)) *MockAPIKeyRepository_FindActiveByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByHash provides a mock function with given fields: hash
+func (_m *MockAPIKeyRepository) FindByHash(hash string) (*models.APIKey, error) {
+	ret := _m.Called(hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByHash")
+	}
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.APIKey, error)); ok {
+		return rf(hash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.APIKey); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_FindByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByHash'
+type MockAPIKeyRepository_FindByHash_Call struct {
+	*mock.Call
+}
+
+// FindByHash is a helper method to define mock.On call
+//   - hash string
+func (_e *MockAPIKeyRepository_Expecter) FindByHash(hash interface{}) *MockAPIKeyRepository_FindByHash_Call {
+	return &MockAPIKeyRepository_FindByHash_Call{Call: _e.mock.On("FindByHash", hash)}
+}
+
+func (_c *MockAPIKeyRepository_FindByHash_Call) Run(run func(hash string)) *MockAPIKeyRepository_FindByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_FindByHash_Call) Return(_a0 *models.APIKey, _a1 error) *MockAPIKeyRepository_FindByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_FindByHash_Call) RunAndReturn(run func(string) (*models.APIKey, error)) *MockAPIKeyRepository_FindByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: userID, id, revokedAt
+func (_m *MockAPIKeyRepository) Revoke(userID uuid.UUID, id uuid.UUID, revokedAt time.Time) (bool, error) {
+	ret := _m.Called(userID, id, revokedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, time.Time) (bool, error)); ok {
+		return rf(userID, id, revokedAt)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, time.Time) bool); ok {
+		r0 = rf(userID, id, revokedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(userID, id, revokedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockAPIKeyRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - id uuid.UUID
+//   - revokedAt time.Time
+func (_e *MockAPIKeyRepository_Expecter) Revoke(userID interface{}, id interface{}, revokedAt interface{}) *MockAPIKeyRepository_Revoke_Call {
+	return &MockAPIKeyRepository_Revoke_Call{Call: _e.mock.On("Revoke", userID, id, revokedAt)}
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) Run(run func(userID uuid.UUID, id uuid.UUID, revokedAt time.Time)) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) Return(_a0 bool, _a1 error) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) RunAndReturn(run func(uuid.UUID, uuid.UUID, time.Time) (bool, error)) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchLastUsed provides a mock function with given fields: id, usedAt, notBefore
+func (_m *MockAPIKeyRepository) TouchLastUsed(id uuid.UUID, usedAt time.Time, notBefore time.Time) error {
+	ret := _m.Called(id, usedAt, notBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time, time.Time) error); ok {
+		r0 = rf(id, usedAt, notBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAPIKeyRepository_TouchLastUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchLastUsed'
+type MockAPIKeyRepository_TouchLastUsed_Call struct {
+	*mock.Call
+}
+
+// TouchLastUsed is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - usedAt time.Time
+//   - notBefore time.Time
+func (_e *MockAPIKeyRepository_Expecter) TouchLastUsed(id interface{}, usedAt interface{}, notBefore interface{}) *MockAPIKeyRepository_TouchLastUsed_Call {
+	return &MockAPIKeyRepository_TouchLastUsed_Call{Call: _e.mock.On("TouchLastUsed", id, usedAt, notBefore)}
+}
+
+func (_c *MockAPIKeyRepository_TouchLastUsed_Call) Run(run func(id uuid.UUID, usedAt time.Time, notBefore time.Time)) *MockAPIKeyRepository_TouchLastUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_TouchLastUsed_Call) Return(_a0 error) *MockAPIKeyRepository_TouchLastUsed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_TouchLastUsed_Call) RunAndReturn(run func(uuid.UUID, time.Time, time.Time) error) *MockAPIKeyRepository_TouchLastUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAPIKeyRepository creates a new instance of MockAPIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
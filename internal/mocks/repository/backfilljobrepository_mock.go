@@ -0,0 +1,579 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockBackfillJobRepository is an autogenerated mock type for the BackfillJobRepository type
+type MockBackfillJobRepository struct {
+	mock.Mock
+}
+
+type MockBackfillJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBackfillJobRepository) EXPECT() *MockBackfillJobRepository_Expecter {
+	return &MockBackfillJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimNext provides a mock function with given fields: startedAt
+func (_m *MockBackfillJobRepository) ClaimNext(startedAt time.Time) (*models.BackfillJob, error) {
+	ret := _m.Called(startedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimNext")
+	}
+
+	var r0 *models.BackfillJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (*models.BackfillJob, error)); ok {
+		return rf(startedAt)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) *models.BackfillJob); ok {
+		r0 = rf(startedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BackfillJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(startedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillJobRepository_ClaimNext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimNext'
+type MockBackfillJobRepository_ClaimNext_Call struct {
+	*mock.Call
+}
+
+// ClaimNext is a helper method to define mock.On call
+//   - startedAt time.Time
+func (_e *MockBackfillJobRepository_Expecter) ClaimNext(startedAt interface{}) *MockBackfillJobRepository_ClaimNext_Call {
+	return &MockBackfillJobRepository_ClaimNext_Call{Call: _e.mock.On("ClaimNext", startedAt)}
+}
+
+func (_c *MockBackfillJobRepository_ClaimNext_Call) Run(run func(startedAt time.Time)) *MockBackfillJobRepository_ClaimNext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_ClaimNext_Call) Return(_a0 *models.BackfillJob, _a1 error) *MockBackfillJobRepository_ClaimNext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_ClaimNext_Call) RunAndReturn(run func(time.Time) (*models.BackfillJob, error)) *MockBackfillJobRepository_ClaimNext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountQueued provides a mock function with no fields
+func (_m *MockBackfillJobRepository) CountQueued() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountQueued")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillJobRepository_CountQueued_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountQueued'
+type MockBackfillJobRepository_CountQueued_Call struct {
+	*mock.Call
+}
+
+// CountQueued is a helper method to define mock.On call
+func (_e *MockBackfillJobRepository_Expecter) CountQueued() *MockBackfillJobRepository_CountQueued_Call {
+	return &MockBackfillJobRepository_CountQueued_Call{Call: _e.mock.On("CountQueued")}
+}
+
+func (_c *MockBackfillJobRepository_CountQueued_Call) Run(run func()) *MockBackfillJobRepository_CountQueued_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_CountQueued_Call) Return(_a0 int64, _a1 error) *MockBackfillJobRepository_CountQueued_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_CountQueued_Call) RunAndReturn(run func() (int64, error)) *MockBackfillJobRepository_CountQueued_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: job
+func (_m *MockBackfillJobRepository) Create(job *models.BackfillJob) error {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.BackfillJob) error); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBackfillJobRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockBackfillJobRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - job *models.BackfillJob
+func (_e *MockBackfillJobRepository_Expecter) Create(job interface{}) *MockBackfillJobRepository_Create_Call {
+	return &MockBackfillJobRepository_Create_Call{Call: _e.mock.On("Create", job)}
+}
+
+func (_c *MockBackfillJobRepository_Create_Call) Run(run func(job *models.BackfillJob)) *MockBackfillJobRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.BackfillJob))
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_Create_Call) Return(_a0 error) *MockBackfillJobRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_Create_Call) RunAndReturn(run func(*models.BackfillJob) error) *MockBackfillJobRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFinishedBefore provides a mock function with given fields: t
+func (_m *MockBackfillJobRepository) DeleteFinishedBefore(t time.Time) error {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFinishedBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBackfillJobRepository_DeleteFinishedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFinishedBefore'
+type MockBackfillJobRepository_DeleteFinishedBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteFinishedBefore is a helper method to define mock.On call
+//   - t time.Time
+func (_e *MockBackfillJobRepository_Expecter) DeleteFinishedBefore(t interface{}) *MockBackfillJobRepository_DeleteFinishedBefore_Call {
+	return &MockBackfillJobRepository_DeleteFinishedBefore_Call{Call: _e.mock.On("DeleteFinishedBefore", t)}
+}
+
+func (_c *MockBackfillJobRepository_DeleteFinishedBefore_Call) Run(run func(t time.Time)) *MockBackfillJobRepository_DeleteFinishedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_DeleteFinishedBefore_Call) Return(_a0 error) *MockBackfillJobRepository_DeleteFinishedBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_DeleteFinishedBefore_Call) RunAndReturn(run func(time.Time) error) *MockBackfillJobRepository_DeleteFinishedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailStale provides a mock function with given fields: cutoff, finishedAt, reason
+func (_m *MockBackfillJobRepository) FailStale(cutoff time.Time, finishedAt time.Time, reason string) (int64, error) {
+	ret := _m.Called(cutoff, finishedAt, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailStale")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) (int64, error)); ok {
+		return rf(cutoff, finishedAt, reason)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) int64); ok {
+		r0 = rf(cutoff, finishedAt, reason)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string) error); ok {
+		r1 = rf(cutoff, finishedAt, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillJobRepository_FailStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailStale'
+type MockBackfillJobRepository_FailStale_Call struct {
+	*mock.Call
+}
+
+// FailStale is a helper method to define mock.On call
+//   - cutoff time.Time
+//   - finishedAt time.Time
+//   - reason string
+func (_e *MockBackfillJobRepository_Expecter) FailStale(cutoff interface{}, finishedAt interface{}, reason interface{}) *MockBackfillJobRepository_FailStale_Call {
+	return &MockBackfillJobRepository_FailStale_Call{Call: _e.mock.On("FailStale", cutoff, finishedAt, reason)}
+}
+
+func (_c *MockBackfillJobRepository_FailStale_Call) Run(run func(cutoff time.Time, finishedAt time.Time, reason string)) *MockBackfillJobRepository_FailStale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(time.Time), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_FailStale_Call) Return(_a0 int64, _a1 error) *MockBackfillJobRepository_FailStale_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_FailStale_Call) RunAndReturn(run func(time.Time, time.Time, string) (int64, error)) *MockBackfillJobRepository_FailStale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: id
+func (_m *MockBackfillJobRepository) FindByID(id uuid.UUID) (*models.BackfillJob, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.BackfillJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.BackfillJob, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.BackfillJob); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BackfillJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillJobRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockBackfillJobRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockBackfillJobRepository_Expecter) FindByID(id interface{}) *MockBackfillJobRepository_FindByID_Call {
+	return &MockBackfillJobRepository_FindByID_Call{Call: _e.mock.On("FindByID", id)}
+}
+
+func (_c *MockBackfillJobRepository_FindByID_Call) Run(run func(id uuid.UUID)) *MockBackfillJobRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_FindByID_Call) Return(_a0 *models.BackfillJob, _a1 error) *MockBackfillJobRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_FindByID_Call) RunAndReturn(run func(uuid.UUID) (*models.BackfillJob, error)) *MockBackfillJobRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPendingBySymbol provides a mock function with given fields: symbol
+func (_m *MockBackfillJobRepository) FindPendingBySymbol(symbol string) (*models.BackfillJob, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPendingBySymbol")
+	}
+
+	var r0 *models.BackfillJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.BackfillJob, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.BackfillJob); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BackfillJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillJobRepository_FindPendingBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPendingBySymbol'
+type MockBackfillJobRepository_FindPendingBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindPendingBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockBackfillJobRepository_Expecter) FindPendingBySymbol(symbol interface{}) *MockBackfillJobRepository_FindPendingBySymbol_Call {
+	return &MockBackfillJobRepository_FindPendingBySymbol_Call{Call: _e.mock.On("FindPendingBySymbol", symbol)}
+}
+
+func (_c *MockBackfillJobRepository_FindPendingBySymbol_Call) Run(run func(symbol string)) *MockBackfillJobRepository_FindPendingBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_FindPendingBySymbol_Call) Return(_a0 *models.BackfillJob, _a1 error) *MockBackfillJobRepository_FindPendingBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_FindPendingBySymbol_Call) RunAndReturn(run func(string) (*models.BackfillJob, error)) *MockBackfillJobRepository_FindPendingBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecent provides a mock function with given fields: limit
+func (_m *MockBackfillJobRepository) FindRecent(limit int) ([]models.BackfillJob, error) {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecent")
+	}
+
+	var r0 []models.BackfillJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.BackfillJob, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.BackfillJob); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BackfillJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillJobRepository_FindRecent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecent'
+type MockBackfillJobRepository_FindRecent_Call struct {
+	*mock.Call
+}
+
+// FindRecent is a helper method to define mock.On call
+//   - limit int
+func (_e *MockBackfillJobRepository_Expecter) FindRecent(limit interface{}) *MockBackfillJobRepository_FindRecent_Call {
+	return &MockBackfillJobRepository_FindRecent_Call{Call: _e.mock.On("FindRecent", limit)}
+}
+
+func (_c *MockBackfillJobRepository_FindRecent_Call) Run(run func(limit int)) *MockBackfillJobRepository_FindRecent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_FindRecent_Call) Return(_a0 []models.BackfillJob, _a1 error) *MockBackfillJobRepository_FindRecent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_FindRecent_Call) RunAndReturn(run func(int) ([]models.BackfillJob, error)) *MockBackfillJobRepository_FindRecent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Finish provides a mock function with given fields: job
+func (_m *MockBackfillJobRepository) Finish(job *models.BackfillJob) (bool, error) {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Finish")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.BackfillJob) (bool, error)); ok {
+		return rf(job)
+	}
+	if rf, ok := ret.Get(0).(func(*models.BackfillJob) bool); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.BackfillJob) error); ok {
+		r1 = rf(job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackfillJobRepository_Finish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Finish'
+type MockBackfillJobRepository_Finish_Call struct {
+	*mock.Call
+}
+
+// Finish is a helper method to define mock.On call
+//   - job *models.BackfillJob
+func (_e *MockBackfillJobRepository_Expecter) Finish(job interface{}) *MockBackfillJobRepository_Finish_Call {
+	return &MockBackfillJobRepository_Finish_Call{Call: _e.mock.On("Finish", job)}
+}
+
+func (_c *MockBackfillJobRepository_Finish_Call) Run(run func(job *models.BackfillJob)) *MockBackfillJobRepository_Finish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.BackfillJob))
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_Finish_Call) Return(_a0 bool, _a1 error) *MockBackfillJobRepository_Finish_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_Finish_Call) RunAndReturn(run func(*models.BackfillJob) (bool, error)) *MockBackfillJobRepository_Finish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProgress provides a mock function with given fields: job
+func (_m *MockBackfillJobRepository) UpdateProgress(job *models.BackfillJob) error {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.BackfillJob) error); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBackfillJobRepository_UpdateProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProgress'
+type MockBackfillJobRepository_UpdateProgress_Call struct {
+	*mock.Call
+}
+
+// UpdateProgress is a helper method to define mock.On call
+//   - job *models.BackfillJob
+func (_e *MockBackfillJobRepository_Expecter) UpdateProgress(job interface{}) *MockBackfillJobRepository_UpdateProgress_Call {
+	return &MockBackfillJobRepository_UpdateProgress_Call{Call: _e.mock.On("UpdateProgress", job)}
+}
+
+func (_c *MockBackfillJobRepository_UpdateProgress_Call) Run(run func(job *models.BackfillJob)) *MockBackfillJobRepository_UpdateProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.BackfillJob))
+	})
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_UpdateProgress_Call) Return(_a0 error) *MockBackfillJobRepository_UpdateProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBackfillJobRepository_UpdateProgress_Call) RunAndReturn(run func(*models.BackfillJob) error) *MockBackfillJobRepository_UpdateProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBackfillJobRepository creates a new instance of MockBackfillJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBackfillJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBackfillJobRepository {
+	mock := &MockBackfillJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
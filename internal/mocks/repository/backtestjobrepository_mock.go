@@ -0,0 +1,590 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockBacktestJobRepository is an autogenerated mock type for the BacktestJobRepository type
+type MockBacktestJobRepository struct {
+	mock.Mock
+}
+
+type MockBacktestJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBacktestJobRepository) EXPECT() *MockBacktestJobRepository_Expecter {
+	return &MockBacktestJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// Cancel provides a mock function with given fields: userID, id, finishedAt
+func (_m *MockBacktestJobRepository) Cancel(userID uuid.UUID, id uuid.UUID, finishedAt time.Time) (bool, error) {
+	ret := _m.Called(userID, id, finishedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, time.Time) (bool, error)); ok {
+		return rf(userID, id, finishedAt)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, time.Time) bool); ok {
+		r0 = rf(userID, id, finishedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(userID, id, finishedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBacktestJobRepository_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockBacktestJobRepository_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - id uuid.UUID
+//   - finishedAt time.Time
+func (_e *MockBacktestJobRepository_Expecter) Cancel(userID interface{}, id interface{}, finishedAt interface{}) *MockBacktestJobRepository_Cancel_Call {
+	return &MockBacktestJobRepository_Cancel_Call{Call: _e.mock.On("Cancel", userID, id, finishedAt)}
+}
+
+func (_c *MockBacktestJobRepository_Cancel_Call) Run(run func(userID uuid.UUID, id uuid.UUID, finishedAt time.Time)) *MockBacktestJobRepository_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_Cancel_Call) Return(_a0 bool, _a1 error) *MockBacktestJobRepository_Cancel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_Cancel_Call) RunAndReturn(run func(uuid.UUID, uuid.UUID, time.Time) (bool, error)) *MockBacktestJobRepository_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimNext provides a mock function with given fields: startedAt
+func (_m *MockBacktestJobRepository) ClaimNext(startedAt time.Time) (*models.BacktestJob, error) {
+	ret := _m.Called(startedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimNext")
+	}
+
+	var r0 *models.BacktestJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (*models.BacktestJob, error)); ok {
+		return rf(startedAt)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) *models.BacktestJob); ok {
+		r0 = rf(startedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BacktestJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(startedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBacktestJobRepository_ClaimNext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimNext'
+type MockBacktestJobRepository_ClaimNext_Call struct {
+	*mock.Call
+}
+
+// ClaimNext is a helper method to define mock.On call
+//   - startedAt time.Time
+func (_e *MockBacktestJobRepository_Expecter) ClaimNext(startedAt interface{}) *MockBacktestJobRepository_ClaimNext_Call {
+	return &MockBacktestJobRepository_ClaimNext_Call{Call: _e.mock.On("ClaimNext", startedAt)}
+}
+
+func (_c *MockBacktestJobRepository_ClaimNext_Call) Run(run func(startedAt time.Time)) *MockBacktestJobRepository_ClaimNext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_ClaimNext_Call) Return(_a0 *models.BacktestJob, _a1 error) *MockBacktestJobRepository_ClaimNext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_ClaimNext_Call) RunAndReturn(run func(time.Time) (*models.BacktestJob, error)) *MockBacktestJobRepository_ClaimNext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountPendingByUser provides a mock function with given fields: userID
+func (_m *MockBacktestJobRepository) CountPendingByUser(userID uuid.UUID) (int64, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPendingByUser")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBacktestJobRepository_CountPendingByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountPendingByUser'
+type MockBacktestJobRepository_CountPendingByUser_Call struct {
+	*mock.Call
+}
+
+// CountPendingByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *MockBacktestJobRepository_Expecter) CountPendingByUser(userID interface{}) *MockBacktestJobRepository_CountPendingByUser_Call {
+	return &MockBacktestJobRepository_CountPendingByUser_Call{Call: _e.mock.On("CountPendingByUser", userID)}
+}
+
+func (_c *MockBacktestJobRepository_CountPendingByUser_Call) Run(run func(userID uuid.UUID)) *MockBacktestJobRepository_CountPendingByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_CountPendingByUser_Call) Return(_a0 int64, _a1 error) *MockBacktestJobRepository_CountPendingByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_CountPendingByUser_Call) RunAndReturn(run func(uuid.UUID) (int64, error)) *MockBacktestJobRepository_CountPendingByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountQueued provides a mock function with no fields
+func (_m *MockBacktestJobRepository) CountQueued() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountQueued")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBacktestJobRepository_CountQueued_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountQueued'
+type MockBacktestJobRepository_CountQueued_Call struct {
+	*mock.Call
+}
+
+// CountQueued is a helper method to define mock.On call
+func (_e *MockBacktestJobRepository_Expecter) CountQueued() *MockBacktestJobRepository_CountQueued_Call {
+	return &MockBacktestJobRepository_CountQueued_Call{Call: _e.mock.On("CountQueued")}
+}
+
+func (_c *MockBacktestJobRepository_CountQueued_Call) Run(run func()) *MockBacktestJobRepository_CountQueued_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_CountQueued_Call) Return(_a0 int64, _a1 error) *MockBacktestJobRepository_CountQueued_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_CountQueued_Call) RunAndReturn(run func() (int64, error)) *MockBacktestJobRepository_CountQueued_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: job
+func (_m *MockBacktestJobRepository) Create(job *models.BacktestJob) error {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.BacktestJob) error); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBacktestJobRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockBacktestJobRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - job *models.BacktestJob
+func (_e *MockBacktestJobRepository_Expecter) Create(job interface{}) *MockBacktestJobRepository_Create_Call {
+	return &MockBacktestJobRepository_Create_Call{Call: _e.mock.On("Create", job)}
+}
+
+func (_c *MockBacktestJobRepository_Create_Call) Run(run func(job *models.BacktestJob)) *MockBacktestJobRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.BacktestJob))
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_Create_Call) Return(_a0 error) *MockBacktestJobRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_Create_Call) RunAndReturn(run func(*models.BacktestJob) error) *MockBacktestJobRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFinishedBefore provides a mock function with given fields: t
+func (_m *MockBacktestJobRepository) DeleteFinishedBefore(t time.Time) error {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFinishedBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBacktestJobRepository_DeleteFinishedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFinishedBefore'
+type MockBacktestJobRepository_DeleteFinishedBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteFinishedBefore is a helper method to define mock.On call
+//   - t time.Time
+func (_e *MockBacktestJobRepository_Expecter) DeleteFinishedBefore(t interface{}) *MockBacktestJobRepository_DeleteFinishedBefore_Call {
+	return &MockBacktestJobRepository_DeleteFinishedBefore_Call{Call: _e.mock.On("DeleteFinishedBefore", t)}
+}
+
+func (_c *MockBacktestJobRepository_DeleteFinishedBefore_Call) Run(run func(t time.Time)) *MockBacktestJobRepository_DeleteFinishedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_DeleteFinishedBefore_Call) Return(_a0 error) *MockBacktestJobRepository_DeleteFinishedBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_DeleteFinishedBefore_Call) RunAndReturn(run func(time.Time) error) *MockBacktestJobRepository_DeleteFinishedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailStale provides a mock function with given fields: cutoff, finishedAt, reason
+func (_m *MockBacktestJobRepository) FailStale(cutoff time.Time, finishedAt time.Time, reason string) (int64, error) {
+	ret := _m.Called(cutoff, finishedAt, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailStale")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) (int64, error)); ok {
+		return rf(cutoff, finishedAt, reason)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) int64); ok {
+		r0 = rf(cutoff, finishedAt, reason)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string) error); ok {
+		r1 = rf(cutoff, finishedAt, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBacktestJobRepository_FailStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailStale'
+type MockBacktestJobRepository_FailStale_Call struct {
+	*mock.Call
+}
+
+// FailStale is a helper method to define mock.On call
+//   - cutoff time.Time
+//   - finishedAt time.Time
+//   - reason string
+func (_e *MockBacktestJobRepository_Expecter) FailStale(cutoff interface{}, finishedAt interface{}, reason interface{}) *MockBacktestJobRepository_FailStale_Call {
+	return &MockBacktestJobRepository_FailStale_Call{Call: _e.mock.On("FailStale", cutoff, finishedAt, reason)}
+}
+
+func (_c *MockBacktestJobRepository_FailStale_Call) Run(run func(cutoff time.Time, finishedAt time.Time, reason string)) *MockBacktestJobRepository_FailStale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(time.Time), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_FailStale_Call) Return(_a0 int64, _a1 error) *MockBacktestJobRepository_FailStale_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_FailStale_Call) RunAndReturn(run func(time.Time, time.Time, string) (int64, error)) *MockBacktestJobRepository_FailStale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: id
+func (_m *MockBacktestJobRepository) FindByID(id uuid.UUID) (*models.BacktestJob, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.BacktestJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.BacktestJob, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.BacktestJob); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BacktestJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBacktestJobRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockBacktestJobRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockBacktestJobRepository_Expecter) FindByID(id interface{}) *MockBacktestJobRepository_FindByID_Call {
+	return &MockBacktestJobRepository_FindByID_Call{Call: _e.mock.On("FindByID", id)}
+}
+
+func (_c *MockBacktestJobRepository_FindByID_Call) Run(run func(id uuid.UUID)) *MockBacktestJobRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_FindByID_Call) Return(_a0 *models.BacktestJob, _a1 error) *MockBacktestJobRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_FindByID_Call) RunAndReturn(run func(uuid.UUID) (*models.BacktestJob, error)) *MockBacktestJobRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUser provides a mock function with given fields: userID, limit
+func (_m *MockBacktestJobRepository) FindByUser(userID uuid.UUID, limit int) ([]models.BacktestJob, error) {
+	ret := _m.Called(userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUser")
+	}
+
+	var r0 []models.BacktestJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int) ([]models.BacktestJob, error)); ok {
+		return rf(userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int) []models.BacktestJob); ok {
+		r0 = rf(userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BacktestJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int) error); ok {
+		r1 = rf(userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBacktestJobRepository_FindByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUser'
+type MockBacktestJobRepository_FindByUser_Call struct {
+	*mock.Call
+}
+
+// FindByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - limit int
+func (_e *MockBacktestJobRepository_Expecter) FindByUser(userID interface{}, limit interface{}) *MockBacktestJobRepository_FindByUser_Call {
+	return &MockBacktestJobRepository_FindByUser_Call{Call: _e.mock.On("FindByUser", userID, limit)}
+}
+
+func (_c *MockBacktestJobRepository_FindByUser_Call) Run(run func(userID uuid.UUID, limit int)) *MockBacktestJobRepository_FindByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_FindByUser_Call) Return(_a0 []models.BacktestJob, _a1 error) *MockBacktestJobRepository_FindByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_FindByUser_Call) RunAndReturn(run func(uuid.UUID, int) ([]models.BacktestJob, error)) *MockBacktestJobRepository_FindByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Finish provides a mock function with given fields: job
+func (_m *MockBacktestJobRepository) Finish(job *models.BacktestJob) (bool, error) {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Finish")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.BacktestJob) (bool, error)); ok {
+		return rf(job)
+	}
+	if rf, ok := ret.Get(0).(func(*models.BacktestJob) bool); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.BacktestJob) error); ok {
+		r1 = rf(job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBacktestJobRepository_Finish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Finish'
+type MockBacktestJobRepository_Finish_Call struct {
+	*mock.Call
+}
+
+// Finish is a helper method to define mock.On call
+//   - job *models.BacktestJob
+func (_e *MockBacktestJobRepository_Expecter) Finish(job interface{}) *MockBacktestJobRepository_Finish_Call {
+	return &MockBacktestJobRepository_Finish_Call{Call: _e.mock.On("Finish", job)}
+}
+
+func (_c *MockBacktestJobRepository_Finish_Call) Run(run func(job *models.BacktestJob)) *MockBacktestJobRepository_Finish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.BacktestJob))
+	})
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_Finish_Call) Return(_a0 bool, _a1 error) *MockBacktestJobRepository_Finish_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBacktestJobRepository_Finish_Call) RunAndReturn(run func(*models.BacktestJob) (bool, error)) *MockBacktestJobRepository_Finish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBacktestJobRepository creates a new instance of MockBacktestJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBacktestJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBacktestJobRepository {
+	mock := &MockBacktestJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
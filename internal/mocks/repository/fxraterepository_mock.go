@@ -0,0 +1,203 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockFXRateRepository is an autogenerated mock type for the FXRateRepository type
+type MockFXRateRepository struct {
+	mock.Mock
+}
+
+type MockFXRateRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFXRateRepository) EXPECT() *MockFXRateRepository_Expecter {
+	return &MockFXRateRepository_Expecter{mock: &_m.Mock}
+}
+
+// FindBetween provides a mock function with given fields: base, start, end
+func (_m *MockFXRateRepository) FindBetween(base string, start time.Time, end time.Time) ([]models.FXRate, error) {
+	ret := _m.Called(base, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBetween")
+	}
+
+	var r0 []models.FXRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) ([]models.FXRate, error)); ok {
+		return rf(base, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) []models.FXRate); ok {
+		r0 = rf(base, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FXRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(base, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFXRateRepository_FindBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBetween'
+type MockFXRateRepository_FindBetween_Call struct {
+	*mock.Call
+}
+
+// FindBetween is a helper method to define mock.On call
+//   - base string
+//   - start time.Time
+//   - end time.Time
+func (_e *MockFXRateRepository_Expecter) FindBetween(base interface{}, start interface{}, end interface{}) *MockFXRateRepository_FindBetween_Call {
+	return &MockFXRateRepository_FindBetween_Call{Call: _e.mock.On("FindBetween", base, start, end)}
+}
+
+func (_c *MockFXRateRepository_FindBetween_Call) Run(run func(base string, start time.Time, end time.Time)) *MockFXRateRepository_FindBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockFXRateRepository_FindBetween_Call) Return(_a0 []models.FXRate, _a1 error) *MockFXRateRepository_FindBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFXRateRepository_FindBetween_Call) RunAndReturn(run func(string, time.Time, time.Time) ([]models.FXRate, error)) *MockFXRateRepository_FindBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindLatest provides a mock function with given fields: base, onOrBefore
+func (_m *MockFXRateRepository) FindLatest(base string, onOrBefore time.Time) ([]models.FXRate, error) {
+	ret := _m.Called(base, onOrBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatest")
+	}
+
+	var r0 []models.FXRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) ([]models.FXRate, error)); ok {
+		return rf(base, onOrBefore)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) []models.FXRate); ok {
+		r0 = rf(base, onOrBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FXRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(base, onOrBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFXRateRepository_FindLatest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindLatest'
+type MockFXRateRepository_FindLatest_Call struct {
+	*mock.Call
+}
+
+// FindLatest is a helper method to define mock.On call
+//   - base string
+//   - onOrBefore time.Time
+func (_e *MockFXRateRepository_Expecter) FindLatest(base interface{}, onOrBefore interface{}) *MockFXRateRepository_FindLatest_Call {
+	return &MockFXRateRepository_FindLatest_Call{Call: _e.mock.On("FindLatest", base, onOrBefore)}
+}
+
+func (_c *MockFXRateRepository_FindLatest_Call) Run(run func(base string, onOrBefore time.Time)) *MockFXRateRepository_FindLatest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockFXRateRepository_FindLatest_Call) Return(_a0 []models.FXRate, _a1 error) *MockFXRateRepository_FindLatest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFXRateRepository_FindLatest_Call) RunAndReturn(run func(string, time.Time) ([]models.FXRate, error)) *MockFXRateRepository_FindLatest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: rates
+func (_m *MockFXRateRepository) Upsert(rates []models.FXRate) error {
+	ret := _m.Called(rates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.FXRate) error); ok {
+		r0 = rf(rates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFXRateRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockFXRateRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - rates []models.FXRate
+func (_e *MockFXRateRepository_Expecter) Upsert(rates interface{}) *MockFXRateRepository_Upsert_Call {
+	return &MockFXRateRepository_Upsert_Call{Call: _e.mock.On("Upsert", rates)}
+}
+
+func (_c *MockFXRateRepository_Upsert_Call) Run(run func(rates []models.FXRate)) *MockFXRateRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]models.FXRate))
+	})
+	return _c
+}
+
+func (_c *MockFXRateRepository_Upsert_Call) Return(_a0 error) *MockFXRateRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFXRateRepository_Upsert_Call) RunAndReturn(run func([]models.FXRate) error) *MockFXRateRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFXRateRepository creates a new instance of MockFXRateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFXRateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFXRateRepository {
+	mock := &MockFXRateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,349 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockHoldingRepository is an autogenerated mock type for the HoldingRepository type
+type MockHoldingRepository struct {
+	mock.Mock
+}
+
+type MockHoldingRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHoldingRepository) EXPECT() *MockHoldingRepository_Expecter {
+	return &MockHoldingRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountByUser provides a mock function with given fields: userID
+func (_m *MockHoldingRepository) CountByUser(userID uuid.UUID) (int64, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByUser")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldingRepository_CountByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByUser'
+type MockHoldingRepository_CountByUser_Call struct {
+	*mock.Call
+}
+
+// CountByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *MockHoldingRepository_Expecter) CountByUser(userID interface{}) *MockHoldingRepository_CountByUser_Call {
+	return &MockHoldingRepository_CountByUser_Call{Call: _e.mock.On("CountByUser", userID)}
+}
+
+func (_c *MockHoldingRepository_CountByUser_Call) Run(run func(userID uuid.UUID)) *MockHoldingRepository_CountByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHoldingRepository_CountByUser_Call) Return(_a0 int64, _a1 error) *MockHoldingRepository_CountByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldingRepository_CountByUser_Call) RunAndReturn(run func(uuid.UUID) (int64, error)) *MockHoldingRepository_CountByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: transaction
+func (_m *MockHoldingRepository) Create(transaction *models.HoldingTransaction) error {
+	ret := _m.Called(transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.HoldingTransaction) error); ok {
+		r0 = rf(transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHoldingRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockHoldingRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - transaction *models.HoldingTransaction
+func (_e *MockHoldingRepository_Expecter) Create(transaction interface{}) *MockHoldingRepository_Create_Call {
+	return &MockHoldingRepository_Create_Call{Call: _e.mock.On("Create", transaction)}
+}
+
+func (_c *MockHoldingRepository_Create_Call) Run(run func(transaction *models.HoldingTransaction)) *MockHoldingRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.HoldingTransaction))
+	})
+	return _c
+}
+
+func (_c *MockHoldingRepository_Create_Call) Return(_a0 error) *MockHoldingRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldingRepository_Create_Call) RunAndReturn(run func(*models.HoldingTransaction) error) *MockHoldingRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatch provides a mock function with given fields: transactions
+func (_m *MockHoldingRepository) CreateBatch(transactions []models.HoldingTransaction) error {
+	ret := _m.Called(transactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.HoldingTransaction) error); ok {
+		r0 = rf(transactions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHoldingRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockHoldingRepository_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - transactions []models.HoldingTransaction
+func (_e *MockHoldingRepository_Expecter) CreateBatch(transactions interface{}) *MockHoldingRepository_CreateBatch_Call {
+	return &MockHoldingRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", transactions)}
+}
+
+func (_c *MockHoldingRepository_CreateBatch_Call) Run(run func(transactions []models.HoldingTransaction)) *MockHoldingRepository_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]models.HoldingTransaction))
+	})
+	return _c
+}
+
+func (_c *MockHoldingRepository_CreateBatch_Call) Return(_a0 error) *MockHoldingRepository_CreateBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldingRepository_CreateBatch_Call) RunAndReturn(run func([]models.HoldingTransaction) error) *MockHoldingRepository_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockHoldingRepository) Delete(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHoldingRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockHoldingRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockHoldingRepository_Expecter) Delete(id interface{}) *MockHoldingRepository_Delete_Call {
+	return &MockHoldingRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockHoldingRepository_Delete_Call) Run(run func(id uuid.UUID)) *MockHoldingRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHoldingRepository_Delete_Call) Return(_a0 error) *MockHoldingRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldingRepository_Delete_Call) RunAndReturn(run func(uuid.UUID) error) *MockHoldingRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: id
+func (_m *MockHoldingRepository) FindByID(id uuid.UUID) (*models.HoldingTransaction, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.HoldingTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.HoldingTransaction, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.HoldingTransaction); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.HoldingTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldingRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockHoldingRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockHoldingRepository_Expecter) FindByID(id interface{}) *MockHoldingRepository_FindByID_Call {
+	return &MockHoldingRepository_FindByID_Call{Call: _e.mock.On("FindByID", id)}
+}
+
+func (_c *MockHoldingRepository_FindByID_Call) Run(run func(id uuid.UUID)) *MockHoldingRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHoldingRepository_FindByID_Call) Return(_a0 *models.HoldingTransaction, _a1 error) *MockHoldingRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldingRepository_FindByID_Call) RunAndReturn(run func(uuid.UUID) (*models.HoldingTransaction, error)) *MockHoldingRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUser provides a mock function with given fields: userID, symbol
+func (_m *MockHoldingRepository) FindByUser(userID uuid.UUID, symbol string) ([]models.HoldingTransaction, error) {
+	ret := _m.Called(userID, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUser")
+	}
+
+	var r0 []models.HoldingTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) ([]models.HoldingTransaction, error)); ok {
+		return rf(userID, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) []models.HoldingTransaction); ok {
+		r0 = rf(userID, symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.HoldingTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(userID, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldingRepository_FindByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUser'
+type MockHoldingRepository_FindByUser_Call struct {
+	*mock.Call
+}
+
+// FindByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - symbol string
+func (_e *MockHoldingRepository_Expecter) FindByUser(userID interface{}, symbol interface{}) *MockHoldingRepository_FindByUser_Call {
+	return &MockHoldingRepository_FindByUser_Call{Call: _e.mock.On("FindByUser", userID, symbol)}
+}
+
+func (_c *MockHoldingRepository_FindByUser_Call) Run(run func(userID uuid.UUID, symbol string)) *MockHoldingRepository_FindByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockHoldingRepository_FindByUser_Call) Return(_a0 []models.HoldingTransaction, _a1 error) *MockHoldingRepository_FindByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldingRepository_FindByUser_Call) RunAndReturn(run func(uuid.UUID, string) ([]models.HoldingTransaction, error)) *MockHoldingRepository_FindByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHoldingRepository creates a new instance of MockHoldingRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHoldingRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHoldingRepository {
+	mock := &MockHoldingRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,619 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "sun-stockanalysis-api/internal/repository"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockMarketCalendarRepository is an autogenerated mock type for the MarketCalendarRepository type
+type MockMarketCalendarRepository struct {
+	mock.Mock
+}
+
+type MockMarketCalendarRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMarketCalendarRepository) EXPECT() *MockMarketCalendarRepository_Expecter {
+	return &MockMarketCalendarRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteHoliday provides a mock function with given fields: code, date
+func (_m *MockMarketCalendarRepository) DeleteHoliday(code string, date time.Time) error {
+	ret := _m.Called(code, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteHoliday")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(code, date)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMarketCalendarRepository_DeleteHoliday_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteHoliday'
+type MockMarketCalendarRepository_DeleteHoliday_Call struct {
+	*mock.Call
+}
+
+// DeleteHoliday is a helper method to define mock.On call
+//   - code string
+//   - date time.Time
+func (_e *MockMarketCalendarRepository_Expecter) DeleteHoliday(code interface{}, date interface{}) *MockMarketCalendarRepository_DeleteHoliday_Call {
+	return &MockMarketCalendarRepository_DeleteHoliday_Call{Call: _e.mock.On("DeleteHoliday", code, date)}
+}
+
+func (_c *MockMarketCalendarRepository_DeleteHoliday_Call) Run(run func(code string, date time.Time)) *MockMarketCalendarRepository_DeleteHoliday_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_DeleteHoliday_Call) Return(_a0 error) *MockMarketCalendarRepository_DeleteHoliday_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_DeleteHoliday_Call) RunAndReturn(run func(string, time.Time) error) *MockMarketCalendarRepository_DeleteHoliday_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureCalendar provides a mock function with given fields: calendar
+func (_m *MockMarketCalendarRepository) EnsureCalendar(calendar *models.MarketCalendar) error {
+	ret := _m.Called(calendar)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureCalendar")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.MarketCalendar) error); ok {
+		r0 = rf(calendar)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMarketCalendarRepository_EnsureCalendar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureCalendar'
+type MockMarketCalendarRepository_EnsureCalendar_Call struct {
+	*mock.Call
+}
+
+// EnsureCalendar is a helper method to define mock.On call
+//   - calendar *models.MarketCalendar
+func (_e *MockMarketCalendarRepository_Expecter) EnsureCalendar(calendar interface{}) *MockMarketCalendarRepository_EnsureCalendar_Call {
+	return &MockMarketCalendarRepository_EnsureCalendar_Call{Call: _e.mock.On("EnsureCalendar", calendar)}
+}
+
+func (_c *MockMarketCalendarRepository_EnsureCalendar_Call) Run(run func(calendar *models.MarketCalendar)) *MockMarketCalendarRepository_EnsureCalendar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.MarketCalendar))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_EnsureCalendar_Call) Return(_a0 error) *MockMarketCalendarRepository_EnsureCalendar_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_EnsureCalendar_Call) RunAndReturn(run func(*models.MarketCalendar) error) *MockMarketCalendarRepository_EnsureCalendar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAll provides a mock function with no fields
+func (_m *MockMarketCalendarRepository) FindAll() ([]models.MarketCalendar, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAll")
+	}
+
+	var r0 []models.MarketCalendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.MarketCalendar, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.MarketCalendar); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MarketCalendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarRepository_FindAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAll'
+type MockMarketCalendarRepository_FindAll_Call struct {
+	*mock.Call
+}
+
+// FindAll is a helper method to define mock.On call
+func (_e *MockMarketCalendarRepository_Expecter) FindAll() *MockMarketCalendarRepository_FindAll_Call {
+	return &MockMarketCalendarRepository_FindAll_Call{Call: _e.mock.On("FindAll")}
+}
+
+func (_c *MockMarketCalendarRepository_FindAll_Call) Run(run func()) *MockMarketCalendarRepository_FindAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindAll_Call) Return(_a0 []models.MarketCalendar, _a1 error) *MockMarketCalendarRepository_FindAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindAll_Call) RunAndReturn(run func() ([]models.MarketCalendar, error)) *MockMarketCalendarRepository_FindAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByCode provides a mock function with given fields: code
+func (_m *MockMarketCalendarRepository) FindByCode(code string) (*models.MarketCalendar, error) {
+	ret := _m.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByCode")
+	}
+
+	var r0 *models.MarketCalendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.MarketCalendar, error)); ok {
+		return rf(code)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.MarketCalendar); ok {
+		r0 = rf(code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MarketCalendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarRepository_FindByCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByCode'
+type MockMarketCalendarRepository_FindByCode_Call struct {
+	*mock.Call
+}
+
+// FindByCode is a helper method to define mock.On call
+//   - code string
+func (_e *MockMarketCalendarRepository_Expecter) FindByCode(code interface{}) *MockMarketCalendarRepository_FindByCode_Call {
+	return &MockMarketCalendarRepository_FindByCode_Call{Call: _e.mock.On("FindByCode", code)}
+}
+
+func (_c *MockMarketCalendarRepository_FindByCode_Call) Run(run func(code string)) *MockMarketCalendarRepository_FindByCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindByCode_Call) Return(_a0 *models.MarketCalendar, _a1 error) *MockMarketCalendarRepository_FindByCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindByCode_Call) RunAndReturn(run func(string) (*models.MarketCalendar, error)) *MockMarketCalendarRepository_FindByCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindExchanges provides a mock function with no fields
+func (_m *MockMarketCalendarRepository) FindExchanges() ([]models.MasterExchange, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExchanges")
+	}
+
+	var r0 []models.MasterExchange
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.MasterExchange, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.MasterExchange); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MasterExchange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarRepository_FindExchanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindExchanges'
+type MockMarketCalendarRepository_FindExchanges_Call struct {
+	*mock.Call
+}
+
+// FindExchanges is a helper method to define mock.On call
+func (_e *MockMarketCalendarRepository_Expecter) FindExchanges() *MockMarketCalendarRepository_FindExchanges_Call {
+	return &MockMarketCalendarRepository_FindExchanges_Call{Call: _e.mock.On("FindExchanges")}
+}
+
+func (_c *MockMarketCalendarRepository_FindExchanges_Call) Run(run func()) *MockMarketCalendarRepository_FindExchanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindExchanges_Call) Return(_a0 []models.MasterExchange, _a1 error) *MockMarketCalendarRepository_FindExchanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindExchanges_Call) RunAndReturn(run func() ([]models.MasterExchange, error)) *MockMarketCalendarRepository_FindExchanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindHolidays provides a mock function with given fields: code
+func (_m *MockMarketCalendarRepository) FindHolidays(code string) ([]models.MarketHoliday, error) {
+	ret := _m.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindHolidays")
+	}
+
+	var r0 []models.MarketHoliday
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.MarketHoliday, error)); ok {
+		return rf(code)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.MarketHoliday); ok {
+		r0 = rf(code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MarketHoliday)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarRepository_FindHolidays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindHolidays'
+type MockMarketCalendarRepository_FindHolidays_Call struct {
+	*mock.Call
+}
+
+// FindHolidays is a helper method to define mock.On call
+//   - code string
+func (_e *MockMarketCalendarRepository_Expecter) FindHolidays(code interface{}) *MockMarketCalendarRepository_FindHolidays_Call {
+	return &MockMarketCalendarRepository_FindHolidays_Call{Call: _e.mock.On("FindHolidays", code)}
+}
+
+func (_c *MockMarketCalendarRepository_FindHolidays_Call) Run(run func(code string)) *MockMarketCalendarRepository_FindHolidays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindHolidays_Call) Return(_a0 []models.MarketHoliday, _a1 error) *MockMarketCalendarRepository_FindHolidays_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindHolidays_Call) RunAndReturn(run func(string) ([]models.MarketHoliday, error)) *MockMarketCalendarRepository_FindHolidays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindSymbolCalendars provides a mock function with no fields
+func (_m *MockMarketCalendarRepository) FindSymbolCalendars() ([]repository.SymbolCalendar, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSymbolCalendars")
+	}
+
+	var r0 []repository.SymbolCalendar
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]repository.SymbolCalendar, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []repository.SymbolCalendar); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.SymbolCalendar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarRepository_FindSymbolCalendars_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSymbolCalendars'
+type MockMarketCalendarRepository_FindSymbolCalendars_Call struct {
+	*mock.Call
+}
+
+// FindSymbolCalendars is a helper method to define mock.On call
+func (_e *MockMarketCalendarRepository_Expecter) FindSymbolCalendars() *MockMarketCalendarRepository_FindSymbolCalendars_Call {
+	return &MockMarketCalendarRepository_FindSymbolCalendars_Call{Call: _e.mock.On("FindSymbolCalendars")}
+}
+
+func (_c *MockMarketCalendarRepository_FindSymbolCalendars_Call) Run(run func()) *MockMarketCalendarRepository_FindSymbolCalendars_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindSymbolCalendars_Call) Return(_a0 []repository.SymbolCalendar, _a1 error) *MockMarketCalendarRepository_FindSymbolCalendars_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_FindSymbolCalendars_Call) RunAndReturn(run func() ([]repository.SymbolCalendar, error)) *MockMarketCalendarRepository_FindSymbolCalendars_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveCalendar provides a mock function with given fields: calendar
+func (_m *MockMarketCalendarRepository) SaveCalendar(calendar *models.MarketCalendar) error {
+	ret := _m.Called(calendar)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCalendar")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.MarketCalendar) error); ok {
+		r0 = rf(calendar)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMarketCalendarRepository_SaveCalendar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCalendar'
+type MockMarketCalendarRepository_SaveCalendar_Call struct {
+	*mock.Call
+}
+
+// SaveCalendar is a helper method to define mock.On call
+//   - calendar *models.MarketCalendar
+func (_e *MockMarketCalendarRepository_Expecter) SaveCalendar(calendar interface{}) *MockMarketCalendarRepository_SaveCalendar_Call {
+	return &MockMarketCalendarRepository_SaveCalendar_Call{Call: _e.mock.On("SaveCalendar", calendar)}
+}
+
+func (_c *MockMarketCalendarRepository_SaveCalendar_Call) Run(run func(calendar *models.MarketCalendar)) *MockMarketCalendarRepository_SaveCalendar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.MarketCalendar))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_SaveCalendar_Call) Return(_a0 error) *MockMarketCalendarRepository_SaveCalendar_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_SaveCalendar_Call) RunAndReturn(run func(*models.MarketCalendar) error) *MockMarketCalendarRepository_SaveCalendar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetExchangeCalendar provides a mock function with given fields: id, code
+func (_m *MockMarketCalendarRepository) SetExchangeCalendar(id uuid.UUID, code string) (*models.MasterExchange, error) {
+	ret := _m.Called(id, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetExchangeCalendar")
+	}
+
+	var r0 *models.MasterExchange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) (*models.MasterExchange, error)); ok {
+		return rf(id, code)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *models.MasterExchange); ok {
+		r0 = rf(id, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MasterExchange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(id, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarketCalendarRepository_SetExchangeCalendar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetExchangeCalendar'
+type MockMarketCalendarRepository_SetExchangeCalendar_Call struct {
+	*mock.Call
+}
+
+// SetExchangeCalendar is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - code string
+func (_e *MockMarketCalendarRepository_Expecter) SetExchangeCalendar(id interface{}, code interface{}) *MockMarketCalendarRepository_SetExchangeCalendar_Call {
+	return &MockMarketCalendarRepository_SetExchangeCalendar_Call{Call: _e.mock.On("SetExchangeCalendar", id, code)}
+}
+
+func (_c *MockMarketCalendarRepository_SetExchangeCalendar_Call) Run(run func(id uuid.UUID, code string)) *MockMarketCalendarRepository_SetExchangeCalendar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_SetExchangeCalendar_Call) Return(_a0 *models.MasterExchange, _a1 error) *MockMarketCalendarRepository_SetExchangeCalendar_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_SetExchangeCalendar_Call) RunAndReturn(run func(uuid.UUID, string) (*models.MasterExchange, error)) *MockMarketCalendarRepository_SetExchangeCalendar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertHoliday provides a mock function with given fields: holiday
+func (_m *MockMarketCalendarRepository) UpsertHoliday(holiday *models.MarketHoliday) error {
+	ret := _m.Called(holiday)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertHoliday")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.MarketHoliday) error); ok {
+		r0 = rf(holiday)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMarketCalendarRepository_UpsertHoliday_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertHoliday'
+type MockMarketCalendarRepository_UpsertHoliday_Call struct {
+	*mock.Call
+}
+
+// UpsertHoliday is a helper method to define mock.On call
+//   - holiday *models.MarketHoliday
+func (_e *MockMarketCalendarRepository_Expecter) UpsertHoliday(holiday interface{}) *MockMarketCalendarRepository_UpsertHoliday_Call {
+	return &MockMarketCalendarRepository_UpsertHoliday_Call{Call: _e.mock.On("UpsertHoliday", holiday)}
+}
+
+func (_c *MockMarketCalendarRepository_UpsertHoliday_Call) Run(run func(holiday *models.MarketHoliday)) *MockMarketCalendarRepository_UpsertHoliday_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.MarketHoliday))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_UpsertHoliday_Call) Return(_a0 error) *MockMarketCalendarRepository_UpsertHoliday_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_UpsertHoliday_Call) RunAndReturn(run func(*models.MarketHoliday) error) *MockMarketCalendarRepository_UpsertHoliday_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertProviderHolidays provides a mock function with given fields: holidays
+func (_m *MockMarketCalendarRepository) UpsertProviderHolidays(holidays []models.MarketHoliday) error {
+	ret := _m.Called(holidays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertProviderHolidays")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.MarketHoliday) error); ok {
+		r0 = rf(holidays)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMarketCalendarRepository_UpsertProviderHolidays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertProviderHolidays'
+type MockMarketCalendarRepository_UpsertProviderHolidays_Call struct {
+	*mock.Call
+}
+
+// UpsertProviderHolidays is a helper method to define mock.On call
+//   - holidays []models.MarketHoliday
+func (_e *MockMarketCalendarRepository_Expecter) UpsertProviderHolidays(holidays interface{}) *MockMarketCalendarRepository_UpsertProviderHolidays_Call {
+	return &MockMarketCalendarRepository_UpsertProviderHolidays_Call{Call: _e.mock.On("UpsertProviderHolidays", holidays)}
+}
+
+func (_c *MockMarketCalendarRepository_UpsertProviderHolidays_Call) Run(run func(holidays []models.MarketHoliday)) *MockMarketCalendarRepository_UpsertProviderHolidays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]models.MarketHoliday))
+	})
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_UpsertProviderHolidays_Call) Return(_a0 error) *MockMarketCalendarRepository_UpsertProviderHolidays_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMarketCalendarRepository_UpsertProviderHolidays_Call) RunAndReturn(run func([]models.MarketHoliday) error) *MockMarketCalendarRepository_UpsertProviderHolidays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMarketCalendarRepository creates a new instance of MockMarketCalendarRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMarketCalendarRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMarketCalendarRepository {
+	mock := &MockMarketCalendarRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
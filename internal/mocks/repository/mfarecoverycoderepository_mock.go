@@ -0,0 +1,245 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockMFARecoveryCodeRepository is an autogenerated mock type for the MFARecoveryCodeRepository type
+type MockMFARecoveryCodeRepository struct {
+	mock.Mock
+}
+
+type MockMFARecoveryCodeRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMFARecoveryCodeRepository) EXPECT() *MockMFARecoveryCodeRepository_Expecter {
+	return &MockMFARecoveryCodeRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: userID, codeHash, usedAt
+func (_m *MockMFARecoveryCodeRepository) Consume(userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error) {
+	ret := _m.Called(userID, codeHash, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, time.Time) (bool, error)); ok {
+		return rf(userID, codeHash, usedAt)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, time.Time) bool); ok {
+		r0 = rf(userID, codeHash, usedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(userID, codeHash, usedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMFARecoveryCodeRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type MockMFARecoveryCodeRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - codeHash string
+//   - usedAt time.Time
+func (_e *MockMFARecoveryCodeRepository_Expecter) Consume(userID interface{}, codeHash interface{}, usedAt interface{}) *MockMFARecoveryCodeRepository_Consume_Call {
+	return &MockMFARecoveryCodeRepository_Consume_Call{Call: _e.mock.On("Consume", userID, codeHash, usedAt)}
+}
+
+func (_c *MockMFARecoveryCodeRepository_Consume_Call) Run(run func(userID uuid.UUID, codeHash string, usedAt time.Time)) *MockMFARecoveryCodeRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockMFARecoveryCodeRepository_Consume_Call) Return(_a0 bool, _a1 error) *MockMFARecoveryCodeRepository_Consume_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMFARecoveryCodeRepository_Consume_Call) RunAndReturn(run func(uuid.UUID, string, time.Time) (bool, error)) *MockMFARecoveryCodeRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUnused provides a mock function with given fields: userID
+func (_m *MockMFARecoveryCodeRepository) CountUnused(userID uuid.UUID) (int64, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnused")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMFARecoveryCodeRepository_CountUnused_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUnused'
+type MockMFARecoveryCodeRepository_CountUnused_Call struct {
+	*mock.Call
+}
+
+// CountUnused is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *MockMFARecoveryCodeRepository_Expecter) CountUnused(userID interface{}) *MockMFARecoveryCodeRepository_CountUnused_Call {
+	return &MockMFARecoveryCodeRepository_CountUnused_Call{Call: _e.mock.On("CountUnused", userID)}
+}
+
+func (_c *MockMFARecoveryCodeRepository_CountUnused_Call) Run(run func(userID uuid.UUID)) *MockMFARecoveryCodeRepository_CountUnused_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockMFARecoveryCodeRepository_CountUnused_Call) Return(_a0 int64, _a1 error) *MockMFARecoveryCodeRepository_CountUnused_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMFARecoveryCodeRepository_CountUnused_Call) RunAndReturn(run func(uuid.UUID) (int64, error)) *MockMFARecoveryCodeRepository_CountUnused_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteForUser provides a mock function with given fields: userID
+func (_m *MockMFARecoveryCodeRepository) DeleteForUser(userID uuid.UUID) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteForUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMFARecoveryCodeRepository_DeleteForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteForUser'
+type MockMFARecoveryCodeRepository_DeleteForUser_Call struct {
+	*mock.Call
+}
+
+// DeleteForUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *MockMFARecoveryCodeRepository_Expecter) DeleteForUser(userID interface{}) *MockMFARecoveryCodeRepository_DeleteForUser_Call {
+	return &MockMFARecoveryCodeRepository_DeleteForUser_Call{Call: _e.mock.On("DeleteForUser", userID)}
+}
+
+func (_c *MockMFARecoveryCodeRepository_DeleteForUser_Call) Run(run func(userID uuid.UUID)) *MockMFARecoveryCodeRepository_DeleteForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockMFARecoveryCodeRepository_DeleteForUser_Call) Return(_a0 error) *MockMFARecoveryCodeRepository_DeleteForUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMFARecoveryCodeRepository_DeleteForUser_Call) RunAndReturn(run func(uuid.UUID) error) *MockMFARecoveryCodeRepository_DeleteForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceForUser provides a mock function with given fields: userID, codeHashes
+func (_m *MockMFARecoveryCodeRepository) ReplaceForUser(userID uuid.UUID, codeHashes []string) error {
+	ret := _m.Called(userID, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceForUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []string) error); ok {
+		r0 = rf(userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMFARecoveryCodeRepository_ReplaceForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceForUser'
+type MockMFARecoveryCodeRepository_ReplaceForUser_Call struct {
+	*mock.Call
+}
+
+// ReplaceForUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - codeHashes []string
+func (_e *MockMFARecoveryCodeRepository_Expecter) ReplaceForUser(userID interface{}, codeHashes interface{}) *MockMFARecoveryCodeRepository_ReplaceForUser_Call {
+	return &MockMFARecoveryCodeRepository_ReplaceForUser_Call{Call: _e.mock.On("ReplaceForUser", userID, codeHashes)}
+}
+
+func (_c *MockMFARecoveryCodeRepository_ReplaceForUser_Call) Run(run func(userID uuid.UUID, codeHashes []string)) *MockMFARecoveryCodeRepository_ReplaceForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockMFARecoveryCodeRepository_ReplaceForUser_Call) Return(_a0 error) *MockMFARecoveryCodeRepository_ReplaceForUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMFARecoveryCodeRepository_ReplaceForUser_Call) RunAndReturn(run func(uuid.UUID, []string) error) *MockMFARecoveryCodeRepository_ReplaceForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMFARecoveryCodeRepository creates a new instance of MockMFARecoveryCodeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMFARecoveryCodeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMFARecoveryCodeRepository {
+	mock := &MockMFARecoveryCodeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,876 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "sun-stockanalysis-api/internal/repository"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockPortfolioRepository is an autogenerated mock type for the PortfolioRepository type
+type MockPortfolioRepository struct {
+	mock.Mock
+}
+
+type MockPortfolioRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPortfolioRepository) EXPECT() *MockPortfolioRepository_Expecter {
+	return &MockPortfolioRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: portfolio
+func (_m *MockPortfolioRepository) Create(portfolio *models.Portfolio) error {
+	ret := _m.Called(portfolio)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Portfolio) error); ok {
+		r0 = rf(portfolio)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPortfolioRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockPortfolioRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - portfolio *models.Portfolio
+func (_e *MockPortfolioRepository_Expecter) Create(portfolio interface{}) *MockPortfolioRepository_Create_Call {
+	return &MockPortfolioRepository_Create_Call{Call: _e.mock.On("Create", portfolio)}
+}
+
+func (_c *MockPortfolioRepository_Create_Call) Run(run func(portfolio *models.Portfolio)) *MockPortfolioRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Portfolio))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_Create_Call) Return(_a0 error) *MockPortfolioRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_Create_Call) RunAndReturn(run func(*models.Portfolio) error) *MockPortfolioRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrder provides a mock function with given fields: order
+func (_m *MockPortfolioRepository) CreateOrder(order *models.PortfolioOrder) error {
+	ret := _m.Called(order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.PortfolioOrder) error); ok {
+		r0 = rf(order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPortfolioRepository_CreateOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrder'
+type MockPortfolioRepository_CreateOrder_Call struct {
+	*mock.Call
+}
+
+// CreateOrder is a helper method to define mock.On call
+//   - order *models.PortfolioOrder
+func (_e *MockPortfolioRepository_Expecter) CreateOrder(order interface{}) *MockPortfolioRepository_CreateOrder_Call {
+	return &MockPortfolioRepository_CreateOrder_Call{Call: _e.mock.On("CreateOrder", order)}
+}
+
+func (_c *MockPortfolioRepository_CreateOrder_Call) Run(run func(order *models.PortfolioOrder)) *MockPortfolioRepository_CreateOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.PortfolioOrder))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_CreateOrder_Call) Return(_a0 error) *MockPortfolioRepository_CreateOrder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_CreateOrder_Call) RunAndReturn(run func(*models.PortfolioOrder) error) *MockPortfolioRepository_CreateOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockPortfolioRepository) Delete(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPortfolioRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockPortfolioRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockPortfolioRepository_Expecter) Delete(id interface{}) *MockPortfolioRepository_Delete_Call {
+	return &MockPortfolioRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockPortfolioRepository_Delete_Call) Run(run func(id uuid.UUID)) *MockPortfolioRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_Delete_Call) Return(_a0 error) *MockPortfolioRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_Delete_Call) RunAndReturn(run func(uuid.UUID) error) *MockPortfolioRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePosition provides a mock function with given fields: portfolioID, symbol
+func (_m *MockPortfolioRepository) DeletePosition(portfolioID uuid.UUID, symbol string) error {
+	ret := _m.Called(portfolioID, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePosition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) error); ok {
+		r0 = rf(portfolioID, symbol)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPortfolioRepository_DeletePosition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePosition'
+type MockPortfolioRepository_DeletePosition_Call struct {
+	*mock.Call
+}
+
+// DeletePosition is a helper method to define mock.On call
+//   - portfolioID uuid.UUID
+//   - symbol string
+func (_e *MockPortfolioRepository_Expecter) DeletePosition(portfolioID interface{}, symbol interface{}) *MockPortfolioRepository_DeletePosition_Call {
+	return &MockPortfolioRepository_DeletePosition_Call{Call: _e.mock.On("DeletePosition", portfolioID, symbol)}
+}
+
+func (_c *MockPortfolioRepository_DeletePosition_Call) Run(run func(portfolioID uuid.UUID, symbol string)) *MockPortfolioRepository_DeletePosition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_DeletePosition_Call) Return(_a0 error) *MockPortfolioRepository_DeletePosition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_DeletePosition_Call) RunAndReturn(run func(uuid.UUID, string) error) *MockPortfolioRepository_DeletePosition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: id
+func (_m *MockPortfolioRepository) FindByID(id uuid.UUID) (*models.Portfolio, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.Portfolio
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.Portfolio, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Portfolio); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Portfolio)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPortfolioRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockPortfolioRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockPortfolioRepository_Expecter) FindByID(id interface{}) *MockPortfolioRepository_FindByID_Call {
+	return &MockPortfolioRepository_FindByID_Call{Call: _e.mock.On("FindByID", id)}
+}
+
+func (_c *MockPortfolioRepository_FindByID_Call) Run(run func(id uuid.UUID)) *MockPortfolioRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindByID_Call) Return(_a0 *models.Portfolio, _a1 error) *MockPortfolioRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindByID_Call) RunAndReturn(run func(uuid.UUID) (*models.Portfolio, error)) *MockPortfolioRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUser provides a mock function with given fields: userID
+func (_m *MockPortfolioRepository) FindByUser(userID uuid.UUID) ([]models.Portfolio, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUser")
+	}
+
+	var r0 []models.Portfolio
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]models.Portfolio, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []models.Portfolio); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Portfolio)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPortfolioRepository_FindByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUser'
+type MockPortfolioRepository_FindByUser_Call struct {
+	*mock.Call
+}
+
+// FindByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *MockPortfolioRepository_Expecter) FindByUser(userID interface{}) *MockPortfolioRepository_FindByUser_Call {
+	return &MockPortfolioRepository_FindByUser_Call{Call: _e.mock.On("FindByUser", userID)}
+}
+
+func (_c *MockPortfolioRepository_FindByUser_Call) Run(run func(userID uuid.UUID)) *MockPortfolioRepository_FindByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindByUser_Call) Return(_a0 []models.Portfolio, _a1 error) *MockPortfolioRepository_FindByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindByUser_Call) RunAndReturn(run func(uuid.UUID) ([]models.Portfolio, error)) *MockPortfolioRepository_FindByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOpenOrdersBySymbol provides a mock function with given fields: symbol
+func (_m *MockPortfolioRepository) FindOpenOrdersBySymbol(symbol string) ([]models.PortfolioOrder, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOpenOrdersBySymbol")
+	}
+
+	var r0 []models.PortfolioOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.PortfolioOrder, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.PortfolioOrder); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PortfolioOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPortfolioRepository_FindOpenOrdersBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOpenOrdersBySymbol'
+type MockPortfolioRepository_FindOpenOrdersBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindOpenOrdersBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockPortfolioRepository_Expecter) FindOpenOrdersBySymbol(symbol interface{}) *MockPortfolioRepository_FindOpenOrdersBySymbol_Call {
+	return &MockPortfolioRepository_FindOpenOrdersBySymbol_Call{Call: _e.mock.On("FindOpenOrdersBySymbol", symbol)}
+}
+
+func (_c *MockPortfolioRepository_FindOpenOrdersBySymbol_Call) Run(run func(symbol string)) *MockPortfolioRepository_FindOpenOrdersBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindOpenOrdersBySymbol_Call) Return(_a0 []models.PortfolioOrder, _a1 error) *MockPortfolioRepository_FindOpenOrdersBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindOpenOrdersBySymbol_Call) RunAndReturn(run func(string) ([]models.PortfolioOrder, error)) *MockPortfolioRepository_FindOpenOrdersBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOrderByID provides a mock function with given fields: id
+func (_m *MockPortfolioRepository) FindOrderByID(id uuid.UUID) (*models.PortfolioOrder, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrderByID")
+	}
+
+	var r0 *models.PortfolioOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.PortfolioOrder, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.PortfolioOrder); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PortfolioOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPortfolioRepository_FindOrderByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOrderByID'
+type MockPortfolioRepository_FindOrderByID_Call struct {
+	*mock.Call
+}
+
+// FindOrderByID is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockPortfolioRepository_Expecter) FindOrderByID(id interface{}) *MockPortfolioRepository_FindOrderByID_Call {
+	return &MockPortfolioRepository_FindOrderByID_Call{Call: _e.mock.On("FindOrderByID", id)}
+}
+
+func (_c *MockPortfolioRepository_FindOrderByID_Call) Run(run func(id uuid.UUID)) *MockPortfolioRepository_FindOrderByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindOrderByID_Call) Return(_a0 *models.PortfolioOrder, _a1 error) *MockPortfolioRepository_FindOrderByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindOrderByID_Call) RunAndReturn(run func(uuid.UUID) (*models.PortfolioOrder, error)) *MockPortfolioRepository_FindOrderByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOrders provides a mock function with given fields: portfolioID, status, limit
+func (_m *MockPortfolioRepository) FindOrders(portfolioID uuid.UUID, status string, limit int) ([]models.PortfolioOrder, error) {
+	ret := _m.Called(portfolioID, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrders")
+	}
+
+	var r0 []models.PortfolioOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, int) ([]models.PortfolioOrder, error)); ok {
+		return rf(portfolioID, status, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, int) []models.PortfolioOrder); ok {
+		r0 = rf(portfolioID, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PortfolioOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string, int) error); ok {
+		r1 = rf(portfolioID, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPortfolioRepository_FindOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOrders'
+type MockPortfolioRepository_FindOrders_Call struct {
+	*mock.Call
+}
+
+// FindOrders is a helper method to define mock.On call
+//   - portfolioID uuid.UUID
+//   - status string
+//   - limit int
+func (_e *MockPortfolioRepository_Expecter) FindOrders(portfolioID interface{}, status interface{}, limit interface{}) *MockPortfolioRepository_FindOrders_Call {
+	return &MockPortfolioRepository_FindOrders_Call{Call: _e.mock.On("FindOrders", portfolioID, status, limit)}
+}
+
+func (_c *MockPortfolioRepository_FindOrders_Call) Run(run func(portfolioID uuid.UUID, status string, limit int)) *MockPortfolioRepository_FindOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindOrders_Call) Return(_a0 []models.PortfolioOrder, _a1 error) *MockPortfolioRepository_FindOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindOrders_Call) RunAndReturn(run func(uuid.UUID, string, int) ([]models.PortfolioOrder, error)) *MockPortfolioRepository_FindOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPosition provides a mock function with given fields: portfolioID, symbol
+func (_m *MockPortfolioRepository) FindPosition(portfolioID uuid.UUID, symbol string) (*models.PortfolioPosition, error) {
+	ret := _m.Called(portfolioID, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPosition")
+	}
+
+	var r0 *models.PortfolioPosition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) (*models.PortfolioPosition, error)); ok {
+		return rf(portfolioID, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *models.PortfolioPosition); ok {
+		r0 = rf(portfolioID, symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PortfolioPosition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(portfolioID, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPortfolioRepository_FindPosition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPosition'
+type MockPortfolioRepository_FindPosition_Call struct {
+	*mock.Call
+}
+
+// FindPosition is a helper method to define mock.On call
+//   - portfolioID uuid.UUID
+//   - symbol string
+func (_e *MockPortfolioRepository_Expecter) FindPosition(portfolioID interface{}, symbol interface{}) *MockPortfolioRepository_FindPosition_Call {
+	return &MockPortfolioRepository_FindPosition_Call{Call: _e.mock.On("FindPosition", portfolioID, symbol)}
+}
+
+func (_c *MockPortfolioRepository_FindPosition_Call) Run(run func(portfolioID uuid.UUID, symbol string)) *MockPortfolioRepository_FindPosition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindPosition_Call) Return(_a0 *models.PortfolioPosition, _a1 error) *MockPortfolioRepository_FindPosition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindPosition_Call) RunAndReturn(run func(uuid.UUID, string) (*models.PortfolioPosition, error)) *MockPortfolioRepository_FindPosition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPositions provides a mock function with given fields: portfolioID
+func (_m *MockPortfolioRepository) FindPositions(portfolioID uuid.UUID) ([]models.PortfolioPosition, error) {
+	ret := _m.Called(portfolioID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPositions")
+	}
+
+	var r0 []models.PortfolioPosition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]models.PortfolioPosition, error)); ok {
+		return rf(portfolioID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []models.PortfolioPosition); ok {
+		r0 = rf(portfolioID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PortfolioPosition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(portfolioID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPortfolioRepository_FindPositions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPositions'
+type MockPortfolioRepository_FindPositions_Call struct {
+	*mock.Call
+}
+
+// FindPositions is a helper method to define mock.On call
+//   - portfolioID uuid.UUID
+func (_e *MockPortfolioRepository_Expecter) FindPositions(portfolioID interface{}) *MockPortfolioRepository_FindPositions_Call {
+	return &MockPortfolioRepository_FindPositions_Call{Call: _e.mock.On("FindPositions", portfolioID)}
+}
+
+func (_c *MockPortfolioRepository_FindPositions_Call) Run(run func(portfolioID uuid.UUID)) *MockPortfolioRepository_FindPositions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindPositions_Call) Return(_a0 []models.PortfolioPosition, _a1 error) *MockPortfolioRepository_FindPositions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_FindPositions_Call) RunAndReturn(run func(uuid.UUID) ([]models.PortfolioPosition, error)) *MockPortfolioRepository_FindPositions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockByID provides a mock function with given fields: id
+func (_m *MockPortfolioRepository) LockByID(id uuid.UUID) (*models.Portfolio, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockByID")
+	}
+
+	var r0 *models.Portfolio
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.Portfolio, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Portfolio); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Portfolio)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPortfolioRepository_LockByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockByID'
+type MockPortfolioRepository_LockByID_Call struct {
+	*mock.Call
+}
+
+// LockByID is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockPortfolioRepository_Expecter) LockByID(id interface{}) *MockPortfolioRepository_LockByID_Call {
+	return &MockPortfolioRepository_LockByID_Call{Call: _e.mock.On("LockByID", id)}
+}
+
+func (_c *MockPortfolioRepository_LockByID_Call) Run(run func(id uuid.UUID)) *MockPortfolioRepository_LockByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_LockByID_Call) Return(_a0 *models.Portfolio, _a1 error) *MockPortfolioRepository_LockByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_LockByID_Call) RunAndReturn(run func(uuid.UUID) (*models.Portfolio, error)) *MockPortfolioRepository_LockByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePosition provides a mock function with given fields: position
+func (_m *MockPortfolioRepository) SavePosition(position *models.PortfolioPosition) error {
+	ret := _m.Called(position)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePosition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.PortfolioPosition) error); ok {
+		r0 = rf(position)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPortfolioRepository_SavePosition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePosition'
+type MockPortfolioRepository_SavePosition_Call struct {
+	*mock.Call
+}
+
+// SavePosition is a helper method to define mock.On call
+//   - position *models.PortfolioPosition
+func (_e *MockPortfolioRepository_Expecter) SavePosition(position interface{}) *MockPortfolioRepository_SavePosition_Call {
+	return &MockPortfolioRepository_SavePosition_Call{Call: _e.mock.On("SavePosition", position)}
+}
+
+func (_c *MockPortfolioRepository_SavePosition_Call) Run(run func(position *models.PortfolioPosition)) *MockPortfolioRepository_SavePosition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.PortfolioPosition))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_SavePosition_Call) Return(_a0 error) *MockPortfolioRepository_SavePosition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_SavePosition_Call) RunAndReturn(run func(*models.PortfolioPosition) error) *MockPortfolioRepository_SavePosition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transaction provides a mock function with given fields: fn
+func (_m *MockPortfolioRepository) Transaction(fn func(repository.PortfolioRepository) error) error {
+	ret := _m.Called(fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(repository.PortfolioRepository) error) error); ok {
+		r0 = rf(fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPortfolioRepository_Transaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transaction'
+type MockPortfolioRepository_Transaction_Call struct {
+	*mock.Call
+}
+
+// Transaction is a helper method to define mock.On call
+//   - fn func(repository.PortfolioRepository) error
+func (_e *MockPortfolioRepository_Expecter) Transaction(fn interface{}) *MockPortfolioRepository_Transaction_Call {
+	return &MockPortfolioRepository_Transaction_Call{Call: _e.mock.On("Transaction", fn)}
+}
+
+func (_c *MockPortfolioRepository_Transaction_Call) Run(run func(fn func(repository.PortfolioRepository) error)) *MockPortfolioRepository_Transaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(func(repository.PortfolioRepository) error))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_Transaction_Call) Return(_a0 error) *MockPortfolioRepository_Transaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_Transaction_Call) RunAndReturn(run func(func(repository.PortfolioRepository) error) error) *MockPortfolioRepository_Transaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBalances provides a mock function with given fields: portfolio
+func (_m *MockPortfolioRepository) UpdateBalances(portfolio *models.Portfolio) error {
+	ret := _m.Called(portfolio)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBalances")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Portfolio) error); ok {
+		r0 = rf(portfolio)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPortfolioRepository_UpdateBalances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBalances'
+type MockPortfolioRepository_UpdateBalances_Call struct {
+	*mock.Call
+}
+
+// UpdateBalances is a helper method to define mock.On call
+//   - portfolio *models.Portfolio
+func (_e *MockPortfolioRepository_Expecter) UpdateBalances(portfolio interface{}) *MockPortfolioRepository_UpdateBalances_Call {
+	return &MockPortfolioRepository_UpdateBalances_Call{Call: _e.mock.On("UpdateBalances", portfolio)}
+}
+
+func (_c *MockPortfolioRepository_UpdateBalances_Call) Run(run func(portfolio *models.Portfolio)) *MockPortfolioRepository_UpdateBalances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Portfolio))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_UpdateBalances_Call) Return(_a0 error) *MockPortfolioRepository_UpdateBalances_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_UpdateBalances_Call) RunAndReturn(run func(*models.Portfolio) error) *MockPortfolioRepository_UpdateBalances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrder provides a mock function with given fields: order
+func (_m *MockPortfolioRepository) UpdateOrder(order *models.PortfolioOrder) error {
+	ret := _m.Called(order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.PortfolioOrder) error); ok {
+		r0 = rf(order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPortfolioRepository_UpdateOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrder'
+type MockPortfolioRepository_UpdateOrder_Call struct {
+	*mock.Call
+}
+
+// UpdateOrder is a helper method to define mock.On call
+//   - order *models.PortfolioOrder
+func (_e *MockPortfolioRepository_Expecter) UpdateOrder(order interface{}) *MockPortfolioRepository_UpdateOrder_Call {
+	return &MockPortfolioRepository_UpdateOrder_Call{Call: _e.mock.On("UpdateOrder", order)}
+}
+
+func (_c *MockPortfolioRepository_UpdateOrder_Call) Run(run func(order *models.PortfolioOrder)) *MockPortfolioRepository_UpdateOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.PortfolioOrder))
+	})
+	return _c
+}
+
+func (_c *MockPortfolioRepository_UpdateOrder_Call) Return(_a0 error) *MockPortfolioRepository_UpdateOrder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPortfolioRepository_UpdateOrder_Call) RunAndReturn(run func(*models.PortfolioOrder) error) *MockPortfolioRepository_UpdateOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPortfolioRepository creates a new instance of MockPortfolioRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPortfolioRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPortfolioRepository {
+	mock := &MockPortfolioRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,11 +3,13 @@
 package repository_mock
 
 import (
-	"time"
-
 	models "sun-stockanalysis-api/internal/models"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 // MockRefreshTokenRepository is an autogenerated mock type for the RefreshTokenRepository type
@@ -69,6 +71,111 @@ func (_c *MockRefreshTokenRepository_Create_Call) RunAndReturn(run func(*models.
 	return _c
 }
 
+// DeleteBefore provides a mock function with given fields: t
+func (_m *MockRefreshTokenRepository) DeleteBefore(t time.Time) error {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRefreshTokenRepository_DeleteBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBefore'
+type MockRefreshTokenRepository_DeleteBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteBefore is a helper method to define mock.On call
+//   - t time.Time
+func (_e *MockRefreshTokenRepository_Expecter) DeleteBefore(t interface{}) *MockRefreshTokenRepository_DeleteBefore_Call {
+	return &MockRefreshTokenRepository_DeleteBefore_Call{Call: _e.mock.On("DeleteBefore", t)}
+}
+
+func (_c *MockRefreshTokenRepository_DeleteBefore_Call) Run(run func(t time.Time)) *MockRefreshTokenRepository_DeleteBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_DeleteBefore_Call) Return(_a0 error) *MockRefreshTokenRepository_DeleteBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_DeleteBefore_Call) RunAndReturn(run func(time.Time) error) *MockRefreshTokenRepository_DeleteBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindActiveByUser provides a mock function with given fields: userID, now
+func (_m *MockRefreshTokenRepository) FindActiveByUser(userID string, now time.Time) ([]models.RefreshTokens, error) {
+	ret := _m.Called(userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByUser")
+	}
+
+	var r0 []models.RefreshTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) ([]models.RefreshTokens, error)); ok {
+		return rf(userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) []models.RefreshTokens); ok {
+		r0 = rf(userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RefreshTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRefreshTokenRepository_FindActiveByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindActiveByUser'
+type MockRefreshTokenRepository_FindActiveByUser_Call struct {
+	*mock.Call
+}
+
+// FindActiveByUser is a helper method to define mock.On call
+//   - userID string
+//   - now time.Time
+func (_e *MockRefreshTokenRepository_Expecter) FindActiveByUser(userID interface{}, now interface{}) *MockRefreshTokenRepository_FindActiveByUser_Call {
+	return &MockRefreshTokenRepository_FindActiveByUser_Call{Call: _e.mock.On("FindActiveByUser", userID, now)}
+}
+
+func (_c *MockRefreshTokenRepository_FindActiveByUser_Call) Run(run func(userID string, now time.Time)) *MockRefreshTokenRepository_FindActiveByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_FindActiveByUser_Call) Return(_a0 []models.RefreshTokens, _a1 error) *MockRefreshTokenRepository_FindActiveByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_FindActiveByUser_Call) RunAndReturn(run func(string, time.Time) ([]models.RefreshTokens, error)) *MockRefreshTokenRepository_FindActiveByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByHash provides a mock function with given fields: hash
 func (_m *MockRefreshTokenRepository) FindByHash(hash string) (*models.RefreshTokens, error) {
 	ret := _m.Called(hash)
@@ -127,17 +234,74 @@ func (_c *MockRefreshTokenRepository_FindByHash_Call) RunAndReturn(run func(stri
 	return _c
 }
 
-// DeleteBefore provides a mock function with given fields: t
-func (_m *MockRefreshTokenRepository) DeleteBefore(t time.Time) error {
-	ret := _m.Called(t)
+// Revoke provides a mock function with given fields: id, revokedAt
+func (_m *MockRefreshTokenRepository) Revoke(id uuid.UUID, revokedAt time.Time) (bool, error) {
+	ret := _m.Called(id, revokedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteBefore")
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) (bool, error)); ok {
+		return rf(id, revokedAt)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) bool); ok {
+		r0 = rf(id, revokedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time) error); ok {
+		r1 = rf(id, revokedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRefreshTokenRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockRefreshTokenRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - revokedAt time.Time
+func (_e *MockRefreshTokenRepository_Expecter) Revoke(id interface{}, revokedAt interface{}) *MockRefreshTokenRepository_Revoke_Call {
+	return &MockRefreshTokenRepository_Revoke_Call{Call: _e.mock.On("Revoke", id, revokedAt)}
+}
+
+func (_c *MockRefreshTokenRepository_Revoke_Call) Run(run func(id uuid.UUID, revokedAt time.Time)) *MockRefreshTokenRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_Revoke_Call) Return(_a0 bool, _a1 error) *MockRefreshTokenRepository_Revoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_Revoke_Call) RunAndReturn(run func(uuid.UUID, time.Time) (bool, error)) *MockRefreshTokenRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAllForUser provides a mock function with given fields: userID, revokedAt
+func (_m *MockRefreshTokenRepository) RevokeAllForUser(userID string, revokedAt time.Time) error {
+	ret := _m.Called(userID, revokedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllForUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
-		r0 = rf(t)
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(userID, revokedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -145,17 +309,46 @@ func (_m *MockRefreshTokenRepository) DeleteBefore(t time.Time) error {
 	return r0
 }
 
-// RevokeByHash provides a mock function with given fields: hash, revokedAt
-func (_m *MockRefreshTokenRepository) RevokeByHash(hash string, revokedAt float64) error {
-	ret := _m.Called(hash, revokedAt)
+// MockRefreshTokenRepository_RevokeAllForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAllForUser'
+type MockRefreshTokenRepository_RevokeAllForUser_Call struct {
+	*mock.Call
+}
+
+// RevokeAllForUser is a helper method to define mock.On call
+//   - userID string
+//   - revokedAt time.Time
+func (_e *MockRefreshTokenRepository_Expecter) RevokeAllForUser(userID interface{}, revokedAt interface{}) *MockRefreshTokenRepository_RevokeAllForUser_Call {
+	return &MockRefreshTokenRepository_RevokeAllForUser_Call{Call: _e.mock.On("RevokeAllForUser", userID, revokedAt)}
+}
+
+func (_c *MockRefreshTokenRepository_RevokeAllForUser_Call) Run(run func(userID string, revokedAt time.Time)) *MockRefreshTokenRepository_RevokeAllForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_RevokeAllForUser_Call) Return(_a0 error) *MockRefreshTokenRepository_RevokeAllForUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_RevokeAllForUser_Call) RunAndReturn(run func(string, time.Time) error) *MockRefreshTokenRepository_RevokeAllForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeFamily provides a mock function with given fields: familyID, revokedAt
+func (_m *MockRefreshTokenRepository) RevokeFamily(familyID uuid.UUID, revokedAt time.Time) error {
+	ret := _m.Called(familyID, revokedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RevokeByHash")
+		panic("no return value specified for RevokeFamily")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, float64) error); ok {
-		r0 = rf(hash, revokedAt)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) error); ok {
+		r0 = rf(familyID, revokedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -163,31 +356,89 @@ func (_m *MockRefreshTokenRepository) RevokeByHash(hash string, revokedAt float6
 	return r0
 }
 
-// MockRefreshTokenRepository_RevokeByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeByHash'
-type MockRefreshTokenRepository_RevokeByHash_Call struct {
+// MockRefreshTokenRepository_RevokeFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeFamily'
+type MockRefreshTokenRepository_RevokeFamily_Call struct {
 	*mock.Call
 }
 
-// RevokeByHash is a helper method to define mock.On call
-//   - hash string
-//   - revokedAt float64
-func (_e *MockRefreshTokenRepository_Expecter) RevokeByHash(hash interface{}, revokedAt interface{}) *MockRefreshTokenRepository_RevokeByHash_Call {
-	return &MockRefreshTokenRepository_RevokeByHash_Call{Call: _e.mock.On("RevokeByHash", hash, revokedAt)}
+// RevokeFamily is a helper method to define mock.On call
+//   - familyID uuid.UUID
+//   - revokedAt time.Time
+func (_e *MockRefreshTokenRepository_Expecter) RevokeFamily(familyID interface{}, revokedAt interface{}) *MockRefreshTokenRepository_RevokeFamily_Call {
+	return &MockRefreshTokenRepository_RevokeFamily_Call{Call: _e.mock.On("RevokeFamily", familyID, revokedAt)}
 }
 
-func (_c *MockRefreshTokenRepository_RevokeByHash_Call) Run(run func(hash string, revokedAt float64)) *MockRefreshTokenRepository_RevokeByHash_Call {
+func (_c *MockRefreshTokenRepository_RevokeFamily_Call) Run(run func(familyID uuid.UUID, revokedAt time.Time)) *MockRefreshTokenRepository_RevokeFamily_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(float64))
+		run(args[0].(uuid.UUID), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockRefreshTokenRepository_RevokeByHash_Call) Return(_a0 error) *MockRefreshTokenRepository_RevokeByHash_Call {
+func (_c *MockRefreshTokenRepository_RevokeFamily_Call) Return(_a0 error) *MockRefreshTokenRepository_RevokeFamily_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockRefreshTokenRepository_RevokeByHash_Call) RunAndReturn(run func(string, float64) error) *MockRefreshTokenRepository_RevokeByHash_Call {
+func (_c *MockRefreshTokenRepository_RevokeFamily_Call) RunAndReturn(run func(uuid.UUID, time.Time) error) *MockRefreshTokenRepository_RevokeFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserFamily provides a mock function with given fields: userID, familyID, revokedAt
+func (_m *MockRefreshTokenRepository) RevokeUserFamily(userID string, familyID uuid.UUID, revokedAt time.Time) (bool, error) {
+	ret := _m.Called(userID, familyID, revokedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserFamily")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID, time.Time) (bool, error)); ok {
+		return rf(userID, familyID, revokedAt)
+	}
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID, time.Time) bool); ok {
+		r0 = rf(userID, familyID, revokedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uuid.UUID, time.Time) error); ok {
+		r1 = rf(userID, familyID, revokedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRefreshTokenRepository_RevokeUserFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserFamily'
+type MockRefreshTokenRepository_RevokeUserFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeUserFamily is a helper method to define mock.On call
+//   - userID string
+//   - familyID uuid.UUID
+//   - revokedAt time.Time
+func (_e *MockRefreshTokenRepository_Expecter) RevokeUserFamily(userID interface{}, familyID interface{}, revokedAt interface{}) *MockRefreshTokenRepository_RevokeUserFamily_Call {
+	return &MockRefreshTokenRepository_RevokeUserFamily_Call{Call: _e.mock.On("RevokeUserFamily", userID, familyID, revokedAt)}
+}
+
+func (_c *MockRefreshTokenRepository_RevokeUserFamily_Call) Run(run func(userID string, familyID uuid.UUID, revokedAt time.Time)) *MockRefreshTokenRepository_RevokeUserFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_RevokeUserFamily_Call) Return(_a0 bool, _a1 error) *MockRefreshTokenRepository_RevokeUserFamily_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRefreshTokenRepository_RevokeUserFamily_Call) RunAndReturn(run func(string, uuid.UUID, time.Time) (bool, error)) *MockRefreshTokenRepository_RevokeUserFamily_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,528 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockStockDailyRepository is an autogenerated mock type for the StockDailyRepository type
+type MockStockDailyRepository struct {
+	mock.Mock
+}
+
+type MockStockDailyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStockDailyRepository) EXPECT() *MockStockDailyRepository_Expecter {
+	return &MockStockDailyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: metric
+func (_m *MockStockDailyRepository) Create(metric *models.StockDaily) error {
+	ret := _m.Called(metric)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.StockDaily) error); ok {
+		r0 = rf(metric)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStockDailyRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockStockDailyRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - metric *models.StockDaily
+func (_e *MockStockDailyRepository_Expecter) Create(metric interface{}) *MockStockDailyRepository_Create_Call {
+	return &MockStockDailyRepository_Create_Call{Call: _e.mock.On("Create", metric)}
+}
+
+func (_c *MockStockDailyRepository_Create_Call) Run(run func(metric *models.StockDaily)) *MockStockDailyRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.StockDaily))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyRepository_Create_Call) Return(_a0 error) *MockStockDailyRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStockDailyRepository_Create_Call) RunAndReturn(run func(*models.StockDaily) error) *MockStockDailyRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindBySymbol provides a mock function with given fields: symbol
+func (_m *MockStockDailyRepository) FindBySymbol(symbol string) ([]models.StockDaily, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySymbol")
+	}
+
+	var r0 []models.StockDaily
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.StockDaily, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.StockDaily); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockDaily)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyRepository_FindBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBySymbol'
+type MockStockDailyRepository_FindBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockStockDailyRepository_Expecter) FindBySymbol(symbol interface{}) *MockStockDailyRepository_FindBySymbol_Call {
+	return &MockStockDailyRepository_FindBySymbol_Call{Call: _e.mock.On("FindBySymbol", symbol)}
+}
+
+func (_c *MockStockDailyRepository_FindBySymbol_Call) Run(run func(symbol string)) *MockStockDailyRepository_FindBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindBySymbol_Call) Return(_a0 []models.StockDaily, _a1 error) *MockStockDailyRepository_FindBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindBySymbol_Call) RunAndReturn(run func(string) ([]models.StockDaily, error)) *MockStockDailyRepository_FindBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindBySymbolBetween provides a mock function with given fields: symbol, start, end
+func (_m *MockStockDailyRepository) FindBySymbolBetween(symbol string, start time.Time, end time.Time) ([]models.StockDaily, error) {
+	ret := _m.Called(symbol, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySymbolBetween")
+	}
+
+	var r0 []models.StockDaily
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) ([]models.StockDaily, error)); ok {
+		return rf(symbol, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) []models.StockDaily); ok {
+		r0 = rf(symbol, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockDaily)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(symbol, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyRepository_FindBySymbolBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBySymbolBetween'
+type MockStockDailyRepository_FindBySymbolBetween_Call struct {
+	*mock.Call
+}
+
+// FindBySymbolBetween is a helper method to define mock.On call
+//   - symbol string
+//   - start time.Time
+//   - end time.Time
+func (_e *MockStockDailyRepository_Expecter) FindBySymbolBetween(symbol interface{}, start interface{}, end interface{}) *MockStockDailyRepository_FindBySymbolBetween_Call {
+	return &MockStockDailyRepository_FindBySymbolBetween_Call{Call: _e.mock.On("FindBySymbolBetween", symbol, start, end)}
+}
+
+func (_c *MockStockDailyRepository_FindBySymbolBetween_Call) Run(run func(symbol string, start time.Time, end time.Time)) *MockStockDailyRepository_FindBySymbolBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindBySymbolBetween_Call) Return(_a0 []models.StockDaily, _a1 error) *MockStockDailyRepository_FindBySymbolBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindBySymbolBetween_Call) RunAndReturn(run func(string, time.Time, time.Time) ([]models.StockDaily, error)) *MockStockDailyRepository_FindBySymbolBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindHistoryBySymbol provides a mock function with given fields: symbol
+func (_m *MockStockDailyRepository) FindHistoryBySymbol(symbol string) ([]models.StockDaily, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindHistoryBySymbol")
+	}
+
+	var r0 []models.StockDaily
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.StockDaily, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.StockDaily); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockDaily)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyRepository_FindHistoryBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindHistoryBySymbol'
+type MockStockDailyRepository_FindHistoryBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindHistoryBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockStockDailyRepository_Expecter) FindHistoryBySymbol(symbol interface{}) *MockStockDailyRepository_FindHistoryBySymbol_Call {
+	return &MockStockDailyRepository_FindHistoryBySymbol_Call{Call: _e.mock.On("FindHistoryBySymbol", symbol)}
+}
+
+func (_c *MockStockDailyRepository_FindHistoryBySymbol_Call) Run(run func(symbol string)) *MockStockDailyRepository_FindHistoryBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindHistoryBySymbol_Call) Return(_a0 []models.StockDaily, _a1 error) *MockStockDailyRepository_FindHistoryBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindHistoryBySymbol_Call) RunAndReturn(run func(string) ([]models.StockDaily, error)) *MockStockDailyRepository_FindHistoryBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindLatestBySymbol provides a mock function with given fields: symbol
+func (_m *MockStockDailyRepository) FindLatestBySymbol(symbol string) (*models.StockDaily, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestBySymbol")
+	}
+
+	var r0 *models.StockDaily
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.StockDaily, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.StockDaily); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockDaily)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyRepository_FindLatestBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindLatestBySymbol'
+type MockStockDailyRepository_FindLatestBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindLatestBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockStockDailyRepository_Expecter) FindLatestBySymbol(symbol interface{}) *MockStockDailyRepository_FindLatestBySymbol_Call {
+	return &MockStockDailyRepository_FindLatestBySymbol_Call{Call: _e.mock.On("FindLatestBySymbol", symbol)}
+}
+
+func (_c *MockStockDailyRepository_FindLatestBySymbol_Call) Run(run func(symbol string)) *MockStockDailyRepository_FindLatestBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindLatestBySymbol_Call) Return(_a0 *models.StockDaily, _a1 error) *MockStockDailyRepository_FindLatestBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindLatestBySymbol_Call) RunAndReturn(run func(string) (*models.StockDaily, error)) *MockStockDailyRepository_FindLatestBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPreviousBySymbol provides a mock function with given fields: symbol
+func (_m *MockStockDailyRepository) FindPreviousBySymbol(symbol string) ([]models.StockDaily, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPreviousBySymbol")
+	}
+
+	var r0 []models.StockDaily
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.StockDaily, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.StockDaily); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockDaily)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyRepository_FindPreviousBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPreviousBySymbol'
+type MockStockDailyRepository_FindPreviousBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindPreviousBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockStockDailyRepository_Expecter) FindPreviousBySymbol(symbol interface{}) *MockStockDailyRepository_FindPreviousBySymbol_Call {
+	return &MockStockDailyRepository_FindPreviousBySymbol_Call{Call: _e.mock.On("FindPreviousBySymbol", symbol)}
+}
+
+func (_c *MockStockDailyRepository_FindPreviousBySymbol_Call) Run(run func(symbol string)) *MockStockDailyRepository_FindPreviousBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindPreviousBySymbol_Call) Return(_a0 []models.StockDaily, _a1 error) *MockStockDailyRepository_FindPreviousBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindPreviousBySymbol_Call) RunAndReturn(run func(string) ([]models.StockDaily, error)) *MockStockDailyRepository_FindPreviousBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecentBySymbol provides a mock function with given fields: symbol, before, limit
+func (_m *MockStockDailyRepository) FindRecentBySymbol(symbol string, before time.Time, limit int) ([]models.StockDaily, error) {
+	ret := _m.Called(symbol, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecentBySymbol")
+	}
+
+	var r0 []models.StockDaily
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, int) ([]models.StockDaily, error)); ok {
+		return rf(symbol, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, int) []models.StockDaily); ok {
+		r0 = rf(symbol, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockDaily)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, int) error); ok {
+		r1 = rf(symbol, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockDailyRepository_FindRecentBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecentBySymbol'
+type MockStockDailyRepository_FindRecentBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindRecentBySymbol is a helper method to define mock.On call
+//   - symbol string
+//   - before time.Time
+//   - limit int
+func (_e *MockStockDailyRepository_Expecter) FindRecentBySymbol(symbol interface{}, before interface{}, limit interface{}) *MockStockDailyRepository_FindRecentBySymbol_Call {
+	return &MockStockDailyRepository_FindRecentBySymbol_Call{Call: _e.mock.On("FindRecentBySymbol", symbol, before, limit)}
+}
+
+func (_c *MockStockDailyRepository_FindRecentBySymbol_Call) Run(run func(symbol string, before time.Time, limit int)) *MockStockDailyRepository_FindRecentBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindRecentBySymbol_Call) Return(_a0 []models.StockDaily, _a1 error) *MockStockDailyRepository_FindRecentBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockDailyRepository_FindRecentBySymbol_Call) RunAndReturn(run func(string, time.Time, int) ([]models.StockDaily, error)) *MockStockDailyRepository_FindRecentBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateIndicators provides a mock function with given fields: metric
+func (_m *MockStockDailyRepository) UpdateIndicators(metric *models.StockDaily) error {
+	ret := _m.Called(metric)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIndicators")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.StockDaily) error); ok {
+		r0 = rf(metric)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStockDailyRepository_UpdateIndicators_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIndicators'
+type MockStockDailyRepository_UpdateIndicators_Call struct {
+	*mock.Call
+}
+
+// UpdateIndicators is a helper method to define mock.On call
+//   - metric *models.StockDaily
+func (_e *MockStockDailyRepository_Expecter) UpdateIndicators(metric interface{}) *MockStockDailyRepository_UpdateIndicators_Call {
+	return &MockStockDailyRepository_UpdateIndicators_Call{Call: _e.mock.On("UpdateIndicators", metric)}
+}
+
+func (_c *MockStockDailyRepository_UpdateIndicators_Call) Run(run func(metric *models.StockDaily)) *MockStockDailyRepository_UpdateIndicators_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.StockDaily))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyRepository_UpdateIndicators_Call) Return(_a0 error) *MockStockDailyRepository_UpdateIndicators_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStockDailyRepository_UpdateIndicators_Call) RunAndReturn(run func(*models.StockDaily) error) *MockStockDailyRepository_UpdateIndicators_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: metric
+func (_m *MockStockDailyRepository) Upsert(metric *models.StockDaily) error {
+	ret := _m.Called(metric)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.StockDaily) error); ok {
+		r0 = rf(metric)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStockDailyRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockStockDailyRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - metric *models.StockDaily
+func (_e *MockStockDailyRepository_Expecter) Upsert(metric interface{}) *MockStockDailyRepository_Upsert_Call {
+	return &MockStockDailyRepository_Upsert_Call{Call: _e.mock.On("Upsert", metric)}
+}
+
+func (_c *MockStockDailyRepository_Upsert_Call) Run(run func(metric *models.StockDaily)) *MockStockDailyRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.StockDaily))
+	})
+	return _c
+}
+
+func (_c *MockStockDailyRepository_Upsert_Call) Return(_a0 error) *MockStockDailyRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStockDailyRepository_Upsert_Call) RunAndReturn(run func(*models.StockDaily) error) *MockStockDailyRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStockDailyRepository creates a new instance of MockStockDailyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStockDailyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStockDailyRepository {
+	mock := &MockStockDailyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
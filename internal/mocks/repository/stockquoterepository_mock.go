@@ -0,0 +1,589 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockStockQuoteRepository is an autogenerated mock type for the StockQuoteRepository type
+type MockStockQuoteRepository struct {
+	mock.Mock
+}
+
+type MockStockQuoteRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStockQuoteRepository) EXPECT() *MockStockQuoteRepository_Expecter {
+	return &MockStockQuoteRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: quote
+func (_m *MockStockQuoteRepository) Create(quote *models.StockQuote) error {
+	ret := _m.Called(quote)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.StockQuote) error); ok {
+		r0 = rf(quote)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStockQuoteRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockStockQuoteRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - quote *models.StockQuote
+func (_e *MockStockQuoteRepository_Expecter) Create(quote interface{}) *MockStockQuoteRepository_Create_Call {
+	return &MockStockQuoteRepository_Create_Call{Call: _e.mock.On("Create", quote)}
+}
+
+func (_c *MockStockQuoteRepository_Create_Call) Run(run func(quote *models.StockQuote)) *MockStockQuoteRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.StockQuote))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_Create_Call) Return(_a0 error) *MockStockQuoteRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_Create_Call) RunAndReturn(run func(*models.StockQuote) error) *MockStockQuoteRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBefore provides a mock function with given fields: t
+func (_m *MockStockQuoteRepository) DeleteBefore(t time.Time) error {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStockQuoteRepository_DeleteBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBefore'
+type MockStockQuoteRepository_DeleteBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteBefore is a helper method to define mock.On call
+//   - t time.Time
+func (_e *MockStockQuoteRepository_Expecter) DeleteBefore(t interface{}) *MockStockQuoteRepository_DeleteBefore_Call {
+	return &MockStockQuoteRepository_DeleteBefore_Call{Call: _e.mock.On("DeleteBefore", t)}
+}
+
+func (_c *MockStockQuoteRepository_DeleteBefore_Call) Run(run func(t time.Time)) *MockStockQuoteRepository_DeleteBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_DeleteBefore_Call) Return(_a0 error) *MockStockQuoteRepository_DeleteBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_DeleteBefore_Call) RunAndReturn(run func(time.Time) error) *MockStockQuoteRepository_DeleteBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAfter provides a mock function with given fields: seq, symbols, limit
+func (_m *MockStockQuoteRepository) FindAfter(seq int64, symbols []string, limit int) ([]models.StockQuote, error) {
+	ret := _m.Called(seq, symbols, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAfter")
+	}
+
+	var r0 []models.StockQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, []string, int) ([]models.StockQuote, error)); ok {
+		return rf(seq, symbols, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, []string, int) []models.StockQuote); ok {
+		r0 = rf(seq, symbols, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, []string, int) error); ok {
+		r1 = rf(seq, symbols, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteRepository_FindAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAfter'
+type MockStockQuoteRepository_FindAfter_Call struct {
+	*mock.Call
+}
+
+// FindAfter is a helper method to define mock.On call
+//   - seq int64
+//   - symbols []string
+//   - limit int
+func (_e *MockStockQuoteRepository_Expecter) FindAfter(seq interface{}, symbols interface{}, limit interface{}) *MockStockQuoteRepository_FindAfter_Call {
+	return &MockStockQuoteRepository_FindAfter_Call{Call: _e.mock.On("FindAfter", seq, symbols, limit)}
+}
+
+func (_c *MockStockQuoteRepository_FindAfter_Call) Run(run func(seq int64, symbols []string, limit int)) *MockStockQuoteRepository_FindAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].([]string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindAfter_Call) Return(_a0 []models.StockQuote, _a1 error) *MockStockQuoteRepository_FindAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindAfter_Call) RunAndReturn(run func(int64, []string, int) ([]models.StockQuote, error)) *MockStockQuoteRepository_FindAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAll provides a mock function with no fields
+func (_m *MockStockQuoteRepository) FindAll() ([]models.StockQuote, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAll")
+	}
+
+	var r0 []models.StockQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.StockQuote, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.StockQuote); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteRepository_FindAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAll'
+type MockStockQuoteRepository_FindAll_Call struct {
+	*mock.Call
+}
+
+// FindAll is a helper method to define mock.On call
+func (_e *MockStockQuoteRepository_Expecter) FindAll() *MockStockQuoteRepository_FindAll_Call {
+	return &MockStockQuoteRepository_FindAll_Call{Call: _e.mock.On("FindAll")}
+}
+
+func (_c *MockStockQuoteRepository_FindAll_Call) Run(run func()) *MockStockQuoteRepository_FindAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindAll_Call) Return(_a0 []models.StockQuote, _a1 error) *MockStockQuoteRepository_FindAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindAll_Call) RunAndReturn(run func() ([]models.StockQuote, error)) *MockStockQuoteRepository_FindAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindBySymbol provides a mock function with given fields: symbol
+func (_m *MockStockQuoteRepository) FindBySymbol(symbol string) ([]models.StockQuote, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySymbol")
+	}
+
+	var r0 []models.StockQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.StockQuote, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.StockQuote); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteRepository_FindBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBySymbol'
+type MockStockQuoteRepository_FindBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockStockQuoteRepository_Expecter) FindBySymbol(symbol interface{}) *MockStockQuoteRepository_FindBySymbol_Call {
+	return &MockStockQuoteRepository_FindBySymbol_Call{Call: _e.mock.On("FindBySymbol", symbol)}
+}
+
+func (_c *MockStockQuoteRepository_FindBySymbol_Call) Run(run func(symbol string)) *MockStockQuoteRepository_FindBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindBySymbol_Call) Return(_a0 []models.StockQuote, _a1 error) *MockStockQuoteRepository_FindBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindBySymbol_Call) RunAndReturn(run func(string) ([]models.StockQuote, error)) *MockStockQuoteRepository_FindBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindBySymbolBetween provides a mock function with given fields: symbol, start, end
+func (_m *MockStockQuoteRepository) FindBySymbolBetween(symbol string, start time.Time, end time.Time) ([]models.StockQuote, error) {
+	ret := _m.Called(symbol, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySymbolBetween")
+	}
+
+	var r0 []models.StockQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) ([]models.StockQuote, error)); ok {
+		return rf(symbol, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) []models.StockQuote); ok {
+		r0 = rf(symbol, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(symbol, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteRepository_FindBySymbolBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBySymbolBetween'
+type MockStockQuoteRepository_FindBySymbolBetween_Call struct {
+	*mock.Call
+}
+
+// FindBySymbolBetween is a helper method to define mock.On call
+//   - symbol string
+//   - start time.Time
+//   - end time.Time
+func (_e *MockStockQuoteRepository_Expecter) FindBySymbolBetween(symbol interface{}, start interface{}, end interface{}) *MockStockQuoteRepository_FindBySymbolBetween_Call {
+	return &MockStockQuoteRepository_FindBySymbolBetween_Call{Call: _e.mock.On("FindBySymbolBetween", symbol, start, end)}
+}
+
+func (_c *MockStockQuoteRepository_FindBySymbolBetween_Call) Run(run func(symbol string, start time.Time, end time.Time)) *MockStockQuoteRepository_FindBySymbolBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindBySymbolBetween_Call) Return(_a0 []models.StockQuote, _a1 error) *MockStockQuoteRepository_FindBySymbolBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindBySymbolBetween_Call) RunAndReturn(run func(string, time.Time, time.Time) ([]models.StockQuote, error)) *MockStockQuoteRepository_FindBySymbolBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindLatestBySymbol provides a mock function with given fields: symbol
+func (_m *MockStockQuoteRepository) FindLatestBySymbol(symbol string) (*models.StockQuote, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestBySymbol")
+	}
+
+	var r0 *models.StockQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.StockQuote, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.StockQuote); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteRepository_FindLatestBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindLatestBySymbol'
+type MockStockQuoteRepository_FindLatestBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindLatestBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockStockQuoteRepository_Expecter) FindLatestBySymbol(symbol interface{}) *MockStockQuoteRepository_FindLatestBySymbol_Call {
+	return &MockStockQuoteRepository_FindLatestBySymbol_Call{Call: _e.mock.On("FindLatestBySymbol", symbol)}
+}
+
+func (_c *MockStockQuoteRepository_FindLatestBySymbol_Call) Run(run func(symbol string)) *MockStockQuoteRepository_FindLatestBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindLatestBySymbol_Call) Return(_a0 *models.StockQuote, _a1 error) *MockStockQuoteRepository_FindLatestBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindLatestBySymbol_Call) RunAndReturn(run func(string) (*models.StockQuote, error)) *MockStockQuoteRepository_FindLatestBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindLatestBySymbolBetween provides a mock function with given fields: symbol, start, end, limit
+func (_m *MockStockQuoteRepository) FindLatestBySymbolBetween(symbol string, start time.Time, end time.Time, limit int) ([]models.StockQuote, error) {
+	ret := _m.Called(symbol, start, end, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestBySymbolBetween")
+	}
+
+	var r0 []models.StockQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time, int) ([]models.StockQuote, error)); ok {
+		return rf(symbol, start, end, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time, int) []models.StockQuote); ok {
+		r0 = rf(symbol, start, end, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time, int) error); ok {
+		r1 = rf(symbol, start, end, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteRepository_FindLatestBySymbolBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindLatestBySymbolBetween'
+type MockStockQuoteRepository_FindLatestBySymbolBetween_Call struct {
+	*mock.Call
+}
+
+// FindLatestBySymbolBetween is a helper method to define mock.On call
+//   - symbol string
+//   - start time.Time
+//   - end time.Time
+//   - limit int
+func (_e *MockStockQuoteRepository_Expecter) FindLatestBySymbolBetween(symbol interface{}, start interface{}, end interface{}, limit interface{}) *MockStockQuoteRepository_FindLatestBySymbolBetween_Call {
+	return &MockStockQuoteRepository_FindLatestBySymbolBetween_Call{Call: _e.mock.On("FindLatestBySymbolBetween", symbol, start, end, limit)}
+}
+
+func (_c *MockStockQuoteRepository_FindLatestBySymbolBetween_Call) Run(run func(symbol string, start time.Time, end time.Time, limit int)) *MockStockQuoteRepository_FindLatestBySymbolBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindLatestBySymbolBetween_Call) Return(_a0 []models.StockQuote, _a1 error) *MockStockQuoteRepository_FindLatestBySymbolBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindLatestBySymbolBetween_Call) RunAndReturn(run func(string, time.Time, time.Time, int) ([]models.StockQuote, error)) *MockStockQuoteRepository_FindLatestBySymbolBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecentBySymbol provides a mock function with given fields: symbol, limit
+func (_m *MockStockQuoteRepository) FindRecentBySymbol(symbol string, limit int) ([]models.StockQuote, error) {
+	ret := _m.Called(symbol, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecentBySymbol")
+	}
+
+	var r0 []models.StockQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]models.StockQuote, error)); ok {
+		return rf(symbol, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []models.StockQuote); ok {
+		r0 = rf(symbol, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(symbol, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockQuoteRepository_FindRecentBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecentBySymbol'
+type MockStockQuoteRepository_FindRecentBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindRecentBySymbol is a helper method to define mock.On call
+//   - symbol string
+//   - limit int
+func (_e *MockStockQuoteRepository_Expecter) FindRecentBySymbol(symbol interface{}, limit interface{}) *MockStockQuoteRepository_FindRecentBySymbol_Call {
+	return &MockStockQuoteRepository_FindRecentBySymbol_Call{Call: _e.mock.On("FindRecentBySymbol", symbol, limit)}
+}
+
+func (_c *MockStockQuoteRepository_FindRecentBySymbol_Call) Run(run func(symbol string, limit int)) *MockStockQuoteRepository_FindRecentBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindRecentBySymbol_Call) Return(_a0 []models.StockQuote, _a1 error) *MockStockQuoteRepository_FindRecentBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_FindRecentBySymbol_Call) RunAndReturn(run func(string, int) ([]models.StockQuote, error)) *MockStockQuoteRepository_FindRecentBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateIndicators provides a mock function with given fields: quote
+func (_m *MockStockQuoteRepository) UpdateIndicators(quote *models.StockQuote) error {
+	ret := _m.Called(quote)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIndicators")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.StockQuote) error); ok {
+		r0 = rf(quote)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStockQuoteRepository_UpdateIndicators_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIndicators'
+type MockStockQuoteRepository_UpdateIndicators_Call struct {
+	*mock.Call
+}
+
+// UpdateIndicators is a helper method to define mock.On call
+//   - quote *models.StockQuote
+func (_e *MockStockQuoteRepository_Expecter) UpdateIndicators(quote interface{}) *MockStockQuoteRepository_UpdateIndicators_Call {
+	return &MockStockQuoteRepository_UpdateIndicators_Call{Call: _e.mock.On("UpdateIndicators", quote)}
+}
+
+func (_c *MockStockQuoteRepository_UpdateIndicators_Call) Run(run func(quote *models.StockQuote)) *MockStockQuoteRepository_UpdateIndicators_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.StockQuote))
+	})
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_UpdateIndicators_Call) Return(_a0 error) *MockStockQuoteRepository_UpdateIndicators_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStockQuoteRepository_UpdateIndicators_Call) RunAndReturn(run func(*models.StockQuote) error) *MockStockQuoteRepository_UpdateIndicators_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStockQuoteRepository creates a new instance of MockStockQuoteRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStockQuoteRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStockQuoteRepository {
+	mock := &MockStockQuoteRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -207,6 +207,63 @@ func (_c *MockStockRepository_EnsureMasterSector_Call) RunAndReturn(run func(str
 	return _c
 }
 
+// FindAll provides a mock function with no fields
+func (_m *MockStockRepository) FindAll() ([]models.Stock, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAll")
+	}
+
+	var r0 []models.Stock
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Stock, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.Stock); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Stock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockRepository_FindAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAll'
+type MockStockRepository_FindAll_Call struct {
+	*mock.Call
+}
+
+// FindAll is a helper method to define mock.On call
+func (_e *MockStockRepository_Expecter) FindAll() *MockStockRepository_FindAll_Call {
+	return &MockStockRepository_FindAll_Call{Call: _e.mock.On("FindAll")}
+}
+
+func (_c *MockStockRepository_FindAll_Call) Run(run func()) *MockStockRepository_FindAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStockRepository_FindAll_Call) Return(_a0 []models.Stock, _a1 error) *MockStockRepository_FindAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockRepository_FindAll_Call) RunAndReturn(run func() ([]models.Stock, error)) *MockStockRepository_FindAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByID provides a mock function with given fields: id
 func (_m *MockStockRepository) FindByID(id uuid.UUID) (*models.Stock, error) {
 	ret := _m.Called(id)
@@ -265,6 +322,121 @@ func (_c *MockStockRepository_FindByID_Call) RunAndReturn(run func(uuid.UUID) (*
 	return _c
 }
 
+// FindBySymbol provides a mock function with given fields: symbol
+func (_m *MockStockRepository) FindBySymbol(symbol string) (*models.Stock, error) {
+	ret := _m.Called(symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySymbol")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Stock, error)); ok {
+		return rf(symbol)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Stock); ok {
+		r0 = rf(symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockRepository_FindBySymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBySymbol'
+type MockStockRepository_FindBySymbol_Call struct {
+	*mock.Call
+}
+
+// FindBySymbol is a helper method to define mock.On call
+//   - symbol string
+func (_e *MockStockRepository_Expecter) FindBySymbol(symbol interface{}) *MockStockRepository_FindBySymbol_Call {
+	return &MockStockRepository_FindBySymbol_Call{Call: _e.mock.On("FindBySymbol", symbol)}
+}
+
+func (_c *MockStockRepository_FindBySymbol_Call) Run(run func(symbol string)) *MockStockRepository_FindBySymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStockRepository_FindBySymbol_Call) Return(_a0 *models.Stock, _a1 error) *MockStockRepository_FindBySymbol_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockRepository_FindBySymbol_Call) RunAndReturn(run func(string) (*models.Stock, error)) *MockStockRepository_FindBySymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSymbols provides a mock function with no fields
+func (_m *MockStockRepository) ListSymbols() ([]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSymbols")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStockRepository_ListSymbols_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSymbols'
+type MockStockRepository_ListSymbols_Call struct {
+	*mock.Call
+}
+
+// ListSymbols is a helper method to define mock.On call
+func (_e *MockStockRepository_Expecter) ListSymbols() *MockStockRepository_ListSymbols_Call {
+	return &MockStockRepository_ListSymbols_Call{Call: _e.mock.On("ListSymbols")}
+}
+
+func (_c *MockStockRepository_ListSymbols_Call) Run(run func()) *MockStockRepository_ListSymbols_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStockRepository_ListSymbols_Call) Return(_a0 []string, _a1 error) *MockStockRepository_ListSymbols_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStockRepository_ListSymbols_Call) RunAndReturn(run func() ([]string, error)) *MockStockRepository_ListSymbols_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockStockRepository creates a new instance of MockStockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockStockRepository(t interface {
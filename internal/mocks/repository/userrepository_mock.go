@@ -25,6 +25,119 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 	return &MockUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// AdvanceTOTPStep provides a mock function with given fields: id, step
+func (_m *MockUserRepository) AdvanceTOTPStep(id uuid.UUID, step int64) (bool, error) {
+	ret := _m.Called(id, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdvanceTOTPStep")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64) (bool, error)); ok {
+		return rf(id, step)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64) bool); ok {
+		r0 = rf(id, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int64) error); ok {
+		r1 = rf(id, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_AdvanceTOTPStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdvanceTOTPStep'
+type MockUserRepository_AdvanceTOTPStep_Call struct {
+	*mock.Call
+}
+
+// AdvanceTOTPStep is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - step int64
+func (_e *MockUserRepository_Expecter) AdvanceTOTPStep(id interface{}, step interface{}) *MockUserRepository_AdvanceTOTPStep_Call {
+	return &MockUserRepository_AdvanceTOTPStep_Call{Call: _e.mock.On("AdvanceTOTPStep", id, step)}
+}
+
+func (_c *MockUserRepository_AdvanceTOTPStep_Call) Run(run func(id uuid.UUID, step int64)) *MockUserRepository_AdvanceTOTPStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_AdvanceTOTPStep_Call) Return(_a0 bool, _a1 error) *MockUserRepository_AdvanceTOTPStep_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_AdvanceTOTPStep_Call) RunAndReturn(run func(uuid.UUID, int64) (bool, error)) *MockUserRepository_AdvanceTOTPStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByRole provides a mock function with given fields: role
+func (_m *MockUserRepository) CountByRole(role string) (int64, error) {
+	ret := _m.Called(role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(role)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_CountByRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByRole'
+type MockUserRepository_CountByRole_Call struct {
+	*mock.Call
+}
+
+// CountByRole is a helper method to define mock.On call
+//   - role string
+func (_e *MockUserRepository_Expecter) CountByRole(role interface{}) *MockUserRepository_CountByRole_Call {
+	return &MockUserRepository_CountByRole_Call{Call: _e.mock.On("CountByRole", role)}
+}
+
+func (_c *MockUserRepository_CountByRole_Call) Run(run func(role string)) *MockUserRepository_CountByRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_CountByRole_Call) Return(_a0 int64, _a1 error) *MockUserRepository_CountByRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_CountByRole_Call) RunAndReturn(run func(string) (int64, error)) *MockUserRepository_CountByRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: user
 func (_m *MockUserRepository) Create(user *models.User) error {
 	ret := _m.Called(user)
@@ -243,6 +356,157 @@ func (_c *MockUserRepository_FindByID_Call) RunAndReturn(run func(uuid.UUID) (*m
 	return _c
 }
 
+// List provides a mock function with no fields
+func (_m *MockUserRepository) List() ([]models.User, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.User, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.User); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockUserRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+func (_e *MockUserRepository_Expecter) List() *MockUserRepository_List_Call {
+	return &MockUserRepository_List_Call{Call: _e.mock.On("List")}
+}
+
+func (_c *MockUserRepository_List_Call) Run(run func()) *MockUserRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_List_Call) Return(_a0 []models.User, _a1 error) *MockUserRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_List_Call) RunAndReturn(run func() ([]models.User, error)) *MockUserRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkEmailVerified provides a mock function with given fields: id
+func (_m *MockUserRepository) MarkEmailVerified(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEmailVerified")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepository_MarkEmailVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEmailVerified'
+type MockUserRepository_MarkEmailVerified_Call struct {
+	*mock.Call
+}
+
+// MarkEmailVerified is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockUserRepository_Expecter) MarkEmailVerified(id interface{}) *MockUserRepository_MarkEmailVerified_Call {
+	return &MockUserRepository_MarkEmailVerified_Call{Call: _e.mock.On("MarkEmailVerified", id)}
+}
+
+func (_c *MockUserRepository_MarkEmailVerified_Call) Run(run func(id uuid.UUID)) *MockUserRepository_MarkEmailVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_MarkEmailVerified_Call) Return(_a0 error) *MockUserRepository_MarkEmailVerified_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepository_MarkEmailVerified_Call) RunAndReturn(run func(uuid.UUID) error) *MockUserRepository_MarkEmailVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTOTP provides a mock function with given fields: id, secret, enabled
+func (_m *MockUserRepository) SetTOTP(id uuid.UUID, secret string, enabled bool) error {
+	ret := _m.Called(id, secret, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, bool) error); ok {
+		r0 = rf(id, secret, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepository_SetTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTOTP'
+type MockUserRepository_SetTOTP_Call struct {
+	*mock.Call
+}
+
+// SetTOTP is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - secret string
+//   - enabled bool
+func (_e *MockUserRepository_Expecter) SetTOTP(id interface{}, secret interface{}, enabled interface{}) *MockUserRepository_SetTOTP_Call {
+	return &MockUserRepository_SetTOTP_Call{Call: _e.mock.On("SetTOTP", id, secret, enabled)}
+}
+
+func (_c *MockUserRepository_SetTOTP_Call) Run(run func(id uuid.UUID, secret string, enabled bool)) *MockUserRepository_SetTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SetTOTP_Call) Return(_a0 error) *MockUserRepository_SetTOTP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepository_SetTOTP_Call) RunAndReturn(run func(uuid.UUID, string, bool) error) *MockUserRepository_SetTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateLastLogin provides a mock function with given fields: id, when
 func (_m *MockUserRepository) UpdateLastLogin(id uuid.UUID, when time.Time) error {
 	ret := _m.Called(id, when)
@@ -290,6 +554,100 @@ func (_c *MockUserRepository_UpdateLastLogin_Call) RunAndReturn(run func(uuid.UU
 	return _c
 }
 
+// UpdatePassword provides a mock function with given fields: id, passwordHash
+func (_m *MockUserRepository) UpdatePassword(id uuid.UUID, passwordHash string) error {
+	ret := _m.Called(id, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) error); ok {
+		r0 = rf(id, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepository_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type MockUserRepository_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - passwordHash string
+func (_e *MockUserRepository_Expecter) UpdatePassword(id interface{}, passwordHash interface{}) *MockUserRepository_UpdatePassword_Call {
+	return &MockUserRepository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", id, passwordHash)}
+}
+
+func (_c *MockUserRepository_UpdatePassword_Call) Run(run func(id uuid.UUID, passwordHash string)) *MockUserRepository_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpdatePassword_Call) Return(_a0 error) *MockUserRepository_UpdatePassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepository_UpdatePassword_Call) RunAndReturn(run func(uuid.UUID, string) error) *MockUserRepository_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function with given fields: id, role
+func (_m *MockUserRepository) UpdateRole(id uuid.UUID, role string) error {
+	ret := _m.Called(id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) error); ok {
+		r0 = rf(id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepository_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type MockUserRepository_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - role string
+func (_e *MockUserRepository_Expecter) UpdateRole(id interface{}, role interface{}) *MockUserRepository_UpdateRole_Call {
+	return &MockUserRepository_UpdateRole_Call{Call: _e.mock.On("UpdateRole", id, role)}
+}
+
+func (_c *MockUserRepository_UpdateRole_Call) Run(run func(id uuid.UUID, role string)) *MockUserRepository_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateRole_Call) Return(_a0 error) *MockUserRepository_UpdateRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateRole_Call) RunAndReturn(run func(uuid.UUID, string) error) *MockUserRepository_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUserRepository creates a new instance of MockUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserRepository(t interface {
@@ -0,0 +1,342 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockUserTokenRepository is an autogenerated mock type for the UserTokenRepository type
+type MockUserTokenRepository struct {
+	mock.Mock
+}
+
+type MockUserTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserTokenRepository) EXPECT() *MockUserTokenRepository_Expecter {
+	return &MockUserTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: id, usedAt
+func (_m *MockUserTokenRepository) Consume(id uuid.UUID, usedAt time.Time) (bool, error) {
+	ret := _m.Called(id, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) (bool, error)); ok {
+		return rf(id, usedAt)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) bool); ok {
+		r0 = rf(id, usedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time) error); ok {
+		r1 = rf(id, usedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserTokenRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type MockUserTokenRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - usedAt time.Time
+func (_e *MockUserTokenRepository_Expecter) Consume(id interface{}, usedAt interface{}) *MockUserTokenRepository_Consume_Call {
+	return &MockUserTokenRepository_Consume_Call{Call: _e.mock.On("Consume", id, usedAt)}
+}
+
+func (_c *MockUserTokenRepository_Consume_Call) Run(run func(id uuid.UUID, usedAt time.Time)) *MockUserTokenRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepository_Consume_Call) Return(_a0 bool, _a1 error) *MockUserTokenRepository_Consume_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserTokenRepository_Consume_Call) RunAndReturn(run func(uuid.UUID, time.Time) (bool, error)) *MockUserTokenRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: token
+func (_m *MockUserTokenRepository) Create(token *models.UserToken) error {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.UserToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUserTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - token *models.UserToken
+func (_e *MockUserTokenRepository_Expecter) Create(token interface{}) *MockUserTokenRepository_Create_Call {
+	return &MockUserTokenRepository_Create_Call{Call: _e.mock.On("Create", token)}
+}
+
+func (_c *MockUserTokenRepository_Create_Call) Run(run func(token *models.UserToken)) *MockUserTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.UserToken))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepository_Create_Call) Return(_a0 error) *MockUserTokenRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserTokenRepository_Create_Call) RunAndReturn(run func(*models.UserToken) error) *MockUserTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBefore provides a mock function with given fields: t
+func (_m *MockUserTokenRepository) DeleteBefore(t time.Time) error {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserTokenRepository_DeleteBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBefore'
+type MockUserTokenRepository_DeleteBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteBefore is a helper method to define mock.On call
+//   - t time.Time
+func (_e *MockUserTokenRepository_Expecter) DeleteBefore(t interface{}) *MockUserTokenRepository_DeleteBefore_Call {
+	return &MockUserTokenRepository_DeleteBefore_Call{Call: _e.mock.On("DeleteBefore", t)}
+}
+
+func (_c *MockUserTokenRepository_DeleteBefore_Call) Run(run func(t time.Time)) *MockUserTokenRepository_DeleteBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepository_DeleteBefore_Call) Return(_a0 error) *MockUserTokenRepository_DeleteBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserTokenRepository_DeleteBefore_Call) RunAndReturn(run func(time.Time) error) *MockUserTokenRepository_DeleteBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByHash provides a mock function with given fields: purpose, hash
+func (_m *MockUserTokenRepository) FindByHash(purpose string, hash string) (*models.UserToken, error) {
+	ret := _m.Called(purpose, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByHash")
+	}
+
+	var r0 *models.UserToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*models.UserToken, error)); ok {
+		return rf(purpose, hash)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *models.UserToken); ok {
+		r0 = rf(purpose, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(purpose, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserTokenRepository_FindByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByHash'
+type MockUserTokenRepository_FindByHash_Call struct {
+	*mock.Call
+}
+
+// FindByHash is a helper method to define mock.On call
+//   - purpose string
+//   - hash string
+func (_e *MockUserTokenRepository_Expecter) FindByHash(purpose interface{}, hash interface{}) *MockUserTokenRepository_FindByHash_Call {
+	return &MockUserTokenRepository_FindByHash_Call{Call: _e.mock.On("FindByHash", purpose, hash)}
+}
+
+func (_c *MockUserTokenRepository_FindByHash_Call) Run(run func(purpose string, hash string)) *MockUserTokenRepository_FindByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepository_FindByHash_Call) Return(_a0 *models.UserToken, _a1 error) *MockUserTokenRepository_FindByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserTokenRepository_FindByHash_Call) RunAndReturn(run func(string, string) (*models.UserToken, error)) *MockUserTokenRepository_FindByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateForUser provides a mock function with given fields: userID, purpose, usedAt
+func (_m *MockUserTokenRepository) InvalidateForUser(userID uuid.UUID, purpose string, usedAt time.Time) error {
+	ret := _m.Called(userID, purpose, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateForUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, time.Time) error); ok {
+		r0 = rf(userID, purpose, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserTokenRepository_InvalidateForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateForUser'
+type MockUserTokenRepository_InvalidateForUser_Call struct {
+	*mock.Call
+}
+
+// InvalidateForUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - purpose string
+//   - usedAt time.Time
+func (_e *MockUserTokenRepository_Expecter) InvalidateForUser(userID interface{}, purpose interface{}, usedAt interface{}) *MockUserTokenRepository_InvalidateForUser_Call {
+	return &MockUserTokenRepository_InvalidateForUser_Call{Call: _e.mock.On("InvalidateForUser", userID, purpose, usedAt)}
+}
+
+func (_c *MockUserTokenRepository_InvalidateForUser_Call) Run(run func(userID uuid.UUID, purpose string, usedAt time.Time)) *MockUserTokenRepository_InvalidateForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepository_InvalidateForUser_Call) Return(_a0 error) *MockUserTokenRepository_InvalidateForUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserTokenRepository_InvalidateForUser_Call) RunAndReturn(run func(uuid.UUID, string, time.Time) error) *MockUserTokenRepository_InvalidateForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordAttempt provides a mock function with given fields: id
+func (_m *MockUserTokenRepository) RecordAttempt(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserTokenRepository_RecordAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAttempt'
+type MockUserTokenRepository_RecordAttempt_Call struct {
+	*mock.Call
+}
+
+// RecordAttempt is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockUserTokenRepository_Expecter) RecordAttempt(id interface{}) *MockUserTokenRepository_RecordAttempt_Call {
+	return &MockUserTokenRepository_RecordAttempt_Call{Call: _e.mock.On("RecordAttempt", id)}
+}
+
+func (_c *MockUserTokenRepository_RecordAttempt_Call) Run(run func(id uuid.UUID)) *MockUserTokenRepository_RecordAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepository_RecordAttempt_Call) Return(_a0 error) *MockUserTokenRepository_RecordAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserTokenRepository_RecordAttempt_Call) RunAndReturn(run func(uuid.UUID) error) *MockUserTokenRepository_RecordAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserTokenRepository creates a new instance of MockUserTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserTokenRepository {
+	mock := &MockUserTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
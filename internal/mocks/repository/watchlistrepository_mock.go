@@ -0,0 +1,498 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package repository_mock
+
+import (
+	models "sun-stockanalysis-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockWatchlistRepository is an autogenerated mock type for the WatchlistRepository type
+type MockWatchlistRepository struct {
+	mock.Mock
+}
+
+type MockWatchlistRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWatchlistRepository) EXPECT() *MockWatchlistRepository_Expecter {
+	return &MockWatchlistRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddSymbol provides a mock function with given fields: watchlistID, symbol
+func (_m *MockWatchlistRepository) AddSymbol(watchlistID uuid.UUID, symbol string) error {
+	ret := _m.Called(watchlistID, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSymbol")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) error); ok {
+		r0 = rf(watchlistID, symbol)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWatchlistRepository_AddSymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSymbol'
+type MockWatchlistRepository_AddSymbol_Call struct {
+	*mock.Call
+}
+
+// AddSymbol is a helper method to define mock.On call
+//   - watchlistID uuid.UUID
+//   - symbol string
+func (_e *MockWatchlistRepository_Expecter) AddSymbol(watchlistID interface{}, symbol interface{}) *MockWatchlistRepository_AddSymbol_Call {
+	return &MockWatchlistRepository_AddSymbol_Call{Call: _e.mock.On("AddSymbol", watchlistID, symbol)}
+}
+
+func (_c *MockWatchlistRepository_AddSymbol_Call) Run(run func(watchlistID uuid.UUID, symbol string)) *MockWatchlistRepository_AddSymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWatchlistRepository_AddSymbol_Call) Return(_a0 error) *MockWatchlistRepository_AddSymbol_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWatchlistRepository_AddSymbol_Call) RunAndReturn(run func(uuid.UUID, string) error) *MockWatchlistRepository_AddSymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: watchlist
+func (_m *MockWatchlistRepository) Create(watchlist *models.Watchlist) error {
+	ret := _m.Called(watchlist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Watchlist) error); ok {
+		r0 = rf(watchlist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWatchlistRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWatchlistRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - watchlist *models.Watchlist
+func (_e *MockWatchlistRepository_Expecter) Create(watchlist interface{}) *MockWatchlistRepository_Create_Call {
+	return &MockWatchlistRepository_Create_Call{Call: _e.mock.On("Create", watchlist)}
+}
+
+func (_c *MockWatchlistRepository_Create_Call) Run(run func(watchlist *models.Watchlist)) *MockWatchlistRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Watchlist))
+	})
+	return _c
+}
+
+func (_c *MockWatchlistRepository_Create_Call) Return(_a0 error) *MockWatchlistRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWatchlistRepository_Create_Call) RunAndReturn(run func(*models.Watchlist) error) *MockWatchlistRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockWatchlistRepository) Delete(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWatchlistRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockWatchlistRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockWatchlistRepository_Expecter) Delete(id interface{}) *MockWatchlistRepository_Delete_Call {
+	return &MockWatchlistRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockWatchlistRepository_Delete_Call) Run(run func(id uuid.UUID)) *MockWatchlistRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWatchlistRepository_Delete_Call) Return(_a0 error) *MockWatchlistRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWatchlistRepository_Delete_Call) RunAndReturn(run func(uuid.UUID) error) *MockWatchlistRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: id
+func (_m *MockWatchlistRepository) FindByID(id uuid.UUID) (*models.Watchlist, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.Watchlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*models.Watchlist, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Watchlist); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Watchlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWatchlistRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockWatchlistRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *MockWatchlistRepository_Expecter) FindByID(id interface{}) *MockWatchlistRepository_FindByID_Call {
+	return &MockWatchlistRepository_FindByID_Call{Call: _e.mock.On("FindByID", id)}
+}
+
+func (_c *MockWatchlistRepository_FindByID_Call) Run(run func(id uuid.UUID)) *MockWatchlistRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWatchlistRepository_FindByID_Call) Return(_a0 *models.Watchlist, _a1 error) *MockWatchlistRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWatchlistRepository_FindByID_Call) RunAndReturn(run func(uuid.UUID) (*models.Watchlist, error)) *MockWatchlistRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByUser provides a mock function with given fields: userID
+func (_m *MockWatchlistRepository) FindByUser(userID uuid.UUID) ([]models.Watchlist, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUser")
+	}
+
+	var r0 []models.Watchlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]models.Watchlist, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []models.Watchlist); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Watchlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWatchlistRepository_FindByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUser'
+type MockWatchlistRepository_FindByUser_Call struct {
+	*mock.Call
+}
+
+// FindByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *MockWatchlistRepository_Expecter) FindByUser(userID interface{}) *MockWatchlistRepository_FindByUser_Call {
+	return &MockWatchlistRepository_FindByUser_Call{Call: _e.mock.On("FindByUser", userID)}
+}
+
+func (_c *MockWatchlistRepository_FindByUser_Call) Run(run func(userID uuid.UUID)) *MockWatchlistRepository_FindByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWatchlistRepository_FindByUser_Call) Return(_a0 []models.Watchlist, _a1 error) *MockWatchlistRepository_FindByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWatchlistRepository_FindByUser_Call) RunAndReturn(run func(uuid.UUID) ([]models.Watchlist, error)) *MockWatchlistRepository_FindByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindSymbolsByUser provides a mock function with given fields: userID
+func (_m *MockWatchlistRepository) FindSymbolsByUser(userID uuid.UUID) ([]string, bool, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSymbolsByUser")
+	}
+
+	var r0 []string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]string, bool, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []string); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) bool); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID) error); ok {
+		r2 = rf(userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockWatchlistRepository_FindSymbolsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSymbolsByUser'
+type MockWatchlistRepository_FindSymbolsByUser_Call struct {
+	*mock.Call
+}
+
+// FindSymbolsByUser is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *MockWatchlistRepository_Expecter) FindSymbolsByUser(userID interface{}) *MockWatchlistRepository_FindSymbolsByUser_Call {
+	return &MockWatchlistRepository_FindSymbolsByUser_Call{Call: _e.mock.On("FindSymbolsByUser", userID)}
+}
+
+func (_c *MockWatchlistRepository_FindSymbolsByUser_Call) Run(run func(userID uuid.UUID)) *MockWatchlistRepository_FindSymbolsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWatchlistRepository_FindSymbolsByUser_Call) Return(_a0 []string, _a1 bool, _a2 error) *MockWatchlistRepository_FindSymbolsByUser_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockWatchlistRepository_FindSymbolsByUser_Call) RunAndReturn(run func(uuid.UUID) ([]string, bool, error)) *MockWatchlistRepository_FindSymbolsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveSymbol provides a mock function with given fields: watchlistID, symbol
+func (_m *MockWatchlistRepository) RemoveSymbol(watchlistID uuid.UUID, symbol string) error {
+	ret := _m.Called(watchlistID, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveSymbol")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) error); ok {
+		r0 = rf(watchlistID, symbol)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWatchlistRepository_RemoveSymbol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveSymbol'
+type MockWatchlistRepository_RemoveSymbol_Call struct {
+	*mock.Call
+}
+
+// RemoveSymbol is a helper method to define mock.On call
+//   - watchlistID uuid.UUID
+//   - symbol string
+func (_e *MockWatchlistRepository_Expecter) RemoveSymbol(watchlistID interface{}, symbol interface{}) *MockWatchlistRepository_RemoveSymbol_Call {
+	return &MockWatchlistRepository_RemoveSymbol_Call{Call: _e.mock.On("RemoveSymbol", watchlistID, symbol)}
+}
+
+func (_c *MockWatchlistRepository_RemoveSymbol_Call) Run(run func(watchlistID uuid.UUID, symbol string)) *MockWatchlistRepository_RemoveSymbol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWatchlistRepository_RemoveSymbol_Call) Return(_a0 error) *MockWatchlistRepository_RemoveSymbol_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWatchlistRepository_RemoveSymbol_Call) RunAndReturn(run func(uuid.UUID, string) error) *MockWatchlistRepository_RemoveSymbol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceSymbols provides a mock function with given fields: watchlistID, symbols
+func (_m *MockWatchlistRepository) ReplaceSymbols(watchlistID uuid.UUID, symbols []string) error {
+	ret := _m.Called(watchlistID, symbols)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceSymbols")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []string) error); ok {
+		r0 = rf(watchlistID, symbols)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWatchlistRepository_ReplaceSymbols_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSymbols'
+type MockWatchlistRepository_ReplaceSymbols_Call struct {
+	*mock.Call
+}
+
+// ReplaceSymbols is a helper method to define mock.On call
+//   - watchlistID uuid.UUID
+//   - symbols []string
+func (_e *MockWatchlistRepository_Expecter) ReplaceSymbols(watchlistID interface{}, symbols interface{}) *MockWatchlistRepository_ReplaceSymbols_Call {
+	return &MockWatchlistRepository_ReplaceSymbols_Call{Call: _e.mock.On("ReplaceSymbols", watchlistID, symbols)}
+}
+
+func (_c *MockWatchlistRepository_ReplaceSymbols_Call) Run(run func(watchlistID uuid.UUID, symbols []string)) *MockWatchlistRepository_ReplaceSymbols_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockWatchlistRepository_ReplaceSymbols_Call) Return(_a0 error) *MockWatchlistRepository_ReplaceSymbols_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWatchlistRepository_ReplaceSymbols_Call) RunAndReturn(run func(uuid.UUID, []string) error) *MockWatchlistRepository_ReplaceSymbols_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateName provides a mock function with given fields: watchlist
+func (_m *MockWatchlistRepository) UpdateName(watchlist *models.Watchlist) error {
+	ret := _m.Called(watchlist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateName")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Watchlist) error); ok {
+		r0 = rf(watchlist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWatchlistRepository_UpdateName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateName'
+type MockWatchlistRepository_UpdateName_Call struct {
+	*mock.Call
+}
+
+// UpdateName is a helper method to define mock.On call
+//   - watchlist *models.Watchlist
+func (_e *MockWatchlistRepository_Expecter) UpdateName(watchlist interface{}) *MockWatchlistRepository_UpdateName_Call {
+	return &MockWatchlistRepository_UpdateName_Call{Call: _e.mock.On("UpdateName", watchlist)}
+}
+
+func (_c *MockWatchlistRepository_UpdateName_Call) Run(run func(watchlist *models.Watchlist)) *MockWatchlistRepository_UpdateName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Watchlist))
+	})
+	return _c
+}
+
+func (_c *MockWatchlistRepository_UpdateName_Call) Return(_a0 error) *MockWatchlistRepository_UpdateName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWatchlistRepository_UpdateName_Call) RunAndReturn(run func(*models.Watchlist) error) *MockWatchlistRepository_UpdateName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWatchlistRepository creates a new instance of MockWatchlistRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWatchlistRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWatchlistRepository {
+	mock := &MockWatchlistRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
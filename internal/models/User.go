@@ -4,15 +4,24 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	RoleUser  = "USER"
+	RoleAdmin = "ADMIN"
+)
+
 type User struct {
-	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Email       string    `gorm:"type:varchar(64);uniqueIndex;" json:"email"`
-	Password    string    `gorm:"type:varchar(128);" json:"password"`
-	FirstName   string    `gorm:"type:varchar(64);" json:"first_name"`
-	LastName    string    `gorm:"type:varchar(64);" json:"last_name"`
-	LastLoginAt LocalTime `gorm:"autoUpdateTime" json:"last_login_at"`
-	Role        string    `gorm:"not null;" json:"role"`
-	IsActive    bool      `gorm:"not null;default:true;" json:"is_active"`
-	CreatedAt   LocalTime `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   LocalTime `gorm:"autoUpdateTime" json:"updated_at"`
+	ID              uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Email           string    `gorm:"type:varchar(64);uniqueIndex;" json:"email"`
+	Password        string    `gorm:"type:varchar(128);" json:"password"`
+	FirstName       string    `gorm:"type:varchar(64);" json:"first_name"`
+	LastName        string    `gorm:"type:varchar(64);" json:"last_name"`
+	LastLoginAt     LocalTime `gorm:"autoUpdateTime" json:"last_login_at"`
+	Role            string    `gorm:"not null;" json:"role"`
+	IsActive        bool      `gorm:"not null;default:true;" json:"is_active"`
+	IsEmailVerified bool      `gorm:"not null;default:false;" json:"is_email_verified"`
+	TOTPSecret      string    `gorm:"type:varchar(64);" json:"-"`
+	TOTPEnabled     bool      `gorm:"not null;default:false;" json:"totp_enabled"`
+	TOTPLastStep    int64     `gorm:"not null;default:0;" json:"-"`
+	CreatedAt       LocalTime `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       LocalTime `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -2,13 +2,16 @@ package models
 
 import "github.com/google/uuid"
 
+// AlertEvent records one firing of an alert rule for its owner.
 type AlertEvent struct {
 	ID           uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Seq          int64     `gorm:"autoIncrement;uniqueIndex" json:"seq"`
+	RuleID       uuid.UUID `gorm:"type:uuid;not null;index" json:"rule_id"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
 	Symbol       string    `gorm:"type:varchar(64);not null;index" json:"symbol"`
-	TrendEMA20   int       `gorm:"column:trend_ema_20;not null" json:"trend_ema_20"`
-	TrendTanhEMA int       `gorm:"column:trend_tanh_ema;not null" json:"trend_tanh_ema"`
-	ScoreEMA        float64   `gorm:"not null" json:"score_ema"`
-	ScorePCrossEMA        float64   `gorm:"not null" json:"score_p_cross_ema"`
+	RuleName     string    `gorm:"type:varchar(128);not null" json:"rule_name"`
+	Message      string    `gorm:"type:text;not null" json:"message"`
+	PriceCurrent float64   `gorm:"column:price_current;not null" json:"price_current"`
 	CreatedAt    LocalTime `gorm:"autoCreateTime" json:"created_at"`
 }
 
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AlertCondition is one node of an alert rule's condition tree. Leaf nodes
+// compare quote values; "and"/"or" nodes combine their Conditions.
+type AlertCondition struct {
+	Type       string           `json:"type" enum:"price_cross,change_percent,ema_cross,indicator,and,or" doc:"Condition kind"`
+	Direction  string           `json:"direction,omitempty" enum:"above,below" doc:"Cross direction for price_cross and ema_cross"`
+	Level      float64          `json:"level,omitempty" doc:"Price level for price_cross"`
+	Field      string           `json:"field,omitempty" doc:"Indicator field for indicator conditions"`
+	Operator   string           `json:"operator,omitempty" enum:">,>=,<,<=" doc:"Comparison for change_percent and indicator conditions"`
+	Value      float64          `json:"value,omitempty" doc:"Threshold for change_percent and indicator conditions"`
+	Conditions []AlertCondition `json:"conditions,omitempty" doc:"Children of and/or conditions"`
+}
+
+// AlertRule is a user-owned condition evaluated on every new quote of Symbol.
+// A rule fires at most once per CooldownSeconds.
+type AlertRule struct {
+	ID              uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID          uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name            string         `gorm:"type:varchar(128);not null" json:"name"`
+	Symbol          string         `gorm:"type:varchar(64);not null;index" json:"symbol"`
+	Condition       AlertCondition `gorm:"type:jsonb;serializer:json;not null" json:"condition"`
+	CooldownSeconds int            `gorm:"not null;default:0" json:"cooldown_seconds"`
+	IsActive        bool           `gorm:"not null;default:true;index" json:"is_active"`
+	LastTriggeredAt *LocalTime     `gorm:"" json:"last_triggered_at"`
+	CreatedAt       LocalTime      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       LocalTime      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (AlertRule) TableName() string {
+	return "alert_rules"
+}
+
+func (r *AlertRule) BeforeCreate(_ *gorm.DB) error {
+	now := NewLocalTime(time.Now())
+	if time.Time(r.CreatedAt).IsZero() {
+		r.CreatedAt = now
+	}
+	r.UpdatedAt = now
+	return nil
+}
+
+func (r *AlertRule) BeforeUpdate(_ *gorm.DB) error {
+	r.UpdatedAt = NewLocalTime(time.Now())
+	return nil
+}
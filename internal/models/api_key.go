@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeWrite = "write"
+)
+
+// APIKey is a long-lived personal credential for scripts and bots. Prefix is
+// the public head of the key shown in listings; only the SHA-256 hash of the
+// whole key is stored.
+type APIKey struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name       string     `gorm:"type:varchar(128);not null" json:"name"`
+	Prefix     string     `gorm:"type:varchar(16);not null;index" json:"prefix"`
+	KeyHash    string     `gorm:"type:varchar(128);not null;uniqueIndex" json:"-"`
+	Scope      string     `gorm:"type:varchar(16);not null" json:"scope"`
+	ExpiresAt  *LocalTime `gorm:"" json:"expires_at"`
+	LastUsedAt *LocalTime `gorm:"" json:"last_used_at"`
+	RevokedAt  *LocalTime `gorm:"" json:"-"`
+	CreatedAt  LocalTime  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
@@ -0,0 +1,32 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	BackfillJobQueued    = "queued"
+	BackfillJobRunning   = "running"
+	BackfillJobCompleted = "completed"
+	BackfillJobFailed    = "failed"
+)
+
+// BackfillJob is a historical candle backfill of one symbol. Jobs live in the
+// database so every replica can run and show them.
+type BackfillJob struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Symbol          string     `gorm:"type:varchar(64);not null;index" json:"symbol"`
+	Status          string     `gorm:"type:varchar(16);not null;index" json:"status"`
+	Stage           string     `gorm:"type:varchar(16);not null;default:''" json:"stage"`
+	Progress        int        `gorm:"not null;default:0" json:"progress"`
+	DailyCandles    int        `gorm:"not null;default:0" json:"daily_candles"`
+	DailyInserted   int        `gorm:"not null;default:0" json:"daily_inserted"`
+	IntradayCandles int        `gorm:"not null;default:0" json:"intraday_candles"`
+	QuotesInserted  int        `gorm:"not null;default:0" json:"quotes_inserted"`
+	Error           string     `gorm:"type:text" json:"error"`
+	CreatedAt       LocalTime  `gorm:"not null;index" json:"created_at"`
+	StartedAt       *LocalTime `gorm:"" json:"started_at"`
+	FinishedAt      *LocalTime `gorm:"" json:"finished_at"`
+}
+
+func (BackfillJob) TableName() string {
+	return "backfill_jobs"
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+const (
+	BacktestJobQueued    = "queued"
+	BacktestJobRunning   = "running"
+	BacktestJobSucceeded = "succeeded"
+	BacktestJobFailed    = "failed"
+	BacktestJobCanceled  = "canceled"
+)
+
+// BacktestJob is a strategy backtest queued by a user. Jobs live in the
+// database so every replica can run, show and cancel them. Params and Result
+// hold the JSON documents of the backtests package.
+type BacktestJob struct {
+	ID         uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID     uuid.UUID       `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status     string          `gorm:"type:varchar(16);not null;index" json:"status"`
+	Symbol     string          `gorm:"type:varchar(64);not null" json:"symbol"`
+	Source     string          `gorm:"type:varchar(16);not null" json:"source"`
+	Strategy   string          `gorm:"type:varchar(16);not null" json:"strategy"`
+	RangeFrom  LocalTime       `gorm:"not null" json:"range_from"`
+	RangeTo    LocalTime       `gorm:"not null" json:"range_to"`
+	Params     json.RawMessage `gorm:"type:jsonb;not null" json:"params"`
+	Result     json.RawMessage `gorm:"type:jsonb" json:"result"`
+	Error      string          `gorm:"type:text" json:"error"`
+	CreatedAt  LocalTime       `gorm:"not null;index" json:"created_at"`
+	StartedAt  *LocalTime      `gorm:"" json:"started_at"`
+	FinishedAt *LocalTime      `gorm:"" json:"finished_at"`
+}
+
+func (BacktestJob) TableName() string {
+	return "backtest_jobs"
+}
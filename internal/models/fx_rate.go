@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// FXRate is the number of Quote units one unit of Base bought on RateDate.
+// Source records whether the rate came from the market data provider or the
+// static fallback file.
+type FXRate struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Base      string    `gorm:"type:varchar(10);not null;index:idx_fx_rates_pair_date,unique" json:"base"`
+	Quote     string    `gorm:"type:varchar(10);not null;index:idx_fx_rates_pair_date,unique" json:"quote"`
+	RateDate  LocalDate `gorm:"type:date;not null;index:idx_fx_rates_pair_date,unique" json:"rate_date"`
+	Rate      float64   `gorm:"not null" json:"rate"`
+	Source    string    `gorm:"type:varchar(32);not null" json:"source"`
+	CreatedAt LocalTime `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt LocalTime `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (FXRate) TableName() string {
+	return "fx_rates"
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	HoldingBuy      = "buy"
+	HoldingSell     = "sell"
+	HoldingDividend = "dividend"
+)
+
+// HoldingTransaction records a real trade or dividend of a user. Prices,
+// fees and amounts are in the stock's Currency; Amount is the gross cash of a
+// dividend and unused for trades.
+type HoldingTransaction struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index:idx_holding_tx_user_symbol,priority:1" json:"user_id"`
+	Symbol    string    `gorm:"type:varchar(64);not null;index:idx_holding_tx_user_symbol,priority:2" json:"symbol"`
+	Type      string    `gorm:"type:varchar(16);not null" json:"type"`
+	Quantity  float64   `gorm:"not null;default:0" json:"quantity"`
+	Price     float64   `gorm:"not null;default:0" json:"price"`
+	Fee       float64   `gorm:"not null;default:0" json:"fee"`
+	Amount    float64   `gorm:"not null;default:0" json:"amount"`
+	Currency  string    `gorm:"type:varchar(10);not null" json:"currency"`
+	TradedAt  LocalTime `gorm:"not null;index" json:"traded_at"`
+	Note      string    `gorm:"type:text" json:"note,omitempty"`
+	CreatedAt LocalTime `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt LocalTime `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (HoldingTransaction) TableName() string {
+	return "holding_transactions"
+}
+
+func (t *HoldingTransaction) BeforeCreate(_ *gorm.DB) error {
+	now := NewLocalTime(time.Now())
+	if time.Time(t.CreatedAt).IsZero() {
+		t.CreatedAt = now
+	}
+	t.UpdatedAt = now
+	return nil
+}
+
+func (t *HoldingTransaction) BeforeUpdate(_ *gorm.DB) error {
+	t.UpdatedAt = NewLocalTime(time.Now())
+	return nil
+}
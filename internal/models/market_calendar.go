@@ -0,0 +1,51 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// MarketCalendar defines the regular trading session of an exchange in its
+// own time zone. Clock fields are "HH:MM" exchange-local times; PreOpen and
+// PostClose are empty when the exchange has no extended session. Weekdays
+// lists the trading days as comma separated Go weekday numbers (Sunday = 0).
+// ProviderCode is the exchange code used to ingest holidays.
+type MarketCalendar struct {
+	Code         string    `gorm:"type:varchar(16);primaryKey" json:"code"`
+	Name         string    `gorm:"type:varchar(120);not null" json:"name"`
+	Timezone     string    `gorm:"type:varchar(64);not null" json:"timezone"`
+	PreOpen      string    `gorm:"type:varchar(5)" json:"pre_open,omitempty"`
+	Open         string    `gorm:"type:varchar(5);not null" json:"open"`
+	Close        string    `gorm:"type:varchar(5);not null" json:"close"`
+	PostClose    string    `gorm:"type:varchar(5)" json:"post_close,omitempty"`
+	Weekdays     string    `gorm:"type:varchar(16);not null;default:'1,2,3,4,5'" json:"weekdays"`
+	ProviderCode string    `gorm:"type:varchar(16)" json:"provider_code,omitempty"`
+	CreatedAt    LocalTime `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    LocalTime `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (MarketCalendar) TableName() string {
+	return "market_calendars"
+}
+
+const (
+	HolidaySourceProvider = "provider"
+	HolidaySourceManual   = "manual"
+)
+
+// MarketHoliday closes a calendar for the day or, when EarlyClose is set,
+// shortens the regular session to end at that exchange-local time. Manual
+// rows are never overwritten by provider ingestion.
+type MarketHoliday struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	CalendarCode string    `gorm:"type:varchar(16);not null;index:idx_market_holidays_calendar_date,unique" json:"calendar_code"`
+	Date         LocalDate `gorm:"type:date;not null;index:idx_market_holidays_calendar_date,unique" json:"date"`
+	Name         string    `gorm:"type:varchar(160);not null" json:"name"`
+	EarlyClose   string    `gorm:"type:varchar(5)" json:"early_close,omitempty"`
+	Source       string    `gorm:"type:varchar(16);not null" json:"source"`
+	CreatedAt    LocalTime `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    LocalTime `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (MarketHoliday) TableName() string {
+	return "market_holidays"
+}
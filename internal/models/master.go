@@ -12,10 +12,13 @@ func (MasterAssetType) TableName() string {
 	return "master_asset_type"
 }
 
+// MasterExchange maps a profile exchange name to the market calendar that
+// governs its trading sessions.
 type MasterExchange struct {
-	ID       uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Name     string    `gorm:"type:varchar(120);not null;unique" json:"name"`
-	IsActive bool      `gorm:"not null;default:true" json:"is_active"`
+	ID           uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name         string    `gorm:"type:varchar(120);not null;unique" json:"name"`
+	IsActive     bool      `gorm:"not null;default:true" json:"is_active"`
+	CalendarCode string    `gorm:"type:varchar(16);not null;default:'US'" json:"calendar_code"`
 }
 
 func (MasterExchange) TableName() string {
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// MFARecoveryCode is a single-use code that stands in for a TOTP code when
+// the authenticator is lost. Only the SHA-256 hash is stored.
+type MFARecoveryCode struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"type:varchar(128);not null" json:"-"`
+	UsedAt    *LocalTime `gorm:"" json:"used_at"`
+	CreatedAt LocalTime  `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	OrderSideBuy  = "buy"
+	OrderSideSell = "sell"
+
+	OrderTypeMarket = "market"
+	OrderTypeLimit  = "limit"
+
+	OrderStatusOpen     = "open"
+	OrderStatusFilled   = "filled"
+	OrderStatusCanceled = "canceled"
+	OrderStatusRejected = "rejected"
+)
+
+// Portfolio is a paper-trading account owned by a user. Cash moves only when
+// orders fill; RealizedPnL accumulates the profit of every filled sell.
+type Portfolio struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name        string    `gorm:"type:varchar(128);not null" json:"name"`
+	InitialCash float64   `gorm:"not null" json:"initial_cash"`
+	Cash        float64   `gorm:"not null" json:"cash"`
+	RealizedPnL float64   `gorm:"column:realized_pnl;not null;default:0" json:"realized_pnl"`
+	CreatedAt   LocalTime `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   LocalTime `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Portfolio) TableName() string {
+	return "portfolios"
+}
+
+func (p *Portfolio) BeforeCreate(_ *gorm.DB) error {
+	now := NewLocalTime(time.Now())
+	if time.Time(p.CreatedAt).IsZero() {
+		p.CreatedAt = now
+	}
+	p.UpdatedAt = now
+	return nil
+}
+
+func (p *Portfolio) BeforeUpdate(_ *gorm.DB) error {
+	p.UpdatedAt = NewLocalTime(time.Now())
+	return nil
+}
+
+// PortfolioOrder is a simulated order. Market orders fill on placement at the
+// latest stored quote; limit orders stay open until a quote crosses the limit.
+type PortfolioOrder struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	PortfolioID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"portfolio_id"`
+	Symbol       string     `gorm:"type:varchar(64);not null;index" json:"symbol"`
+	Side         string     `gorm:"type:varchar(8);not null" json:"side"`
+	Type         string     `gorm:"type:varchar(16);not null" json:"type"`
+	Quantity     float64    `gorm:"not null" json:"quantity"`
+	LimitPrice   *float64   `gorm:"" json:"limit_price,omitempty"`
+	Status       string     `gorm:"type:varchar(16);not null;index" json:"status"`
+	FilledPrice  *float64   `gorm:"" json:"filled_price,omitempty"`
+	FilledAt     *LocalTime `gorm:"" json:"filled_at,omitempty"`
+	QuoteID      *uuid.UUID `gorm:"type:uuid" json:"quote_id,omitempty"`
+	RealizedPnL  *float64   `gorm:"column:realized_pnl" json:"realized_pnl,omitempty"`
+	RejectReason string     `gorm:"type:text" json:"reject_reason,omitempty"`
+	CreatedAt    LocalTime  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    LocalTime  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (PortfolioOrder) TableName() string {
+	return "portfolio_orders"
+}
+
+func (o *PortfolioOrder) BeforeCreate(_ *gorm.DB) error {
+	now := NewLocalTime(time.Now())
+	if time.Time(o.CreatedAt).IsZero() {
+		o.CreatedAt = now
+	}
+	o.UpdatedAt = now
+	return nil
+}
+
+func (o *PortfolioOrder) BeforeUpdate(_ *gorm.DB) error {
+	o.UpdatedAt = NewLocalTime(time.Now())
+	return nil
+}
+
+// PortfolioPosition is the open holding of one symbol. Rows are removed once
+// the quantity is sold down to zero.
+type PortfolioPosition struct {
+	PortfolioID uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	Symbol      string    `gorm:"type:varchar(64);primaryKey" json:"symbol"`
+	Quantity    float64   `gorm:"not null" json:"quantity"`
+	AvgCost     float64   `gorm:"not null" json:"avg_cost"`
+	UpdatedAt   LocalTime `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (PortfolioPosition) TableName() string {
+	return "portfolio_positions"
+}
+
+func (p *PortfolioPosition) BeforeSave(_ *gorm.DB) error {
+	p.UpdatedAt = NewLocalTime(time.Now())
+	return nil
+}
@@ -4,11 +4,18 @@ import (
 	"github.com/google/uuid"
 )
 
+// RefreshTokens stores one refresh token per row. Every rotation inserts a
+// new row in the same family and revokes the previous one, so a family is a
+// login session and the unrevoked row is its current token.
 type RefreshTokens struct {
-	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	UserID    string    `gorm:"type:varchar(64);" json:"user_id"`
-	TokenHash string    `gorm:"type:varchar(128);" json:"token_hash"`
-	ExpiresAt string    `gorm:"type:varchar(64);" json:"expires_at"`
-	RevokedAt float64   `gorm:"not null;" json:"revoked_at"`
-	CreatedAt LocalTime `gorm:"autoCreateTime" json:"created_at"`
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    string     `gorm:"type:varchar(64);index" json:"user_id"`
+	FamilyID  uuid.UUID  `gorm:"type:uuid;not null;default:gen_random_uuid();index" json:"family_id"`
+	TokenHash string     `gorm:"type:varchar(128);index" json:"token_hash"`
+	Device    string     `gorm:"type:varchar(128)" json:"device"`
+	UserAgent string     `gorm:"type:text" json:"user_agent"`
+	IPAddress string     `gorm:"type:varchar(64)" json:"ip_address"`
+	ExpiresAt LocalTime  `gorm:"not null" json:"expires_at"`
+	RevokedAt *LocalTime `gorm:"" json:"revoked_at"`
+	CreatedAt LocalTime  `gorm:"autoCreateTime" json:"created_at"`
 }
@@ -4,22 +4,33 @@ import (
 	"github.com/google/uuid"
 )
 
+// StockDaily summarises one trading session of a symbol. PricePrevClose is
+// the close of the previous stored session and DeltaPrice is the session
+// range (high - low).
 type StockDaily struct {
 	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Symbol         string    `gorm:"type:varchar(64);not null;index" json:"symbol"`
+	Symbol         string    `gorm:"type:varchar(64);not null;index;index:idx_stock_daily_symbol_date,unique" json:"symbol"`
 	PriceAverage   float64   `gorm:"not null" json:"price_average"`
 	PriceHigh      float64   `gorm:"not null" json:"price_high"`
 	PriceLow       float64   `gorm:"not null" json:"price_low"`
 	PriceOpen      float64   `gorm:"not null" json:"price_open"`
+	PriceClose     float64   `gorm:"not null;default:0" json:"price_close"`
 	PricePrevClose float64   `gorm:"not null" json:"price_prev_close"`
-	ChangePrice   *float64  `gorm:"" json:"change_price"`
-	ChangePercent *float64  `gorm:"" json:"change_percent"`
-	DeltaPrice     float64   `gorm:"column:dalta_price;not null" json:"dalta_price"`
+	ChangePrice    *float64  `gorm:"" json:"change_price"`
+	ChangePercent  *float64  `gorm:"" json:"change_percent"`
+	DeltaPrice     float64   `gorm:"column:delta_price;not null" json:"delta_price"`
+	Volume         *float64  `gorm:"" json:"volume"`
 	EMA20          float64   `gorm:"column:ema_20;not null" json:"ema_20"`
 	EMA100         float64   `gorm:"column:ema_100;not null" json:"ema_100"`
 	EMATrend       int       `gorm:"column:ema_trend;not null" json:"ema_trend"`
-	TradeDate      LocalDate `gorm:"column:trend_date;not null" json:"trend_date"`
+	TradeDate      LocalDate `gorm:"column:trade_date;not null;index:idx_stock_daily_symbol_date,unique" json:"trade_date"`
 	CreatedAt      LocalTime `gorm:"autoCreateTime" json:"created_at"`
+
+	// Currency and FXRate are only set when prices were converted on request.
+	Currency string   `gorm:"-" json:"currency,omitempty"`
+	FXRate   *float64 `gorm:"-" json:"fx_rate,omitempty"`
+
+	TechnicalIndicators `gorm:"embedded"`
 }
 
 func (StockDaily) TableName() string {
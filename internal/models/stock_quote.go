@@ -6,10 +6,12 @@ import (
 
 type StockQuote struct {
 	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Seq           int64     `gorm:"autoIncrement;uniqueIndex" json:"seq"`
 	Symbol        string    `gorm:"type:varchar(64);not null;index" json:"symbol"`
 	PriceCurrent  float64   `gorm:"column:price_current;not null" json:"price_current"`
 	ChangePrice   *float64  `gorm:"" json:"change_price"`
 	ChangePercent *float64  `gorm:"" json:"change_percent"`
+	Volume        *float64  `gorm:"" json:"volume"`
 	EMA20         float64   `gorm:"column:ema_20;not null" json:"ema_20"`
 	EMA100        float64   `gorm:"column:ema_100;not null" json:"ema_100"`
 	TanhEMA       float64   `gorm:"column:tanh_ema;not null" json:"tanh_ema"`
@@ -17,6 +19,12 @@ type StockQuote struct {
 	ChangeTanhEMA float64   `gorm:"column:change_tanh_ema;not null" json:"change_tanh_ema"`
 	EMATrend      int       `gorm:"column:ema_trend;not null" json:"ema_trend"`
 	CreatedAt     LocalTime `gorm:"autoCreateTime" json:"created_at"`
+
+	// Currency and FXRate are only set when prices were converted on request.
+	Currency string   `gorm:"-" json:"currency,omitempty"`
+	FXRate   *float64 `gorm:"-" json:"fx_rate,omitempty"`
+
+	TechnicalIndicators `gorm:"embedded"`
 }
 
 func (StockQuote) TableName() string {
@@ -0,0 +1,67 @@
+package models
+
+import "sun-stockanalysis-api/internal/indicators"
+
+// TechnicalIndicators are the RSI(14), MACD(12,26,9), Bollinger(20,2) and
+// ATR(14) values stored with quotes and daily rows. Values stay null until
+// enough history exists; the unexported-in-JSON columns carry the smoothing
+// state forward to the next row.
+type TechnicalIndicators struct {
+	RSI14            *float64 `gorm:"column:rsi_14" json:"rsi_14"`
+	MACD             *float64 `gorm:"column:macd" json:"macd"`
+	MACDSignal       *float64 `gorm:"column:macd_signal" json:"macd_signal"`
+	MACDHistogram    *float64 `gorm:"column:macd_histogram" json:"macd_histogram"`
+	BollingerMiddle  *float64 `gorm:"column:bb_middle" json:"bb_middle"`
+	BollingerUpper   *float64 `gorm:"column:bb_upper" json:"bb_upper"`
+	BollingerLower   *float64 `gorm:"column:bb_lower" json:"bb_lower"`
+	ATR14            *float64 `gorm:"column:atr_14" json:"atr_14"`
+	IndicatorSamples int      `gorm:"column:indicator_samples;not null;default:0" json:"-"`
+	RSIAvgGain       float64  `gorm:"column:rsi_avg_gain;not null;default:0" json:"-"`
+	RSIAvgLoss       float64  `gorm:"column:rsi_avg_loss;not null;default:0" json:"-"`
+	MACDEMAFast      float64  `gorm:"column:macd_ema_fast;not null;default:0" json:"-"`
+	MACDEMASlow      float64  `gorm:"column:macd_ema_slow;not null;default:0" json:"-"`
+	MACDSignalState  float64  `gorm:"column:macd_signal_state;not null;default:0" json:"-"`
+	ATRState         float64  `gorm:"column:atr_state;not null;default:0" json:"-"`
+}
+
+// TechnicalIndicatorColumns lists the columns written by indicator recomputes.
+var TechnicalIndicatorColumns = []string{
+	"rsi_14", "macd", "macd_signal", "macd_histogram", "bb_middle", "bb_upper", "bb_lower", "atr_14",
+	"indicator_samples", "rsi_avg_gain", "rsi_avg_loss", "macd_ema_fast", "macd_ema_slow",
+	"macd_signal_state", "atr_state",
+}
+
+func NewTechnicalIndicators(snap indicators.Snapshot, state indicators.State) TechnicalIndicators {
+	return TechnicalIndicators{
+		RSI14:            snap.RSI,
+		MACD:             snap.MACD,
+		MACDSignal:       snap.MACDSignal,
+		MACDHistogram:    snap.MACDHistogram,
+		BollingerMiddle:  snap.BollingerMiddle,
+		BollingerUpper:   snap.BollingerUpper,
+		BollingerLower:   snap.BollingerLower,
+		ATR14:            snap.ATR,
+		IndicatorSamples: state.Samples,
+		RSIAvgGain:       state.RSIAvgGain,
+		RSIAvgLoss:       state.RSIAvgLoss,
+		MACDEMAFast:      state.MACDFast,
+		MACDEMASlow:      state.MACDSlow,
+		MACDSignalState:  state.MACDSignal,
+		ATRState:         state.ATR,
+	}
+}
+
+// IndicatorState returns the state needed to continue the indicators from a
+// row whose closing price is close.
+func (t TechnicalIndicators) IndicatorState(close float64) indicators.State {
+	return indicators.State{
+		Samples:    t.IndicatorSamples,
+		PrevClose:  close,
+		RSIAvgGain: t.RSIAvgGain,
+		RSIAvgLoss: t.RSIAvgLoss,
+		MACDFast:   t.MACDEMAFast,
+		MACDSlow:   t.MACDEMASlow,
+		MACDSignal: t.MACDSignalState,
+		ATR:        t.ATRState,
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	UserTokenVerifyEmail   = "verify_email"
+	UserTokenResetPassword = "reset_password"
+	UserTokenMFAChallenge  = "mfa_challenge"
+)
+
+// UserToken is a single-use, time-limited token mailed to a user, for example
+// to confirm their email address. Only the SHA-256 hash is stored.
+type UserToken struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Purpose   string     `gorm:"type:varchar(32);not null" json:"purpose"`
+	TokenHash string     `gorm:"type:varchar(128);not null;uniqueIndex" json:"-"`
+	ExpiresAt LocalTime  `gorm:"not null" json:"expires_at"`
+	UsedAt    *LocalTime `gorm:"" json:"used_at"`
+	Attempts  int        `gorm:"not null;default:0" json:"attempts"`
+	CreatedAt LocalTime  `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Watchlist is a named set of symbols owned by a user. A user follows the union
+// of their watchlists; users without any watchlist follow every symbol.
+type Watchlist struct {
+	ID        uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    uuid.UUID       `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name      string          `gorm:"type:varchar(128);not null" json:"name"`
+	Items     []WatchlistItem `gorm:"foreignKey:WatchlistID;constraint:OnDelete:CASCADE" json:"items"`
+	CreatedAt LocalTime       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt LocalTime       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Watchlist) TableName() string {
+	return "watchlists"
+}
+
+func (w *Watchlist) BeforeCreate(_ *gorm.DB) error {
+	now := NewLocalTime(time.Now())
+	if time.Time(w.CreatedAt).IsZero() {
+		w.CreatedAt = now
+	}
+	w.UpdatedAt = now
+	return nil
+}
+
+func (w *Watchlist) BeforeUpdate(_ *gorm.DB) error {
+	w.UpdatedAt = NewLocalTime(time.Now())
+	return nil
+}
+
+type WatchlistItem struct {
+	WatchlistID uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	Symbol      string    `gorm:"type:varchar(64);primaryKey;index" json:"symbol"`
+	CreatedAt   LocalTime `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (WatchlistItem) TableName() string {
+	return "watchlist_items"
+}
@@ -1,9 +1,12 @@
 package realtime
 
 import (
-	"sync"
+	"bufio"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
 
 	"sun-stockanalysis-api/internal/models"
 )
@@ -12,54 +15,115 @@ type AlertEventNotifier interface {
 	Notify(event *models.AlertEvent, message string)
 }
 
+// AlertEventSource loads a user's stored alert events for SSE Last-Event-ID
+// replay.
+type AlertEventSource interface {
+	FindByUserAfter(userID uuid.UUID, seq int64, limit int) ([]models.AlertEvent, error)
+}
+
+type alertMessage struct {
+	Type    string             `json:"type"`
+	Event   *models.AlertEvent `json:"event"`
+	Message string             `json:"message"`
+}
+
+func newAlertOutbound(event *models.AlertEvent, message string) (outbound, bool) {
+	return newOutbound(eventID(event.Seq), MessageAlert, event.Symbol, alertMessage{
+		Type:    MessageAlert,
+		Event:   event,
+		Message: message,
+	})
+}
+
+// AlertHub pushes alert events to the WebSocket and SSE clients of the
+// event's owner, limited to the symbols on the owner's watchlists and, once a
+// client has subscribed, to its subscription set.
 type AlertHub struct {
-	mu    sync.Mutex
-	conns map[*websocket.Conn]struct{}
+	hub    *hub
+	events AlertEventSource
 }
 
-func NewAlertHub() *AlertHub {
-	return &AlertHub{
-		conns: make(map[*websocket.Conn]struct{}),
-	}
+func NewAlertHub(lookup WatchlistLookup, events AlertEventSource) *AlertHub {
+	return &AlertHub{hub: newHub(lookup, nil), events: events}
 }
 
-func (h *AlertHub) Register(conn *websocket.Conn) {
+// Serve authenticates conn, registers it and answers its protocol messages
+// until it closes. An empty session makes the client authenticate in-band.
+func (h *AlertHub) Serve(conn *websocket.Conn, session Session, authenticate Authenticator) {
 	if h == nil || conn == nil {
 		return
 	}
-	h.mu.Lock()
-	h.conns[conn] = struct{}{}
-	h.mu.Unlock()
+	h.hub.serve(conn, session, authenticate)
 }
 
-func (h *AlertHub) Unregister(conn *websocket.Conn) {
-	if h == nil || conn == nil {
+// Stream writes the session user's alerts for symbols (all watched symbols
+// when empty) to an SSE response until the client goes away or session
+// expires. With a lastEventID the stream first replays the user's stored
+// events after that event.
+func (h *AlertHub) Stream(w *bufio.Writer, session Session, symbols []string, lastEventID string) {
+	if h == nil || w == nil {
 		return
 	}
-	h.mu.Lock()
-	delete(h.conns, conn)
-	h.mu.Unlock()
+	h.hub.stream(w, session, symbols, func() []outbound {
+		if h.events == nil {
+			return nil
+		}
+		lastSeq, err := strconv.ParseInt(strings.TrimSpace(lastEventID), 10, 64)
+		if err != nil {
+			return nil
+		}
+		owner, err := uuid.Parse(session.UserID)
+		if err != nil {
+			return nil
+		}
+		events, err := h.events.FindByUserAfter(owner, lastSeq, replayLimit)
+		if err != nil {
+			return nil
+		}
+		msgs := make([]outbound, 0, len(events))
+		for i := range events {
+			if msg, ok := newAlertOutbound(&events[i], events[i].Message); ok {
+				msgs = append(msgs, msg)
+			}
+		}
+		return msgs
+	})
+}
+
+// WatchlistChanged updates the symbol filter of every connection of userID.
+func (h *AlertHub) WatchlistChanged(userID string, symbols []string, restricted bool) {
+	if h == nil {
+		return
+	}
+	h.hub.watchlistChanged(userID, symbols, restricted)
 }
 
 func (h *AlertHub) Notify(event *models.AlertEvent, message string) {
 	if h == nil || event == nil {
 		return
 	}
-	h.mu.Lock()
-	defer h.mu.Unlock()
+	msg, ok := newAlertOutbound(event, message)
+	if !ok {
+		return
+	}
+	owner := event.UserID.String()
+	h.hub.publish(msg, func(c *client) bool {
+		return c.userID == owner && c.wants(event.Symbol)
+	})
+}
 
-	payload := struct {
-		Event   *models.AlertEvent `json:"event"`
-		Message string            `json:"message"`
-	}{
-		Event:   event,
-		Message: message,
+// Close disconnects every client of the hub.
+func (h *AlertHub) Close() {
+	if h == nil {
+		return
 	}
+	h.hub.closeAll("server shutting down")
+}
 
-	for conn := range h.conns {
-		if err := conn.WriteJSON(payload); err != nil {
-			_ = conn.Close()
-			delete(h.conns, conn)
-		}
+// Stats reports the hub's connected clients and delivery counters.
+func (h *AlertHub) Stats() HubStats {
+	if h == nil {
+		return HubStats{}
 	}
+	return h.hub.snapshotStats()
 }
@@ -0,0 +1,69 @@
+package realtime
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gofiber/websocket/v2"
+)
+
+const authTimeout = 10 * time.Second
+
+var ErrAuthRequired = errors.New("authentication required")
+
+// Session is the user a connection is authenticated as. A zero ExpiresAt
+// never expires.
+type Session struct {
+	UserID    string
+	ExpiresAt time.Time
+}
+
+// Authenticator validates an access token sent by a client.
+type Authenticator func(token string) (Session, error)
+
+type authenticatedMessage struct {
+	Type      string     `json:"type"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func newAuthenticatedMessage(session Session) authenticatedMessage {
+	msg := authenticatedMessage{Type: MessageAuthenticated}
+	if !session.ExpiresAt.IsZero() {
+		expiresAt := session.ExpiresAt
+		msg.ExpiresAt = &expiresAt
+	}
+	return msg
+}
+
+// handshake authenticates a connection that was opened without a token. Its
+// first message must be an auth message and arrive within authTimeout.
+func handshake(conn *websocket.Conn, authenticate Authenticator) (Session, error) {
+	if authenticate == nil {
+		return Session{}, ErrAuthRequired
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(authTimeout))
+	_, raw, err := conn.ReadMessage()
+	if err != nil {
+		return Session{}, err
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	var msg ClientMessage
+	if err := json.Unmarshal(raw, &msg); err != nil || msg.Type != MessageAuth {
+		return Session{}, ErrAuthRequired
+	}
+	return authenticate(msg.Token)
+}
+
+func expired(session Session, now time.Time) bool {
+	return !session.ExpiresAt.IsZero() && !now.Before(session.ExpiresAt)
+}
+
+// closeWithReason sends a close frame before dropping conn so clients can tell
+// an auth failure from a network error.
+func closeWithReason(conn *websocket.Conn, reason string) {
+	msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason)
+	_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+	_ = conn.Close()
+}
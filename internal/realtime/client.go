@@ -0,0 +1,199 @@
+package realtime
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/websocket/v2"
+)
+
+// WatchlistLookup resolves the symbols a user follows. restricted is false when
+// the user has no watchlist and follows every symbol.
+type WatchlistLookup interface {
+	WatchedSymbols(ctx context.Context, userID string) (symbols []string, restricted bool, err error)
+}
+
+// client is one authenticated hub connection, either a WebSocket or an SSE
+// stream. A message for a symbol is
+// delivered when the symbol is on the user's watchlists and, once the client
+// has sent a subscribe message, in its subscription set. A nil set means no
+// restriction.
+//
+// Only the client's writer goroutine writes to the connection. Everything else
+// queues on send, so a slow connection never blocks the hub.
+type client struct {
+	conn       *websocket.Conn
+	userID     string
+	send       chan outbound
+	closing    chan string
+	closeOnce  sync.Once
+	watched    map[string]struct{}
+	subscribed map[string]struct{}
+	expiry     *time.Timer
+}
+
+func newClient(conn *websocket.Conn, userID string, lookup WatchlistLookup, sendBuffer int) *client {
+	c := &client{
+		conn:    conn,
+		userID:  userID,
+		send:    make(chan outbound, sendBuffer),
+		closing: make(chan string, 1),
+	}
+	if userID == "" || lookup == nil {
+		return c
+	}
+	symbols, restricted, err := lookup.WatchedSymbols(context.Background(), userID)
+	if err == nil {
+		c.setWatched(symbols, restricted)
+	}
+	return c
+}
+
+func (c *client) setWatched(symbols []string, restricted bool) {
+	if !restricted {
+		c.watched = nil
+		return
+	}
+	c.watched = symbolSet(symbols)
+}
+
+// enqueue queues msg for the writer without blocking. It reports false when
+// the send buffer is full.
+func (c *client) enqueue(msg outbound) bool {
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// disconnect asks the writer to close the connection with reason. Only the
+// first call has an effect.
+func (c *client) disconnect(reason string) {
+	c.closeOnce.Do(func() {
+		c.closing <- reason
+	})
+}
+
+// writePump writes queued messages and periodic pings until stop is closed,
+// a write fails or the client is disconnected.
+func (c *client) writePump(stats *hubStats, writeWait, pingPeriod time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case reason := <-c.closing:
+			closeWithReason(c.conn, reason)
+			return
+		case msg := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg.data); err != nil {
+				_ = c.conn.Close()
+				return
+			}
+			stats.sent.Add(1)
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				_ = c.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// expireAt disconnects the client when the session token expires, replacing
+// the previous deadline. A zero time never expires.
+func (c *client) expireAt(expiresAt time.Time) {
+	c.stopExpiry()
+	if expiresAt.IsZero() {
+		return
+	}
+	c.expiry = time.AfterFunc(time.Until(expiresAt), func() {
+		c.disconnect("token expired")
+	})
+}
+
+func (c *client) stopExpiry() {
+	if c.expiry != nil {
+		c.expiry.Stop()
+		c.expiry = nil
+	}
+}
+
+// subscribe adds symbols to the subscription set and returns the symbols that
+// were not subscribed before. Nothing changes when the set would grow past limit.
+func (c *client) subscribe(symbols []string, limit int) ([]string, bool) {
+	var added []string
+	for symbol := range symbolSet(symbols) {
+		if _, ok := c.subscribed[symbol]; !ok {
+			added = append(added, symbol)
+		}
+	}
+	if len(c.subscribed)+len(added) > limit {
+		return nil, false
+	}
+	if c.subscribed == nil {
+		c.subscribed = make(map[string]struct{}, len(added))
+	}
+	for _, symbol := range added {
+		c.subscribed[symbol] = struct{}{}
+	}
+	return added, true
+}
+
+func (c *client) unsubscribe(symbols []string) {
+	if c.subscribed == nil {
+		c.subscribed = make(map[string]struct{})
+	}
+	for symbol := range symbolSet(symbols) {
+		delete(c.subscribed, symbol)
+	}
+}
+
+func (c *client) wants(symbol string) bool {
+	symbol = normalizeSymbol(symbol)
+	if c.watched != nil {
+		if _, ok := c.watched[symbol]; !ok {
+			return false
+		}
+	}
+	if c.subscribed != nil {
+		if _, ok := c.subscribed[symbol]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// subscriptions returns the explicit subscription set, or nil when the client
+// has not subscribed yet.
+func (c *client) subscriptions() []string {
+	if c.subscribed == nil {
+		return nil
+	}
+	out := make([]string, 0, len(c.subscribed))
+	for symbol := range c.subscribed {
+		out = append(out, symbol)
+	}
+	return out
+}
+
+func symbolSet(symbols []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		if s := normalizeSymbol(symbol); s != "" {
+			set[s] = struct{}{}
+		}
+	}
+	return set
+}
+
+func normalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
@@ -0,0 +1,100 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientSuite struct {
+	suite.Suite
+}
+
+func (s *ClientSuite) TestWantsEverythingBeforeSubscribe() {
+	c := &client{}
+
+	s.True(c.wants("AAPL"))
+	s.Nil(c.subscriptions())
+}
+
+func (s *ClientSuite) TestSubscribeLimitsSymbols() {
+	c := &client{}
+
+	added, ok := c.subscribe([]string{" aapl", "MSFT", "AAPL"}, maxSubscriptions)
+
+	s.True(ok)
+	s.ElementsMatch([]string{"AAPL", "MSFT"}, added)
+	s.True(c.wants("aapl"))
+	s.False(c.wants("NVDA"))
+
+	added, ok = c.subscribe([]string{"MSFT", "NVDA"}, maxSubscriptions)
+	s.True(ok)
+	s.Equal([]string{"NVDA"}, added)
+}
+
+func (s *ClientSuite) TestSubscribeRejectsOverLimit() {
+	c := &client{}
+	_, ok := c.subscribe([]string{"AAPL"}, 2)
+	s.True(ok)
+
+	added, ok := c.subscribe([]string{"MSFT", "NVDA"}, 2)
+
+	s.False(ok)
+	s.Nil(added)
+	s.Equal([]string{"AAPL"}, c.subscriptions())
+}
+
+func (s *ClientSuite) TestUnsubscribeBeforeSubscribeReceivesNothing() {
+	c := &client{}
+
+	c.unsubscribe([]string{"AAPL"})
+
+	s.False(c.wants("MSFT"))
+	s.Empty(c.subscriptions())
+}
+
+func (s *ClientSuite) TestWatchlistAndSubscriptionIntersect() {
+	c := &client{userID: "user"}
+	c.setWatched([]string{"AAPL", "MSFT"}, true)
+	c.subscribe([]string{"MSFT", "NVDA"}, maxSubscriptions)
+
+	s.False(c.wants("AAPL"))
+	s.True(c.wants("MSFT"))
+	s.False(c.wants("NVDA"))
+
+	c.setWatched(nil, false)
+	s.True(c.wants("NVDA"))
+}
+
+func (s *ClientSuite) TestDeliverDropsWhenBufferFull() {
+	h := newHub(nil, nil)
+	h.slowPolicy = SlowClientDrop
+	c := newClient(nil, "user", nil, 1)
+
+	h.deliver(c, outbound{data: []byte("a")})
+	h.deliver(c, outbound{data: []byte("b")})
+
+	s.Len(c.send, 1)
+	s.Equal(uint64(1), h.stats.dropped.Load())
+	s.Equal(uint64(0), h.stats.disconnected.Load())
+	s.Empty(c.closing)
+}
+
+func (s *ClientSuite) TestDeliverDisconnectsSlowClient() {
+	h := newHub(nil, nil)
+	h.slowPolicy = SlowClientDisconnect
+	c := newClient(nil, "user", nil, 1)
+
+	h.deliver(c, outbound{data: []byte("a")})
+	h.deliver(c, outbound{data: []byte("b")})
+	h.deliver(c, outbound{data: []byte("c")})
+
+	s.Equal(uint64(2), h.stats.dropped.Load())
+	s.Equal(uint64(2), h.stats.disconnected.Load())
+	s.Equal("slow consumer", <-c.closing)
+	s.Empty(c.closing)
+}
+
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
@@ -0,0 +1,26 @@
+package realtime
+
+import "sun-stockanalysis-api/internal/models"
+
+type CompositeQuoteNotifier struct {
+	notifiers []StockQuoteNotifier
+}
+
+func NewCompositeQuoteNotifier(notifiers ...StockQuoteNotifier) *CompositeQuoteNotifier {
+	filtered := make([]StockQuoteNotifier, 0, len(notifiers))
+	for _, notifier := range notifiers {
+		if notifier != nil {
+			filtered = append(filtered, notifier)
+		}
+	}
+	return &CompositeQuoteNotifier{notifiers: filtered}
+}
+
+func (n *CompositeQuoteNotifier) NotifyQuote(quote *models.StockQuote) {
+	if n == nil {
+		return
+	}
+	for _, notifier := range n.notifiers {
+		notifier.NotifyQuote(quote)
+	}
+}
@@ -0,0 +1,381 @@
+package realtime
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/websocket/v2"
+)
+
+const maxSubscriptions = 200
+
+const (
+	defaultSendBuffer  = 64
+	defaultWriteWait   = 10 * time.Second
+	defaultPingPeriod  = 30 * time.Second
+	defaultSlowPolicy  = SlowClientDrop
+	maxClientMessageKB = 64
+)
+
+// Slow client policies decide what happens to a message for a client whose
+// send buffer is full.
+const (
+	SlowClientDrop       = "drop"
+	SlowClientDisconnect = "disconnect"
+)
+
+var (
+	sendBuffer = getEnvInt("WS_SEND_BUFFER", defaultSendBuffer)
+	writeWait  = time.Duration(getEnvInt("WS_WRITE_TIMEOUT_SECONDS", int(defaultWriteWait/time.Second))) * time.Second
+	pingPeriod = time.Duration(getEnvInt("WS_PING_INTERVAL_SECONDS", int(defaultPingPeriod/time.Second))) * time.Second
+	slowPolicy = getEnvPolicy("WS_SLOW_CLIENT_POLICY", defaultSlowPolicy)
+)
+
+const (
+	MessageAuth          = "auth"
+	MessageAuthenticated = "authenticated"
+	MessageSubscribe     = "subscribe"
+	MessageUnsubscribe   = "unsubscribe"
+	MessagePing          = "ping"
+	MessagePong          = "pong"
+	MessageSubscribed    = "subscribed"
+	MessageSnapshot      = "snapshot"
+	MessageError         = "error"
+	MessageQuote         = "quote"
+	MessageAlert         = "alert"
+)
+
+// ClientMessage is a request sent by a WebSocket client.
+type ClientMessage struct {
+	Type    string   `json:"type"`
+	Symbols []string `json:"symbols,omitempty"`
+	Token   string   `json:"token,omitempty"`
+}
+
+type subscribedMessage struct {
+	Type    string   `json:"type"`
+	Symbols []string `json:"symbols"`
+}
+
+type typeMessage struct {
+	Type    string `json:"type"`
+	Message string `json:"message,omitempty"`
+}
+
+// outbound is one queued message. id and event are only used by SSE streams,
+// where id is the Last-Event-ID a client resumes from: the stored row's
+// sequence number.
+type outbound struct {
+	id     string
+	event  string
+	symbol string
+	data   []byte
+}
+
+func newOutbound(id, event, symbol string, v any) (outbound, bool) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return outbound{}, false
+	}
+	return outbound{id: id, event: event, symbol: symbol, data: data}, true
+}
+
+// eventID formats a row sequence number as an SSE event id. Rows that were
+// never stored have no sequence number and get no id.
+func eventID(seq int64) string {
+	if seq <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(seq, 10)
+}
+
+// HubStats is a point-in-time view of a hub's connections and delivery
+// counters since start.
+type HubStats struct {
+	Clients          int    `json:"clients"`
+	MessagesSent     uint64 `json:"messages_sent"`
+	MessagesDropped  uint64 `json:"messages_dropped"`
+	SlowDisconnected uint64 `json:"slow_disconnected"`
+}
+
+type hubStats struct {
+	sent         atomic.Uint64
+	dropped      atomic.Uint64
+	disconnected atomic.Uint64
+}
+
+// hub holds the connections of one WebSocket endpoint and implements the
+// subscribe/unsubscribe/ping protocol shared by the quote and alert streams.
+// Messages are queued per client and written by the client's own goroutine.
+type hub struct {
+	mu      sync.Mutex
+	clients map[*client]struct{}
+	lookup  WatchlistLookup
+	// snapshot returns the message sent when a client subscribes to symbol,
+	// or false when there is nothing to send.
+	snapshot func(symbol string) (outbound, bool)
+
+	sendBuffer int
+	writeWait  time.Duration
+	pingPeriod time.Duration
+	slowPolicy string
+	stats      hubStats
+}
+
+func newHub(lookup WatchlistLookup, snapshot func(symbol string) (outbound, bool)) *hub {
+	return &hub{
+		clients:    make(map[*client]struct{}),
+		lookup:     lookup,
+		snapshot:   snapshot,
+		sendBuffer: sendBuffer,
+		writeWait:  writeWait,
+		pingPeriod: pingPeriod,
+		slowPolicy: slowPolicy,
+	}
+}
+
+func (h *hub) register(conn *websocket.Conn, userID string) *client {
+	c := newClient(conn, userID, h.lookup, h.sendBuffer)
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+	return c
+}
+
+func (h *hub) unregister(c *client) {
+	h.mu.Lock()
+	delete(h.clients, c)
+	h.mu.Unlock()
+}
+
+func (h *hub) snapshotStats() HubStats {
+	h.mu.Lock()
+	clients := len(h.clients)
+	h.mu.Unlock()
+	return HubStats{
+		Clients:          clients,
+		MessagesSent:     h.stats.sent.Load(),
+		MessagesDropped:  h.stats.dropped.Load(),
+		SlowDisconnected: h.stats.disconnected.Load(),
+	}
+}
+
+// serve authenticates conn, registers it and handles its messages until the
+// connection closes or its token expires. session is empty when the client did
+// not send a token with the upgrade request and must authenticate in-band.
+func (h *hub) serve(conn *websocket.Conn, session Session, authenticate Authenticator) {
+	if session.UserID == "" {
+		var err error
+		session, err = handshake(conn, authenticate)
+		if err != nil || session.UserID == "" {
+			closeWithReason(conn, "authentication required")
+			return
+		}
+	}
+	if expired(session, time.Now()) {
+		closeWithReason(conn, "token expired")
+		return
+	}
+
+	c := h.register(conn, session.UserID)
+	defer h.unregister(c)
+	c.expireAt(session.ExpiresAt)
+	defer c.stopExpiry()
+
+	stop := make(chan struct{})
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		c.writePump(&h.stats, h.writeWait, h.pingPeriod, stop)
+	}()
+	// The websocket package reuses conn once the handler returns, so wait for
+	// the writer before leaving.
+	defer func() {
+		close(stop)
+		<-written
+	}()
+
+	pongWait := h.pingPeriod + h.writeWait
+	conn.SetReadLimit(maxClientMessageKB * 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	h.reply(c, newAuthenticatedMessage(session))
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+		h.handle(c, raw, authenticate)
+	}
+}
+
+// handle answers one client message.
+func (h *hub) handle(c *client, raw []byte, authenticate Authenticator) {
+	var msg ClientMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		h.reply(c, typeMessage{Type: MessageError, Message: "invalid message"})
+		return
+	}
+
+	switch msg.Type {
+	case MessageAuth:
+		// A new token for the same user extends the connection past the
+		// expiry of the one it was opened with.
+		if authenticate == nil {
+			h.reply(c, typeMessage{Type: MessageError, Message: "invalid token"})
+			return
+		}
+		session, err := authenticate(msg.Token)
+		if err != nil || session.UserID != c.userID || expired(session, time.Now()) {
+			h.reply(c, typeMessage{Type: MessageError, Message: "invalid token"})
+			return
+		}
+		c.expireAt(session.ExpiresAt)
+		h.reply(c, newAuthenticatedMessage(session))
+	case MessagePing:
+		h.reply(c, typeMessage{Type: MessagePong})
+	case MessageSubscribe:
+		h.mu.Lock()
+		added, ok := c.subscribe(msg.Symbols, maxSubscriptions)
+		if !ok {
+			h.mu.Unlock()
+			h.reply(c, typeMessage{Type: MessageError, Message: "too many subscriptions"})
+			return
+		}
+		snapshots := added[:0]
+		for _, symbol := range added {
+			if c.wants(symbol) {
+				snapshots = append(snapshots, symbol)
+			}
+		}
+		current := sortedSymbols(c.subscriptions())
+		h.mu.Unlock()
+
+		h.reply(c, subscribedMessage{Type: MessageSubscribed, Symbols: current})
+		h.sendSnapshots(c, sortedSymbols(snapshots))
+	case MessageUnsubscribe:
+		h.mu.Lock()
+		c.unsubscribe(msg.Symbols)
+		current := sortedSymbols(c.subscriptions())
+		h.mu.Unlock()
+		h.reply(c, subscribedMessage{Type: MessageSubscribed, Symbols: current})
+	default:
+		h.reply(c, typeMessage{Type: MessageError, Message: "unknown message type"})
+	}
+}
+
+func (h *hub) sendSnapshots(c *client, symbols []string) {
+	if h.snapshot == nil {
+		return
+	}
+	for _, symbol := range symbols {
+		if msg, ok := h.snapshot(symbol); ok {
+			h.deliver(c, msg)
+		}
+	}
+}
+
+// reply queues v for a single client.
+func (h *hub) reply(c *client, v any) {
+	if msg, ok := newOutbound("", "", "", v); ok {
+		h.deliver(c, msg)
+	}
+}
+
+// publish queues msg for every client that accepts it. It never waits on a
+// connection; clients that cannot keep up are handled by the hub's slow
+// client policy.
+func (h *hub) publish(msg outbound, accept func(c *client) bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for c := range h.clients {
+		if accept(c) {
+			h.deliver(c, msg)
+		}
+	}
+}
+
+// deliver queues msg for c, dropping it or disconnecting c when its send
+// buffer is full.
+func (h *hub) deliver(c *client, msg outbound) {
+	if c.enqueue(msg) {
+		return
+	}
+	h.stats.dropped.Add(1)
+	if h.slowPolicy == SlowClientDisconnect {
+		h.stats.disconnected.Add(1)
+		c.disconnect("slow consumer")
+	}
+}
+
+// closeAll disconnects every client, ending SSE streams that would otherwise
+// hold up a graceful shutdown until their next ping.
+func (h *hub) closeAll(reason string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		c.disconnect(reason)
+	}
+}
+
+func (h *hub) watchlistChanged(userID string, symbols []string, restricted bool) {
+	if userID == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		if c.userID == userID {
+			c.setWatched(symbols, restricted)
+		}
+	}
+}
+
+// normalizedSymbols upper-cases symbols and drops blanks and duplicates.
+func normalizedSymbols(symbols []string) []string {
+	set := symbolSet(symbols)
+	out := make([]string, 0, len(set))
+	for symbol := range set {
+		out = append(out, symbol)
+	}
+	return out
+}
+
+func sortedSymbols(symbols []string) []string {
+	out := append([]string{}, symbols...)
+	sort.Strings(out)
+	return out
+}
+
+func getEnvInt(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+func getEnvPolicy(key, fallback string) string {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(key))) {
+	case SlowClientDrop:
+		return SlowClientDrop
+	case SlowClientDisconnect:
+		return SlowClientDisconnect
+	}
+	return fallback
+}
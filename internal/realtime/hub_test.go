@@ -0,0 +1,308 @@
+package realtime
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	fastws "github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type fakeQuotes struct {
+	stored []models.StockQuote
+}
+
+func (f *fakeQuotes) FindLatestBySymbol(symbol string) (*models.StockQuote, error) {
+	for i := len(f.stored) - 1; i >= 0; i-- {
+		if f.stored[i].Symbol == symbol {
+			return &f.stored[i], nil
+		}
+	}
+	return &models.StockQuote{Symbol: symbol, PriceCurrent: 101}, nil
+}
+
+func (f *fakeQuotes) FindAfter(seq int64, symbols []string, limit int) ([]models.StockQuote, error) {
+	var out []models.StockQuote
+	for _, quote := range f.stored {
+		if quote.Seq > seq && (len(symbols) == 0 || slices.Contains(symbols, quote.Symbol)) {
+			out = append(out, quote)
+		}
+	}
+	slices.SortFunc(out, func(a, b models.StockQuote) int { return cmp.Compare(a.Seq, b.Seq) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+type sseEvent struct {
+	id    string
+	event string
+	data  map[string]any
+}
+
+type HubSuite struct {
+	suite.Suite
+	app    *fiber.App
+	url    string
+	sseURL string
+	hub    *StockQuoteHub
+	quotes *fakeQuotes
+	tokens map[string]Session
+}
+
+func (s *HubSuite) SetupTest() {
+	s.quotes = &fakeQuotes{stored: []models.StockQuote{
+		{ID: uuid.New(), Seq: 1, Symbol: "AAPL", PriceCurrent: 100},
+		{ID: uuid.New(), Seq: 2, Symbol: "MSFT", PriceCurrent: 200},
+		{ID: uuid.New(), Seq: 3, Symbol: "AAPL", PriceCurrent: 101},
+	}}
+	s.hub = NewStockQuoteHub(nil, s.quotes)
+	s.tokens = map[string]Session{
+		"valid": {UserID: "user-1", ExpiresAt: time.Now().Add(time.Hour)},
+		"other": {UserID: "user-2", ExpiresAt: time.Now().Add(time.Hour)},
+		"short": {UserID: "user-1", ExpiresAt: time.Now().Add(300 * time.Millisecond)},
+	}
+	authenticate := func(token string) (Session, error) {
+		session, ok := s.tokens[token]
+		if !ok {
+			return Session{}, errors.New("invalid token")
+		}
+		return session, nil
+	}
+
+	s.app = fiber.New(fiber.Config{DisableStartupMessage: true})
+	s.app.Get("/ws", websocket.New(func(c *websocket.Conn) {
+		var session Session
+		if token := c.Query("token"); token != "" {
+			session, _ = authenticate(token)
+		}
+		s.hub.Serve(c, session, authenticate)
+	}))
+	s.app.Get("/sse", func(c *fiber.Ctx) error {
+		session := s.tokens[c.Query("token")]
+		symbols := strings.Split(c.Query("symbols"), ",")
+		lastEventID := c.Get("Last-Event-ID")
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			s.hub.Stream(w, session, symbols, lastEventID)
+		})
+		return nil
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	go func() { _ = s.app.Listener(ln) }()
+	s.url = "ws://" + ln.Addr().String() + "/ws"
+	s.sseURL = "http://" + ln.Addr().String() + "/sse"
+}
+
+func (s *HubSuite) TearDownTest() {
+	s.hub.Close()
+	_ = s.app.Shutdown()
+}
+
+func (s *HubSuite) dial(query string) *fastws.Conn {
+	conn, _, err := fastws.DefaultDialer.Dial(s.url+query, nil)
+	s.Require().NoError(err)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	return conn
+}
+
+func (s *HubSuite) read(conn *fastws.Conn) map[string]any {
+	var msg map[string]any
+	s.Require().NoError(conn.ReadJSON(&msg))
+	return msg
+}
+
+func (s *HubSuite) TestQueryTokenAuthenticates() {
+	conn := s.dial("?token=valid")
+	defer conn.Close()
+
+	s.Equal(MessageAuthenticated, s.read(conn)["type"])
+}
+
+func (s *HubSuite) TestHandshakeRequiresAuthMessage() {
+	conn := s.dial("")
+	defer conn.Close()
+
+	s.Require().NoError(conn.WriteJSON(ClientMessage{Type: MessagePing}))
+
+	_, _, err := conn.ReadMessage()
+	var closeErr *fastws.CloseError
+	s.Require().True(errors.As(err, &closeErr))
+	s.Equal(fastws.ClosePolicyViolation, closeErr.Code)
+}
+
+func (s *HubSuite) TestHandshakeThenSubscribeSendsSnapshot() {
+	conn := s.dial("")
+	defer conn.Close()
+
+	s.Require().NoError(conn.WriteJSON(ClientMessage{Type: MessageAuth, Token: "valid"}))
+	s.Equal(MessageAuthenticated, s.read(conn)["type"])
+
+	s.Require().NoError(conn.WriteJSON(ClientMessage{Type: MessageSubscribe, Symbols: []string{"aapl"}}))
+	ack := s.read(conn)
+	s.Equal(MessageSubscribed, ack["type"])
+	s.Equal([]any{"AAPL"}, ack["symbols"])
+	snapshot := s.read(conn)
+	s.Equal(MessageSnapshot, snapshot["type"])
+	s.Equal("AAPL", snapshot["quote"].(map[string]any)["symbol"])
+
+	s.Require().NoError(conn.WriteJSON(ClientMessage{Type: MessagePing}))
+	s.Equal(MessagePong, s.read(conn)["type"])
+}
+
+func (s *HubSuite) TestRefreshRejectsOtherUser() {
+	conn := s.dial("?token=valid")
+	defer conn.Close()
+	s.read(conn)
+
+	s.Require().NoError(conn.WriteJSON(ClientMessage{Type: MessageAuth, Token: "other"}))
+
+	s.Equal(MessageError, s.read(conn)["type"])
+}
+
+func (s *HubSuite) TestClosesWhenTokenExpires() {
+	conn := s.dial("?token=short")
+	defer conn.Close()
+	s.read(conn)
+
+	_, _, err := conn.ReadMessage()
+	var closeErr *fastws.CloseError
+	s.Require().True(errors.As(err, &closeErr))
+	s.True(strings.Contains(closeErr.Text, "expired"))
+}
+
+func (s *HubSuite) TestRefreshExtendsSession() {
+	conn := s.dial("?token=short")
+	defer conn.Close()
+	s.read(conn)
+
+	s.Require().NoError(conn.WriteJSON(ClientMessage{Type: MessageAuth, Token: "valid"}))
+	s.Equal(MessageAuthenticated, s.read(conn)["type"])
+
+	time.Sleep(500 * time.Millisecond)
+	s.Require().NoError(conn.WriteJSON(ClientMessage{Type: MessagePing}))
+	s.Equal(MessagePong, s.read(conn)["type"])
+}
+
+func (s *HubSuite) TestBroadcastReachesSubscribedClient() {
+	conn := s.dial("?token=valid")
+	defer conn.Close()
+	s.read(conn)
+	s.Require().NoError(conn.WriteJSON(ClientMessage{Type: MessageSubscribe, Symbols: []string{"MSFT"}}))
+	s.read(conn)
+	s.read(conn)
+
+	s.hub.NotifyQuote(&models.StockQuote{Symbol: "AAPL", PriceCurrent: 1})
+	s.hub.NotifyQuote(&models.StockQuote{Symbol: "MSFT", PriceCurrent: 2})
+
+	msg := s.read(conn)
+	s.Equal(MessageQuote, msg["type"])
+	s.Equal("MSFT", msg["quote"].(map[string]any)["symbol"])
+	stats := s.hub.Stats()
+	s.Equal(1, stats.Clients)
+	s.Equal(uint64(0), stats.MessagesDropped)
+}
+
+func (s *HubSuite) openStream(query, lastEventID string) (*bufio.Reader, func()) {
+	req, err := http.NewRequest(http.MethodGet, s.sseURL+query, nil)
+	s.Require().NoError(err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Do(req)
+	s.Require().NoError(err)
+	return bufio.NewReader(resp.Body), func() { _ = resp.Body.Close() }
+}
+
+func (s *HubSuite) readEvent(r *bufio.Reader) sseEvent {
+	var ev sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		s.Require().NoError(err)
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			if ev.data != nil {
+				return ev
+			}
+		case strings.HasPrefix(line, "id: "):
+			ev.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			ev.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			s.Require().NoError(json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &ev.data))
+		}
+	}
+}
+
+func (s *HubSuite) TestStreamStartsWithSnapshot() {
+	r, done := s.openStream("?token=valid&symbols=msft", "")
+	defer done()
+
+	ev := s.readEvent(r)
+
+	s.Equal(MessageSnapshot, ev.event)
+	s.Equal("2", ev.id)
+}
+
+func (s *HubSuite) TestStreamResumesFromLastEventID() {
+	r, done := s.openStream("?token=valid&symbols=AAPL", "1")
+	defer done()
+
+	ev := s.readEvent(r)
+	s.Equal(MessageQuote, ev.event)
+	s.Equal("3", ev.id)
+
+	live := &models.StockQuote{ID: uuid.New(), Seq: 5, Symbol: "AAPL", PriceCurrent: 102}
+	s.Eventually(func() bool { return s.hub.Stats().Clients == 1 }, time.Second, 10*time.Millisecond)
+	s.hub.NotifyQuote(&models.StockQuote{ID: uuid.New(), Seq: 4, Symbol: "MSFT", PriceCurrent: 201})
+	s.hub.NotifyQuote(live)
+
+	ev = s.readEvent(r)
+	s.Equal("5", ev.id)
+	s.Equal("AAPL", ev.data["quote"].(map[string]any)["symbol"])
+}
+
+// Quotes stored within one minute share created_at and have random ids, so
+// only the sequence number orders them. Resuming from the middle one must
+// replay exactly the quotes stored after it.
+func (s *HubSuite) TestStreamResumesWithinTheSameMinute() {
+	minute := models.NewLocalTime(time.Date(2025, 1, 2, 9, 31, 0, 0, time.UTC))
+	s.quotes.stored = nil
+	for seq := int64(10); seq < 15; seq++ {
+		s.quotes.stored = append(s.quotes.stored, models.StockQuote{
+			ID: uuid.New(), Seq: seq, Symbol: "AAPL", PriceCurrent: float64(seq), CreatedAt: minute,
+		})
+	}
+	slices.SortFunc(s.quotes.stored, func(a, b models.StockQuote) int { return strings.Compare(a.ID.String(), b.ID.String()) })
+
+	r, done := s.openStream("?token=valid&symbols=AAPL", "12")
+	defer done()
+
+	s.Equal("13", s.readEvent(r).id)
+	s.Equal("14", s.readEvent(r).id)
+
+	s.Eventually(func() bool { return s.hub.Stats().Clients == 1 }, time.Second, 10*time.Millisecond)
+	s.hub.NotifyQuote(&models.StockQuote{ID: uuid.New(), Seq: 15, Symbol: "AAPL", PriceCurrent: 15, CreatedAt: minute})
+	s.Equal("15", s.readEvent(r).id)
+}
+
+func TestHubSuite(t *testing.T) {
+	suite.Run(t, new(HubSuite))
+}
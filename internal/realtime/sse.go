@@ -0,0 +1,126 @@
+package realtime
+
+import (
+	"bufio"
+	"time"
+)
+
+const defaultReplayLimit = 500
+
+var replayLimit = getEnvInt("SSE_REPLAY_LIMIT", defaultReplayLimit)
+
+// stream registers an SSE client for session and writes its events to w until
+// a write fails or the session expires. backlog runs after the client is
+// registered so nothing published in between is lost; queued messages that
+// the backlog already contained are skipped.
+func (h *hub) stream(w *bufio.Writer, session Session, symbols []string, backlog func() []outbound) {
+	c := h.register(nil, session.UserID)
+	defer h.unregister(c)
+	c.expireAt(session.ExpiresAt)
+	defer c.stopExpiry()
+
+	if len(symbols) > 0 {
+		h.mu.Lock()
+		_, ok := c.subscribe(symbols, maxSubscriptions)
+		h.mu.Unlock()
+		if !ok {
+			if msg, ok := newOutbound("", MessageError, "", typeMessage{Type: MessageError, Message: "too many subscriptions"}); ok {
+				_ = writeEvent(w, msg)
+				_ = w.Flush()
+			}
+			return
+		}
+	}
+
+	var msgs []outbound
+	if backlog != nil {
+		msgs = backlog()
+	}
+	replayed := make(map[string]struct{}, len(msgs))
+	h.mu.Lock()
+	wanted := msgs[:0]
+	for _, msg := range msgs {
+		if msg.symbol != "" && !c.wants(msg.symbol) {
+			continue
+		}
+		wanted = append(wanted, msg)
+		if msg.id != "" {
+			replayed[msg.id] = struct{}{}
+		}
+	}
+	h.mu.Unlock()
+
+	if _, err := w.WriteString(": connected\n\n"); err != nil {
+		return
+	}
+	for _, msg := range wanted {
+		if err := writeEvent(w, msg); err != nil {
+			return
+		}
+		h.stats.sent.Add(1)
+	}
+	if err := w.Flush(); err != nil {
+		return
+	}
+	c.ssePump(w, &h.stats, h.pingPeriod, replayed)
+}
+
+// ssePump writes queued messages as SSE events and a comment line every
+// pingPeriod to keep proxies from closing an idle stream.
+func (c *client) ssePump(w *bufio.Writer, stats *hubStats, pingPeriod time.Duration, skip map[string]struct{}) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case reason := <-c.closing:
+			if msg, ok := newOutbound("", MessageError, "", typeMessage{Type: MessageError, Message: reason}); ok {
+				_ = writeEvent(w, msg)
+				_ = w.Flush()
+			}
+			return
+		case msg := <-c.send:
+			if _, ok := skip[msg.id]; ok && msg.id != "" {
+				delete(skip, msg.id)
+				continue
+			}
+			if err := writeEvent(w, msg); err != nil {
+				return
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+			stats.sent.Add(1)
+		case <-ticker.C:
+			if _, err := w.WriteString(": ping\n\n"); err != nil {
+				return
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes msg in text/event-stream framing. The JSON payload never
+// contains a newline, so it fits on a single data line.
+func writeEvent(w *bufio.Writer, msg outbound) error {
+	if msg.id != "" {
+		if _, err := w.WriteString("id: " + msg.id + "\n"); err != nil {
+			return err
+		}
+	}
+	if msg.event != "" {
+		if _, err := w.WriteString("event: " + msg.event + "\n"); err != nil {
+			return err
+		}
+	}
+	if _, err := w.WriteString("data: "); err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.data); err != nil {
+		return err
+	}
+	_, err := w.WriteString("\n\n")
+	return err
+}
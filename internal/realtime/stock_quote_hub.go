@@ -1,7 +1,9 @@
 package realtime
 
 import (
-	"sync"
+	"bufio"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/websocket/v2"
 
@@ -12,52 +14,130 @@ type StockQuoteNotifier interface {
 	NotifyQuote(quote *models.StockQuote)
 }
 
+// QuoteSource loads stored quotes for the snapshot sent on subscribe and for
+// SSE Last-Event-ID replay.
+type QuoteSource interface {
+	FindLatestBySymbol(symbol string) (*models.StockQuote, error)
+	FindAfter(seq int64, symbols []string, limit int) ([]models.StockQuote, error)
+}
+
+type quoteMessage struct {
+	Type  string             `json:"type"`
+	Quote *models.StockQuote `json:"quote"`
+}
+
+func newQuoteOutbound(kind string, quote *models.StockQuote) (outbound, bool) {
+	return newOutbound(eventID(quote.Seq), kind, quote.Symbol, quoteMessage{Type: kind, Quote: quote})
+}
+
+// StockQuoteHub pushes new quotes to authenticated WebSocket and SSE clients.
+// Clients only receive the symbols on their user's watchlists, and clients
+// that have subscribed only receive their subscribed symbols.
 type StockQuoteHub struct {
-	mu    sync.Mutex
-	conns map[*websocket.Conn]struct{}
+	hub    *hub
+	quotes QuoteSource
+}
+
+func NewStockQuoteHub(lookup WatchlistLookup, quotes QuoteSource) *StockQuoteHub {
+	h := &StockQuoteHub{quotes: quotes}
+	var snapshot func(symbol string) (outbound, bool)
+	if quotes != nil {
+		snapshot = h.snapshot
+	}
+	h.hub = newHub(lookup, snapshot)
+	return h
 }
 
-func NewStockQuoteHub() *StockQuoteHub {
-	return &StockQuoteHub{
-		conns: make(map[*websocket.Conn]struct{}),
+func (h *StockQuoteHub) snapshot(symbol string) (outbound, bool) {
+	quote, err := h.quotes.FindLatestBySymbol(symbol)
+	if err != nil || quote == nil {
+		return outbound{}, false
 	}
+	return newQuoteOutbound(MessageSnapshot, quote)
 }
 
-func (h *StockQuoteHub) Register(conn *websocket.Conn) {
+// Serve authenticates conn, registers it and answers its protocol messages
+// until it closes. An empty session makes the client authenticate in-band.
+func (h *StockQuoteHub) Serve(conn *websocket.Conn, session Session, authenticate Authenticator) {
 	if h == nil || conn == nil {
 		return
 	}
-	h.mu.Lock()
-	h.conns[conn] = struct{}{}
-	h.mu.Unlock()
+	h.hub.serve(conn, session, authenticate)
 }
 
-func (h *StockQuoteHub) Unregister(conn *websocket.Conn) {
-	if h == nil || conn == nil {
+// Stream writes quotes for symbols (all watched symbols when empty) to an SSE
+// response until the client goes away or session expires. With a
+// lastEventID the stream first replays the stored quotes after that quote;
+// without one it starts with a snapshot of each requested symbol.
+func (h *StockQuoteHub) Stream(w *bufio.Writer, session Session, symbols []string, lastEventID string) {
+	if h == nil || w == nil {
+		return
+	}
+	h.hub.stream(w, session, symbols, func() []outbound {
+		if h.quotes == nil {
+			return nil
+		}
+		lastSeq, err := strconv.ParseInt(strings.TrimSpace(lastEventID), 10, 64)
+		if err != nil {
+			return h.snapshots(symbols)
+		}
+		quotes, err := h.quotes.FindAfter(lastSeq, normalizedSymbols(symbols), replayLimit)
+		if err != nil {
+			return nil
+		}
+		msgs := make([]outbound, 0, len(quotes))
+		for i := range quotes {
+			if msg, ok := newQuoteOutbound(MessageQuote, &quotes[i]); ok {
+				msgs = append(msgs, msg)
+			}
+		}
+		return msgs
+	})
+}
+
+func (h *StockQuoteHub) snapshots(symbols []string) []outbound {
+	var msgs []outbound
+	for _, symbol := range sortedSymbols(normalizedSymbols(symbols)) {
+		if msg, ok := h.snapshot(symbol); ok {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// WatchlistChanged updates the symbol filter of every connection of userID.
+func (h *StockQuoteHub) WatchlistChanged(userID string, symbols []string, restricted bool) {
+	if h == nil {
 		return
 	}
-	h.mu.Lock()
-	delete(h.conns, conn)
-	h.mu.Unlock()
+	h.hub.watchlistChanged(userID, symbols, restricted)
 }
 
 func (h *StockQuoteHub) NotifyQuote(quote *models.StockQuote) {
 	if h == nil || quote == nil {
 		return
 	}
-	h.mu.Lock()
-	defer h.mu.Unlock()
+	msg, ok := newQuoteOutbound(MessageQuote, quote)
+	if !ok {
+		return
+	}
+	h.hub.publish(msg, func(c *client) bool {
+		return c.wants(quote.Symbol)
+	})
+}
 
-	payload := struct {
-		Quote *models.StockQuote `json:"quote"`
-	}{
-		Quote: quote,
+// Close disconnects every client of the hub.
+func (h *StockQuoteHub) Close() {
+	if h == nil {
+		return
 	}
+	h.hub.closeAll("server shutting down")
+}
 
-	for conn := range h.conns {
-		if err := conn.WriteJSON(payload); err != nil {
-			_ = conn.Close()
-			delete(h.conns, conn)
-		}
+// Stats reports the hub's connected clients and delivery counters.
+func (h *StockQuoteHub) Stats() HubStats {
+	if h == nil {
+		return HubStats{}
 	}
+	return h.hub.snapshotStats()
 }
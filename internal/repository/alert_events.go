@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"sun-stockanalysis-api/internal/models"
@@ -11,6 +12,8 @@ import (
 
 type AlertEventRepository interface {
 	Create(event *models.AlertEvent) error
+	FindByUser(userID uuid.UUID, limit int) ([]models.AlertEvent, error)
+	FindByUserAfter(userID uuid.UUID, seq int64, limit int) ([]models.AlertEvent, error)
 	DeleteBefore(t time.Time) error
 }
 
@@ -29,6 +32,37 @@ func (r *AlertEventRepositoryImpl) Create(event *models.AlertEvent) error {
 	return r.db.Create(event).Error
 }
 
+// FindByUser returns up to limit events of userID, newest first.
+func (r *AlertEventRepositoryImpl) FindByUser(userID uuid.UUID, limit int) ([]models.AlertEvent, error) {
+	var events []models.AlertEvent
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// FindByUserAfter returns up to limit events of userID stored after the event
+// id, oldest first. It returns nothing when id no longer exists.
+func (r *AlertEventRepositoryImpl) FindByUserAfter(userID uuid.UUID, seq int64, limit int) ([]models.AlertEvent, error) {
+	if limit <= 0 {
+		return []models.AlertEvent{}, nil
+	}
+	var events []models.AlertEvent
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Where("seq > ?", seq).
+		Order("seq asc").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
 func (r *AlertEventRepositoryImpl) DeleteBefore(t time.Time) error {
 	return r.db.
 		Where("created_at < ?", t).
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type AlertRuleRepository interface {
+	Create(rule *models.AlertRule) error
+	Update(rule *models.AlertRule) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.AlertRule, error)
+	FindByUser(userID uuid.UUID) ([]models.AlertRule, error)
+	FindActiveBySymbol(symbol string) ([]models.AlertRule, error)
+	MarkTriggered(id uuid.UUID, at time.Time) error
+}
+
+type AlertRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAlertRuleRepository(db *gorm.DB) AlertRuleRepository {
+	return &AlertRuleRepositoryImpl{db: db}
+}
+
+func (r *AlertRuleRepositoryImpl) Create(rule *models.AlertRule) error {
+	if rule == nil {
+		return errors.New("alert rule is nil")
+	}
+	return r.db.Create(rule).Error
+}
+
+func (r *AlertRuleRepositoryImpl) Update(rule *models.AlertRule) error {
+	if rule == nil {
+		return errors.New("alert rule is nil")
+	}
+	return r.db.
+		Model(rule).
+		Select("name", "symbol", "condition", "cooldown_seconds", "is_active", "updated_at").
+		Updates(rule).Error
+}
+
+func (r *AlertRuleRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.
+		Where("id = ?", id).
+		Delete(&models.AlertRule{}).Error
+}
+
+func (r *AlertRuleRepositoryImpl) FindByID(id uuid.UUID) (*models.AlertRule, error) {
+	var rule models.AlertRule
+	if err := r.db.First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *AlertRuleRepositoryImpl) FindByUser(userID uuid.UUID) ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *AlertRuleRepositoryImpl) FindActiveBySymbol(symbol string) ([]models.AlertRule, error) {
+	if symbol == "" {
+		return nil, errors.New("symbol is empty")
+	}
+	var rules []models.AlertRule
+	if err := r.db.
+		Where("symbol = ? AND is_active = ?", symbol, true).
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *AlertRuleRepositoryImpl) MarkTriggered(id uuid.UUID, at time.Time) error {
+	return r.db.
+		Model(&models.AlertRule{}).
+		Where("id = ?", id).
+		UpdateColumn("last_triggered_at", models.NewLocalTime(at)).Error
+}
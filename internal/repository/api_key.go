@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type APIKeyRepository interface {
+	Create(key *models.APIKey) error
+	FindByHash(hash string) (*models.APIKey, error)
+	// FindActiveByUser lists the keys of a user that are neither revoked nor
+	// expired, newest first.
+	FindActiveByUser(userID uuid.UUID, now time.Time) ([]models.APIKey, error)
+	// Revoke revokes a key only if it belongs to userID and reports whether
+	// an active key was revoked.
+	Revoke(userID, id uuid.UUID, revokedAt time.Time) (bool, error)
+	// TouchLastUsed records a use of the key unless one was already recorded
+	// after notBefore, which keeps busy keys from writing on every request.
+	TouchLastUsed(id uuid.UUID, usedAt, notBefore time.Time) error
+}
+
+type APIKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &APIKeyRepositoryImpl{db: db}
+}
+
+func (r *APIKeyRepositoryImpl) Create(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *APIKeyRepositoryImpl) FindByHash(hash string) (*models.APIKey, error) {
+	var k models.APIKey
+	if err := r.db.First(&k, "key_hash = ?", hash).Error; err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r *APIKeyRepositoryImpl) FindActiveByUser(userID uuid.UUID, now time.Time) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Order("created_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+func (r *APIKeyRepositoryImpl) Revoke(userID, id uuid.UUID, revokedAt time.Time) (bool, error) {
+	res := r.db.Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", revokedAt)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *APIKeyRepositoryImpl) TouchLastUsed(id uuid.UUID, usedAt, notBefore time.Time) error {
+	return r.db.Model(&models.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, notBefore).
+		Update("last_used_at", usedAt).Error
+}
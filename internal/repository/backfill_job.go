@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type BackfillJobRepository interface {
+	Create(job *models.BackfillJob) error
+	FindByID(id uuid.UUID) (*models.BackfillJob, error)
+	// FindPendingBySymbol returns the queued or running job of symbol, in any
+	// letter case, or gorm.ErrRecordNotFound when there is none.
+	FindPendingBySymbol(symbol string) (*models.BackfillJob, error)
+	// FindRecent lists up to limit jobs, newest first.
+	FindRecent(limit int) ([]models.BackfillJob, error)
+	CountQueued() (int64, error)
+	// ClaimNext marks the oldest queued job running and returns it, or
+	// gorm.ErrRecordNotFound when none is queued. Concurrent workers, on
+	// any replica, never claim the same job.
+	ClaimNext(startedAt time.Time) (*models.BackfillJob, error)
+	// UpdateProgress stores the stage, progress and counters of a running job.
+	UpdateProgress(job *models.BackfillJob) error
+	// Finish stores the outcome of a running job and reports false when the
+	// job was failed as stale in the meantime.
+	Finish(job *models.BackfillJob) (bool, error)
+	// FailStale fails the jobs still running that started before cutoff,
+	// whose worker must have died.
+	FailStale(cutoff, finishedAt time.Time, reason string) (int64, error)
+	DeleteFinishedBefore(t time.Time) error
+}
+
+type BackfillJobRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBackfillJobRepository(db *gorm.DB) BackfillJobRepository {
+	return &BackfillJobRepositoryImpl{db: db}
+}
+
+func (r *BackfillJobRepositoryImpl) Create(job *models.BackfillJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *BackfillJobRepositoryImpl) FindByID(id uuid.UUID) (*models.BackfillJob, error) {
+	var job models.BackfillJob
+	if err := r.db.First(&job, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *BackfillJobRepositoryImpl) FindPendingBySymbol(symbol string) (*models.BackfillJob, error) {
+	var job models.BackfillJob
+	if err := r.db.
+		Where("UPPER(symbol) = UPPER(?) AND status IN ?", symbol, []string{models.BackfillJobQueued, models.BackfillJobRunning}).
+		Order("created_at").
+		First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *BackfillJobRepositoryImpl) FindRecent(limit int) ([]models.BackfillJob, error) {
+	var jobs []models.BackfillJob
+	err := r.db.
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *BackfillJobRepositoryImpl) CountQueued() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.BackfillJob{}).
+		Where("status = ?", models.BackfillJobQueued).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *BackfillJobRepositoryImpl) ClaimNext(startedAt time.Time) (*models.BackfillJob, error) {
+	var job models.BackfillJob
+	res := r.db.Raw(`UPDATE backfill_jobs SET status = ?, started_at = ?
+		WHERE id = (
+			SELECT id FROM backfill_jobs WHERE status = ?
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING *`, models.BackfillJobRunning, startedAt, models.BackfillJobQueued).
+		Scan(&job)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &job, nil
+}
+
+func (r *BackfillJobRepositoryImpl) UpdateProgress(job *models.BackfillJob) error {
+	return r.db.Model(&models.BackfillJob{}).
+		Where("id = ? AND status = ?", job.ID, models.BackfillJobRunning).
+		Updates(progressColumns(job)).Error
+}
+
+func (r *BackfillJobRepositoryImpl) Finish(job *models.BackfillJob) (bool, error) {
+	columns := progressColumns(job)
+	columns["status"] = job.Status
+	columns["error"] = job.Error
+	columns["finished_at"] = job.FinishedAt
+	res := r.db.Model(&models.BackfillJob{}).
+		Where("id = ? AND status = ?", job.ID, models.BackfillJobRunning).
+		Updates(columns)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *BackfillJobRepositoryImpl) FailStale(cutoff, finishedAt time.Time, reason string) (int64, error) {
+	res := r.db.Model(&models.BackfillJob{}).
+		Where("status = ? AND started_at < ?", models.BackfillJobRunning, cutoff).
+		Updates(map[string]any{"status": models.BackfillJobFailed, "error": reason, "finished_at": finishedAt})
+	return res.RowsAffected, res.Error
+}
+
+func (r *BackfillJobRepositoryImpl) DeleteFinishedBefore(t time.Time) error {
+	return r.db.
+		Where("status IN ? AND finished_at < ?", []string{models.BackfillJobCompleted, models.BackfillJobFailed}, t).
+		Delete(&models.BackfillJob{}).Error
+}
+
+func progressColumns(job *models.BackfillJob) map[string]any {
+	return map[string]any{
+		"stage":            job.Stage,
+		"progress":         job.Progress,
+		"daily_candles":    job.DailyCandles,
+		"daily_inserted":   job.DailyInserted,
+		"intraday_candles": job.IntradayCandles,
+		"quotes_inserted":  job.QuotesInserted,
+	}
+}
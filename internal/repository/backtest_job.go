@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type BacktestJobRepository interface {
+	Create(job *models.BacktestJob) error
+	FindByID(id uuid.UUID) (*models.BacktestJob, error)
+	// FindByUser lists up to limit jobs of a user, newest first, without
+	// their results.
+	FindByUser(userID uuid.UUID, limit int) ([]models.BacktestJob, error)
+	CountPendingByUser(userID uuid.UUID) (int64, error)
+	CountQueued() (int64, error)
+	// ClaimNext marks the oldest queued job running and returns it, or
+	// gorm.ErrRecordNotFound when none is queued. Concurrent workers, on
+	// any replica, never claim the same job.
+	ClaimNext(startedAt time.Time) (*models.BacktestJob, error)
+	// Finish stores the outcome of a running job and reports false when the
+	// job was canceled or failed as stale in the meantime.
+	Finish(job *models.BacktestJob) (bool, error)
+	// Cancel cancels a queued or running job of userID and reports whether
+	// it did.
+	Cancel(userID, id uuid.UUID, finishedAt time.Time) (bool, error)
+	// FailStale fails the jobs still running that started before cutoff,
+	// whose worker must have died.
+	FailStale(cutoff, finishedAt time.Time, reason string) (int64, error)
+	DeleteFinishedBefore(t time.Time) error
+}
+
+type BacktestJobRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBacktestJobRepository(db *gorm.DB) BacktestJobRepository {
+	return &BacktestJobRepositoryImpl{db: db}
+}
+
+func (r *BacktestJobRepositoryImpl) Create(job *models.BacktestJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *BacktestJobRepositoryImpl) FindByID(id uuid.UUID) (*models.BacktestJob, error) {
+	var job models.BacktestJob
+	if err := r.db.First(&job, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *BacktestJobRepositoryImpl) FindByUser(userID uuid.UUID, limit int) ([]models.BacktestJob, error) {
+	var jobs []models.BacktestJob
+	err := r.db.
+		Omit("result").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *BacktestJobRepositoryImpl) CountPendingByUser(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.BacktestJob{}).
+		Where("user_id = ? AND status IN ?", userID, []string{models.BacktestJobQueued, models.BacktestJobRunning}).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *BacktestJobRepositoryImpl) CountQueued() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.BacktestJob{}).
+		Where("status = ?", models.BacktestJobQueued).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *BacktestJobRepositoryImpl) ClaimNext(startedAt time.Time) (*models.BacktestJob, error) {
+	var job models.BacktestJob
+	res := r.db.Raw(`UPDATE backtest_jobs SET status = ?, started_at = ?
+		WHERE id = (
+			SELECT id FROM backtest_jobs WHERE status = ?
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING *`, models.BacktestJobRunning, startedAt, models.BacktestJobQueued).
+		Scan(&job)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &job, nil
+}
+
+func (r *BacktestJobRepositoryImpl) Finish(job *models.BacktestJob) (bool, error) {
+	res := r.db.Model(&models.BacktestJob{}).
+		Where("id = ? AND status = ?", job.ID, models.BacktestJobRunning).
+		Updates(map[string]any{
+			"status":      job.Status,
+			"result":      job.Result,
+			"error":       job.Error,
+			"finished_at": job.FinishedAt,
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *BacktestJobRepositoryImpl) Cancel(userID, id uuid.UUID, finishedAt time.Time) (bool, error) {
+	res := r.db.Model(&models.BacktestJob{}).
+		Where("id = ? AND user_id = ? AND status IN ?", id, userID, []string{models.BacktestJobQueued, models.BacktestJobRunning}).
+		Updates(map[string]any{"status": models.BacktestJobCanceled, "finished_at": finishedAt})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *BacktestJobRepositoryImpl) FailStale(cutoff, finishedAt time.Time, reason string) (int64, error) {
+	res := r.db.Model(&models.BacktestJob{}).
+		Where("status = ? AND started_at < ?", models.BacktestJobRunning, cutoff).
+		Updates(map[string]any{"status": models.BacktestJobFailed, "error": reason, "finished_at": finishedAt})
+	return res.RowsAffected, res.Error
+}
+
+func (r *BacktestJobRepositoryImpl) DeleteFinishedBefore(t time.Time) error {
+	return r.db.
+		Where("status IN ? AND finished_at < ?", []string{models.BacktestJobSucceeded, models.BacktestJobFailed, models.BacktestJobCanceled}, t).
+		Delete(&models.BacktestJob{}).Error
+}
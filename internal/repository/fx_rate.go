@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type FXRateRepository interface {
+	Upsert(rates []models.FXRate) error
+	FindLatest(base string, onOrBefore time.Time) ([]models.FXRate, error)
+	FindBetween(base string, start, end time.Time) ([]models.FXRate, error)
+}
+
+type FXRateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewFXRateRepository(db *gorm.DB) FXRateRepository {
+	return &FXRateRepositoryImpl{db: db}
+}
+
+// Upsert stores rates, replacing any rate already stored for the same pair
+// and date.
+func (r *FXRateRepositoryImpl) Upsert(rates []models.FXRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+	return r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "base"}, {Name: "quote"}, {Name: "rate_date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate", "source", "updated_at"}),
+		}).
+		Create(&rates).Error
+}
+
+// FindLatest returns, for every quote currency of base, the most recent rate
+// dated on or before onOrBefore.
+func (r *FXRateRepositoryImpl) FindLatest(base string, onOrBefore time.Time) ([]models.FXRate, error) {
+	if base == "" {
+		return nil, errors.New("base currency is empty")
+	}
+	var rates []models.FXRate
+	if err := r.db.
+		Select("DISTINCT ON (quote) *").
+		Where("base = ? AND rate_date <= ?", base, onOrBefore).
+		Order("quote, rate_date desc").
+		Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// FindBetween returns the rates of base dated between start and end
+// inclusive, oldest first.
+func (r *FXRateRepositoryImpl) FindBetween(base string, start, end time.Time) ([]models.FXRate, error) {
+	if base == "" {
+		return nil, errors.New("base currency is empty")
+	}
+	var rates []models.FXRate
+	if err := r.db.
+		Where("base = ? AND rate_date >= ? AND rate_date <= ?", base, start, end).
+		Order("rate_date asc, quote asc").
+		Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
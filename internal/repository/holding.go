@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type HoldingRepository interface {
+	Create(transaction *models.HoldingTransaction) error
+	CreateBatch(transactions []models.HoldingTransaction) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.HoldingTransaction, error)
+	FindByUser(userID uuid.UUID, symbol string) ([]models.HoldingTransaction, error)
+	CountByUser(userID uuid.UUID) (int64, error)
+}
+
+type HoldingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewHoldingRepository(db *gorm.DB) HoldingRepository {
+	return &HoldingRepositoryImpl{db: db}
+}
+
+func (r *HoldingRepositoryImpl) Create(transaction *models.HoldingTransaction) error {
+	if transaction == nil {
+		return errors.New("holding transaction is nil")
+	}
+	return r.db.Create(transaction).Error
+}
+
+// CreateBatch inserts all transactions or none of them.
+func (r *HoldingRepositoryImpl) CreateBatch(transactions []models.HoldingTransaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&transactions, 500).Error
+	})
+}
+
+func (r *HoldingRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&models.HoldingTransaction{}).Error
+}
+
+func (r *HoldingRepositoryImpl) FindByID(id uuid.UUID) (*models.HoldingTransaction, error) {
+	var transaction models.HoldingTransaction
+	if err := r.db.First(&transaction, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// FindByUser returns the user's transactions in trade order, optionally for a
+// single symbol.
+func (r *HoldingRepositoryImpl) FindByUser(userID uuid.UUID, symbol string) ([]models.HoldingTransaction, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if symbol != "" {
+		query = query.Where("symbol = ?", symbol)
+	}
+	var transactions []models.HoldingTransaction
+	if err := query.
+		Order("traded_at asc, created_at asc").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (r *HoldingRepositoryImpl) CountByUser(userID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.
+		Model(&models.HoldingTransaction{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
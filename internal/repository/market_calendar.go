@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+// SymbolCalendar pairs an active stock symbol with the calendar code of its
+// exchange. CalendarCode is empty when the exchange is not in master_exchange.
+type SymbolCalendar struct {
+	Symbol       string
+	CalendarCode string
+}
+
+type MarketCalendarRepository interface {
+	FindAll() ([]models.MarketCalendar, error)
+	FindByCode(code string) (*models.MarketCalendar, error)
+	EnsureCalendar(calendar *models.MarketCalendar) error
+	SaveCalendar(calendar *models.MarketCalendar) error
+	FindHolidays(code string) ([]models.MarketHoliday, error)
+	UpsertHoliday(holiday *models.MarketHoliday) error
+	UpsertProviderHolidays(holidays []models.MarketHoliday) error
+	DeleteHoliday(code string, date time.Time) error
+	FindExchanges() ([]models.MasterExchange, error)
+	SetExchangeCalendar(id uuid.UUID, code string) (*models.MasterExchange, error)
+	FindSymbolCalendars() ([]SymbolCalendar, error)
+}
+
+type MarketCalendarRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMarketCalendarRepository(db *gorm.DB) MarketCalendarRepository {
+	return &MarketCalendarRepositoryImpl{db: db}
+}
+
+func (r *MarketCalendarRepositoryImpl) FindAll() ([]models.MarketCalendar, error) {
+	var calendars []models.MarketCalendar
+	if err := r.db.Order("code asc").Find(&calendars).Error; err != nil {
+		return nil, err
+	}
+	return calendars, nil
+}
+
+func (r *MarketCalendarRepositoryImpl) FindByCode(code string) (*models.MarketCalendar, error) {
+	var calendar models.MarketCalendar
+	if err := r.db.First(&calendar, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+// EnsureCalendar creates calendar unless a calendar with the same code
+// already exists, leaving an existing definition untouched.
+func (r *MarketCalendarRepositoryImpl) EnsureCalendar(calendar *models.MarketCalendar) error {
+	if calendar == nil {
+		return errors.New("market calendar is nil")
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(calendar).Error
+}
+
+// SaveCalendar creates or replaces the session definition of a calendar.
+func (r *MarketCalendarRepositoryImpl) SaveCalendar(calendar *models.MarketCalendar) error {
+	if calendar == nil {
+		return errors.New("market calendar is nil")
+	}
+	return r.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "code"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"name", "timezone", "pre_open", "open", "close", "post_close", "weekdays", "provider_code", "updated_at",
+			}),
+		}).
+		Create(calendar).Error
+}
+
+func (r *MarketCalendarRepositoryImpl) FindHolidays(code string) ([]models.MarketHoliday, error) {
+	var holidays []models.MarketHoliday
+	if err := r.db.
+		Where("calendar_code = ?", code).
+		Order("date asc").
+		Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// UpsertHoliday stores holiday, replacing any holiday already stored for the
+// same calendar and date regardless of its source.
+func (r *MarketCalendarRepositoryImpl) UpsertHoliday(holiday *models.MarketHoliday) error {
+	if holiday == nil {
+		return errors.New("market holiday is nil")
+	}
+	return r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "calendar_code"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "early_close", "source", "updated_at"}),
+		}).
+		Create(holiday).Error
+}
+
+// UpsertProviderHolidays stores ingested holidays without touching days that
+// carry a manual override.
+func (r *MarketCalendarRepositoryImpl) UpsertProviderHolidays(holidays []models.MarketHoliday) error {
+	if len(holidays) == 0 {
+		return nil
+	}
+	return r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "calendar_code"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "early_close", "source", "updated_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Neq{Column: clause.Column{Table: "market_holidays", Name: "source"}, Value: models.HolidaySourceManual},
+			}},
+		}).
+		Create(&holidays).Error
+}
+
+// DeleteHoliday removes the holiday of code on date and returns
+// gorm.ErrRecordNotFound when there is none.
+func (r *MarketCalendarRepositoryImpl) DeleteHoliday(code string, date time.Time) error {
+	result := r.db.
+		Where("calendar_code = ? AND date = ?", code, date).
+		Delete(&models.MarketHoliday{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *MarketCalendarRepositoryImpl) FindExchanges() ([]models.MasterExchange, error) {
+	var exchanges []models.MasterExchange
+	if err := r.db.Order("name asc").Find(&exchanges).Error; err != nil {
+		return nil, err
+	}
+	return exchanges, nil
+}
+
+func (r *MarketCalendarRepositoryImpl) SetExchangeCalendar(id uuid.UUID, code string) (*models.MasterExchange, error) {
+	result := r.db.Model(&models.MasterExchange{}).
+		Where("id = ?", id).
+		Update("calendar_code", code)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	var exchange models.MasterExchange
+	if err := r.db.First(&exchange, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &exchange, nil
+}
+
+func (r *MarketCalendarRepositoryImpl) FindSymbolCalendars() ([]SymbolCalendar, error) {
+	var rows []SymbolCalendar
+	if err := r.db.Model(&models.Stock{}).
+		Select("stocks.symbol AS symbol, COALESCE(master_exchange.calendar_code, '') AS calendar_code").
+		Joins("LEFT JOIN master_exchange ON master_exchange.name = stocks.exchange").
+		Where("stocks.is_active = ?", true).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -11,7 +11,7 @@ import (
 )
 
 type MarketOpenRepository interface {
-	FindByTradeDate(tradeDate time.Time) (*models.MarketOpen, error)
+	FindByTradeDate(exchange string, tradeDate time.Time) (*models.MarketOpen, error)
 	Create(record *models.MarketOpen) error
 	UpdateCloseAt(id uuid.UUID, closeAt time.Time, isTradingDay bool) error
 	DeleteBefore(t time.Time) error
@@ -25,9 +25,9 @@ func NewMarketOpenRepository(db *gorm.DB) MarketOpenRepository {
 	return &MarketOpenRepositoryImpl{db: db}
 }
 
-func (r *MarketOpenRepositoryImpl) FindByTradeDate(tradeDate time.Time) (*models.MarketOpen, error) {
+func (r *MarketOpenRepositoryImpl) FindByTradeDate(exchange string, tradeDate time.Time) (*models.MarketOpen, error) {
 	var record models.MarketOpen
-	if err := r.db.Where("trade_date = ?", tradeDate).First(&record).Error; err != nil {
+	if err := r.db.Where("exchange = ? AND trade_date = ?", exchange, tradeDate).First(&record).Error; err != nil {
 		return nil, err
 	}
 	return &record, nil
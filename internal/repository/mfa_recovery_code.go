@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type MFARecoveryCodeRepository interface {
+	// ReplaceForUser swaps the user's recovery codes for new ones.
+	ReplaceForUser(userID uuid.UUID, codeHashes []string) error
+	// Consume marks an unused code used and reports whether one matched.
+	Consume(userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error)
+	CountUnused(userID uuid.UUID) (int64, error)
+	DeleteForUser(userID uuid.UUID) error
+}
+
+type MFARecoveryCodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMFARecoveryCodeRepository(db *gorm.DB) MFARecoveryCodeRepository {
+	return &MFARecoveryCodeRepositoryImpl{db: db}
+}
+
+func (r *MFARecoveryCodeRepositoryImpl) ReplaceForUser(userID uuid.UUID, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codeHashes) == 0 {
+			return nil
+		}
+		codes := make([]models.MFARecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.MFARecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *MFARecoveryCodeRepositoryImpl) Consume(userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *MFARecoveryCodeRepositoryImpl) CountUnused(userID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.MFARecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *MFARecoveryCodeRepositoryImpl) DeleteForUser(userID uuid.UUID) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error
+}
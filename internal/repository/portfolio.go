@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type PortfolioRepository interface {
+	// Transaction runs fn against a repository bound to a single database
+	// transaction; returning an error rolls everything back.
+	Transaction(fn func(tx PortfolioRepository) error) error
+	Create(portfolio *models.Portfolio) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.Portfolio, error)
+	FindByUser(userID uuid.UUID) ([]models.Portfolio, error)
+	// LockByID loads a portfolio with a row lock held until the surrounding
+	// transaction ends, serializing fills against the same account.
+	LockByID(id uuid.UUID) (*models.Portfolio, error)
+	UpdateBalances(portfolio *models.Portfolio) error
+	CreateOrder(order *models.PortfolioOrder) error
+	UpdateOrder(order *models.PortfolioOrder) error
+	FindOrderByID(id uuid.UUID) (*models.PortfolioOrder, error)
+	FindOrders(portfolioID uuid.UUID, status string, limit int) ([]models.PortfolioOrder, error)
+	FindOpenOrdersBySymbol(symbol string) ([]models.PortfolioOrder, error)
+	FindPositions(portfolioID uuid.UUID) ([]models.PortfolioPosition, error)
+	FindPosition(portfolioID uuid.UUID, symbol string) (*models.PortfolioPosition, error)
+	SavePosition(position *models.PortfolioPosition) error
+	DeletePosition(portfolioID uuid.UUID, symbol string) error
+}
+
+type PortfolioRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPortfolioRepository(db *gorm.DB) PortfolioRepository {
+	return &PortfolioRepositoryImpl{db: db}
+}
+
+func (r *PortfolioRepositoryImpl) Transaction(fn func(tx PortfolioRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&PortfolioRepositoryImpl{db: tx})
+	})
+}
+
+func (r *PortfolioRepositoryImpl) Create(portfolio *models.Portfolio) error {
+	if portfolio == nil {
+		return errors.New("portfolio is nil")
+	}
+	return r.db.Create(portfolio).Error
+}
+
+func (r *PortfolioRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("portfolio_id = ?", id).Delete(&models.PortfolioPosition{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("portfolio_id = ?", id).Delete(&models.PortfolioOrder{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.Portfolio{}).Error
+	})
+}
+
+func (r *PortfolioRepositoryImpl) FindByID(id uuid.UUID) (*models.Portfolio, error) {
+	var portfolio models.Portfolio
+	if err := r.db.First(&portfolio, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &portfolio, nil
+}
+
+func (r *PortfolioRepositoryImpl) FindByUser(userID uuid.UUID) ([]models.Portfolio, error) {
+	var portfolios []models.Portfolio
+	if err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at asc").
+		Find(&portfolios).Error; err != nil {
+		return nil, err
+	}
+	return portfolios, nil
+}
+
+func (r *PortfolioRepositoryImpl) LockByID(id uuid.UUID) (*models.Portfolio, error) {
+	var portfolio models.Portfolio
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&portfolio, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &portfolio, nil
+}
+
+func (r *PortfolioRepositoryImpl) UpdateBalances(portfolio *models.Portfolio) error {
+	if portfolio == nil {
+		return errors.New("portfolio is nil")
+	}
+	return r.db.
+		Model(portfolio).
+		Select("cash", "realized_pnl", "updated_at").
+		Updates(portfolio).Error
+}
+
+func (r *PortfolioRepositoryImpl) CreateOrder(order *models.PortfolioOrder) error {
+	if order == nil {
+		return errors.New("portfolio order is nil")
+	}
+	return r.db.Create(order).Error
+}
+
+func (r *PortfolioRepositoryImpl) UpdateOrder(order *models.PortfolioOrder) error {
+	if order == nil {
+		return errors.New("portfolio order is nil")
+	}
+	return r.db.
+		Model(order).
+		Select("status", "filled_price", "filled_at", "quote_id", "realized_pnl", "reject_reason", "updated_at").
+		Updates(order).Error
+}
+
+func (r *PortfolioRepositoryImpl) FindOrderByID(id uuid.UUID) (*models.PortfolioOrder, error) {
+	var order models.PortfolioOrder
+	if err := r.db.First(&order, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// FindOrders returns the newest orders of a portfolio first, optionally
+// filtered by status.
+func (r *PortfolioRepositoryImpl) FindOrders(portfolioID uuid.UUID, status string, limit int) ([]models.PortfolioOrder, error) {
+	query := r.db.Where("portfolio_id = ?", portfolioID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var orders []models.PortfolioOrder
+	if err := query.Order("created_at desc").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *PortfolioRepositoryImpl) FindOpenOrdersBySymbol(symbol string) ([]models.PortfolioOrder, error) {
+	var orders []models.PortfolioOrder
+	if err := r.db.
+		Where("symbol = ? AND status = ?", symbol, models.OrderStatusOpen).
+		Order("created_at asc").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *PortfolioRepositoryImpl) FindPositions(portfolioID uuid.UUID) ([]models.PortfolioPosition, error) {
+	var positions []models.PortfolioPosition
+	if err := r.db.
+		Where("portfolio_id = ?", portfolioID).
+		Order("symbol asc").
+		Find(&positions).Error; err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// FindPosition returns nil without an error when the portfolio holds none of
+// symbol.
+func (r *PortfolioRepositoryImpl) FindPosition(portfolioID uuid.UUID, symbol string) (*models.PortfolioPosition, error) {
+	var position models.PortfolioPosition
+	err := r.db.First(&position, "portfolio_id = ? AND symbol = ?", portfolioID, symbol).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &position, nil
+}
+
+func (r *PortfolioRepositoryImpl) SavePosition(position *models.PortfolioPosition) error {
+	if position == nil {
+		return errors.New("portfolio position is nil")
+	}
+	return r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "portfolio_id"}, {Name: "symbol"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "avg_cost", "updated_at"}),
+		}).
+		Create(position).Error
+}
+
+func (r *PortfolioRepositoryImpl) DeletePosition(portfolioID uuid.UUID, symbol string) error {
+	return r.db.
+		Where("portfolio_id = ? AND symbol = ?", portfolioID, symbol).
+		Delete(&models.PortfolioPosition{}).Error
+}
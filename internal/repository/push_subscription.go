@@ -14,6 +14,7 @@ import (
 type PushSubscriptionRepository interface {
 	Upsert(subscription *models.PushSubscription) error
 	ListActive() ([]models.PushSubscription, error)
+	ListActiveByUser(userID uuid.UUID) ([]models.PushSubscription, error)
 	DeleteByEndpoint(endpoint string) error
 	DeleteByUserAndDevice(userID uuid.UUID, deviceID string) error
 	DeleteBefore(t time.Time) error
@@ -57,6 +58,16 @@ func (r *PushSubscriptionRepositoryImpl) ListActive() ([]models.PushSubscription
 	return subscriptions, nil
 }
 
+func (r *PushSubscriptionRepositoryImpl) ListActiveByUser(userID uuid.UUID) ([]models.PushSubscription, error) {
+	var subscriptions []models.PushSubscription
+	if err := r.db.
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
 func (r *PushSubscriptionRepositoryImpl) DeleteByEndpoint(endpoint string) error {
 	if endpoint == "" {
 		return nil
@@ -3,6 +3,7 @@ package repository
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"sun-stockanalysis-api/internal/models"
@@ -11,7 +12,15 @@ import (
 type RefreshTokenRepository interface {
 	Create(token *models.RefreshTokens) error
 	FindByHash(hash string) (*models.RefreshTokens, error)
-	RevokeByHash(hash string, revokedAt float64) error
+	// Revoke marks a token revoked and reports whether it was still active,
+	// so two concurrent refreshes cannot both rotate the same token.
+	Revoke(id uuid.UUID, revokedAt time.Time) (bool, error)
+	RevokeFamily(familyID uuid.UUID, revokedAt time.Time) error
+	// RevokeUserFamily revokes a family only if it belongs to userID and
+	// reports whether any active token was revoked.
+	RevokeUserFamily(userID string, familyID uuid.UUID, revokedAt time.Time) (bool, error)
+	RevokeAllForUser(userID string, revokedAt time.Time) error
+	FindActiveByUser(userID string, now time.Time) ([]models.RefreshTokens, error)
 	DeleteBefore(t time.Time) error
 }
 
@@ -35,12 +44,41 @@ func (r *RefreshTokenRepositoryImpl) FindByHash(hash string) (*models.RefreshTok
 	return &t, nil
 }
 
-func (r *RefreshTokenRepositoryImpl) RevokeByHash(hash string, revokedAt float64) error {
+func (r *RefreshTokenRepositoryImpl) Revoke(id uuid.UUID, revokedAt time.Time) (bool, error) {
+	res := r.db.Model(&models.RefreshTokens{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeFamily(familyID uuid.UUID, revokedAt time.Time) error {
+	return r.db.Model(&models.RefreshTokens{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeUserFamily(userID string, familyID uuid.UUID, revokedAt time.Time) (bool, error) {
+	res := r.db.Model(&models.RefreshTokens{}).
+		Where("user_id = ? AND family_id = ? AND revoked_at IS NULL", userID, familyID).
+		Update("revoked_at", revokedAt)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeAllForUser(userID string, revokedAt time.Time) error {
 	return r.db.Model(&models.RefreshTokens{}).
-		Where("token_hash = ?", hash).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
 		Update("revoked_at", revokedAt).Error
 }
 
+func (r *RefreshTokenRepositoryImpl) FindActiveByUser(userID string, now time.Time) ([]models.RefreshTokens, error) {
+	var tokens []models.RefreshTokens
+	err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
 func (r *RefreshTokenRepositoryImpl) DeleteBefore(t time.Time) error {
 	return r.db.
 		Where("created_at < ?", t).
@@ -12,6 +12,7 @@ type RelationNewsRepository interface {
 	CreateMany(items []models.RelationNews) error
 	ListDistinctRelationSymbols() ([]string, error)
 	ListRelationSymbolsBySymbol(symbol string) ([]string, error)
+	ListSymbolsByRelationSymbols(relationSymbols []string) ([]string, error)
 }
 
 type RelationNewsRepositoryImpl struct {
@@ -53,3 +54,19 @@ func (r *RelationNewsRepositoryImpl) ListRelationSymbolsBySymbol(symbol string)
 	}
 	return symbols, nil
 }
+
+// ListSymbolsByRelationSymbols returns the stock symbols whose news feed
+// includes any of relationSymbols.
+func (r *RelationNewsRepositoryImpl) ListSymbolsByRelationSymbols(relationSymbols []string) ([]string, error) {
+	if len(relationSymbols) == 0 {
+		return []string{}, nil
+	}
+	var symbols []string
+	if err := r.db.Model(&models.RelationNews{}).
+		Select("distinct symbol").
+		Where("relation_symbol IN ?", relationSymbols).
+		Pluck("symbol", &symbols).Error; err != nil {
+		return nil, err
+	}
+	return symbols, nil
+}
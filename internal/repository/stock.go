@@ -9,6 +9,7 @@ import (
 
 type StockRepository interface {
 	FindByID(id uuid.UUID) (*models.Stock, error)
+	FindBySymbol(symbol string) (*models.Stock, error)
 	Create(stock *models.Stock) error
 	ListSymbols() ([]string, error)
 	FindAll() ([]models.Stock, error)
@@ -33,6 +34,15 @@ func (r *StockRepositoryImpl) FindByID(id uuid.UUID) (*models.Stock, error) {
 	return &s, nil
 }
 
+// FindBySymbol matches symbol case-insensitively.
+func (r *StockRepositoryImpl) FindBySymbol(symbol string) (*models.Stock, error) {
+	var s models.Stock
+	if err := r.db.First(&s, "UPPER(symbol) = UPPER(?)", symbol).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
 func (r *StockRepositoryImpl) Create(s *models.Stock) error {
 	return r.db.Create(s).Error
 }
@@ -2,17 +2,24 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"sun-stockanalysis-api/internal/models"
 )
 
 type StockDailyRepository interface {
 	Create(metric *models.StockDaily) error
+	Upsert(metric *models.StockDaily) error
 	FindLatestBySymbol(symbol string) (*models.StockDaily, error)
 	FindPreviousBySymbol(symbol string) ([]models.StockDaily, error)
 	FindBySymbol(symbol string) ([]models.StockDaily, error)
+	FindHistoryBySymbol(symbol string) ([]models.StockDaily, error)
+	FindBySymbolBetween(symbol string, start, end time.Time) ([]models.StockDaily, error)
+	FindRecentBySymbol(symbol string, before time.Time, limit int) ([]models.StockDaily, error)
+	UpdateIndicators(metric *models.StockDaily) error
 }
 
 type StockDailyRepositoryImpl struct {
@@ -30,6 +37,25 @@ func (r *StockDailyRepositoryImpl) Create(metric *models.StockDaily) error {
 	return r.db.Create(metric).Error
 }
 
+// Upsert inserts metric or, when a row for the same symbol and trade date
+// already exists, overwrites its prices and indicators in place.
+func (r *StockDailyRepositoryImpl) Upsert(metric *models.StockDaily) error {
+	if metric == nil {
+		return errors.New("stock daily is nil")
+	}
+	columns := append([]string{
+		"price_average", "price_high", "price_low", "price_open", "price_close",
+		"price_prev_close", "change_price", "change_percent", "delta_price", "volume",
+		"ema_20", "ema_100", "ema_trend",
+	}, models.TechnicalIndicatorColumns...)
+	return r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "symbol"}, {Name: "trade_date"}},
+			DoUpdates: clause.AssignmentColumns(columns),
+		}).
+		Create(metric).Error
+}
+
 func (r *StockDailyRepositoryImpl) FindLatestBySymbol(symbol string) (*models.StockDaily, error) {
 	if symbol == "" {
 		return nil, errors.New("symbol is empty")
@@ -37,7 +63,7 @@ func (r *StockDailyRepositoryImpl) FindLatestBySymbol(symbol string) (*models.St
 	var metric models.StockDaily
 	if err := r.db.
 		Where("symbol = ?", symbol).
-		Order("created_at desc").
+		Order("trade_date desc").
 		First(&metric).Error; err != nil {
 		return nil, err
 	}
@@ -51,7 +77,7 @@ func (r *StockDailyRepositoryImpl) FindBySymbol(symbol string) ([]models.StockDa
 	var metrics []models.StockDaily
 	if err := r.db.
 		Where("symbol = ?", symbol).
-		Order("created_at desc").
+		Order("trade_date desc").
 		Find(&metrics).Error; err != nil {
 		return nil, err
 	}
@@ -65,7 +91,7 @@ func (r *StockDailyRepositoryImpl) FindPreviousBySymbol(symbol string) ([]models
 	var metrics []models.StockDaily
 	if err := r.db.
 		Where("symbol = ?", symbol).
-		Order("created_at desc").
+		Order("trade_date desc").
 		Offset(1).
 		Limit(1).
 		Find(&metrics).Error; err != nil {
@@ -73,3 +99,68 @@ func (r *StockDailyRepositoryImpl) FindPreviousBySymbol(symbol string) ([]models
 	}
 	return metrics, nil
 }
+
+// FindHistoryBySymbol returns every row for symbol, oldest trade date first.
+func (r *StockDailyRepositoryImpl) FindHistoryBySymbol(symbol string) ([]models.StockDaily, error) {
+	if symbol == "" {
+		return nil, errors.New("symbol is empty")
+	}
+	var metrics []models.StockDaily
+	if err := r.db.
+		Where("symbol = ?", symbol).
+		Order("trade_date asc, created_at asc").
+		Find(&metrics).Error; err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// FindBySymbolBetween returns the sessions of symbol traded between start and
+// end inclusive, oldest first.
+func (r *StockDailyRepositoryImpl) FindBySymbolBetween(symbol string, start, end time.Time) ([]models.StockDaily, error) {
+	if symbol == "" {
+		return nil, errors.New("symbol is empty")
+	}
+	var metrics []models.StockDaily
+	if err := r.db.
+		Where("symbol = ? AND trade_date >= ? AND trade_date <= ?", symbol, start, end).
+		Order("trade_date asc, created_at asc").
+		Find(&metrics).Error; err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// FindRecentBySymbol returns up to limit rows for symbol traded before the
+// given date, newest trade date first. A zero before means no upper bound.
+func (r *StockDailyRepositoryImpl) FindRecentBySymbol(symbol string, before time.Time, limit int) ([]models.StockDaily, error) {
+	if symbol == "" {
+		return nil, errors.New("symbol is empty")
+	}
+	if limit <= 0 {
+		return []models.StockDaily{}, nil
+	}
+	query := r.db.Where("symbol = ?", symbol)
+	if !before.IsZero() {
+		query = query.Where("trade_date < ?", before)
+	}
+	var metrics []models.StockDaily
+	if err := query.
+		Order("trade_date desc, created_at desc").
+		Limit(limit).
+		Find(&metrics).Error; err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (r *StockDailyRepositoryImpl) UpdateIndicators(metric *models.StockDaily) error {
+	if metric == nil {
+		return errors.New("stock daily is nil")
+	}
+	columns := append([]string{"ema_20", "ema_100", "ema_trend"}, models.TechnicalIndicatorColumns...)
+	return r.db.
+		Model(metric).
+		Select(columns).
+		Updates(metric).Error
+}
@@ -17,6 +17,9 @@ type StockQuoteRepository interface {
 	FindAll() ([]models.StockQuote, error)
 	FindBySymbol(symbol string) ([]models.StockQuote, error)
 	DeleteBefore(t time.Time) error
+	FindRecentBySymbol(symbol string, limit int) ([]models.StockQuote, error)
+	UpdateIndicators(quote *models.StockQuote) error
+	FindAfter(seq int64, symbols []string, limit int) ([]models.StockQuote, error)
 }
 
 type StockQuoteRepositoryImpl struct {
@@ -112,3 +115,55 @@ func (r *StockQuoteRepositoryImpl) DeleteBefore(t time.Time) error {
 		Where("created_at < ?", t).
 		Delete(&models.StockQuote{}).Error
 }
+
+func (r *StockQuoteRepositoryImpl) FindRecentBySymbol(symbol string, limit int) ([]models.StockQuote, error) {
+	if symbol == "" {
+		return nil, errors.New("symbol is empty")
+	}
+	if limit <= 0 {
+		return []models.StockQuote{}, nil
+	}
+	var quotes []models.StockQuote
+	if err := r.db.
+		Where("symbol = ?", symbol).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&quotes).Error; err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+func (r *StockQuoteRepositoryImpl) UpdateIndicators(quote *models.StockQuote) error {
+	if quote == nil {
+		return errors.New("stock quote is nil")
+	}
+	columns := append([]string{
+		"ema_20", "ema_100", "tanh_ema", "change_ema_20", "change_tanh_ema", "ema_trend",
+	}, models.TechnicalIndicatorColumns...)
+	return r.db.
+		Model(quote).
+		Select(columns).
+		Updates(quote).Error
+}
+
+// FindAfter returns up to limit quotes stored after sequence number seq, in
+// insert order, optionally restricted to symbols.
+func (r *StockQuoteRepositoryImpl) FindAfter(seq int64, symbols []string, limit int) ([]models.StockQuote, error) {
+	if limit <= 0 {
+		return []models.StockQuote{}, nil
+	}
+	query := r.db.
+		Where("seq > ?", seq)
+	if len(symbols) > 0 {
+		query = query.Where("symbol IN ?", symbols)
+	}
+	var quotes []models.StockQuote
+	if err := query.
+		Order("seq asc").
+		Limit(limit).
+		Find(&quotes).Error; err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
@@ -15,6 +15,15 @@ type UserRepository interface {
 	Create(user *models.User) error
 	FindByID(id uuid.UUID) (*models.User, error)
 	UpdateLastLogin(id uuid.UUID, when time.Time) error
+	List() ([]models.User, error)
+	UpdateRole(id uuid.UUID, role string) error
+	CountByRole(role string) (int64, error)
+	MarkEmailVerified(id uuid.UUID) error
+	UpdatePassword(id uuid.UUID, passwordHash string) error
+	SetTOTP(id uuid.UUID, secret string, enabled bool) error
+	// AdvanceTOTPStep records the time step of an accepted TOTP code and
+	// reports false when that step or a later one was already used.
+	AdvanceTOTPStep(id uuid.UUID, step int64) (bool, error)
 }
 
 type UserRepositoryImpl struct {
@@ -60,3 +69,83 @@ func (r *UserRepositoryImpl) UpdateLastLogin(id uuid.UUID, when time.Time) error
 		Where("id = ?", id).
 		Update("last_login_at", when).Error
 }
+
+// List returns every active user, oldest first.
+func (r *UserRepositoryImpl) List() ([]models.User, error) {
+	var users []models.User
+	if err := r.db.
+		Where("is_active = true").
+		Order("created_at asc").
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *UserRepositoryImpl) UpdateRole(id uuid.UUID, role string) error {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND is_active = true", id).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *UserRepositoryImpl) CountByRole(role string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.User{}).
+		Where("role = ? AND is_active = true", role).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *UserRepositoryImpl) MarkEmailVerified(id uuid.UUID) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ?", id).
+		Update("is_email_verified", true).Error
+}
+
+func (r *UserRepositoryImpl) UpdatePassword(id uuid.UUID, passwordHash string) error {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND is_active = true", id).
+		Update("password", passwordHash)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetTOTP stores the TOTP secret and whether it is active. An empty secret
+// with enabled false turns two-factor authentication off.
+func (r *UserRepositoryImpl) SetTOTP(id uuid.UUID, secret string, enabled bool) error {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND is_active = true", id).
+		Updates(map[string]any{
+			"totp_secret":    secret,
+			"totp_enabled":   enabled,
+			"totp_last_step": 0,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *UserRepositoryImpl) AdvanceTOTPStep(id uuid.UUID, step int64) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type UserTokenRepository interface {
+	Create(token *models.UserToken) error
+	FindByHash(purpose, hash string) (*models.UserToken, error)
+	// Consume marks a token used and reports whether it was still unused, so
+	// a token cannot be redeemed twice even by concurrent requests.
+	Consume(id uuid.UUID, usedAt time.Time) (bool, error)
+	// InvalidateForUser consumes every unused token of a purpose, so only the
+	// most recently mailed link works.
+	InvalidateForUser(userID uuid.UUID, purpose string, usedAt time.Time) error
+	RecordAttempt(id uuid.UUID) error
+	DeleteBefore(t time.Time) error
+}
+
+type UserTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &UserTokenRepositoryImpl{db: db}
+}
+
+func (r *UserTokenRepositoryImpl) Create(token *models.UserToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *UserTokenRepositoryImpl) FindByHash(purpose, hash string) (*models.UserToken, error) {
+	var t models.UserToken
+	if err := r.db.First(&t, "purpose = ? AND token_hash = ?", purpose, hash).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *UserTokenRepositoryImpl) Consume(id uuid.UUID, usedAt time.Time) (bool, error) {
+	res := r.db.Model(&models.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *UserTokenRepositoryImpl) InvalidateForUser(userID uuid.UUID, purpose string, usedAt time.Time) error {
+	return r.db.Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", usedAt).Error
+}
+
+func (r *UserTokenRepositoryImpl) RecordAttempt(id uuid.UUID) error {
+	return r.db.Model(&models.UserToken{}).
+		Where("id = ?", id).
+		UpdateColumn("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+func (r *UserTokenRepositoryImpl) DeleteBefore(t time.Time) error {
+	return r.db.
+		Where("created_at < ?", t).
+		Delete(&models.UserToken{}).Error
+}
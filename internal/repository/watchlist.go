@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sun-stockanalysis-api/internal/models"
+)
+
+type WatchlistRepository interface {
+	Create(watchlist *models.Watchlist) error
+	UpdateName(watchlist *models.Watchlist) error
+	ReplaceSymbols(watchlistID uuid.UUID, symbols []string) error
+	AddSymbol(watchlistID uuid.UUID, symbol string) error
+	RemoveSymbol(watchlistID uuid.UUID, symbol string) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.Watchlist, error)
+	FindByUser(userID uuid.UUID) ([]models.Watchlist, error)
+	FindSymbolsByUser(userID uuid.UUID) ([]string, bool, error)
+}
+
+type WatchlistRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWatchlistRepository(db *gorm.DB) WatchlistRepository {
+	return &WatchlistRepositoryImpl{db: db}
+}
+
+func (r *WatchlistRepositoryImpl) Create(watchlist *models.Watchlist) error {
+	if watchlist == nil {
+		return errors.New("watchlist is nil")
+	}
+	return r.db.Create(watchlist).Error
+}
+
+func (r *WatchlistRepositoryImpl) UpdateName(watchlist *models.Watchlist) error {
+	if watchlist == nil {
+		return errors.New("watchlist is nil")
+	}
+	return r.db.
+		Model(watchlist).
+		Select("name", "updated_at").
+		Updates(watchlist).Error
+}
+
+// ReplaceSymbols swaps the symbols of a watchlist for the given set.
+func (r *WatchlistRepositoryImpl) ReplaceSymbols(watchlistID uuid.UUID, symbols []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("watchlist_id = ?", watchlistID).Delete(&models.WatchlistItem{}).Error; err != nil {
+			return err
+		}
+		if len(symbols) == 0 {
+			return nil
+		}
+		items := make([]models.WatchlistItem, 0, len(symbols))
+		for _, symbol := range symbols {
+			items = append(items, models.WatchlistItem{WatchlistID: watchlistID, Symbol: symbol})
+		}
+		return tx.Create(&items).Error
+	})
+}
+
+func (r *WatchlistRepositoryImpl) AddSymbol(watchlistID uuid.UUID, symbol string) error {
+	return r.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.WatchlistItem{WatchlistID: watchlistID, Symbol: symbol}).Error
+}
+
+func (r *WatchlistRepositoryImpl) RemoveSymbol(watchlistID uuid.UUID, symbol string) error {
+	return r.db.
+		Where("watchlist_id = ? AND symbol = ?", watchlistID, symbol).
+		Delete(&models.WatchlistItem{}).Error
+}
+
+func (r *WatchlistRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("watchlist_id = ?", id).Delete(&models.WatchlistItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.Watchlist{}).Error
+	})
+}
+
+func (r *WatchlistRepositoryImpl) FindByID(id uuid.UUID) (*models.Watchlist, error) {
+	var watchlist models.Watchlist
+	if err := r.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("symbol asc") }).
+		First(&watchlist, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &watchlist, nil
+}
+
+func (r *WatchlistRepositoryImpl) FindByUser(userID uuid.UUID) ([]models.Watchlist, error) {
+	var watchlists []models.Watchlist
+	if err := r.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("symbol asc") }).
+		Where("user_id = ?", userID).
+		Order("created_at asc").
+		Find(&watchlists).Error; err != nil {
+		return nil, err
+	}
+	return watchlists, nil
+}
+
+// FindSymbolsByUser returns the distinct symbols across the user's watchlists
+// and whether the user has any watchlist at all.
+func (r *WatchlistRepositoryImpl) FindSymbolsByUser(userID uuid.UUID) ([]string, bool, error) {
+	var count int64
+	if err := r.db.
+		Model(&models.Watchlist{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error; err != nil {
+		return nil, false, err
+	}
+	if count == 0 {
+		return nil, false, nil
+	}
+	var symbols []string
+	if err := r.db.
+		Model(&models.WatchlistItem{}).
+		Distinct("watchlist_items.symbol").
+		Joins("JOIN watchlists ON watchlists.id = watchlist_items.watchlist_id").
+		Where("watchlists.user_id = ?", userID).
+		Order("watchlist_items.symbol asc").
+		Pluck("watchlist_items.symbol", &symbols).Error; err != nil {
+		return nil, true, err
+	}
+	return symbols, true, nil
+}
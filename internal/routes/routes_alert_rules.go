@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterAlertRuleRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/alert-rules",
+		Summary: "List the current user's alert rules",
+		Tags:    v1Tags(),
+	}, controllers.AlertRuleController.List)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/alert-rules",
+		Summary: "Create an alert rule",
+		Tags:    v1Tags(),
+	}, controllers.AlertRuleController.Create)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/alert-rules/{id}",
+		Summary: "Get an alert rule",
+		Tags:    v1Tags(),
+	}, controllers.AlertRuleController.Get)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodPut,
+		Path:    "/alert-rules/{id}",
+		Summary: "Replace an alert rule",
+		Tags:    v1Tags(),
+	}, controllers.AlertRuleController.Update)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodDelete,
+		Path:    "/alert-rules/{id}",
+		Summary: "Delete an alert rule",
+		Tags:    v1Tags(),
+	}, controllers.AlertRuleController.Delete)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/alert-events",
+		Summary: "List the current user's alert events",
+		Tags:    v1Tags(),
+	}, controllers.AlertRuleController.ListEvents)
+}
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+// RegisterAPIKeyRoutes registers personal API key management. Keys cannot
+// manage keys themselves; these routes need a signed-in session.
+func RegisterAPIKeyRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/api-keys",
+		Summary:  "List API keys",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.APIKeyController.List)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/api-keys",
+		Summary:  "Create an API key",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.APIKeyController.Create)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodDelete,
+		Path:     "/api-keys/{id}",
+		Summary:  "Revoke an API key",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.APIKeyController.Revoke)
+}
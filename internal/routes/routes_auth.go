@@ -8,7 +8,7 @@ import (
 	"sun-stockanalysis-api/internal/controllers"
 )
 
-func RegisterAuthRoutes(api huma.API, controllers *controllers.Controllers) {
+func RegisterAuthRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
 	huma.Register(api, huma.Operation{
 		Method:  http.MethodPost,
 		Path:    "/register",
@@ -23,10 +23,112 @@ func RegisterAuthRoutes(api huma.API, controllers *controllers.Controllers) {
 		Tags:    v1Tags(),
 	}, controllers.AuthController.Login)
 
+	huma.Register(api, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/login/mfa",
+		Summary: "Finish login with a TOTP or recovery code",
+		Tags:    v1Tags(),
+	}, controllers.AuthController.LoginMFA)
+
 	huma.Register(api, huma.Operation{
 		Method:  http.MethodPost,
 		Path:    "/refresh",
 		Summary: "Refresh access token",
 		Tags:    v1Tags(),
 	}, controllers.AuthController.Refresh)
+
+	huma.Register(api, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/verify-email",
+		Summary: "Verify an email address with the mailed token",
+		Tags:    v1Tags(),
+	}, controllers.AuthController.VerifyEmail)
+
+	huma.Register(api, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/verify-email/resend",
+		Summary: "Mail a new verification link",
+		Tags:    v1Tags(),
+	}, controllers.AuthController.ResendVerification)
+
+	huma.Register(api, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/password/forgot",
+		Summary: "Mail a password reset link",
+		Tags:    v1Tags(),
+	}, controllers.AuthController.ForgotPassword)
+
+	huma.Register(api, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/password/reset",
+		Summary: "Set a new password with the mailed token",
+		Tags:    v1Tags(),
+	}, controllers.AuthController.ResetPassword)
+
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/logout",
+		Summary:  "Log out of one session or all sessions",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.AuthController.Logout)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/sessions",
+		Summary:  "List signed-in sessions",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.AuthController.Sessions)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodDelete,
+		Path:     "/sessions/{id}",
+		Summary:  "Sign a session out",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.AuthController.RevokeSession)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/mfa",
+		Summary:  "Show two-factor authentication status",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.AuthController.MFAStatus)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/mfa/totp/enroll",
+		Summary:  "Start TOTP enrollment",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.AuthController.EnrollTOTP)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/mfa/totp/confirm",
+		Summary:  "Enable TOTP with a first code and get recovery codes",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.AuthController.ConfirmTOTP)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/mfa/totp/disable",
+		Summary:  "Disable TOTP",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.AuthController.DisableTOTP)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/mfa/recovery-codes",
+		Summary:  "Replace the recovery codes",
+		Tags:     v1Tags(),
+		Metadata: sessionOnly(),
+	}, controllers.AuthController.RegenerateRecoveryCodes)
 }
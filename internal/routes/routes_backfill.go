@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterBackfillRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:        http.MethodPost,
+		Path:          "/admin/backfill",
+		Summary:       "Queue a historical candle backfill for a symbol",
+		Tags:          v1Tags(),
+		Metadata:      adminOnly(),
+		DefaultStatus: http.StatusAccepted,
+	}, controllers.BackfillController.Enqueue)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/admin/backfill",
+		Summary:  "List backfill jobs",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.BackfillController.List)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/admin/backfill/{id}",
+		Summary:  "Get backfill job progress",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.BackfillController.Get)
+}
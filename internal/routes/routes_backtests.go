@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterBacktestRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:        http.MethodPost,
+		Path:          "/backtests",
+		Summary:       "Queue a strategy backtest over stored quotes or daily bars",
+		Tags:          v1Tags(),
+		DefaultStatus: http.StatusAccepted,
+	}, controllers.BacktestController.Submit)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/backtests",
+		Summary: "List the current user's backtests",
+		Tags:    v1Tags(),
+	}, controllers.BacktestController.List)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/backtests/{id}",
+		Summary: "Get a backtest's status and result",
+		Tags:    v1Tags(),
+	}, controllers.BacktestController.Get)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodDelete,
+		Path:    "/backtests/{id}",
+		Summary: "Cancel a queued or running backtest",
+		Tags:    v1Tags(),
+	}, controllers.BacktestController.Cancel)
+}
@@ -1,5 +1,38 @@
 package routes
 
+import (
+	"sun-stockanalysis-api/internal/authctx"
+	"sun-stockanalysis-api/internal/models"
+)
+
 func v1Tags() []string {
 	return []string{"v1"}
 }
+
+// adminOnly restricts an operation to ADMIN users.
+func adminOnly() map[string]any {
+	return requireRoles(models.RoleAdmin)
+}
+
+// requireRoles declares the roles allowed to call an operation. The auth
+// middleware rejects other roles with 403.
+func requireRoles(roles ...string) map[string]any {
+	return map[string]any{authctx.RolesMetadataKey: roles}
+}
+
+// sessionOnly makes an operation reject API keys, for endpoints that manage
+// credentials and must not be reachable with a leaked key.
+func sessionOnly() map[string]any {
+	return map[string]any{authctx.APIKeysMetadataKey: false}
+}
+
+// metadata merges the metadata of several restrictions into one map.
+func metadata(parts ...map[string]any) map[string]any {
+	merged := map[string]any{}
+	for _, part := range parts {
+		for key, value := range part {
+			merged[key] = value
+		}
+	}
+	return merged
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterCandleRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/candles",
+		Summary: "List OHLCV candles aggregated from stock quotes",
+		Tags:    v1Tags(),
+	}, controllers.CandleController.List)
+}
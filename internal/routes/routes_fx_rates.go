@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterFXRateRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/fx-rates",
+		Summary: "List the FX rates in effect on a date",
+		Tags:    v1Tags(),
+	}, controllers.FXRateController.List)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/admin/fx-rates/sync",
+		Summary:  "Fetch and store today's FX rates",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.FXRateController.Sync)
+}
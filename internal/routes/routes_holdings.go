@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterHoldingRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/holdings",
+		Summary: "Get the current user's positions with cost basis and P&L",
+		Tags:    v1Tags(),
+	}, controllers.HoldingController.Positions)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/holdings/history",
+		Summary: "Get daily holding value history per currency",
+		Tags:    v1Tags(),
+	}, controllers.HoldingController.History)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/holdings/transactions",
+		Summary: "List recorded buys, sells and dividends",
+		Tags:    v1Tags(),
+	}, controllers.HoldingController.ListTransactions)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/holdings/transactions",
+		Summary: "Record a buy, sell or dividend",
+		Tags:    v1Tags(),
+	}, controllers.HoldingController.AddTransaction)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/holdings/transactions/import",
+		Summary: "Import transactions from CSV",
+		Tags:    v1Tags(),
+	}, controllers.HoldingController.ImportTransactions)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodDelete,
+		Path:    "/holdings/transactions/{id}",
+		Summary: "Delete a recorded transaction",
+		Tags:    v1Tags(),
+	}, controllers.HoldingController.DeleteTransaction)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterIndicatorRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/admin/indicators/recompute",
+		Summary:  "Recompute stored EMA and indicator columns from price history",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.IndicatorController.RecomputeIndicators)
+}
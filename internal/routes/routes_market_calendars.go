@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterMarketCalendarRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/market-calendars",
+		Summary: "List market calendars with their current phase",
+		Tags:    v1Tags(),
+	}, controllers.MarketCalendarController.List)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/market-calendars/{code}/sessions",
+		Summary: "List the trading sessions of a calendar",
+		Tags:    v1Tags(),
+	}, controllers.MarketCalendarController.Sessions)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/market-calendars/{code}/holidays",
+		Summary: "List the holidays of a calendar",
+		Tags:    v1Tags(),
+	}, controllers.MarketCalendarController.Holidays)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPut,
+		Path:     "/admin/market-calendars/{code}",
+		Summary:  "Create or replace a calendar's session definition",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.MarketCalendarController.Save)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPut,
+		Path:     "/admin/market-calendars/{code}/holidays/{date}",
+		Summary:  "Override a day as a holiday or early close",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.MarketCalendarController.SetHoliday)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodDelete,
+		Path:     "/admin/market-calendars/{code}/holidays/{date}",
+		Summary:  "Remove a holiday",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.MarketCalendarController.DeleteHoliday)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/admin/market-calendars/{code}/holidays/sync",
+		Summary:  "Ingest a calendar's holidays from the market data provider",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.MarketCalendarController.SyncHolidays)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/admin/master-exchanges",
+		Summary:  "List exchanges and their calendars",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.MarketCalendarController.ListExchanges)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPut,
+		Path:     "/admin/master-exchanges/{id}/calendar",
+		Summary:  "Assign the calendar that governs an exchange",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.MarketCalendarController.SetExchangeCalendar)
+}
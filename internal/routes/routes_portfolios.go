@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterPortfolioRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/portfolios",
+		Summary: "List the current user's paper-trading portfolios",
+		Tags:    v1Tags(),
+	}, controllers.PortfolioController.List)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/portfolios",
+		Summary: "Open a paper-trading portfolio",
+		Tags:    v1Tags(),
+	}, controllers.PortfolioController.Create)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/portfolios/{id}",
+		Summary: "Get a portfolio with cash, positions and P&L",
+		Tags:    v1Tags(),
+	}, controllers.PortfolioController.Get)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodDelete,
+		Path:    "/portfolios/{id}",
+		Summary: "Delete a portfolio and its history",
+		Tags:    v1Tags(),
+	}, controllers.PortfolioController.Delete)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/portfolios/{id}/orders",
+		Summary: "List a portfolio's order history",
+		Tags:    v1Tags(),
+	}, controllers.PortfolioController.ListOrders)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/portfolios/{id}/orders",
+		Summary: "Place a simulated market or limit order",
+		Tags:    v1Tags(),
+	}, controllers.PortfolioController.PlaceOrder)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodDelete,
+		Path:    "/portfolios/{id}/orders/{orderId}",
+		Summary: "Cancel an open order",
+		Tags:    v1Tags(),
+	}, controllers.PortfolioController.CancelOrder)
+}
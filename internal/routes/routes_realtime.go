@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterRealtimeRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/admin/realtime/stats",
+		Summary:  "Connected WebSocket clients and dropped message counters",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.RealtimeController.Stats)
+}
@@ -17,6 +17,7 @@ func RegisterRelationNewsRoutes(api huma.API, controllers *controllers.Controlle
 		Path:          "/relation-news",
 		Summary:       "Create relation news",
 		Tags:          v1Tags(),
+		Metadata:      adminOnly(),
 		DefaultStatus: http.StatusCreated,
 	}, controllers.RelationNewsController.Create)
 }
@@ -31,6 +31,7 @@ func RegisterStockRoutes(api huma.API, controllers *controllers.Controllers, mid
 		Path:          "/stocks",
 		Summary:       "Create stock",
 		Tags:          v1Tags(),
+		Metadata:      adminOnly(),
 		DefaultStatus: http.StatusCreated,
 	}, controllers.StockController.CreateStock)
 }
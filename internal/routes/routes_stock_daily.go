@@ -18,4 +18,12 @@ func RegisterStockDailyRoutes(api huma.API, controllers *controllers.Controllers
 		Summary: "List stock daily by symbol",
 		Tags:    v1Tags(),
 	}, controllers.StockDailyController.ListBySymbol)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/admin/stock-daily/rebuild",
+		Summary:  "Rebuild stock daily rows for a date range from stored quotes",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.StockDailyController.Rebuild)
 }
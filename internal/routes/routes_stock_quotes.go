@@ -18,4 +18,12 @@ func RegisterStockQuoteRoutes(api huma.API, controllers *controllers.Controllers
 		Summary: "List all stock quotes",
 		Tags:    v1Tags(),
 	}, controllers.StockQuoteController.ListAll)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/admin/stock-quotes/cycles/latest",
+		Summary:  "Get the result of the latest quote polling cycle",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.StockQuoteController.LatestCycle)
 }
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterUserRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodGet,
+		Path:     "/admin/users",
+		Summary:  "List users and their roles",
+		Tags:     v1Tags(),
+		Metadata: adminOnly(),
+	}, controllers.UserController.List)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/admin/users/{id}/promote",
+		Summary:  "Grant a user the ADMIN role",
+		Tags:     v1Tags(),
+		Metadata: metadata(adminOnly(), sessionOnly()),
+	}, controllers.UserController.Promote)
+
+	huma.Register(protected, huma.Operation{
+		Method:   http.MethodPost,
+		Path:     "/admin/users/{id}/demote",
+		Summary:  "Return an admin to the USER role",
+		Tags:     v1Tags(),
+		Metadata: metadata(adminOnly(), sessionOnly()),
+	}, controllers.UserController.Demote)
+}
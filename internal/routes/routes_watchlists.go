@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"sun-stockanalysis-api/internal/controllers"
+)
+
+func RegisterWatchlistRoutes(api huma.API, controllers *controllers.Controllers, middleware func(huma.Context, func(huma.Context))) {
+	protected := huma.NewGroup(api, "")
+	protected.UseMiddleware(middleware)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/watchlists",
+		Summary: "List the current user's watchlists",
+		Tags:    v1Tags(),
+	}, controllers.WatchlistController.List)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/watchlists",
+		Summary: "Create a watchlist",
+		Tags:    v1Tags(),
+	}, controllers.WatchlistController.Create)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodGet,
+		Path:    "/watchlists/{id}",
+		Summary: "Get a watchlist",
+		Tags:    v1Tags(),
+	}, controllers.WatchlistController.Get)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodPut,
+		Path:    "/watchlists/{id}",
+		Summary: "Rename a watchlist and replace its symbols",
+		Tags:    v1Tags(),
+	}, controllers.WatchlistController.Update)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodDelete,
+		Path:    "/watchlists/{id}",
+		Summary: "Delete a watchlist",
+		Tags:    v1Tags(),
+	}, controllers.WatchlistController.Delete)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodPost,
+		Path:    "/watchlists/{id}/symbols",
+		Summary: "Add a symbol to a watchlist",
+		Tags:    v1Tags(),
+	}, controllers.WatchlistController.AddSymbol)
+
+	huma.Register(protected, huma.Operation{
+		Method:  http.MethodDelete,
+		Path:    "/watchlists/{id}/symbols/{symbol}",
+		Summary: "Remove a symbol from a watchlist",
+		Tags:    v1Tags(),
+	}, controllers.WatchlistController.RemoveSymbol)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"strings"
@@ -29,6 +30,7 @@ type Server struct {
 func NewServer(
 	cfg *configurations.Config,
 	controllers *controllers.Controllers,
+	apiKeys handler.APIKeyVerifier,
 	alertHub *realtime.AlertHub,
 	stockQuoteHub *realtime.StockQuoteHub,
 	log *logger.Logger,
@@ -45,7 +47,7 @@ func NewServer(
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     strings.Join(cfg.Server.AllowedOrigins, ","),
 		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Correlation-Id",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-API-Key,X-Correlation-Id",
 		ExposeHeaders:    "X-Correlation-Id",
 		AllowCredentials: true,
 	}))
@@ -60,9 +62,17 @@ func NewServer(
 		if path == contextPath+"/docs" ||
 			path == contextPath+"/openapi.json" ||
 			path == contextPath+"/openapi.yaml" ||
-			path == contextPath+"/schemas" ||
-			path == contextPath+"/alerts/ws" ||
-			path == contextPath+"/stock-quotes/ws" {
+			path == contextPath+"/schemas" {
+			return c.Next()
+		}
+		// Browsers cannot set headers on a WebSocket upgrade or an
+		// EventSource request; these endpoints authenticate themselves in
+		// websocketAuth and streamAuth.
+		if (path == contextPath+"/alerts/ws" || path == contextPath+"/stock-quotes/ws") &&
+			websocket.IsWebSocketUpgrade(c) {
+			return c.Next()
+		}
+		if path == contextPath+"/alerts/stream" || path == contextPath+"/stock-quotes/stream" {
 			return c.Next()
 		}
 
@@ -110,37 +120,38 @@ func NewServer(
 		Scheme:       "bearer",
 		BearerFormat: "JWT",
 	}
+	apiConfig.Components.SecuritySchemes["ApiKeyAuth"] = &huma.SecurityScheme{
+		Type: "apiKey",
+		In:   "header",
+		Name: "X-API-Key",
+	}
 
 	humaAPI := humafiber.NewWithGroup(app, apiGroup, apiConfig)
-	handler.RegisterRoutes(humaAPI, controllers, cfg.State.Secret, cfg.State.Issuer)
+	handler.RegisterRoutes(humaAPI, controllers, cfg.State.Secret, cfg.State.Issuer, apiKeys)
 	addCorrelationIDToOpenAPI(humaAPI)
 	addBearerAuthToOpenAPI(humaAPI)
 
+	authenticate := websocketAuthenticator(cfg.State.Secret, cfg.State.Issuer)
+	wsAuth := websocketAuth(authenticate)
 	if alertHub != nil {
-		apiGroup.Get("/alerts/ws", websocket.New(func(c *websocket.Conn) {
-			alertHub.Register(c)
-			defer alertHub.Unregister(c)
-
-			for {
-				if _, _, err := c.ReadMessage(); err != nil {
-					break
-				}
-			}
+		apiGroup.Get("/alerts/ws", wsAuth, websocket.New(func(c *websocket.Conn) {
+			alertHub.Serve(c, websocketSession(c), authenticate)
 		}))
 	}
 	if stockQuoteHub != nil {
-		apiGroup.Get("/stock-quotes/ws", websocket.New(func(c *websocket.Conn) {
-			stockQuoteHub.Register(c)
-			defer stockQuoteHub.Unregister(c)
-
-			for {
-				if _, _, err := c.ReadMessage(); err != nil {
-					break
-				}
-			}
+		apiGroup.Get("/stock-quotes/ws", wsAuth, websocket.New(func(c *websocket.Conn) {
+			stockQuoteHub.Serve(c, websocketSession(c), authenticate)
 		}))
 	}
 
+	sseAuth := streamAuth(authenticate)
+	if alertHub != nil {
+		apiGroup.Get("/alerts/stream", sseAuth, eventStream(alertHub.Stream))
+	}
+	if stockQuoteHub != nil {
+		apiGroup.Get("/stock-quotes/stream", sseAuth, eventStream(stockQuoteHub.Stream))
+	}
+
 	app.Get(contextPath+"/docs", func(c *fiber.Ctx) error {
 		c.Set("Content-Type", "text/html; charset=utf-8")
 		return c.SendString(swaggerUIHTML(contextPath))
@@ -175,6 +186,101 @@ func (s *Server) Stop() error {
 	return s.app.ShutdownWithContext(ctx)
 }
 
+const realtimeSessionKey = "realtime_session"
+
+// websocketAuthenticator validates access tokens sent to the WebSocket
+// endpoints with the same HS256 and issuer rules as authMiddleware.
+func websocketAuthenticator(secret, issuer string) realtime.Authenticator {
+	return func(token string) (realtime.Session, error) {
+		userID, expiresAt, err := handler.VerifyAccessToken(secret, issuer, strings.TrimSpace(token))
+		if err != nil {
+			return realtime.Session{}, err
+		}
+		return realtime.Session{UserID: userID, ExpiresAt: expiresAt}, nil
+	}
+}
+
+// websocketAuth authenticates a WebSocket upgrade from the token query
+// parameter or a bearer Authorization header. An invalid token is rejected
+// before the upgrade; without a token the client must send an auth message
+// as its first frame.
+func websocketAuth(authenticate realtime.Authenticator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+		token := requestToken(c)
+		if token == "" {
+			return c.Next()
+		}
+		session, err := authenticate(token)
+		if err != nil {
+			return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
+		}
+		c.Locals(realtimeSessionKey, session)
+		return c.Next()
+	}
+}
+
+func websocketSession(c *websocket.Conn) realtime.Session {
+	session, _ := c.Locals(realtimeSessionKey).(realtime.Session)
+	return session
+}
+
+// streamAuth authenticates an SSE request from the token query parameter or a
+// bearer Authorization header. EventSource cannot send anything after the
+// request, so the token is required up front.
+func streamAuth(authenticate realtime.Authenticator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := requestToken(c)
+		if token == "" {
+			return fiber.NewError(fiber.StatusUnauthorized, "missing token")
+		}
+		session, err := authenticate(token)
+		if err != nil {
+			return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
+		}
+		c.Locals(realtimeSessionKey, session)
+		return c.Next()
+	}
+}
+
+// eventStream serves a hub as text/event-stream. Clients pick symbols with a
+// comma-separated symbols query parameter and resume with the Last-Event-ID
+// header, or last_event_id when they reconnect by hand.
+func eventStream(stream func(w *bufio.Writer, session realtime.Session, symbols []string, lastEventID string)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		session, _ := c.Locals(realtimeSessionKey).(realtime.Session)
+		var symbols []string
+		if raw := strings.TrimSpace(c.Query("symbols")); raw != "" {
+			symbols = strings.Split(raw, ",")
+		}
+		lastEventID := c.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = c.Query("last_event_id")
+		}
+
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		c.Set(fiber.HeaderConnection, "keep-alive")
+		c.Set("X-Accel-Buffering", "no")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			stream(w, session, symbols, lastEventID)
+		})
+		return nil
+	}
+}
+
+func requestToken(c *fiber.Ctx) string {
+	if token := strings.TrimSpace(c.Query("token")); token != "" {
+		return token
+	}
+	if header := c.Get(fiber.HeaderAuthorization); strings.HasPrefix(strings.ToLower(header), "bearer ") {
+		return strings.TrimSpace(header[len("bearer "):])
+	}
+	return ""
+}
+
 func addCorrelationIDToOpenAPI(api huma.API) {
 	openapi := api.OpenAPI()
 	correlationParam := &huma.Param{
@@ -206,6 +312,7 @@ func addCorrelationIDToOpenAPI(api huma.API) {
 func addBearerAuthToOpenAPI(api huma.API) {
 	openapi := api.OpenAPI()
 	security := map[string][]string{"BearerAuth": {}}
+	apiKeySecurity := map[string][]string{"ApiKeyAuth": {}}
 	for path := range openapi.Paths {
 		pathItem := openapi.Paths[path]
 		operations := []*huma.Operation{
@@ -217,7 +324,7 @@ func addBearerAuthToOpenAPI(api huma.API) {
 		}
 		for _, op := range operations {
 			if op != nil {
-				op.Security = append(op.Security, security)
+				op.Security = append(op.Security, security, apiKeySecurity)
 			}
 		}
 	}